@@ -42,6 +42,22 @@ type ClusterAssessmentSpec struct {
 	// +optional
 	Validators []string `json:"validators,omitempty"`
 
+	// CriticalValidators lists validators that must complete for the
+	// assessment to be valid. If any of them returns an error or does not
+	// run, for example because it is not registered or not selected by
+	// validators or scope, the assessment moves to the Failed phase.
+	// +optional
+	CriticalValidators []string `json:"criticalValidators,omitempty"`
+
+	// Scope limits the assessment to an audience: "cluster" runs only the
+	// platform validators, "workloads" only the namespace-scoped ones, and
+	// "all" every validator. When Validators is also set, only the listed
+	// validators within the scope run.
+	// +kubebuilder:validation:Enum=cluster;workloads;all
+	// +kubebuilder:default=all
+	// +optional
+	Scope string `json:"scope,omitempty"`
+
 	// Suspend prevents scheduled assessments from running when true.
 	// +optional
 	Suspend bool `json:"suspend,omitempty"`
@@ -50,12 +66,222 @@ type ClusterAssessmentSpec struct {
 	// +optional
 	ReportStorage ReportStorageSpec `json:"reportStorage,omitempty"`
 
-	// MinSeverity filters findings to only include this severity level and above.
-	// Valid values are: "INFO", "PASS", "WARN", "FAIL"
+	// ReportMetadata identifies who requested the assessment and who to
+	// contact about its findings. It is shown in the report header.
+	// +optional
+	ReportMetadata ReportMetadataSpec `json:"reportMetadata,omitempty"`
+
+	// Tags categorize the assessment, e.g. by environment, region or team.
+	// They are copied into the report metadata and exported as tag_<key>
+	// labels of the cluster_assessment_tags metric. Keys must be valid
+	// Prometheus label names.
+	// +kubebuilder:validation:MaxProperties=10
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k.matches('^[a-zA-Z_][a-zA-Z0-9_]*$'))",message="tag keys must be valid Prometheus label names"
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// MinSeverity filters findings to only include this severity level and above,
+	// in the order PASS < INFO < WARN < FAIL.
+	// Valid values are: "PASS", "INFO", "WARN", "FAIL", and the shorthands
+	// "actionable" (WARN and FAIL) and "fail-only" (FAIL).
 	// Leave empty to include all findings.
-	// +kubebuilder:validation:Enum=INFO;PASS;WARN;FAIL
+	// +kubebuilder:validation:Enum=INFO;PASS;WARN;FAIL;actionable;fail-only
 	// +optional
 	MinSeverity string `json:"minSeverity,omitempty"`
+
+	// MinConfidence drops findings below this confidence level, in the order
+	// low < medium < high, to hide the advisory results of heuristic checks
+	// while keeping definitive ones. Leave empty to include all findings.
+	// +kubebuilder:validation:Enum=high;medium;low
+	// +optional
+	MinConfidence string `json:"minConfidence,omitempty"`
+
+	// ValidatorConfig provides per-validator overrides layered on top of the
+	// selected profile, keyed by validator name and then by setting name.
+	// Keys not recognized by a validator are ignored and reported as an INFO finding.
+	// +optional
+	ValidatorConfig map[string]map[string]string `json:"validatorConfig,omitempty"`
+
+	// EmitEvents records a Kubernetes Event on the ClusterAssessment for each
+	// FAIL finding so that problems show up in `oc describe`.
+	// +optional
+	EmitEvents bool `json:"emitEvents,omitempty"`
+
+	// HistoryLimit is the number of past run summaries kept in status.history.
+	// Defaults to 10. Set to 0 to disable history.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+
+	// MinFindings is the minimum number of findings a run must produce to be
+	// marked Completed. Runs below it, or where every validator failed, are
+	// marked Failed instead of reporting a misleadingly clean score.
+	// Defaults to 1. Set to 0 to only fail when every validator errored.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinFindings *int32 `json:"minFindings,omitempty"`
+
+	// PassingScore is the minimum score for the Passed condition to be True.
+	// When unset, the assessment passes only if it has no FAIL findings.
+	// Findings dropped by minSeverity, minConfidence or compliancePack do not
+	// count.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	PassingScore *int32 `json:"passingScore,omitempty"`
+
+	// MaxRetries is the number of times a failed one-time assessment is
+	// retried, with exponential backoff between attempts, before it stays
+	// Failed. Setting the rerun annotation allows another attempt.
+	// Defaults to 5. Set to 0 to never retry.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+
+	// CompliancePack restricts findings to those mapped to the given compliance
+	// framework, annotates them with its control IDs, and adds a per-control
+	// report to the report ConfigMap.
+	// +kubebuilder:validation:Enum=cis;nist-800-53;pci-dss
+	// +optional
+	CompliancePack string `json:"compliancePack,omitempty"`
+
+	// IncrementalScan re-runs only the validators whose watched resources
+	// changed since the last run, carrying forward the previous findings of
+	// the others. Validators that do not declare their inputs always run.
+	// Any change to the spec or to the expected-state ConfigMap forces a full
+	// run.
+	// +optional
+	IncrementalScan bool `json:"incrementalScan,omitempty"`
+
+	// RedactNamespaces replaces user namespace names in findings and reports
+	// with stable hashes, so reports can be shared outside the organization.
+	// +optional
+	RedactNamespaces bool `json:"redactNamespaces,omitempty"`
+
+	// RedactResourceNames replaces resource names in findings and reports
+	// with stable hashes. In finding text, names are recognized in
+	// "namespace/name" form.
+	// +optional
+	RedactResourceNames bool `json:"redactResourceNames,omitempty"`
+
+	// IncludeEvidence attaches a truncated YAML copy of the offending
+	// resource to findings that identify one, for offline review of reports.
+	// Secret values are redacted. Off by default, as it enlarges the status
+	// and reports considerably. Evidence is dropped when names are redacted.
+	// +optional
+	IncludeEvidence bool `json:"includeEvidence,omitempty"`
+
+	// MetricsRemoteWrite pushes the score and finding counts of every run to a
+	// Prometheus remote-write endpoint, so a central Prometheus can collect
+	// results from many clusters without scraping each operator.
+	// +optional
+	MetricsRemoteWrite *RemoteWriteSpec `json:"metricsRemoteWrite,omitempty"`
+
+	// RequiredOperators lists operators that must be installed and healthy,
+	// matched against ClusterServiceVersion names. An entry matches the CSV
+	// of that name or of that package at any version ("cluster-logging"
+	// matches "cluster-logging.v5.9.1"); a trailing "*" matches any CSV
+	// name with that prefix. Overrides the profile's list when set.
+	// +optional
+	RequiredOperators []string `json:"requiredOperators,omitempty"`
+
+	// ExpectedClusterAdmins lists the subjects expected to hold cluster-admin,
+	// as Kind:name, or ServiceAccount:namespace/name for service accounts
+	// (e.g. "Group:platform-admins", "ServiceAccount:gitops/argocd"). When
+	// set, the security validator reports unexpected and missing
+	// cluster-admin subjects instead of applying the profile's binding-count
+	// threshold. Overrides the expected state's clusterAdmins when set.
+	// +optional
+	ExpectedClusterAdmins []string `json:"expectedClusterAdmins,omitempty"`
+
+	// ExpectedStateConfigMap names a ConfigMap, in the operator namespace,
+	// whose expected-state.yaml key describes the expected node counts,
+	// required operators, cluster-admins, namespace labels and image
+	// registries in one YAML or JSON document. The validators owning each
+	// expectation report deviations from it. The assessment fails if the
+	// ConfigMap cannot be read or parsed.
+	// +optional
+	ExpectedStateConfigMap string `json:"expectedStateConfigMap,omitempty"`
+
+	// ResourceLabelSelector restricts the workloads and namespaced objects
+	// assessed by the workloadhealth, costoptimization, security and
+	// deprecation validators to those carrying all of these labels, for
+	// example app.kubernetes.io/part-of: payments. The resourcequotas
+	// validator applies it to namespaces. Other validators assess cluster
+	// objects and ignore it.
+	// +optional
+	ResourceLabelSelector map[string]string `json:"resourceLabelSelector,omitempty"`
+
+	// BaselineConfigMap names the ConfigMap, in the operator namespace, that
+	// the capture-baseline annotation writes the current findings to. Later
+	// runs compare their findings with it in the Regressed condition.
+	// Defaults to <assessment name>-baseline.
+	// +optional
+	BaselineConfigMap string `json:"baselineConfigMap,omitempty"`
+
+	// CompareProfiles runs the validators again under each of these profiles
+	// and records in status.profileComparison how the findings' statuses
+	// differ from those under spec.profile, e.g. to see what would fail if a
+	// development cluster were held to production standards. Each profile
+	// adds a full validator run.
+	// +kubebuilder:validation:MaxItems=2
+	// +kubebuilder:validation:items:Enum=production;development
+	// +optional
+	CompareProfiles []string `json:"compareProfiles,omitempty"`
+
+	// Digest periodically stores a digest of the recent runs of a scheduled
+	// assessment in the <name>-digest ConfigMap: the score trend and the
+	// findings that appeared, were resolved or persisted since the previous
+	// digest. Ignored for one-time assessments.
+	// +optional
+	Digest *DigestSpec `json:"digest,omitempty"`
+
+	// EnableMetricsAnalysis makes the costoptimization validator compare the
+	// peak CPU and memory usage of user workload containers, as recorded by
+	// Prometheus, with their requests and limits. Containers requesting far
+	// more than they use are reported as savings opportunities, and those
+	// running close to their limits as at risk of throttling or OOM kills.
+	// +optional
+	EnableMetricsAnalysis bool `json:"enableMetricsAnalysis,omitempty"`
+
+	// MetricsEndpoint is the Prometheus query API used by metrics analysis,
+	// authenticated with the operator's service account token. It must be an
+	// https URL of the in-cluster Thanos Querier or of a host allowed by the
+	// operator's --metrics-query-hosts flag. Defaults to
+	// https://thanos-querier.openshift-monitoring.svc:9091.
+	// +kubebuilder:validation:Pattern=`^https://`
+	// +optional
+	MetricsEndpoint string `json:"metricsEndpoint,omitempty"`
+}
+
+// DigestSpec configures the periodic digest of a scheduled assessment
+type DigestSpec struct {
+	// Schedule in cron format for how often the digest is generated. It is
+	// generated after the first run at or after each scheduled time, and
+	// after the first run once enabled. Defaults to weekly, on Mondays.
+	// +kubebuilder:default="0 0 * * 1"
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Runs is the number of most recent runs the score trend covers, up to
+	// spec.historyLimit. Defaults to 7.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Runs *int32 `json:"runs,omitempty"`
+}
+
+// ReportMetadataSpec describes the people responsible for an assessment.
+type ReportMetadataSpec struct {
+	// Owner is the person or team that requested the assessment.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// Contact is where to send questions about the findings, such as an
+	// email address or chat channel.
+	// +optional
+	Contact string `json:"contact,omitempty"`
 }
 
 // ReportStorageSpec configures report storage options
@@ -67,6 +293,14 @@ type ReportStorageSpec struct {
 	// Git enables exporting the report to a Git repository.
 	// +optional
 	Git *GitStorageSpec `json:"git,omitempty"`
+
+	// OffloadToJob generates the ConfigMap report in a Job running the
+	// operator image instead of inside the controller. Use this on very large
+	// clusters where rendering thousands of findings, PDFs in particular,
+	// would use too much controller memory or hold up other reconciles. The
+	// Job records the ConfigMap name in status.reportConfigMap when done.
+	// +optional
+	OffloadToJob bool `json:"offloadToJob,omitempty"`
 }
 
 // ConfigMapStorageSpec configures ConfigMap storage
@@ -80,10 +314,34 @@ type ConfigMapStorageSpec struct {
 	Name string `json:"name,omitempty"`
 
 	// Format specifies the report format(s) to generate.
-	// Valid values are: "json", "html", "pdf", or combinations like "json,html,pdf"
+	// Valid values are: "json", "html", "pdf", "ndjson", "remediation", or combinations like "json,html,pdf"
 	// Defaults to "json"
 	// +optional
 	Format string `json:"format,omitempty"`
+
+	// Compress gzips the JSON and HTML reports and stores them in BinaryData
+	// as report.json.gz and report.html.gz. Use this on large clusters where
+	// the uncompressed reports would exceed the 1MB ConfigMap size limit.
+	// +optional
+	Compress bool `json:"compress,omitempty"`
+
+	// TemplateRef references a ConfigMap in the operator namespace holding a
+	// Go html/template used for report.html instead of the built-in layout.
+	// The template receives the report, exposing .ClusterInfo, .Summary and
+	// .Findings. If the template cannot be loaded or rendered, the built-in
+	// layout is used.
+	// +optional
+	TemplateRef *TemplateReference `json:"templateRef,omitempty"`
+}
+
+// TemplateReference identifies a report template stored in a ConfigMap.
+type TemplateReference struct {
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Key is the ConfigMap key holding the template. Defaults to "report.html.tmpl".
+	// +optional
+	Key string `json:"key,omitempty"`
 }
 
 // GitStorageSpec configures Git repository export
@@ -110,6 +368,19 @@ type GitStorageSpec struct {
 	SecretRef string `json:"secretRef,omitempty"`
 }
 
+// RemoteWriteSpec defines a Prometheus remote-write endpoint.
+type RemoteWriteSpec struct {
+	// URL is the remote-write endpoint, e.g. https://prometheus.example.com/api/v1/write.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// SecretRef references a secret in the operator namespace containing
+	// 'username' and 'password' keys for basic auth, or a 'token' key for a
+	// bearer token.
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+}
+
 // ClusterAssessmentStatus defines the observed state of ClusterAssessment
 type ClusterAssessmentStatus struct {
 	// Phase represents the current phase of the assessment.
@@ -125,6 +396,11 @@ type ClusterAssessmentStatus struct {
 	// +optional
 	NextRunTime *metav1.Time `json:"nextRunTime,omitempty"`
 
+	// LastRerun is the value of the assessment.openshift.io/rerun annotation
+	// at the last completed or failed run.
+	// +optional
+	LastRerun string `json:"lastRerun,omitempty"`
+
 	// ClusterInfo contains metadata about the assessed cluster.
 	// +optional
 	ClusterInfo ClusterInfo `json:"clusterInfo,omitempty"`
@@ -141,6 +417,12 @@ type ClusterAssessmentStatus struct {
 	// +optional
 	ReportConfigMap string `json:"reportConfigMap,omitempty"`
 
+	// ReportJob is the name of the Job generating the report of the latest
+	// run when spec.reportStorage.offloadToJob is set. It is cleared once
+	// the Job has finished.
+	// +optional
+	ReportJob string `json:"reportJob,omitempty"`
+
 	// Conditions represent the latest available observations of the assessment's state.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
@@ -148,6 +430,113 @@ type ClusterAssessmentStatus struct {
 	// Message provides additional information about the current phase.
 	// +optional
 	Message string `json:"message,omitempty"`
+
+	// History holds summaries of past runs, newest first, capped at spec.historyLimit.
+	// +optional
+	History []RunSummary `json:"history,omitempty"`
+
+	// ValidatorFingerprints records a digest of each validator's inputs at the
+	// last run, keyed by validator name. Used by spec.incrementalScan.
+	// +optional
+	ValidatorFingerprints map[string]string `json:"validatorFingerprints,omitempty"`
+
+	// WarnStreaks counts, per finding ID, the consecutive completed runs that
+	// reported the finding as WARN. Used to escalate long-ignored warnings.
+	// +optional
+	WarnStreaks map[string]int32 `json:"warnStreaks,omitempty"`
+
+	// FailureCount is the number of consecutive failed runs. It is reset when
+	// a run completes.
+	// +optional
+	FailureCount int32 `json:"failureCount,omitempty"`
+
+	// LastFailureTime is the time of the last failed run.
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+
+	// ProfileComparison compares the last run under spec.profile with runs
+	// under spec.compareProfiles.
+	// +optional
+	ProfileComparison *ProfileComparison `json:"profileComparison,omitempty"`
+}
+
+// ProfileComparison records how findings differ between profiles
+type ProfileComparison struct {
+	// Profiles summarizes the run under each profile, starting with spec.profile.
+	Profiles []ProfileResult `json:"profiles"`
+
+	// Differences lists the findings whose status differs between profiles,
+	// sorted by validator and ID, capped at 50 entries.
+	// +optional
+	Differences []ProfileDifference `json:"differences,omitempty"`
+
+	// OmittedDifferences is the number of differences beyond the cap.
+	// +optional
+	OmittedDifferences int `json:"omittedDifferences,omitempty"`
+}
+
+// ProfileResult summarizes a run under a single profile
+type ProfileResult struct {
+	// Profile is the profile name.
+	Profile string `json:"profile"`
+
+	// Score is the overall score under the profile, if calculated. Like the
+	// counts, it is computed before minSeverity, minConfidence and compliancePack
+	// filtering.
+	// +optional
+	Score *int `json:"score,omitempty"`
+
+	// WarnCount is the number of checks with warnings.
+	WarnCount int `json:"warnCount"`
+
+	// FailCount is the number of checks that failed.
+	FailCount int `json:"failCount"`
+}
+
+// ProfileDifference is a finding whose status differs between profiles
+type ProfileDifference struct {
+	// ID is the finding ID.
+	ID string `json:"id"`
+
+	// Validator is the validator that produced the finding.
+	Validator string `json:"validator"`
+
+	// Title is the finding title.
+	Title string `json:"title"`
+
+	// Namespace is the namespace of the affected resource, if any.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Resource is the affected resource, if any.
+	// +optional
+	Resource string `json:"resource,omitempty"`
+
+	// Statuses maps each profile to the finding's status under it. Profiles
+	// under which the finding was not reported are omitted.
+	Statuses map[string]FindingStatus `json:"statuses"`
+}
+
+// RunSummary records the outcome of a single assessment run
+type RunSummary struct {
+	// Time is when the run completed.
+	Time metav1.Time `json:"time"`
+
+	// Score is the overall score of the run, if calculated.
+	// +optional
+	Score *int `json:"score,omitempty"`
+
+	// PassCount is the number of checks that passed.
+	PassCount int `json:"passCount"`
+
+	// WarnCount is the number of checks with warnings.
+	WarnCount int `json:"warnCount"`
+
+	// FailCount is the number of checks that failed.
+	FailCount int `json:"failCount"`
+
+	// InfoCount is the number of informational findings.
+	InfoCount int `json:"infoCount"`
 }
 
 // ClusterInfo contains metadata about the OpenShift cluster
@@ -205,6 +594,59 @@ type AssessmentSummary struct {
 	// ProfileUsed is the baseline profile that was used.
 	// +optional
 	ProfileUsed string `json:"profileUsed,omitempty"`
+
+	// FleetComparison compares the score with other recent assessments in the cluster.
+	// +optional
+	FleetComparison *FleetComparison `json:"fleetComparison,omitempty"`
+
+	// CategoryBreakdown lists finding counts and pass rate per category, sorted by category.
+	// +optional
+	CategoryBreakdown []CategoryStat `json:"categoryBreakdown,omitempty"`
+
+	// RanValidators lists the validators whose findings this assessment
+	// contains, sorted by name.
+	// +optional
+	RanValidators []string `json:"ranValidators,omitempty"`
+
+	// OmittedValidators lists the registered validators that did not run,
+	// because spec.validators or spec.scope excluded them or the cluster does
+	// not serve the APIs they need. It is empty for a full assessment.
+	// +optional
+	OmittedValidators []string `json:"omittedValidators,omitempty"`
+}
+
+// FleetComparison compares an assessment's score with the average of other assessments
+type FleetComparison struct {
+	// AverageScore is the mean score of the other recent completed assessments.
+	AverageScore int `json:"averageScore"`
+
+	// AssessmentCount is the number of other assessments included in the average.
+	AssessmentCount int `json:"assessmentCount"`
+
+	// Delta is this assessment's score minus the fleet average.
+	Delta int `json:"delta"`
+}
+
+// CategoryStat summarizes the findings of a single category
+type CategoryStat struct {
+	// Category is the finding category.
+	Category string `json:"category"`
+
+	// PassCount is the number of checks that passed.
+	PassCount int `json:"passCount"`
+
+	// WarnCount is the number of checks with warnings.
+	WarnCount int `json:"warnCount"`
+
+	// FailCount is the number of checks that failed.
+	FailCount int `json:"failCount"`
+
+	// InfoCount is the number of informational findings.
+	InfoCount int `json:"infoCount"`
+
+	// PassRate is the percentage (0-100) of checks in the category that
+	// passed. INFO findings are not checks and are not counted.
+	PassRate int `json:"passRate"`
 }
 
 // Finding represents a single assessment finding
@@ -246,9 +688,36 @@ type Finding struct {
 	// +optional
 	Recommendation string `json:"recommendation,omitempty"`
 
+	// RemediationCommand is a suggested oc command that applies the
+	// recommendation. It is never run by the operator.
+	// +optional
+	RemediationCommand string `json:"remediationCommand,omitempty"`
+
 	// References provides links to relevant documentation.
 	// +optional
 	References []string `json:"references,omitempty"`
+
+	// Controls lists the control IDs of the selected compliance pack that
+	// this finding maps to.
+	// +optional
+	Controls []string `json:"controls,omitempty"`
+
+	// EscalatedFrom is the status reported by the validator when the finding
+	// was escalated because it persisted across too many runs.
+	// +optional
+	EscalatedFrom FindingStatus `json:"escalatedFrom,omitempty"`
+
+	// Evidence is a truncated YAML copy of the resource the finding is
+	// about, attached when the assessment sets includeEvidence.
+	// +optional
+	Evidence string `json:"evidence,omitempty"`
+
+	// Confidence indicates how certain the finding is: high for definitive
+	// checks of reported state, medium or low for findings inferred from
+	// naming conventions, partial data or the absence of known tools.
+	// +kubebuilder:validation:Enum=high;medium;low
+	// +optional
+	Confidence FindingConfidence `json:"confidence,omitempty"`
 }
 
 // FindingStatus represents the status of a finding
@@ -266,6 +735,19 @@ const (
 	FindingStatusInfo FindingStatus = "INFO"
 )
 
+// FindingConfidence represents how certain a finding is
+// +kubebuilder:validation:Enum=high;medium;low
+type FindingConfidence string
+
+const (
+	// FindingConfidenceHigh indicates a definitive check of reported state.
+	FindingConfidenceHigh FindingConfidence = "high"
+	// FindingConfidenceMedium indicates a finding inferred from partial data.
+	FindingConfidenceMedium FindingConfidence = "medium"
+	// FindingConfidenceLow indicates an advisory, heuristic finding.
+	FindingConfidenceLow FindingConfidence = "low"
+)
+
 // Assessment phase constants
 const (
 	PhasePending   = "Pending"
@@ -274,6 +756,15 @@ const (
 	PhaseFailed    = "Failed"
 )
 
+// RerunAnnotation re-runs a completed one-time ClusterAssessment whenever its
+// value changes, e.g. `oc annotate ca/<name> assessment.openshift.io/rerun="$(date +%s)" --overwrite`.
+const RerunAnnotation = "assessment.openshift.io/rerun"
+
+// CaptureBaselineAnnotation, set to "true" on a completed ClusterAssessment,
+// writes its current findings to spec.baselineConfigMap and is then removed,
+// e.g. `oc annotate ca/<name> assessment.openshift.io/capture-baseline=true`.
+const CaptureBaselineAnnotation = "assessment.openshift.io/capture-baseline"
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,shortName=ca
@@ -282,6 +773,8 @@ const (
 // +kubebuilder:printcolumn:name="Pass",type=integer,JSONPath=`.status.summary.passCount`
 // +kubebuilder:printcolumn:name="Warn",type=integer,JSONPath=`.status.summary.warnCount`
 // +kubebuilder:printcolumn:name="Fail",type=integer,JSONPath=`.status.summary.failCount`
+// +kubebuilder:printcolumn:name="Score",type=integer,JSONPath=`.status.summary.score`
+// +kubebuilder:printcolumn:name="Previous Score",type=integer,JSONPath=`.status.history[1].score`,priority=1
 // +kubebuilder:printcolumn:name="Last Run",type=date,JSONPath=`.status.lastRunTime`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
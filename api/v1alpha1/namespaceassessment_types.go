@@ -0,0 +1,117 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NamespaceAssessmentSpec defines the desired state of NamespaceAssessment
+type NamespaceAssessmentSpec struct {
+	// Profile specifies the baseline profile to use for assessment.
+	// Valid values are: "production", "development"
+	// +kubebuilder:validation:Enum=production;development
+	// +kubebuilder:default=production
+	// +optional
+	Profile string `json:"profile,omitempty"`
+
+	// Validators is the list of specific validators to run.
+	// Only namespace-scoped validators are supported: resourcequotas,
+	// networkpolicyaudit, workloadhealth and costoptimization.
+	// Leave empty to run all of them.
+	// +optional
+	Validators []string `json:"validators,omitempty"`
+
+	// MinSeverity filters findings to only include this severity level and above,
+	// in the order PASS < INFO < WARN < FAIL.
+	// Valid values are: "PASS", "INFO", "WARN", "FAIL", and the shorthands
+	// "actionable" (WARN and FAIL) and "fail-only" (FAIL).
+	// Leave empty to include all findings.
+	// +kubebuilder:validation:Enum=INFO;PASS;WARN;FAIL;actionable;fail-only
+	// +optional
+	MinSeverity string `json:"minSeverity,omitempty"`
+
+	// MinConfidence drops findings below this confidence level, in the order
+	// low < medium < high, to hide the advisory results of heuristic checks
+	// while keeping definitive ones. Leave empty to include all findings.
+	// +kubebuilder:validation:Enum=high;medium;low
+	// +optional
+	MinConfidence string `json:"minConfidence,omitempty"`
+}
+
+// NamespaceAssessmentStatus defines the observed state of NamespaceAssessment
+type NamespaceAssessmentStatus struct {
+	// Phase represents the current phase of the assessment.
+	// +kubebuilder:validation:Enum=Pending;Running;Completed;Failed
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// LastRunTime is the timestamp of the last assessment run.
+	// +optional
+	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
+
+	// Summary provides an overview of assessment results.
+	// +optional
+	Summary AssessmentSummary `json:"summary,omitempty"`
+
+	// Findings is the list of all assessment findings.
+	// +optional
+	Findings []Finding `json:"findings,omitempty"`
+
+	// Conditions represent the latest available observations of the assessment's state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Message provides additional information about the current phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,shortName=nsa
+// +kubebuilder:printcolumn:name="Profile",type=string,JSONPath=`.spec.profile`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Pass",type=integer,JSONPath=`.status.summary.passCount`
+// +kubebuilder:printcolumn:name="Warn",type=integer,JSONPath=`.status.summary.warnCount`
+// +kubebuilder:printcolumn:name="Fail",type=integer,JSONPath=`.status.summary.failCount`
+// +kubebuilder:printcolumn:name="Last Run",type=date,JSONPath=`.status.lastRunTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NamespaceAssessment is the Schema for the namespaceassessments API.
+// It runs the namespace-scoped subset of validators against the namespace
+// it is created in, for teams without access to cluster-scoped resources.
+type NamespaceAssessment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NamespaceAssessmentSpec   `json:"spec,omitempty"`
+	Status NamespaceAssessmentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NamespaceAssessmentList contains a list of NamespaceAssessment
+type NamespaceAssessmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NamespaceAssessment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NamespaceAssessment{}, &NamespaceAssessmentList{})
+}
@@ -93,7 +93,90 @@ func (in *ClusterAssessmentSpec) DeepCopyInto(out *ClusterAssessmentSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CriticalValidators != nil {
+		in, out := &in.CriticalValidators, &out.CriticalValidators
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.ReportStorage.DeepCopyInto(&out.ReportStorage)
+	out.ReportMetadata = in.ReportMetadata
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ValidatorConfig != nil {
+		in, out := &in.ValidatorConfig, &out.ValidatorConfig
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinFindings != nil {
+		in, out := &in.MinFindings, &out.MinFindings
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PassingScore != nil {
+		in, out := &in.PassingScore, &out.PassingScore
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MetricsRemoteWrite != nil {
+		in, out := &in.MetricsRemoteWrite, &out.MetricsRemoteWrite
+		*out = new(RemoteWriteSpec)
+		**out = **in
+	}
+	if in.RequiredOperators != nil {
+		in, out := &in.RequiredOperators, &out.RequiredOperators
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpectedClusterAdmins != nil {
+		in, out := &in.ExpectedClusterAdmins, &out.ExpectedClusterAdmins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceLabelSelector != nil {
+		in, out := &in.ResourceLabelSelector, &out.ResourceLabelSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CompareProfiles != nil {
+		in, out := &in.CompareProfiles, &out.CompareProfiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Digest != nil {
+		in, out := &in.Digest, &out.Digest
+		*out = new(DigestSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAssessmentSpec.
@@ -133,6 +216,36 @@ func (in *ClusterAssessmentStatus) DeepCopyInto(out *ClusterAssessmentStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]RunSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ValidatorFingerprints != nil {
+		in, out := &in.ValidatorFingerprints, &out.ValidatorFingerprints
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.WarnStreaks != nil {
+		in, out := &in.WarnStreaks, &out.WarnStreaks
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ProfileComparison != nil {
+		in, out := &in.ProfileComparison, &out.ProfileComparison
+		*out = new(ProfileComparison)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAssessmentStatus.
@@ -168,6 +281,26 @@ func (in *AssessmentSummary) DeepCopyInto(out *AssessmentSummary) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.FleetComparison != nil {
+		in, out := &in.FleetComparison, &out.FleetComparison
+		*out = new(FleetComparison)
+		**out = **in
+	}
+	if in.CategoryBreakdown != nil {
+		in, out := &in.CategoryBreakdown, &out.CategoryBreakdown
+		*out = make([]CategoryStat, len(*in))
+		copy(*out, *in)
+	}
+	if in.RanValidators != nil {
+		in, out := &in.RanValidators, &out.RanValidators
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OmittedValidators != nil {
+		in, out := &in.OmittedValidators, &out.OmittedValidators
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssessmentSummary.
@@ -180,6 +313,112 @@ func (in *AssessmentSummary) DeepCopy() *AssessmentSummary {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetComparison) DeepCopyInto(out *FleetComparison) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetComparison.
+func (in *FleetComparison) DeepCopy() *FleetComparison {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetComparison)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DigestSpec) DeepCopyInto(out *DigestSpec) {
+	*out = *in
+	if in.Runs != nil {
+		in, out := &in.Runs, &out.Runs
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DigestSpec.
+func (in *DigestSpec) DeepCopy() *DigestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DigestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileComparison) DeepCopyInto(out *ProfileComparison) {
+	*out = *in
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]ProfileResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Differences != nil {
+		in, out := &in.Differences, &out.Differences
+		*out = make([]ProfileDifference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileComparison.
+func (in *ProfileComparison) DeepCopy() *ProfileComparison {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfileComparison)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileResult) DeepCopyInto(out *ProfileResult) {
+	*out = *in
+	if in.Score != nil {
+		in, out := &in.Score, &out.Score
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileResult.
+func (in *ProfileResult) DeepCopy() *ProfileResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfileResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileDifference) DeepCopyInto(out *ProfileDifference) {
+	*out = *in
+	if in.Statuses != nil {
+		in, out := &in.Statuses, &out.Statuses
+		*out = make(map[string]FindingStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileDifference.
+func (in *ProfileDifference) DeepCopy() *ProfileDifference {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfileDifference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Finding) DeepCopyInto(out *Finding) {
 	*out = *in
@@ -188,6 +427,11 @@ func (in *Finding) DeepCopyInto(out *Finding) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Controls != nil {
+		in, out := &in.Controls, &out.Controls
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Finding.
@@ -200,13 +444,43 @@ func (in *Finding) DeepCopy() *Finding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteWriteSpec) DeepCopyInto(out *RemoteWriteSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteWriteSpec.
+func (in *RemoteWriteSpec) DeepCopy() *RemoteWriteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteWriteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportMetadataSpec) DeepCopyInto(out *ReportMetadataSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportMetadataSpec.
+func (in *ReportMetadataSpec) DeepCopy() *ReportMetadataSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportMetadataSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReportStorageSpec) DeepCopyInto(out *ReportStorageSpec) {
 	*out = *in
 	if in.ConfigMap != nil {
 		in, out := &in.ConfigMap, &out.ConfigMap
 		*out = new(ConfigMapStorageSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Git != nil {
 		in, out := &in.Git, &out.Git
@@ -228,6 +502,11 @@ func (in *ReportStorageSpec) DeepCopy() *ReportStorageSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapStorageSpec) DeepCopyInto(out *ConfigMapStorageSpec) {
 	*out = *in
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(TemplateReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapStorageSpec.
@@ -240,6 +519,21 @@ func (in *ConfigMapStorageSpec) DeepCopy() *ConfigMapStorageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateReference) DeepCopyInto(out *TemplateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateReference.
+func (in *TemplateReference) DeepCopy() *TemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitStorageSpec) DeepCopyInto(out *GitStorageSpec) {
 	*out = *in
@@ -254,3 +548,152 @@ func (in *GitStorageSpec) DeepCopy() *GitStorageSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceAssessment) DeepCopyInto(out *NamespaceAssessment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceAssessment.
+func (in *NamespaceAssessment) DeepCopy() *NamespaceAssessment {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceAssessment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceAssessment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceAssessmentList) DeepCopyInto(out *NamespaceAssessmentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespaceAssessment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceAssessmentList.
+func (in *NamespaceAssessmentList) DeepCopy() *NamespaceAssessmentList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceAssessmentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceAssessmentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceAssessmentSpec) DeepCopyInto(out *NamespaceAssessmentSpec) {
+	*out = *in
+	if in.Validators != nil {
+		in, out := &in.Validators, &out.Validators
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceAssessmentSpec.
+func (in *NamespaceAssessmentSpec) DeepCopy() *NamespaceAssessmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceAssessmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceAssessmentStatus) DeepCopyInto(out *NamespaceAssessmentStatus) {
+	*out = *in
+	if in.LastRunTime != nil {
+		in, out := &in.LastRunTime, &out.LastRunTime
+		*out = (*in).DeepCopy()
+	}
+	in.Summary.DeepCopyInto(&out.Summary)
+	if in.Findings != nil {
+		in, out := &in.Findings, &out.Findings
+		*out = make([]Finding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceAssessmentStatus.
+func (in *NamespaceAssessmentStatus) DeepCopy() *NamespaceAssessmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceAssessmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CategoryStat) DeepCopyInto(out *CategoryStat) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CategoryStat.
+func (in *CategoryStat) DeepCopy() *CategoryStat {
+	if in == nil {
+		return nil
+	}
+	out := new(CategoryStat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunSummary) DeepCopyInto(out *RunSummary) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Score != nil {
+		in, out := &in.Score, &out.Score
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunSummary.
+func (in *RunSummary) DeepCopy() *RunSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(RunSummary)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -40,5 +40,7 @@ export interface Finding {
     description: string;
     impact?: string;
     recommendation?: string;
+    remediationCommand?: string;
     references?: string[];
+    confidence?: 'high' | 'medium' | 'low';
 }
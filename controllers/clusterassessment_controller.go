@@ -17,11 +17,23 @@ limitations under the License.
 package controllers
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"io"
+	"net"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/go-git/go-git/v5"
@@ -30,19 +42,26 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	configv1 "github.com/openshift/api/config/v1"
 
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/compliance"
 	"github.com/openshift-assessment/cluster-assessment-operator/pkg/metrics"
 	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
 	"github.com/openshift-assessment/cluster-assessment-operator/pkg/report"
@@ -54,6 +73,37 @@ type ClusterAssessmentReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Registry *validator.Registry
+	Recorder record.EventRecorder
+
+	// APIReader reads directly from the API server. Validators page large
+	// lists through it, which the cached Client does not support. Nil lists
+	// them at once through Client.
+	APIReader client.Reader
+
+	// Limiter caps how many assessments run at the same time.
+	Limiter *AssessmentLimiter
+
+	// Enricher adds organization-specific context to findings. Nil leaves
+	// findings unchanged.
+	Enricher report.Enricher
+
+	// StatusBoard publishes the latest result of every ClusterAssessment to
+	// the shared assessment-status-board ConfigMap.
+	StatusBoard bool
+
+	// ReportJobImage is the operator image run by report Jobs. When empty,
+	// reports are generated in the controller even if
+	// spec.reportStorage.offloadToJob is set.
+	ReportJobImage string
+
+	// ReportJobServiceAccount is the service account report Jobs run as.
+	// Defaults to DefaultReportJobServiceAccount.
+	ReportJobServiceAccount string
+
+	// MetricsQueryHosts are the hosts spec.metricsEndpoint may name besides
+	// the in-cluster Thanos Querier. The service account token is sent to
+	// that endpoint.
+	MetricsQueryHosts []string
 }
 
 // +kubebuilder:rbac:groups=assessment.openshift.io,resources=clusterassessments,verbs=get;list;watch;create;update;patch;delete
@@ -61,15 +111,28 @@ type ClusterAssessmentReconciler struct {
 // +kubebuilder:rbac:groups=assessment.openshift.io,resources=clusterassessments/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=nodes;namespaces;pods;services;configmaps;secrets;persistentvolumes;persistentvolumeclaims;serviceaccounts,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=config.openshift.io,resources=*,verbs=get;list;watch
 // +kubebuilder:rbac:groups=machineconfiguration.openshift.io,resources=*,verbs=get;list;watch
+// +kubebuilder:rbac:groups=machine.openshift.io,resources=machinesets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=autoscaling.openshift.io,resources=clusterautoscalers;machineautoscalers,verbs=get;list;watch
 // +kubebuilder:rbac:groups=security.openshift.io,resources=securitycontextconstraints,verbs=get;list;watch
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses;csidrivers;csinodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies;ingresses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=policy.networking.k8s.io,resources=adminnetworkpolicies;baselineadminnetworkpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings;roles;rolebindings,verbs=get;list;watch
 // +kubebuilder:rbac:groups=operator.openshift.io,resources=*,verbs=get;list;watch
+// +kubebuilder:rbac:groups=operators.coreos.com,resources=clusterserviceversions;subscriptions;installplans;catalogsources;operatorgroups,verbs=get;list;watch
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=*,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments;daemonsets;statefulsets;replicasets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=template.openshift.io,resources=templates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations;mutatingwebhookconfigurations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 
 // Reconcile handles ClusterAssessment reconciliation.
 func (r *ClusterAssessmentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -86,6 +149,20 @@ func (r *ClusterAssessmentReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, err
 	}
 
+	// Capture a baseline of the current findings when requested. Removing the
+	// annotation triggers another reconcile that resumes normal handling.
+	if captureBaselineRequested(assessment) {
+		return ctrl.Result{}, r.captureBaseline(ctx, assessment)
+	}
+
+	// Record the outcome of the report Job of the latest run
+	if assessment.Status.ReportJob != "" {
+		if err := r.checkReportJob(ctx, assessment); err != nil {
+			logger.Error(err, "Failed to check report Job")
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Check if this is a scheduled assessment
 	if assessment.Spec.Schedule != "" {
 		return r.reconcileScheduled(ctx, assessment)
@@ -99,9 +176,27 @@ func (r *ClusterAssessmentReconciler) Reconcile(ctx context.Context, req ctrl.Re
 func (r *ClusterAssessmentReconciler) reconcileOneTime(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	// Skip if already completed
+	// Skip if already completed, unless a rerun was requested through the annotation
 	if assessment.Status.Phase == assessmentv1alpha1.PhaseCompleted {
-		return ctrl.Result{}, nil
+		if !rerunRequested(assessment) {
+			return ctrl.Result{}, nil
+		}
+		logger.Info("Rerun requested", "annotation", assessmentv1alpha1.RerunAnnotation,
+			"value", assessment.Annotations[assessmentv1alpha1.RerunAnnotation])
+	}
+
+	// Back off between retries of failed runs, and stop after spec.maxRetries
+	if assessment.Status.Phase == assessmentv1alpha1.PhaseFailed && !rerunRequested(assessment) {
+		if assessment.Status.FailureCount > int32(maxRetries(assessment)) {
+			logger.Info("Assessment failed too many times, not retrying", "failures", assessment.Status.FailureCount)
+			return ctrl.Result{}, nil
+		}
+		if last := assessment.Status.LastFailureTime; last != nil {
+			if wait := failureBackoff(assessment.Status.FailureCount) - time.Since(last.Time); wait > 0 {
+				logger.Info("Waiting before retrying failed assessment", "failures", assessment.Status.FailureCount, "requeueAfter", wait)
+				return ctrl.Result{RequeueAfter: wait}, nil
+			}
+		}
 	}
 
 	// Check for stuck Running assessments (timeout after 5 minutes)
@@ -122,13 +217,9 @@ func (r *ClusterAssessmentReconciler) reconcileOneTime(ctx context.Context, asse
 			stuckDuration := time.Since(latestAssessment.Status.LastRunTime.Time)
 			if stuckDuration > 5*time.Minute {
 				logger.Info("Assessment appears stuck, resetting to allow retry", "stuckDuration", stuckDuration)
-				latestAssessment.Status.Phase = assessmentv1alpha1.PhaseFailed
-				latestAssessment.Status.Message = "Assessment timed out after 5 minutes, restarting..."
-				if err := r.Status().Update(ctx, latestAssessment); err != nil {
-					return ctrl.Result{RequeueAfter: time.Second}, nil // Retry on conflict
-				}
-				// Requeue to run the assessment
-				return ctrl.Result{Requeue: true}, nil
+				// Count the timeout as a failure, so that the retry backs off
+				return r.updateStatus(ctx, latestAssessment, assessmentv1alpha1.PhaseFailed,
+					"Assessment timed out after 5 minutes, restarting...")
 			} else {
 				logger.Info("Assessment already running, skipping", "runningFor", stuckDuration)
 				return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
@@ -180,6 +271,13 @@ func (r *ClusterAssessmentReconciler) reconcileScheduled(ctx context.Context, as
 		nextRun = now
 	}
 
+	// A failed run is retried once its backoff has elapsed, and not before
+	// the next scheduled run; see updateStatus
+	if assessment.Status.Phase == assessmentv1alpha1.PhaseFailed && assessment.Status.NextRunTime != nil &&
+		assessment.Status.NextRunTime.After(nextRun) {
+		nextRun = assessment.Status.NextRunTime.Time
+	}
+
 	// Update next run time in status
 	assessment.Status.NextRunTime = &metav1.Time{Time: nextRun}
 
@@ -203,8 +301,46 @@ func (r *ClusterAssessmentReconciler) reconcileScheduled(ctx context.Context, as
 // runAssessment executes the assessment.
 func (r *ClusterAssessmentReconciler) runAssessment(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
+
+	// Wait for a free slot if too many assessments are already running
+	if !r.Limiter.TryAcquire() {
+		logger.Info("Too many assessments running, requeuing", "limit", r.Limiter.Limit(), "requeueAfter", assessmentLimitRequeueDelay)
+		return ctrl.Result{RequeueAfter: assessmentLimitRequeueDelay}, nil
+	}
+	defer r.Limiter.Release()
+
 	startTime := time.Now()
 
+	// Tags become metric labels. The CRD validates them too, but only if the
+	// installed schema is up to date.
+	if err := metrics.ValidateTags(assessment.Spec.Tags); err != nil {
+		logger.Error(err, "Invalid tags")
+		return r.updateStatus(ctx, assessment, assessmentv1alpha1.PhaseFailed,
+			fmt.Sprintf("Invalid tags: %v", err))
+	}
+
+	var querier *metrics.QueryClient
+	if assessment.Spec.EnableMetricsAnalysis {
+		var err error
+		querier, err = metrics.InClusterQueryClient(assessment.Spec.MetricsEndpoint, r.MetricsQueryHosts)
+		if err != nil {
+			logger.Error(err, "Invalid metrics endpoint")
+			return r.updateStatus(ctx, assessment, assessmentv1alpha1.PhaseFailed,
+				fmt.Sprintf("Invalid metrics endpoint: %v", err))
+		}
+	}
+
+	expected, err := r.loadExpectedState(ctx, assessment)
+	if err != nil {
+		logger.Error(err, "Invalid expected state")
+		return r.updateStatus(ctx, assessment, assessmentv1alpha1.PhaseFailed,
+			fmt.Sprintf("Invalid expected state: %v", err))
+	}
+
+	// Keep the previous results for incremental scans before the status is reset
+	prevFingerprints := assessment.Status.ValidatorFingerprints
+	prevFindings := assessment.Status.Findings
+
 	// Update status to Running
 	if _, err := r.updateStatus(ctx, assessment, assessmentv1alpha1.PhaseRunning, "Assessment in progress"); err != nil {
 		return ctrl.Result{}, err
@@ -212,6 +348,12 @@ func (r *ClusterAssessmentReconciler) runAssessment(ctx context.Context, assessm
 
 	// Get the profile
 	profile := profiles.GetProfile(assessment.Spec.Profile)
+	if len(assessment.Spec.RequiredOperators) > 0 {
+		profile.RequiredOperators = assessment.Spec.RequiredOperators
+	}
+	if len(assessment.Spec.ExpectedClusterAdmins) > 0 {
+		profile.ExpectedClusterAdmins = assessment.Spec.ExpectedClusterAdmins
+	}
 	logger.Info("Using profile", "profile", profile.Name)
 
 	// Collect cluster info
@@ -224,6 +366,23 @@ func (r *ClusterAssessmentReconciler) runAssessment(ctx context.Context, assessm
 
 	// Create validator runner
 	runner := validator.NewRunner(r.Registry, r.Client)
+	runner.SetAPIReader(r.APIReader)
+	runner.SetValidatorConfig(assessment.Spec.ValidatorConfig)
+	runner.SetScope(assessment.Spec.Scope)
+	runner.SetIncludeEvidence(assessment.Spec.IncludeEvidence)
+	runner.SetResourceLabelSelector(assessment.Spec.ResourceLabelSelector)
+	runner.SetExpectedState(expected)
+	if querier != nil {
+		runner.SetMetricsQuerier(querier)
+	}
+	if assessment.Spec.IncrementalScan {
+		salt, err := incrementalSalt(assessment.Generation, expected)
+		if err != nil {
+			logger.Error(err, "Failed to hash expected state, running all validators")
+		} else {
+			runner.SetIncremental(salt, prevFingerprints, prevFindings)
+		}
+	}
 
 	// Run validators
 	findings, err := runner.Run(ctx, profile, assessment.Spec.Validators)
@@ -232,34 +391,108 @@ func (r *ClusterAssessmentReconciler) runAssessment(ctx context.Context, assessm
 		return r.updateStatus(ctx, assessment, assessmentv1alpha1.PhaseFailed,
 			fmt.Sprintf("Assessment failed: %v", err))
 	}
+	if reason := criticalFailure(assessment.Spec.CriticalValidators, runner.Executed(), runner.Skipped(), runner.Errors()); reason != "" {
+		logger.Info("Critical validator failed", "reason", reason)
+		return r.updateStatus(ctx, assessment, assessmentv1alpha1.PhaseFailed, reason)
+	}
+	// Keep the unfiltered findings for the comparison with other profiles.
+	// They are copied, as escalation changes findings in place.
+	profileFindings := slices.Clone(findings)
+	if skipped := runner.Skipped(); len(skipped) > 0 {
+		logger.Info("Reused findings of validators with unchanged inputs", "validators", skipped)
+	}
+
+	// Refuse to report a clean score when the validators could not inspect the
+	// cluster. This is checked before severity filtering, which may
+	// legitimately drop every finding.
+	if reason := insufficientResults(runner.Executed(), runner.Failed(), len(findings), minFindings(assessment)); reason != "" {
+		logger.Info("Assessment produced insufficient results", "reason", reason)
+		return r.updateStatus(ctx, assessment, assessmentv1alpha1.PhaseFailed, reason)
+	}
+
+	// Attach organization-specific references and impact text
+	findings = enrichFindings(ctx, r.Enricher, findings)
+
+	// Escalate warnings that have persisted for too many consecutive runs.
+	// This happens before severity filtering so escalated findings survive it.
+	findings, warnStreaks := escalatePersistentWarnings(findings, assessment.Status.WarnStreaks, profile.Thresholds.EscalateAfterRuns)
 
 	// Apply severity filtering if configured
 	if assessment.Spec.MinSeverity != "" {
-		findings = r.filterBySeverity(findings, assessment.Spec.MinSeverity)
+		findings = filterBySeverity(findings, assessment.Spec.MinSeverity)
 		logger.Info("Filtered findings by severity", "minSeverity", assessment.Spec.MinSeverity, "filteredCount", len(findings))
 	}
+	if assessment.Spec.MinConfidence != "" {
+		findings = filterByConfidence(findings, assessment.Spec.MinConfidence)
+		logger.Info("Filtered findings by confidence", "minConfidence", assessment.Spec.MinConfidence, "filteredCount", len(findings))
+	}
+
+	// Restrict findings to the selected compliance pack and tag their controls
+	if assessment.Spec.CompliancePack != "" {
+		findings = compliance.Apply(assessment.Spec.CompliancePack, findings)
+		logger.Info("Applied compliance pack", "pack", assessment.Spec.CompliancePack, "mappedCount", len(findings))
+	}
+
+	// Redact internal names last, so that reports, events and status never
+	// contain them
+	if assessment.Spec.RedactNamespaces || assessment.Spec.RedactResourceNames {
+		findings = r.redactFindings(ctx, assessment, findings)
+	}
+
+	// Run the validators again under the profiles to compare with
+	assessment.Status.ProfileComparison = r.compareProfiles(ctx, assessment, profile, expected, runner.ResourceCache(), profileFindings, runner.Skipped())
 
 	// Update findings
 	assessment.Status.Findings = findings
 
 	// Calculate summary
-	assessment.Status.Summary = r.calculateSummary(findings, string(profile.Name))
+	assessment.Status.Summary = calculateSummary(findings, string(profile.Name))
+	assessment.Status.Summary.RanValidators, assessment.Status.Summary.OmittedValidators =
+		reportCoverage(r.Registry.Names(), runner.Executed(), runner.Skipped())
+	assessment.Status.Summary.FleetComparison = r.compareToFleet(ctx, assessment)
+
+	// Compare with the captured baseline, if any
+	base, err := r.loadBaseline(ctx, assessment)
+	if err != nil {
+		logger.Error(err, "Failed to load baseline; skipping the comparison")
+	}
 
-	// Generate and store report
-	if assessment.Spec.ReportStorage.ConfigMap != nil && assessment.Spec.ReportStorage.ConfigMap.Enabled {
-		if err := r.storeReportInConfigMap(ctx, assessment); err != nil {
+	// Generate and store report, retrying transient failures
+	storageConfigured := false
+	var storageErrors []string
+	var reportJob string
+	if r.offloadReport(assessment) {
+		// Generated by a Job once the findings are stored in the status below
+		storageConfigured = true
+		reportJob = reportJobName(assessment.Name, time.Now())
+	} else if assessment.Spec.ReportStorage.ConfigMap != nil && assessment.Spec.ReportStorage.ConfigMap.Enabled {
+		storageConfigured = true
+		err := retryReportStorage(isTransientAPIError, func() error {
+			return r.storeReportInConfigMap(ctx, assessment)
+		})
+		if err != nil {
 			logger.Error(err, "Failed to store report in ConfigMap")
+			storageErrors = append(storageErrors, fmt.Sprintf("ConfigMap: %v", err))
 		}
 	}
 
 	// Export to Git if configured
 	if assessment.Spec.ReportStorage.Git != nil && assessment.Spec.ReportStorage.Git.Enabled {
-		if err := r.exportToGit(ctx, assessment); err != nil {
+		storageConfigured = true
+		// Every attempt clones into a fresh directory, so a push rejected
+		// because the branch moved succeeds on retry.
+		err := retryReportStorage(isTransientGitError, func() error {
+			return r.exportToGit(ctx, assessment)
+		})
+		if err != nil {
 			logger.Error(err, "Failed to export report to Git")
+			storageErrors = append(storageErrors, fmt.Sprintf("Git: %v", err))
 		}
 	}
 
 	// Update status to Completed with retry on conflict
+	var history []assessmentv1alpha1.RunSummary
+	var completedAt time.Time
 	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		// Re-fetch the latest version
 		latest := &assessmentv1alpha1.ClusterAssessment{}
@@ -274,11 +507,21 @@ func (r *ClusterAssessmentReconciler) runAssessment(ctx context.Context, assessm
 		latest.Status.Message = fmt.Sprintf("Assessment completed with %d findings", len(findings))
 		latest.Status.ClusterInfo = clusterInfo
 		latest.Status.Findings = findings
-		latest.Status.Summary = r.calculateSummary(findings, string(profile.Name))
+		latest.Status.Summary = assessment.Status.Summary
 		latest.Status.ReportConfigMap = assessment.Status.ReportConfigMap
+		latest.Status.ReportJob = reportJob
+		latest.Status.ValidatorFingerprints = runner.Fingerprints()
+		latest.Status.LastRerun = assessment.Annotations[assessmentv1alpha1.RerunAnnotation]
+		latest.Status.WarnStreaks = warnStreaks
+		latest.Status.ProfileComparison = assessment.Status.ProfileComparison
+		latest.Status.FailureCount = 0
+		latest.Status.LastFailureTime = nil
+		latest.Status.History = appendHistory(latest.Status.History, runSummary(assessment.Status.Summary, now), historyLimit(assessment))
+		history = latest.Status.History
+		completedAt = now.Time
 
 		// Update conditions
-		latest.Status.Conditions = []metav1.Condition{
+		conditions := []metav1.Condition{
 			{
 				Type:               "Ready",
 				Status:             metav1.ConditionTrue,
@@ -287,6 +530,16 @@ func (r *ClusterAssessmentReconciler) runAssessment(ctx context.Context, assessm
 				Message:            latest.Status.Message,
 			},
 		}
+		conditions = append(conditions, passedCondition(assessment.Status.Summary, assessment.Spec.PassingScore, now))
+		if base != nil {
+			conditions = append(conditions, regressedCondition(base, findings, now))
+		}
+		if reportJob != "" && len(storageErrors) == 0 {
+			conditions = append(conditions, reportJobRunningCondition(reportJob, now))
+		} else if storageConfigured {
+			conditions = append(conditions, reportStoredCondition(storageErrors, now))
+		}
+		latest.Status.Conditions = mergeConditions(latest.Status.Conditions, conditions)
 
 		return r.Status().Update(ctx, latest)
 	})
@@ -295,9 +548,17 @@ func (r *ClusterAssessmentReconciler) runAssessment(ctx context.Context, assessm
 		return ctrl.Result{}, err
 	}
 
+	// Generate the report from the stored findings. Failures are recorded in
+	// the ReportStored condition.
+	if reportJob != "" {
+		if err := r.startReportJob(ctx, assessment, reportJob); err != nil {
+			logger.Error(err, "Failed to record report Job failure", "job", reportJob)
+		}
+	}
+
 	// Record Prometheus metrics
 	duration := time.Since(startTime).Seconds()
-	summary := r.calculateSummary(findings, string(profile.Name))
+	summary := calculateSummary(findings, string(profile.Name))
 	score := 0
 	if summary.Score != nil {
 		score = *summary.Score
@@ -316,9 +577,38 @@ func (r *ClusterAssessmentReconciler) runAssessment(ctx context.Context, assessm
 		clusterInfo.Platform,
 		clusterInfo.Channel,
 	)
+	metrics.RecordAssessmentTags(assessment.Name, assessment.Spec.Tags)
 	// Record per-validator metrics
 	r.recordValidatorMetrics(assessment.Name, findings)
 
+	// Push a snapshot of the metrics to the central Prometheus. Failures are
+	// logged only; the next run pushes the then current values again.
+	if assessment.Spec.MetricsRemoteWrite != nil {
+		if err := r.pushRemoteWrite(ctx, assessment, clusterInfo.ClusterID); err != nil {
+			logger.Error(err, "Failed to push metrics to remote-write endpoint", "url", assessment.Spec.MetricsRemoteWrite.URL)
+		}
+	}
+
+	if assessment.Spec.EmitEvents {
+		r.emitFindingEvents(assessment, findings)
+	}
+
+	// Store the periodic digest of a scheduled assessment. Failures are logged
+	// only; the digest is retried after the next run.
+	if assessment.Spec.Schedule != "" && assessment.Spec.Digest != nil {
+		if err := r.storeDigest(ctx, assessment, history, findings, time.Now()); err != nil {
+			logger.Error(err, "Failed to store digest")
+		}
+	}
+
+	// Publish the result on the shared status board. Failures are logged only;
+	// the entry is refreshed after the next run.
+	if r.StatusBoard {
+		if err := r.updateStatusBoard(ctx, assessment, completedAt); err != nil {
+			logger.Error(err, "Failed to update status board")
+		}
+	}
+
 	logger.Info("Assessment completed", "findings", len(findings), "duration", duration)
 
 	// If scheduled, requeue for next run
@@ -381,7 +671,7 @@ func (r *ClusterAssessmentReconciler) collectClusterInfo(ctx context.Context) (a
 }
 
 // calculateSummary computes the assessment summary from findings.
-func (r *ClusterAssessmentReconciler) calculateSummary(findings []assessmentv1alpha1.Finding, profileName string) assessmentv1alpha1.AssessmentSummary {
+func calculateSummary(findings []assessmentv1alpha1.Finding, profileName string) assessmentv1alpha1.AssessmentSummary {
 	summary := assessmentv1alpha1.AssessmentSummary{
 		TotalChecks: len(findings),
 		ProfileUsed: profileName,
@@ -407,9 +697,634 @@ func (r *ClusterAssessmentReconciler) calculateSummary(findings []assessmentv1al
 		summary.Score = &score
 	}
 
+	summary.CategoryBreakdown = report.CategoryBreakdown(findings)
+
 	return summary
 }
 
+// reportCoverage splits the registered validators into those whose findings
+// are in the report, either from running them or from reusing their previous
+// findings, and those that were left out.
+func reportCoverage(registered, executed, reused []string) (ran, omitted []string) {
+	covered := make(map[string]bool)
+	for _, name := range append(append([]string{}, executed...), reused...) {
+		covered[name] = true
+	}
+	for _, name := range registered {
+		if covered[name] {
+			ran = append(ran, name)
+		} else {
+			omitted = append(omitted, name)
+		}
+	}
+	sort.Strings(ran)
+	sort.Strings(omitted)
+	return ran, omitted
+}
+
+// defaultHistoryLimit is the number of run summaries kept when spec.historyLimit is unset.
+const defaultHistoryLimit = 10
+
+// historyLimit returns the configured history length for an assessment.
+func historyLimit(assessment *assessmentv1alpha1.ClusterAssessment) int {
+	if assessment.Spec.HistoryLimit == nil {
+		return defaultHistoryLimit
+	}
+	return int(*assessment.Spec.HistoryLimit)
+}
+
+// rerunRequested reports whether the rerun annotation changed since the last
+// completed or failed run.
+func rerunRequested(assessment *assessmentv1alpha1.ClusterAssessment) bool {
+	value := assessment.Annotations[assessmentv1alpha1.RerunAnnotation]
+	return value != "" && value != assessment.Status.LastRerun
+}
+
+// captureBaselineRequested reports whether the capture-baseline annotation is
+// set on a completed assessment. On other phases it is left in place until the
+// run completes.
+func captureBaselineRequested(assessment *assessmentv1alpha1.ClusterAssessment) bool {
+	return assessment.Annotations[assessmentv1alpha1.CaptureBaselineAnnotation] == "true" &&
+		assessment.Status.Phase == assessmentv1alpha1.PhaseCompleted
+}
+
+// Baseline ConfigMap keys and size.
+const (
+	// baselineKey is the ConfigMap key the captured baseline is stored under.
+	baselineKey = "baseline.json"
+	// baselineGzipKey is the binary key a baseline too large for baselineKey
+	// is stored under, gzip-compressed.
+	baselineGzipKey = "baseline.json.gz"
+	// maxBaselineSize is the largest baseline stored. ConfigMaps are limited
+	// to 1MiB; the margin leaves room for their metadata.
+	maxBaselineSize = 1000 * 1024
+)
+
+// baseline is the content written to the baseline ConfigMap.
+type baseline struct {
+	Assessment string                       `json:"assessment"`
+	CapturedAt metav1.Time                  `json:"capturedAt"`
+	Score      *int                         `json:"score,omitempty"`
+	Findings   []assessmentv1alpha1.Finding `json:"findings"`
+}
+
+// loadExpectedState reads and parses spec.expectedStateConfigMap. It returns
+// nil when no ConfigMap is configured.
+func (r *ClusterAssessmentReconciler) loadExpectedState(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment) (*validator.ExpectedState, error) {
+	name := assessment.Spec.ExpectedStateConfigMap
+	if name == "" {
+		return nil, nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: reportNamespace}, cm); err != nil {
+		return nil, fmt.Errorf("reading ConfigMap %s/%s: %w", reportNamespace, name, err)
+	}
+	data, ok := cm.Data[validator.ExpectedStateKey]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found in ConfigMap %s/%s", validator.ExpectedStateKey, reportNamespace, name)
+	}
+	return validator.ParseExpectedState(data)
+}
+
+// incrementalSalt returns the salt of the incremental scan fingerprints. It
+// covers the assessment generation and the content of the expected state, so
+// that editing spec or the expected-state ConfigMap forces a full run.
+func incrementalSalt(generation int64, expected *validator.ExpectedState) (string, error) {
+	salt := strconv.FormatInt(generation, 10)
+	if expected == nil {
+		return salt, nil
+	}
+	data, err := json.Marshal(expected)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return salt + "/" + hex.EncodeToString(sum[:]), nil
+}
+
+// baselineConfigMapName returns spec.baselineConfigMap, defaulting to
+// <assessment name>-baseline.
+func baselineConfigMapName(assessment *assessmentv1alpha1.ClusterAssessment) string {
+	if name := assessment.Spec.BaselineConfigMap; name != "" {
+		return name
+	}
+	return assessment.Name + "-baseline"
+}
+
+// encodeBaseline returns the JSON encoding of b and the ConfigMap key to store
+// it under. Baselines larger than maxBaselineSize are gzip-compressed and
+// stored under baselineGzipKey; an error is returned if they still do not fit.
+func encodeBaseline(b baseline) (string, []byte, error) {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encode baseline: %w", err)
+	}
+	if len(data) <= maxBaselineSize {
+		return baselineKey, data, nil
+	}
+	compressed, err := gzipBytes(data)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to compress baseline: %w", err)
+	}
+	if len(compressed) > maxBaselineSize {
+		return "", nil, fmt.Errorf("baseline of %d findings is %d bytes compressed, more than the %d bytes a ConfigMap can hold",
+			len(b.Findings), len(compressed), maxBaselineSize)
+	}
+	return baselineGzipKey, compressed, nil
+}
+
+// loadBaseline reads the baseline captured for the assessment. It returns nil
+// when none has been captured.
+func (r *ClusterAssessmentReconciler) loadBaseline(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment) (*baseline, error) {
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Name: baselineConfigMapName(assessment), Namespace: reportNamespace}
+	if err := r.Get(ctx, key, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading baseline ConfigMap %s/%s: %w", key.Namespace, key.Name, err)
+	}
+
+	data := []byte(cm.Data[baselineKey])
+	if compressed, ok := cm.BinaryData[baselineGzipKey]; ok {
+		zr, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return nil, fmt.Errorf("decompressing baseline ConfigMap %s/%s: %w", key.Namespace, key.Name, err)
+		}
+		defer zr.Close()
+		if data, err = io.ReadAll(zr); err != nil {
+			return nil, fmt.Errorf("decompressing baseline ConfigMap %s/%s: %w", key.Namespace, key.Name, err)
+		}
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	b := &baseline{}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("parsing baseline ConfigMap %s/%s: %w", key.Namespace, key.Name, err)
+	}
+	return b, nil
+}
+
+// baselineRegressions returns the IDs of the WARN and FAIL findings that are
+// new or more severe than in the baseline.
+func baselineRegressions(base *baseline, findings []assessmentv1alpha1.Finding) []string {
+	previous := make(map[string]assessmentv1alpha1.FindingStatus, len(base.Findings))
+	for _, f := range base.Findings {
+		previous[f.ID] = f.Status
+	}
+
+	var regressed []string
+	for _, f := range findings {
+		if f.Status != assessmentv1alpha1.FindingStatusWarn && f.Status != assessmentv1alpha1.FindingStatusFail {
+			continue
+		}
+		if status, ok := previous[f.ID]; !ok || severityRank[status] < severityRank[f.Status] {
+			regressed = append(regressed, f.ID)
+		}
+	}
+	return regressed
+}
+
+// regressedCondition is the Regressed condition of a run compared with the
+// captured baseline.
+func regressedCondition(base *baseline, findings []assessmentv1alpha1.Finding, now metav1.Time) metav1.Condition {
+	cond := metav1.Condition{
+		Type:               "Regressed",
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: now,
+		Reason:             "NoRegressions",
+		Message:            fmt.Sprintf("No findings regressed since the baseline captured at %s", base.CapturedAt.UTC().Format(time.RFC3339)),
+	}
+	if regressed := baselineRegressions(base, findings); len(regressed) > 0 {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "FindingsRegressed"
+		cond.Message = fmt.Sprintf("%d finding(s) are new or more severe than in the baseline captured at %s: %s",
+			len(regressed), base.CapturedAt.UTC().Format(time.RFC3339), strings.Join(validator.SampleOf(regressed), ", "))
+	}
+	return cond
+}
+
+// captureBaseline writes the current findings to the baseline ConfigMap and
+// removes the capture-baseline annotation. A baseline too large for a
+// ConfigMap is not stored and a warning event is recorded instead.
+func (r *ClusterAssessmentReconciler) captureBaseline(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment) error {
+	logger := log.FromContext(ctx)
+	count := len(assessment.Status.Findings)
+
+	key, data, err := encodeBaseline(baseline{
+		Assessment: assessment.Name,
+		CapturedAt: metav1.Now(),
+		Score:      assessment.Status.Summary.Score,
+		Findings:   assessment.Status.Findings,
+	})
+	if err != nil {
+		// Retrying cannot make the baseline smaller
+		logger.Error(err, "Failed to capture baseline")
+		if r.Recorder != nil {
+			r.Recorder.Eventf(assessment, corev1.EventTypeWarning, "BaselineNotCaptured", "Failed to capture baseline: %v", err)
+		}
+		return r.removeCaptureBaselineAnnotation(ctx, assessment)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      baselineConfigMapName(assessment),
+			Namespace: reportNamespace,
+		},
+	}
+	_, err = ctrl.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		if cm.Labels == nil {
+			cm.Labels = map[string]string{}
+		}
+		cm.Labels["app.kubernetes.io/name"] = "cluster-assessment-operator"
+		cm.Labels["app.kubernetes.io/managed-by"] = "cluster-assessment-operator"
+		cm.Labels["assessment.openshift.io/name"] = assessment.Name
+		// Only one encoding of the latest baseline is kept
+		delete(cm.Data, baselineKey)
+		delete(cm.BinaryData, baselineGzipKey)
+		if key == baselineGzipKey {
+			if cm.BinaryData == nil {
+				cm.BinaryData = map[string][]byte{}
+			}
+			cm.BinaryData[key] = data
+			return nil
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[key] = string(data)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store baseline in ConfigMap %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+	if err := r.removeCaptureBaselineAnnotation(ctx, assessment); err != nil {
+		return err
+	}
+
+	logger.Info("Captured baseline", "configMap", cm.Name, "findings", count)
+	if r.Recorder != nil {
+		r.Recorder.Eventf(assessment, corev1.EventTypeNormal, "BaselineCaptured",
+			"Captured %d findings to ConfigMap %s/%s", count, cm.Namespace, cm.Name)
+	}
+	return nil
+}
+
+// removeCaptureBaselineAnnotation removes the capture-baseline annotation
+// from the assessment.
+func (r *ClusterAssessmentReconciler) removeCaptureBaselineAnnotation(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment) error {
+	patch := client.MergeFrom(assessment.DeepCopy())
+	delete(assessment.Annotations, assessmentv1alpha1.CaptureBaselineAnnotation)
+	if err := r.Patch(ctx, assessment, patch); err != nil {
+		return fmt.Errorf("failed to remove %s annotation: %w", assessmentv1alpha1.CaptureBaselineAnnotation, err)
+	}
+	return nil
+}
+
+// defaultMaxRetries is the number of retries of a failed one-time assessment
+// when spec.maxRetries is unset.
+const defaultMaxRetries = 5
+
+// Backoff between retries of a failed one-time assessment.
+const (
+	failureBackoffBase = 30 * time.Second
+	failureBackoffCap  = 30 * time.Minute
+)
+
+// maxRetries returns the configured number of retries for an assessment.
+func maxRetries(assessment *assessmentv1alpha1.ClusterAssessment) int {
+	if assessment.Spec.MaxRetries == nil {
+		return defaultMaxRetries
+	}
+	return int(*assessment.Spec.MaxRetries)
+}
+
+// failureBackoff returns how long to wait before retrying after the given
+// number of consecutive failures. It doubles with every failure, starting at
+// failureBackoffBase, up to failureBackoffCap.
+func failureBackoff(failures int32) time.Duration {
+	backoff := failureBackoffBase
+	for i := int32(1); i < failures && backoff < failureBackoffCap; i++ {
+		backoff *= 2
+	}
+	return min(backoff, failureBackoffCap)
+}
+
+// scheduledRetryTime returns when a scheduled assessment that has failed
+// failures times in a row runs again: after its failure backoff, but no
+// earlier than the next time the schedule fires after now.
+func scheduledRetryTime(scheduleSpec string, failures int32, now time.Time) time.Time {
+	retryAt := now.Add(failureBackoff(failures))
+	if schedule, err := cron.ParseStandard(scheduleSpec); err == nil {
+		if next := schedule.Next(now); next.After(retryAt) {
+			retryAt = next
+		}
+	}
+	return retryAt
+}
+
+// defaultMinFindings is the minimum number of findings when spec.minFindings is unset.
+const defaultMinFindings = 1
+
+// minFindings returns the configured minimum number of findings for an assessment.
+func minFindings(assessment *assessmentv1alpha1.ClusterAssessment) int {
+	if assessment.Spec.MinFindings == nil {
+		return defaultMinFindings
+	}
+	return int(*assessment.Spec.MinFindings)
+}
+
+// insufficientResults returns why a run's results cannot be trusted, or an
+// empty string if they can.
+func insufficientResults(executed, failed []string, findingCount, minFindings int) string {
+	if len(executed) > 0 && len(failed) == len(executed) {
+		return fmt.Sprintf("Assessment failed: all %d validators returned errors (%s); check the operator's RBAC permissions",
+			len(failed), strings.Join(failed, ", "))
+	}
+	if findingCount < minFindings {
+		return fmt.Sprintf("Assessment failed: produced %d findings, fewer than the minimum of %d (spec.minFindings)",
+			findingCount, minFindings)
+	}
+	return ""
+}
+
+// criticalFailure returns the reason an assessment must fail because one of
+// its critical validators returned an error or did not run, or "" if all of
+// them completed. Validators whose previous findings were reused by an
+// incremental run count as completed.
+func criticalFailure(critical, executed, skipped []string, errs map[string]error) string {
+	var failures []string
+	for _, name := range critical {
+		if err, ok := errs[name]; ok {
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+		} else if !slices.Contains(executed, name) && !slices.Contains(skipped, name) {
+			failures = append(failures, fmt.Sprintf("%s: did not run", name))
+		}
+	}
+	if len(failures) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Assessment failed: critical validator(s) did not complete (%s); see spec.criticalValidators",
+		strings.Join(failures, "; "))
+}
+
+// runSummary captures the result of a completed run for status.history.
+func runSummary(summary assessmentv1alpha1.AssessmentSummary, now metav1.Time) assessmentv1alpha1.RunSummary {
+	return assessmentv1alpha1.RunSummary{
+		Time:      now,
+		Score:     summary.Score,
+		PassCount: summary.PassCount,
+		WarnCount: summary.WarnCount,
+		FailCount: summary.FailCount,
+		InfoCount: summary.InfoCount,
+	}
+}
+
+// appendHistory prepends run to history and trims it to at most limit entries.
+func appendHistory(history []assessmentv1alpha1.RunSummary, run assessmentv1alpha1.RunSummary, limit int) []assessmentv1alpha1.RunSummary {
+	if limit <= 0 {
+		return nil
+	}
+	history = append([]assessmentv1alpha1.RunSummary{run}, history...)
+	if len(history) > limit {
+		history = history[:limit]
+	}
+	return history
+}
+
+// fleetComparisonWindow bounds how old another assessment's last run may be
+// for its score to count towards the fleet average.
+const fleetComparisonWindow = 30 * 24 * time.Hour
+
+// compareToFleet compares the assessment's score with the latest scores of
+// other recent assessments in the cluster.
+func (r *ClusterAssessmentReconciler) compareToFleet(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment) *assessmentv1alpha1.FleetComparison {
+	if assessment.Status.Summary.Score == nil {
+		return nil
+	}
+
+	assessments := &assessmentv1alpha1.ClusterAssessmentList{}
+	if err := r.List(ctx, assessments); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list assessments for fleet comparison")
+		return nil
+	}
+
+	return fleetComparison(assessment.Name, *assessment.Status.Summary.Score, assessments.Items, time.Now())
+}
+
+// fleetComparison computes the mean score of the other recent assessments and
+// how the given score compares to it. It returns nil when there is nothing to compare to.
+func fleetComparison(name string, score int, assessments []assessmentv1alpha1.ClusterAssessment, now time.Time) *assessmentv1alpha1.FleetComparison {
+	total, count := 0, 0
+	for _, other := range assessments {
+		if other.Name == name || other.Status.Summary.Score == nil || other.Status.LastRunTime == nil {
+			continue
+		}
+		if now.Sub(other.Status.LastRunTime.Time) > fleetComparisonWindow {
+			continue
+		}
+		total += *other.Status.Summary.Score
+		count++
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	average := total / count
+	return &assessmentv1alpha1.FleetComparison{
+		AverageScore:    average,
+		AssessmentCount: count,
+		Delta:           score - average,
+	}
+}
+
+// maxProfileDifferences caps status.profileComparison.differences.
+const maxProfileDifferences = 50
+
+// profileRun holds the unfiltered findings of a run under one profile.
+type profileRun struct {
+	profile  string
+	findings []assessmentv1alpha1.Finding
+}
+
+// compareProfiles runs the validators under each of spec.compareProfiles and
+// compares the results with findings, those of the run under profile. The
+// runs share cache, the resources already listed by that run, so that the
+// comparison does not list pods, namespaces and deployments again. It returns
+// nil when there is no other profile to compare with.
+//
+// The validators in skipped did not run under profile: their findings were
+// carried forward by an incremental scan from the previous status, already
+// filtered and redacted. They are left out of the comparison.
+func (r *ClusterAssessmentReconciler) compareProfiles(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment, profile profiles.Profile, expected *validator.ExpectedState, cache *validator.ResourceCache, findings []assessmentv1alpha1.Finding, skipped []string) *assessmentv1alpha1.ProfileComparison {
+	logger := log.FromContext(ctx)
+	redact := assessment.Spec.RedactNamespaces || assessment.Spec.RedactResourceNames
+
+	runs := []profileRun{{profile: string(profile.Name), findings: withoutValidators(findings, skipped)}}
+	for _, name := range assessment.Spec.CompareProfiles {
+		if slices.ContainsFunc(runs, func(run profileRun) bool { return run.profile == name }) {
+			continue
+		}
+		other := profiles.GetProfile(name)
+		if len(assessment.Spec.RequiredOperators) > 0 {
+			other.RequiredOperators = assessment.Spec.RequiredOperators
+		}
+		if len(assessment.Spec.ExpectedClusterAdmins) > 0 {
+			other.ExpectedClusterAdmins = assessment.Spec.ExpectedClusterAdmins
+		}
+
+		runner := validator.NewRunner(r.Registry, r.Client)
+		runner.SetAPIReader(r.APIReader)
+		runner.SetValidatorConfig(assessment.Spec.ValidatorConfig)
+		runner.SetScope(assessment.Spec.Scope)
+		runner.SetResourceLabelSelector(assessment.Spec.ResourceLabelSelector)
+		runner.SetExpectedState(expected)
+		runner.SetResourceCache(cache)
+		otherFindings, err := runner.Run(ctx, other, assessment.Spec.Validators)
+		if err != nil {
+			logger.Error(err, "Failed to run validators for profile comparison", "profile", name)
+			continue
+		}
+		runs = append(runs, profileRun{profile: string(other.Name), findings: withoutValidators(otherFindings, skipped)})
+	}
+	if len(runs) < 2 {
+		return nil
+	}
+
+	if redact {
+		for i := range runs {
+			runs[i].findings = r.redactFindings(ctx, assessment, runs[i].findings)
+		}
+	}
+	return profileComparison(runs)
+}
+
+// withoutValidators returns the findings not reported by any of validators.
+func withoutValidators(findings []assessmentv1alpha1.Finding, validators []string) []assessmentv1alpha1.Finding {
+	if len(validators) == 0 {
+		return findings
+	}
+	var kept []assessmentv1alpha1.Finding
+	for _, f := range findings {
+		if !slices.Contains(validators, f.Validator) {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// profileComparison summarizes each run and lists the findings, identified by
+// ID, namespace and resource, whose status is not the same in every run.
+func profileComparison(runs []profileRun) *assessmentv1alpha1.ProfileComparison {
+	comparison := &assessmentv1alpha1.ProfileComparison{}
+	differences := make(map[string]*assessmentv1alpha1.ProfileDifference)
+	for _, run := range runs {
+		summary := calculateSummary(run.findings, run.profile)
+		comparison.Profiles = append(comparison.Profiles, assessmentv1alpha1.ProfileResult{
+			Profile:   run.profile,
+			Score:     summary.Score,
+			WarnCount: summary.WarnCount,
+			FailCount: summary.FailCount,
+		})
+		for _, f := range run.findings {
+			key := f.ID + "/" + f.Namespace + "/" + f.Resource
+			d, ok := differences[key]
+			if !ok {
+				d = &assessmentv1alpha1.ProfileDifference{
+					ID:        f.ID,
+					Validator: f.Validator,
+					Title:     f.Title,
+					Namespace: f.Namespace,
+					Resource:  f.Resource,
+					Statuses:  make(map[string]assessmentv1alpha1.FindingStatus),
+				}
+				differences[key] = d
+			}
+			d.Statuses[run.profile] = f.Status
+		}
+	}
+
+	for _, d := range differences {
+		if !sameStatus(d.Statuses, len(runs)) {
+			comparison.Differences = append(comparison.Differences, *d)
+		}
+	}
+	sort.Slice(comparison.Differences, func(i, j int) bool {
+		a, b := comparison.Differences[i], comparison.Differences[j]
+		if a.Validator != b.Validator {
+			return a.Validator < b.Validator
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return a.Namespace+"/"+a.Resource < b.Namespace+"/"+b.Resource
+	})
+	if len(comparison.Differences) > maxProfileDifferences {
+		comparison.OmittedDifferences = len(comparison.Differences) - maxProfileDifferences
+		comparison.Differences = comparison.Differences[:maxProfileDifferences]
+	}
+	return comparison
+}
+
+// sameStatus reports whether a finding was reported with the same status in
+// all of runs runs.
+func sameStatus(statuses map[string]assessmentv1alpha1.FindingStatus, runs int) bool {
+	if len(statuses) != runs {
+		return false
+	}
+	var first assessmentv1alpha1.FindingStatus
+	for _, status := range statuses {
+		if first == "" {
+			first = status
+		} else if status != first {
+			return false
+		}
+	}
+	return true
+}
+
+// redactFindings hashes namespace and resource names in findings as requested
+// by the assessment spec. The assessment UID salts the hashes, so they are
+// stable across runs of one assessment but cannot be correlated across
+// assessments.
+func (r *ClusterAssessmentReconciler) redactFindings(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment, findings []assessmentv1alpha1.Finding) []assessmentv1alpha1.Finding {
+	var namespaces []string
+	nsList := &metav1.PartialObjectMetadataList{}
+	nsList.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("NamespaceList"))
+	if err := r.List(ctx, nsList); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list namespaces for redaction, redacting namespaces named in findings only")
+	}
+	for _, ns := range nsList.Items {
+		namespaces = append(namespaces, ns.Name)
+	}
+	for _, f := range findings {
+		if f.Namespace != "" {
+			namespaces = append(namespaces, f.Namespace)
+		}
+	}
+
+	redactor := report.NewRedactor(string(assessment.UID), assessment.Spec.RedactNamespaces, assessment.Spec.RedactResourceNames, namespaces)
+	return redactor.Redact(findings)
+}
+
+// enrichFindings applies enricher to findings. Enrichment is best effort: on
+// error the findings are returned unchanged.
+func enrichFindings(ctx context.Context, enricher report.Enricher, findings []assessmentv1alpha1.Finding) []assessmentv1alpha1.Finding {
+	if enricher == nil {
+		return findings
+	}
+	enriched, err := enricher.Enrich(ctx, findings)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to enrich findings, continuing without enrichment")
+		return findings
+	}
+	return enriched
+}
+
 // storeReportInConfigMap creates a ConfigMap with the full report.
 func (r *ClusterAssessmentReconciler) storeReportInConfigMap(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment) error {
 	logger := log.FromContext(ctx)
@@ -423,6 +1338,7 @@ func (r *ClusterAssessmentReconciler) storeReportInConfigMap(ctx context.Context
 	// Prepare data map
 	data := make(map[string]string)
 	binaryData := make(map[string][]byte)
+	compress := assessment.Spec.ReportStorage.ConfigMap.Compress
 
 	// Generate requested formats
 	formats := strings.Split(format, ",")
@@ -435,17 +1351,35 @@ func (r *ClusterAssessmentReconciler) storeReportInConfigMap(ctx context.Context
 				logger.Error(err, "Failed to generate JSON report")
 				continue
 			}
-			data["report.json"] = string(reportData)
-			logger.Info("Generated JSON report")
+			if compress {
+				compressed, err := gzipBytes(reportData)
+				if err != nil {
+					logger.Error(err, "Failed to compress JSON report")
+					continue
+				}
+				binaryData["report.json.gz"] = compressed
+			} else {
+				data["report.json"] = string(reportData)
+			}
+			logger.Info("Generated JSON report", "compressed", compress)
 
 		case "html":
-			reportData, err := report.GenerateHTML(assessment)
+			reportData, err := r.generateHTMLReport(ctx, assessment)
 			if err != nil {
 				logger.Error(err, "Failed to generate HTML report")
 				continue
 			}
-			data["report.html"] = string(reportData)
-			logger.Info("Generated HTML report")
+			if compress {
+				compressed, err := gzipBytes(reportData)
+				if err != nil {
+					logger.Error(err, "Failed to compress HTML report")
+					continue
+				}
+				binaryData["report.html.gz"] = compressed
+			} else {
+				data["report.html"] = string(reportData)
+			}
+			logger.Info("Generated HTML report", "compressed", compress)
 
 		case "pdf":
 			reportData, err := report.GeneratePDF(assessment)
@@ -455,6 +1389,35 @@ func (r *ClusterAssessmentReconciler) storeReportInConfigMap(ctx context.Context
 			}
 			binaryData["report.pdf"] = reportData
 			logger.Info("Generated PDF report")
+
+		case "ndjson":
+			reportData, err := report.GenerateNDJSON(assessment)
+			if err != nil {
+				logger.Error(err, "Failed to generate NDJSON report")
+				continue
+			}
+			data["findings.ndjson"] = string(reportData)
+			logger.Info("Generated NDJSON report")
+
+		case "remediation":
+			reportData, err := report.GenerateRemediationPlan(assessment)
+			if err != nil {
+				logger.Error(err, "Failed to generate remediation plan")
+				continue
+			}
+			data["remediation.yaml"] = string(reportData)
+			logger.Info("Generated remediation plan")
+		}
+	}
+
+	// Add the per-control report of the selected compliance pack
+	if pack := assessment.Spec.CompliancePack; pack != "" {
+		reportData, err := report.GenerateCompliancePackReport(assessment)
+		if err != nil {
+			logger.Error(err, "Failed to generate compliance pack report", "pack", pack)
+		} else {
+			data[fmt.Sprintf("compliance-%s.json", pack)] = string(reportData)
+			logger.Info("Generated compliance pack report", "pack", pack)
 		}
 	}
 
@@ -471,7 +1434,7 @@ func (r *ClusterAssessmentReconciler) storeReportInConfigMap(ctx context.Context
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cmName,
-			Namespace: "cluster-assessment-operator",
+			Namespace: reportNamespace,
 			Labels: map[string]string{
 				"app.kubernetes.io/name":       "cluster-assessment-operator",
 				"app.kubernetes.io/managed-by": "cluster-assessment-operator",
@@ -510,6 +1473,321 @@ func (r *ClusterAssessmentReconciler) storeReportInConfigMap(ctx context.Context
 	return nil
 }
 
+// Digest defaults and ConfigMap keys.
+const (
+	defaultDigestSchedule = "0 0 * * 1"
+	defaultDigestRuns     = 7
+	digestJSONKey         = "digest.json"
+	digestHTMLKey         = "digest.html"
+)
+
+// storeDigest writes a digest of the latest runs to the <name>-digest
+// ConfigMap when the digest schedule is due since the previous digest stored
+// there. history is the run history, newest first.
+func (r *ClusterAssessmentReconciler) storeDigest(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment, history []assessmentv1alpha1.RunSummary, findings []assessmentv1alpha1.Finding, now time.Time) error {
+	spec := assessment.Spec.Digest
+	scheduleSpec := spec.Schedule
+	if scheduleSpec == "" {
+		scheduleSpec = defaultDigestSchedule
+	}
+	schedule, err := cron.ParseStandard(scheduleSpec)
+	if err != nil {
+		return fmt.Errorf("invalid digest schedule: %w", err)
+	}
+
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Name: assessment.Name + "-digest", Namespace: reportNamespace}
+	exists := true
+	var previous report.Digest
+	if err := r.Get(ctx, key, cm); errors.IsNotFound(err) {
+		exists = false
+	} else if err != nil {
+		return fmt.Errorf("failed to get digest ConfigMap: %w", err)
+	} else if err := json.Unmarshal([]byte(cm.Data[digestJSONKey]), &previous); err != nil {
+		log.FromContext(ctx).Info("Ignoring unreadable previous digest", "configMap", key.Name, "error", err.Error())
+	} else if now.Before(schedule.Next(previous.GeneratedAt)) {
+		return nil
+	}
+
+	runs := defaultDigestRuns
+	if spec.Runs != nil {
+		runs = int(*spec.Runs)
+	}
+	if len(history) > runs {
+		history = history[:runs]
+	}
+	digest := report.GenerateDigest(history, previous.Findings, findings)
+	digest.AssessmentName = assessment.Name
+	digest.GeneratedAt = now
+	data, err := json.MarshalIndent(digest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode digest: %w", err)
+	}
+
+	cm.Name, cm.Namespace = key.Name, key.Namespace
+	cm.Labels = map[string]string{
+		"app.kubernetes.io/name":       "cluster-assessment-operator",
+		"app.kubernetes.io/managed-by": "cluster-assessment-operator",
+		"assessment.openshift.io/name": assessment.Name,
+	}
+	cm.Data = map[string]string{
+		digestJSONKey: string(data),
+		digestHTMLKey: string(report.GenerateDigestHTML(digest)),
+	}
+	if err := ctrl.SetControllerReference(assessment, cm, r.Scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference on digest ConfigMap: %w", err)
+	}
+
+	if exists {
+		err = r.Update(ctx, cm)
+	} else {
+		err = r.Create(ctx, cm)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store digest ConfigMap: %w", err)
+	}
+	log.FromContext(ctx).Info("Digest stored in ConfigMap", "configMap", key.Name, "runs", len(digest.Runs))
+	return nil
+}
+
+// Status board name and size.
+const (
+	statusBoardName       = "assessment-status-board"
+	maxStatusBoardEntries = 100
+)
+
+// updateStatusBoard upserts the latest result of the assessment into the
+// shared status board ConfigMap, keyed by assessment name. The board is not
+// owned by any assessment, and concurrent completions are retried on conflict.
+func (r *ClusterAssessmentReconciler) updateStatusBoard(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment, completedAt time.Time) error {
+	entry := report.NewStatusBoardEntry(assessment, completedAt)
+	key := client.ObjectKey{Name: statusBoardName, Namespace: reportNamespace}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm := &corev1.ConfigMap{}
+		exists := true
+		if err := r.Get(ctx, key, cm); errors.IsNotFound(err) {
+			exists = false
+			cm.Name, cm.Namespace = key.Name, key.Namespace
+		} else if err != nil {
+			return fmt.Errorf("failed to get status board ConfigMap: %w", err)
+		}
+
+		if cm.Labels == nil {
+			cm.Labels = map[string]string{}
+		}
+		cm.Labels["app.kubernetes.io/name"] = "cluster-assessment-operator"
+		cm.Labels["app.kubernetes.io/managed-by"] = "cluster-assessment-operator"
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		if err := report.UpsertStatusBoard(cm.Data, assessment.Name, entry, maxStatusBoardEntries); err != nil {
+			return err
+		}
+
+		if exists {
+			return r.Update(ctx, cm)
+		}
+		return r.Create(ctx, cm)
+	})
+}
+
+// reportNamespace is the namespace report ConfigMaps and report templates live in.
+const reportNamespace = "cluster-assessment-operator"
+
+// defaultTemplateKey is the ConfigMap key read when templateRef.key is unset.
+const defaultTemplateKey = "report.html.tmpl"
+
+// generateHTMLReport renders the HTML report with the template referenced by
+// reportStorage.configMap.templateRef, falling back to the built-in layout when
+// none is set or the template cannot be loaded or rendered.
+func (r *ClusterAssessmentReconciler) generateHTMLReport(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment) ([]byte, error) {
+	ref := assessment.Spec.ReportStorage.ConfigMap.TemplateRef
+	if ref == nil || ref.Name == "" {
+		return report.GenerateHTML(assessment)
+	}
+
+	key := ref.Key
+	if key == "" {
+		key = defaultTemplateKey
+	}
+
+	cm := &corev1.ConfigMap{}
+	err := r.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: reportNamespace}, cm)
+	if err == nil {
+		text, ok := cm.Data[key]
+		if !ok {
+			err = fmt.Errorf("key %q not found in ConfigMap %s/%s", key, reportNamespace, ref.Name)
+		} else {
+			var html []byte
+			if html, err = report.GenerateHTMLFromTemplate(assessment, text); err == nil {
+				return html, nil
+			}
+		}
+	}
+
+	log.FromContext(ctx).Error(err, "Failed to use custom report template, falling back to built-in layout",
+		"configMap", ref.Name, "key", key)
+	return report.GenerateHTML(assessment)
+}
+
+// reportStorageBackoff is the retry schedule for report storage operations.
+var reportStorageBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 500 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
+// retryReportStorage runs fn with exponential backoff while it returns errors
+// accepted by retriable.
+func retryReportStorage(retriable func(error) bool, fn func() error) error {
+	return retry.OnError(reportStorageBackoff, retriable, fn)
+}
+
+// isTransientAPIError reports whether err is a transient API server error worth retrying.
+func isTransientAPIError(err error) bool {
+	return errors.IsTooManyRequests(err) ||
+		errors.IsServerTimeout(err) ||
+		errors.IsTimeout(err) ||
+		errors.IsServiceUnavailable(err) ||
+		errors.IsInternalError(err) ||
+		errors.IsConflict(err)
+}
+
+// isTransientGitError reports whether a Git export error is worth retrying:
+// network failures, server errors and pushes rejected because the branch
+// moved. Authentication failures, missing repositories and local errors are
+// permanent.
+func isTransientGitError(err error) bool {
+	if stderrors.Is(err, git.ErrForceNeeded) || stderrors.Is(err, io.ErrUnexpectedEOF) ||
+		stderrors.Is(err, syscall.ECONNREFUSED) || stderrors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	var httpErr *http.Err
+	if stderrors.As(err, &httpErr) {
+		code := httpErr.StatusCode()
+		return code == 429 || code >= 500
+	}
+	var dnsErr *net.DNSError
+	if stderrors.As(err, &dnsErr) {
+		return !dnsErr.IsNotFound
+	}
+	var netErr net.Error
+	return stderrors.As(err, &netErr)
+}
+
+// mergeConditions returns conditions, with the LastTransitionTime of those
+// whose status is the same in previous kept from previous. Conditions of
+// other types in previous are dropped.
+func mergeConditions(previous, conditions []metav1.Condition) []metav1.Condition {
+	var merged []metav1.Condition
+	for _, cond := range conditions {
+		if old := meta.FindStatusCondition(previous, cond.Type); old != nil {
+			merged = append(merged, *old)
+		}
+		meta.SetStatusCondition(&merged, cond)
+	}
+	return merged
+}
+
+// passedCondition builds the Passed condition used to gate pipelines with
+// `kubectl wait --for=condition=Passed`. Without a passing score, any FAIL
+// finding fails the assessment; with one, only the score is compared.
+func passedCondition(summary assessmentv1alpha1.AssessmentSummary, passingScore *int32, now metav1.Time) metav1.Condition {
+	cond := metav1.Condition{
+		Type:               "Passed",
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: now,
+	}
+
+	if passingScore != nil {
+		score := 0
+		if summary.Score != nil {
+			score = *summary.Score
+		}
+		cond.Message = fmt.Sprintf("Score %d, passing score %d", score, *passingScore)
+		if score >= int(*passingScore) {
+			cond.Status = metav1.ConditionTrue
+			cond.Reason = "ScoreAboveThreshold"
+		} else {
+			cond.Reason = "ScoreBelowThreshold"
+		}
+		return cond
+	}
+
+	if summary.FailCount > 0 {
+		cond.Reason = "FailedChecks"
+		cond.Message = fmt.Sprintf("%d check(s) failed", summary.FailCount)
+		return cond
+	}
+	cond.Status = metav1.ConditionTrue
+	cond.Reason = "NoFailedChecks"
+	cond.Message = "No checks failed"
+	return cond
+}
+
+// reportStoredCondition builds the ReportStored condition from the storage errors of a run.
+func reportStoredCondition(storageErrors []string, now metav1.Time) metav1.Condition {
+	if len(storageErrors) > 0 {
+		return metav1.Condition{
+			Type:               "ReportStored",
+			Status:             metav1.ConditionFalse,
+			LastTransitionTime: now,
+			Reason:             "StorageFailed",
+			Message:            strings.Join(storageErrors, "; "),
+		}
+	}
+	return metav1.Condition{
+		Type:               "ReportStored",
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: now,
+		Reason:             "ReportStored",
+		Message:            "Report stored in all configured destinations",
+	}
+}
+
+// gzipBytes compresses data using gzip at the default compression level.
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// pushRemoteWrite pushes the current metric values of the assessment to the
+// configured Prometheus remote-write endpoint.
+func (r *ClusterAssessmentReconciler) pushRemoteWrite(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment, clusterID string) error {
+	spec := assessment.Spec.MetricsRemoteWrite
+	rw := &metrics.RemoteWriteClient{URL: spec.URL}
+
+	if spec.SecretRef != "" {
+		namespace := os.Getenv("POD_NAMESPACE")
+		if namespace == "" {
+			namespace = "cluster-assessment-operator"
+		}
+
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Name: spec.SecretRef, Namespace: namespace}, secret); err != nil {
+			return fmt.Errorf("failed to get remote-write secret: %w", err)
+		}
+		rw.Username = string(secret.Data["username"])
+		rw.Password = string(secret.Data["password"])
+		rw.BearerToken = string(secret.Data["token"])
+	}
+
+	series, err := metrics.Snapshot(ctrlmetrics.Registry, assessment.Name, clusterID)
+	if err != nil {
+		return err
+	}
+	return rw.Push(ctx, series, time.Now())
+}
+
 // exportToGit exports the report to a Git repository.
 func (r *ClusterAssessmentReconciler) exportToGit(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment) error {
 	logger := log.FromContext(ctx)
@@ -686,6 +1964,7 @@ func (r *ClusterAssessmentReconciler) exportToGit(ctx context.Context, assessmen
 
 // updateStatus updates the assessment status with retry on conflict.
 func (r *ClusterAssessmentReconciler) updateStatus(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment, phase, message string) (ctrl.Result, error) {
+	var retryAfter time.Duration
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		// Fetch latest version
 		latest := &assessmentv1alpha1.ClusterAssessment{}
@@ -694,6 +1973,27 @@ func (r *ClusterAssessmentReconciler) updateStatus(ctx context.Context, assessme
 		}
 		latest.Status.Phase = phase
 		latest.Status.Message = message
+		if phase == assessmentv1alpha1.PhaseFailed {
+			now := metav1.Now()
+			latest.Status.FailureCount++
+			latest.Status.LastFailureTime = &now
+			// A failed rerun counts as handled, so it is not retried in a loop
+			latest.Status.LastRerun = assessment.Annotations[assessmentv1alpha1.RerunAnnotation]
+			// Do not leave a stale Passed condition from an earlier run
+			meta.SetStatusCondition(&latest.Status.Conditions, metav1.Condition{
+				Type:    "Passed",
+				Status:  metav1.ConditionFalse,
+				Reason:  "AssessmentFailed",
+				Message: message,
+			})
+			// A failed scheduled run has no LastRunTime to schedule the next
+			// one from, so record when to retry it
+			if latest.Spec.Schedule != "" {
+				retryAt := scheduledRetryTime(latest.Spec.Schedule, latest.Status.FailureCount, now.Time)
+				latest.Status.NextRunTime = &metav1.Time{Time: retryAt}
+				retryAfter = retryAt.Sub(now.Time)
+			}
+		}
 		return r.Status().Update(ctx, latest)
 	})
 	if err != nil {
@@ -702,6 +2002,15 @@ func (r *ClusterAssessmentReconciler) updateStatus(ctx context.Context, assessme
 	// Update the local copy
 	assessment.Status.Phase = phase
 	assessment.Status.Message = message
+	if phase == assessmentv1alpha1.PhaseFailed {
+		// Status updates trigger a reconcile anyway; requeue in case the
+		// backoff outlasts it
+		assessment.Status.FailureCount++
+		if assessment.Spec.Schedule != "" {
+			return ctrl.Result{RequeueAfter: retryAfter}, nil
+		}
+		return ctrl.Result{RequeueAfter: failureBackoff(assessment.Status.FailureCount)}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -742,17 +2051,89 @@ func (r *ClusterAssessmentReconciler) recordValidatorMetrics(assessmentName stri
 	}
 }
 
-// filterBySeverity filters findings to only include those at or above the minimum severity.
-// Severity order (from lowest to highest): INFO < PASS < WARN < FAIL
-func (r *ClusterAssessmentReconciler) filterBySeverity(findings []assessmentv1alpha1.Finding, minSeverity string) []assessmentv1alpha1.Finding {
-	severityOrder := map[string]int{
-		"INFO": 0,
-		"PASS": 1,
-		"WARN": 2,
-		"FAIL": 3,
+// maxFindingEvents caps the number of per-finding Events recorded for a single
+// assessment run; remaining failures are aggregated into one summary Event.
+const maxFindingEvents = 10
+
+// emitFindingEvents records a Warning Event on the assessment for each FAIL finding.
+func (r *ClusterAssessmentReconciler) emitFindingEvents(assessment *assessmentv1alpha1.ClusterAssessment, findings []assessmentv1alpha1.Finding) {
+	if r.Recorder == nil {
+		return
+	}
+
+	var failed []assessmentv1alpha1.Finding
+	for _, f := range findings {
+		if f.Status == assessmentv1alpha1.FindingStatusFail {
+			failed = append(failed, f)
+		}
+	}
+
+	for i, f := range failed {
+		if i == maxFindingEvents {
+			r.Recorder.Eventf(assessment, corev1.EventTypeWarning, "AssessmentFinding",
+				"%d more failed finding(s) not shown; see the assessment report for details", len(failed)-maxFindingEvents)
+			return
+		}
+		r.Recorder.Event(assessment, corev1.EventTypeWarning, "AssessmentFinding", f.Title)
 	}
+}
 
-	minLevel, ok := severityOrder[minSeverity]
+// escalatePersistentWarnings escalates WARN findings to FAIL once they have
+// been reported for more than after consecutive runs, and returns the updated
+// WARN streaks keyed by finding ID. Streaks are tracked even when escalation
+// is disabled (after is 0).
+func escalatePersistentWarnings(findings []assessmentv1alpha1.Finding, prevStreaks map[string]int32, after int) ([]assessmentv1alpha1.Finding, map[string]int32) {
+	streaks := make(map[string]int32)
+	for i := range findings {
+		f := &findings[i]
+		// Findings carried forward by incremental scans may already be escalated.
+		if f.EscalatedFrom != "" {
+			f.Status, f.EscalatedFrom = f.EscalatedFrom, ""
+		}
+		if f.Status != assessmentv1alpha1.FindingStatusWarn {
+			continue
+		}
+		if _, counted := streaks[f.ID]; !counted {
+			streaks[f.ID] = prevStreaks[f.ID] + 1
+		}
+		if after > 0 && int(streaks[f.ID]) > after {
+			f.EscalatedFrom = assessmentv1alpha1.FindingStatusWarn
+			f.Status = assessmentv1alpha1.FindingStatusFail
+		}
+	}
+
+	if len(streaks) == 0 {
+		return findings, nil
+	}
+	return findings, streaks
+}
+
+// severityRank orders finding statuses for minSeverity filtering, from least
+// to most severe. PASS ranks lowest, as it needs no attention, followed by
+// INFO observations that may.
+var severityRank = map[assessmentv1alpha1.FindingStatus]int{
+	assessmentv1alpha1.FindingStatusPass: 0,
+	assessmentv1alpha1.FindingStatusInfo: 1,
+	assessmentv1alpha1.FindingStatusWarn: 2,
+	assessmentv1alpha1.FindingStatusFail: 3,
+}
+
+// minSeverityShorthands are the minSeverity values that name a set of
+// statuses rather than a status, mapped to the least severe status they keep.
+var minSeverityShorthands = map[string]assessmentv1alpha1.FindingStatus{
+	"actionable": assessmentv1alpha1.FindingStatusWarn,
+	"fail-only":  assessmentv1alpha1.FindingStatusFail,
+}
+
+// filterBySeverity filters findings to only include those at or above the
+// minimum severity, a status or one of minSeverityShorthands.
+// Returns all findings if minSeverity is invalid.
+func filterBySeverity(findings []assessmentv1alpha1.Finding, minSeverity string) []assessmentv1alpha1.Finding {
+	status := assessmentv1alpha1.FindingStatus(minSeverity)
+	if shorthand, ok := minSeverityShorthands[minSeverity]; ok {
+		status = shorthand
+	}
+	minLevel, ok := severityRank[status]
 	if !ok {
 		// Invalid minSeverity, return all findings
 		return findings
@@ -760,7 +2141,7 @@ func (r *ClusterAssessmentReconciler) filterBySeverity(findings []assessmentv1al
 
 	var filtered []assessmentv1alpha1.Finding
 	for _, f := range findings {
-		level, ok := severityOrder[string(f.Status)]
+		level, ok := severityRank[f.Status]
 		if !ok {
 			continue
 		}
@@ -772,10 +2153,41 @@ func (r *ClusterAssessmentReconciler) filterBySeverity(findings []assessmentv1al
 	return filtered
 }
 
+// confidenceRank orders finding confidence levels from least to most certain.
+var confidenceRank = map[assessmentv1alpha1.FindingConfidence]int{
+	assessmentv1alpha1.FindingConfidenceLow:    0,
+	assessmentv1alpha1.FindingConfidenceMedium: 1,
+	assessmentv1alpha1.FindingConfidenceHigh:   2,
+}
+
+// filterByConfidence filters findings to only include those at or above the
+// minimum confidence. Findings without a confidence are treated as high.
+// Returns all findings if minConfidence is invalid.
+func filterByConfidence(findings []assessmentv1alpha1.Finding, minConfidence string) []assessmentv1alpha1.Finding {
+	minLevel, ok := confidenceRank[assessmentv1alpha1.FindingConfidence(minConfidence)]
+	if !ok {
+		return findings
+	}
+
+	var filtered []assessmentv1alpha1.Finding
+	for _, f := range findings {
+		level, ok := confidenceRank[f.Confidence]
+		if !ok {
+			level = confidenceRank[assessmentv1alpha1.FindingConfidenceHigh]
+		}
+		if level >= minLevel {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterAssessmentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&assessmentv1alpha1.ClusterAssessment{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.Limiter.Limit()}).
 		Owns(&corev1.ConfigMap{}).
+		Owns(&batchv1.Job{}).
 		Complete(r)
 }
@@ -17,14 +17,46 @@ limitations under the License.
 package controllers
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/report"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator"
 )
 
 func TestFilterBySeverity(t *testing.T) {
-	r := &ClusterAssessmentReconciler{}
-
 	findings := []assessmentv1alpha1.Finding{
 		{ID: "info-1", Status: assessmentv1alpha1.FindingStatusInfo, Title: "Info Finding 1"},
 		{ID: "pass-1", Status: assessmentv1alpha1.FindingStatusPass, Title: "Pass Finding 1"},
@@ -42,14 +74,14 @@ func TestFilterBySeverity(t *testing.T) {
 		wantCount   int
 	}{
 		{
-			name:        "filter INFO - include all",
-			minSeverity: "INFO",
+			name:        "filter PASS - include all",
+			minSeverity: "PASS",
 			wantCount:   8,
 		},
 		{
-			name:        "filter PASS - exclude INFO",
-			minSeverity: "PASS",
-			wantCount:   6, // 2 PASS + 2 WARN + 2 FAIL
+			name:        "filter INFO - exclude PASS",
+			minSeverity: "INFO",
+			wantCount:   6, // 2 INFO + 2 WARN + 2 FAIL
 		},
 		{
 			name:        "filter WARN - include WARN and FAIL only",
@@ -61,6 +93,26 @@ func TestFilterBySeverity(t *testing.T) {
 			minSeverity: "FAIL",
 			wantCount:   2, // 2 FAIL
 		},
+		{
+			name:        "actionable shorthand - include WARN and FAIL",
+			minSeverity: "actionable",
+			wantCount:   4,
+		},
+		{
+			name:        "fail-only shorthand - include FAIL only",
+			minSeverity: "fail-only",
+			wantCount:   2,
+		},
+		{
+			name:        "shorthands are case-sensitive - return all",
+			minSeverity: "FAIL-ONLY",
+			wantCount:   8,
+		},
+		{
+			name:        "lowercase status - return all",
+			minSeverity: "warn",
+			wantCount:   8,
+		},
 		{
 			name:        "invalid severity - return all",
 			minSeverity: "INVALID",
@@ -75,7 +127,7 @@ func TestFilterBySeverity(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			filtered := r.filterBySeverity(findings, tt.minSeverity)
+			filtered := filterBySeverity(findings, tt.minSeverity)
 			if len(filtered) != tt.wantCount {
 				t.Errorf("filterBySeverity(%s) returned %d findings, want %d",
 					tt.minSeverity, len(filtered), tt.wantCount)
@@ -87,9 +139,35 @@ func TestFilterBySeverity(t *testing.T) {
 	}
 }
 
-func TestCalculateSummary(t *testing.T) {
-	r := &ClusterAssessmentReconciler{}
+func TestFilterByConfidence(t *testing.T) {
+	findings := []assessmentv1alpha1.Finding{
+		{ID: "high-1", Confidence: assessmentv1alpha1.FindingConfidenceHigh},
+		{ID: "unset-1"},
+		{ID: "medium-1", Confidence: assessmentv1alpha1.FindingConfidenceMedium},
+		{ID: "low-1", Confidence: assessmentv1alpha1.FindingConfidenceLow},
+	}
+
+	tests := []struct {
+		minConfidence string
+		wantCount     int
+	}{
+		{minConfidence: "low", wantCount: 4},
+		{minConfidence: "medium", wantCount: 3},
+		{minConfidence: "high", wantCount: 2},
+		{minConfidence: "HIGH", wantCount: 4},
+		{minConfidence: "", wantCount: 4},
+	}
 
+	for _, tt := range tests {
+		t.Run(tt.minConfidence, func(t *testing.T) {
+			if got := filterByConfidence(findings, tt.minConfidence); len(got) != tt.wantCount {
+				t.Errorf("filterByConfidence(%q) returned %d findings, want %d", tt.minConfidence, len(got), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestCalculateSummary(t *testing.T) {
 	findings := []assessmentv1alpha1.Finding{
 		{ID: "info-1", Status: assessmentv1alpha1.FindingStatusInfo},
 		{ID: "pass-1", Status: assessmentv1alpha1.FindingStatusPass},
@@ -98,7 +176,7 @@ func TestCalculateSummary(t *testing.T) {
 		{ID: "fail-1", Status: assessmentv1alpha1.FindingStatusFail},
 	}
 
-	summary := r.calculateSummary(findings, "production")
+	summary := calculateSummary(findings, "production")
 
 	if summary.TotalChecks != 5 {
 		t.Errorf("Expected TotalChecks=5, got %d", summary.TotalChecks)
@@ -136,15 +214,13 @@ func TestCalculateSummary(t *testing.T) {
 }
 
 func TestCalculateSummary_AllPass(t *testing.T) {
-	r := &ClusterAssessmentReconciler{}
-
 	findings := []assessmentv1alpha1.Finding{
 		{ID: "pass-1", Status: assessmentv1alpha1.FindingStatusPass},
 		{ID: "pass-2", Status: assessmentv1alpha1.FindingStatusPass},
 		{ID: "pass-3", Status: assessmentv1alpha1.FindingStatusPass},
 	}
 
-	summary := r.calculateSummary(findings, "production")
+	summary := calculateSummary(findings, "production")
 
 	if summary.Score == nil {
 		t.Error("Expected Score to be set")
@@ -154,14 +230,12 @@ func TestCalculateSummary_AllPass(t *testing.T) {
 }
 
 func TestCalculateSummary_AllFail(t *testing.T) {
-	r := &ClusterAssessmentReconciler{}
-
 	findings := []assessmentv1alpha1.Finding{
 		{ID: "fail-1", Status: assessmentv1alpha1.FindingStatusFail},
 		{ID: "fail-2", Status: assessmentv1alpha1.FindingStatusFail},
 	}
 
-	summary := r.calculateSummary(findings, "production")
+	summary := calculateSummary(findings, "production")
 
 	if summary.Score == nil {
 		t.Error("Expected Score to be set")
@@ -171,11 +245,9 @@ func TestCalculateSummary_AllFail(t *testing.T) {
 }
 
 func TestCalculateSummary_Empty(t *testing.T) {
-	r := &ClusterAssessmentReconciler{}
-
 	findings := []assessmentv1alpha1.Finding{}
 
-	summary := r.calculateSummary(findings, "production")
+	summary := calculateSummary(findings, "production")
 
 	if summary.TotalChecks != 0 {
 		t.Errorf("Expected TotalChecks=0, got %d", summary.TotalChecks)
@@ -185,3 +257,1219 @@ func TestCalculateSummary_Empty(t *testing.T) {
 		t.Error("Expected Score to be nil for empty findings")
 	}
 }
+
+func TestGzipBytes_RoundTrip(t *testing.T) {
+	original := []byte(strings.Repeat(`{"status":"PASS"}`, 1000))
+
+	compressed, err := gzipBytes(original)
+	if err != nil {
+		t.Fatalf("gzipBytes() returned error: %v", err)
+	}
+	if len(compressed) >= len(original) {
+		t.Errorf("Expected compressed size < %d, got %d", len(original), len(compressed))
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("gzip.NewReader() returned error: %v", err)
+	}
+	decompressed, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("Failed to read decompressed data: %v", err)
+	}
+	if !bytes.Equal(decompressed, original) {
+		t.Error("Decompressed data does not match original")
+	}
+}
+
+func TestFleetComparison(t *testing.T) {
+	now := time.Now()
+	recent := metav1.NewTime(now.Add(-time.Hour))
+	stale := metav1.NewTime(now.Add(-2 * fleetComparisonWindow))
+	score := func(s int) *int { return &s }
+
+	assessments := []assessmentv1alpha1.ClusterAssessment{
+		{ObjectMeta: metav1.ObjectMeta{Name: "self"}, Status: assessmentv1alpha1.ClusterAssessmentStatus{LastRunTime: &recent, Summary: assessmentv1alpha1.AssessmentSummary{Score: score(10)}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "a"}, Status: assessmentv1alpha1.ClusterAssessmentStatus{LastRunTime: &recent, Summary: assessmentv1alpha1.AssessmentSummary{Score: score(80)}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "b"}, Status: assessmentv1alpha1.ClusterAssessmentStatus{LastRunTime: &recent, Summary: assessmentv1alpha1.AssessmentSummary{Score: score(82)}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "stale"}, Status: assessmentv1alpha1.ClusterAssessmentStatus{LastRunTime: &stale, Summary: assessmentv1alpha1.AssessmentSummary{Score: score(0)}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "never-run"}},
+	}
+
+	cmp := fleetComparison("self", 72, assessments, now)
+	if cmp == nil {
+		t.Fatal("Expected fleet comparison, got nil")
+	}
+	if cmp.AverageScore != 81 {
+		t.Errorf("Expected AverageScore=81, got %d", cmp.AverageScore)
+	}
+	if cmp.AssessmentCount != 2 {
+		t.Errorf("Expected AssessmentCount=2, got %d", cmp.AssessmentCount)
+	}
+	if cmp.Delta != -9 {
+		t.Errorf("Expected Delta=-9, got %d", cmp.Delta)
+	}
+
+	if cmp := fleetComparison("self", 72, assessments[:1], now); cmp != nil {
+		t.Errorf("Expected nil comparison without other assessments, got %+v", cmp)
+	}
+}
+
+func TestStoreReportInConfigMap_RetriesTransientErrors(t *testing.T) {
+	origBackoff := reportStorageBackoff
+	reportStorageBackoff = wait.Backoff{Steps: 5, Duration: time.Millisecond, Factor: 2.0}
+	defer func() { reportStorageBackoff = origBackoff }()
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = assessmentv1alpha1.AddToScheme(scheme)
+
+	// Fail the first two ConfigMap creates with a throttling error.
+	failures := 2
+	createCalls := 0
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				createCalls++
+				if createCalls <= failures {
+					return apierrors.NewTooManyRequests("throttled", 1)
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	r := &ClusterAssessmentReconciler{Client: c, Scheme: scheme}
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "uid"},
+		Spec: assessmentv1alpha1.ClusterAssessmentSpec{
+			ReportStorage: assessmentv1alpha1.ReportStorageSpec{
+				ConfigMap: &assessmentv1alpha1.ConfigMapStorageSpec{Enabled: true},
+			},
+		},
+	}
+
+	err := retryReportStorage(isTransientAPIError, func() error {
+		return r.storeReportInConfigMap(context.Background(), assessment)
+	})
+	if err != nil {
+		t.Fatalf("Expected storage to succeed after retries, got %v", err)
+	}
+	if createCalls != failures+1 {
+		t.Errorf("Expected %d create calls, got %d", failures+1, createCalls)
+	}
+
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Name: assessment.Status.ReportConfigMap, Namespace: "cluster-assessment-operator"}
+	if err := c.Get(context.Background(), key, cm); err != nil {
+		t.Errorf("Expected report ConfigMap to exist: %v", err)
+	}
+}
+
+func TestRetryReportStorage_PersistentFailure(t *testing.T) {
+	origBackoff := reportStorageBackoff
+	reportStorageBackoff = wait.Backoff{Steps: 3, Duration: time.Millisecond, Factor: 2.0}
+	defer func() { reportStorageBackoff = origBackoff }()
+
+	calls := 0
+	err := retryReportStorage(isTransientAPIError, func() error {
+		calls++
+		return apierrors.NewServiceUnavailable("unavailable")
+	})
+	if err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+
+	cond := reportStoredCondition([]string{"ConfigMap: " + err.Error()}, metav1.Now())
+	if cond.Type != "ReportStored" || cond.Status != metav1.ConditionFalse {
+		t.Errorf("Expected ReportStored=False condition, got %s=%s", cond.Type, cond.Status)
+	}
+}
+
+func TestIsTransientGitError(t *testing.T) {
+	httpErr := func(code int) error {
+		return &githttp.Err{Response: &http.Response{
+			StatusCode: code,
+			Request:    httptest.NewRequest(http.MethodGet, "https://git.example.com/reports.git", nil),
+		}}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"connection refused", &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, true},
+		{"connection reset", fmt.Errorf("failed to push to repository: %w", syscall.ECONNRESET), true},
+		{"server error", fmt.Errorf("failed to clone repository: %w", httpErr(http.StatusBadGateway)), true},
+		{"throttled", httpErr(http.StatusTooManyRequests), true},
+		{"branch moved", fmt.Errorf("failed to push to repository: %w", git.ErrForceNeeded), true},
+		{"authentication", fmt.Errorf("failed to clone repository: %w", transport.ErrAuthenticationRequired), false},
+		{"authorization", transport.ErrAuthorizationFailed, false},
+		{"missing repository", fmt.Errorf("failed to clone repository: %w", transport.ErrRepositoryNotFound), false},
+		{"unknown host", &net.DNSError{Err: "no such host", Name: "git.example.com", IsNotFound: true}, false},
+		{"bad request", httpErr(http.StatusBadRequest), false},
+		{"invalid path", errors.New("invalid path: ../etc"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientGitError(tt.err); got != tt.want {
+				t.Errorf("isTransientGitError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmitFindingEvents(t *testing.T) {
+	recorder := record.NewFakeRecorder(100)
+	r := &ClusterAssessmentReconciler{Recorder: recorder}
+	assessment := &assessmentv1alpha1.ClusterAssessment{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+
+	findings := []assessmentv1alpha1.Finding{
+		{ID: "pass-1", Status: assessmentv1alpha1.FindingStatusPass, Title: "All good"},
+		{ID: "warn-1", Status: assessmentv1alpha1.FindingStatusWarn, Title: "Needs review"},
+	}
+	for i := 0; i < maxFindingEvents+3; i++ {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:     fmt.Sprintf("fail-%d", i),
+			Status: assessmentv1alpha1.FindingStatusFail,
+			Title:  fmt.Sprintf("Failure %d", i),
+		})
+	}
+
+	r.emitFindingEvents(assessment, findings)
+	close(recorder.Events)
+
+	var events []string
+	for e := range recorder.Events {
+		events = append(events, e)
+	}
+	if len(events) != maxFindingEvents+1 {
+		t.Fatalf("expected %d events, got %d: %v", maxFindingEvents+1, len(events), events)
+	}
+	if events[0] != "Warning AssessmentFinding Failure 0" {
+		t.Errorf("unexpected first event: %q", events[0])
+	}
+	if !strings.Contains(events[maxFindingEvents], "3 more failed finding(s)") {
+		t.Errorf("expected aggregated event, got %q", events[maxFindingEvents])
+	}
+}
+
+func TestSummaryHandler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = assessmentv1alpha1.AddToScheme(scheme)
+	_ = authenticationv1.AddToScheme(scheme)
+	_ = authorizationv1.AddToScheme(scheme)
+	score := 95
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		ObjectMeta: metav1.ObjectMeta{Name: "weekly"},
+		Status: assessmentv1alpha1.ClusterAssessmentStatus{
+			Summary: assessmentv1alpha1.AssessmentSummary{Score: &score},
+		},
+	}
+	// "viewer-token" authenticates as viewer, who may get any assessment;
+	// "other-token" authenticates as a user without access
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(assessment).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				switch review := obj.(type) {
+				case *authenticationv1.TokenReview:
+					switch review.Spec.Token {
+					case "viewer-token":
+						review.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: authenticationv1.UserInfo{Username: "viewer"}}
+					case "other-token":
+						review.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: authenticationv1.UserInfo{Username: "other"}}
+					}
+					return nil
+				case *authorizationv1.SubjectAccessReview:
+					review.Status.Allowed = review.Spec.User == "viewer" && review.Spec.ResourceAttributes.Resource == "clusterassessments"
+					return nil
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).Build()
+
+	mux := http.NewServeMux()
+	mux.Handle(SummaryHandlerPath, SummaryHandler(c))
+
+	get := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("/assessments/weekly/summary", "viewer-token")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"grade":"A"`) {
+		t.Errorf("unexpected body: %s", rec.Body.String())
+	}
+
+	if rec := get("/assessments/missing/summary", "viewer-token"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
+	}
+	if rec := get("/assessments/weekly/summary", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a token, got %d", rec.Code)
+	}
+	if rec := get("/assessments/weekly/summary", "invalid-token"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for an invalid token, got %d", rec.Code)
+	}
+	if rec := get("/assessments/weekly/summary", "other-token"); rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for a user without access, got %d", rec.Code)
+	}
+}
+
+func TestCalculateSummary_CategoryBreakdown(t *testing.T) {
+	findings := []assessmentv1alpha1.Finding{
+		{Category: "Security", Status: assessmentv1alpha1.FindingStatusPass},
+		{Category: "Platform", Status: assessmentv1alpha1.FindingStatusInfo},
+	}
+
+	summary := calculateSummary(findings, "production")
+	if len(summary.CategoryBreakdown) != 2 {
+		t.Errorf("expected calculateSummary to include the breakdown, got %+v", summary.CategoryBreakdown)
+	}
+}
+
+func TestAppendHistory(t *testing.T) {
+	run := func(score int) assessmentv1alpha1.RunSummary {
+		return assessmentv1alpha1.RunSummary{Time: metav1.Now(), Score: &score}
+	}
+
+	var history []assessmentv1alpha1.RunSummary
+	for i := 1; i <= 4; i++ {
+		history = appendHistory(history, run(i*10), 3)
+	}
+	if len(history) != 3 {
+		t.Fatalf("expected history capped at 3, got %d", len(history))
+	}
+	for i, want := range []int{40, 30, 20} {
+		if *history[i].Score != want {
+			t.Errorf("history[%d]: expected score %d, got %d", i, want, *history[i].Score)
+		}
+	}
+
+	if got := appendHistory(history, run(50), 0); got != nil {
+		t.Errorf("expected history disabled with limit 0, got %+v", got)
+	}
+}
+
+func TestHistoryLimit(t *testing.T) {
+	assessment := &assessmentv1alpha1.ClusterAssessment{}
+	if got := historyLimit(assessment); got != defaultHistoryLimit {
+		t.Errorf("expected default %d, got %d", defaultHistoryLimit, got)
+	}
+	limit := int32(3)
+	assessment.Spec.HistoryLimit = &limit
+	if got := historyLimit(assessment); got != 3 {
+		t.Errorf("expected 3, got %d", got)
+	}
+}
+
+// stubValidator returns fixed findings or an error.
+type stubValidator struct {
+	name     string
+	findings []assessmentv1alpha1.Finding
+	err      error
+}
+
+func (v *stubValidator) Name() string        { return v.name }
+func (v *stubValidator) Description() string { return "stub" }
+func (v *stubValidator) Category() string    { return "Test" }
+func (v *stubValidator) Validate(context.Context, client.Client, profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
+	return v.findings, v.err
+}
+
+func TestRunAssessment_AllValidatorsFail(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = assessmentv1alpha1.AddToScheme(scheme)
+
+	assessment := &assessmentv1alpha1.ClusterAssessment{ObjectMeta: metav1.ObjectMeta{Name: "rbac-broken"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(assessment).
+		WithStatusSubresource(assessment).
+		Build()
+
+	registry := validator.NewRegistry()
+	forbidden := fmt.Errorf("nodes is forbidden")
+	_ = registry.Register(&stubValidator{name: "nodes", err: forbidden})
+	_ = registry.Register(&stubValidator{name: "storage", err: forbidden})
+
+	r := &ClusterAssessmentReconciler{Client: c, Scheme: scheme, Registry: registry}
+	if _, err := r.runAssessment(context.Background(), assessment); err != nil {
+		t.Fatalf("runAssessment returned error: %v", err)
+	}
+
+	got := &assessmentv1alpha1.ClusterAssessment{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(assessment), got); err != nil {
+		t.Fatalf("failed to get assessment: %v", err)
+	}
+	if got.Status.Phase != assessmentv1alpha1.PhaseFailed {
+		t.Errorf("expected phase Failed, got %s", got.Status.Phase)
+	}
+	if !strings.Contains(got.Status.Message, "all 2 validators returned errors") {
+		t.Errorf("unexpected message: %s", got.Status.Message)
+	}
+	if got.Status.Summary.Score != nil {
+		t.Errorf("expected no score for a failed run, got %d", *got.Status.Summary.Score)
+	}
+}
+
+func TestInsufficientResults(t *testing.T) {
+	tests := []struct {
+		name         string
+		executed     []string
+		failed       []string
+		findingCount int
+		minFindings  int
+		want         string
+	}{
+		{"healthy", []string{"a", "b"}, nil, 10, 1, ""},
+		{"some validators failed", []string{"a", "b"}, []string{"a"}, 3, 1, ""},
+		{"all validators failed", []string{"a", "b"}, []string{"a", "b"}, 2, 1, "all 2 validators returned errors (a, b)"},
+		{"no findings", []string{"a"}, nil, 0, 1, "produced 0 findings, fewer than the minimum of 1"},
+		{"below custom minimum", []string{"a"}, nil, 4, 5, "fewer than the minimum of 5"},
+		{"gating disabled", []string{"a"}, nil, 0, 0, ""},
+	}
+	for _, tt := range tests {
+		got := insufficientResults(tt.executed, tt.failed, tt.findingCount, tt.minFindings)
+		if tt.want == "" && got != "" || !strings.Contains(got, tt.want) {
+			t.Errorf("%s: insufficientResults = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCriticalFailure(t *testing.T) {
+	errs := map[string]error{"version": errors.New("forbidden"), "storage": errors.New("timeout")}
+
+	executed := []string{"version", "storage", "nodes"}
+
+	if got := criticalFailure(nil, executed, nil, errs); got != "" {
+		t.Errorf("expected no failure without critical validators, got %q", got)
+	}
+	if got := criticalFailure([]string{"nodes"}, executed, nil, errs); got != "" {
+		t.Errorf("expected no failure when critical validators succeed, got %q", got)
+	}
+	got := criticalFailure([]string{"version", "nodes"}, executed, nil, errs)
+	if !strings.Contains(got, "version: forbidden") || strings.Contains(got, "storage") {
+		t.Errorf("unexpected reason %q", got)
+	}
+
+	// A critical validator that did not run fails the assessment, unless an
+	// incremental run reused its findings.
+	got = criticalFailure([]string{"security"}, executed, nil, errs)
+	if !strings.Contains(got, "security: did not run") {
+		t.Errorf("expected a validator that did not run to fail, got %q", got)
+	}
+	if got := criticalFailure([]string{"security"}, executed, []string{"security"}, errs); got != "" {
+		t.Errorf("expected a skipped validator to count as completed, got %q", got)
+	}
+}
+
+func TestIncrementalSalt(t *testing.T) {
+	salt := func(generation int64, expected *validator.ExpectedState) string {
+		t.Helper()
+		got, err := incrementalSalt(generation, expected)
+		if err != nil {
+			t.Fatalf("incrementalSalt returned an error: %v", err)
+		}
+		return got
+	}
+
+	if got := salt(3, nil); got != "3" {
+		t.Errorf("expected the generation without expected state, got %q", got)
+	}
+	base := salt(3, &validator.ExpectedState{RequiredOperators: []string{"etcd"}})
+	if base == salt(3, nil) || base == salt(4, &validator.ExpectedState{RequiredOperators: []string{"etcd"}}) {
+		t.Errorf("expected the salt to change with the expected state and generation, got %q", base)
+	}
+	if got := salt(3, &validator.ExpectedState{RequiredOperators: []string{"etcd", "dns"}}); got == base {
+		t.Error("expected an edited expected state to change the salt")
+	}
+	if got := salt(3, &validator.ExpectedState{RequiredOperators: []string{"etcd"}}); got != base {
+		t.Errorf("expected a stable salt, got %q and %q", base, got)
+	}
+}
+
+func TestReportCoverage(t *testing.T) {
+	registered := []string{"version", "security", "nodes", "certificates", "storage"}
+
+	ran, omitted := reportCoverage(registered, []string{"security", "certificates"}, []string{"storage"})
+	if !reflect.DeepEqual(ran, []string{"certificates", "security", "storage"}) {
+		t.Errorf("ran = %v", ran)
+	}
+	if !reflect.DeepEqual(omitted, []string{"nodes", "version"}) {
+		t.Errorf("omitted = %v", omitted)
+	}
+
+	ran, omitted = reportCoverage(registered, registered, nil)
+	if len(ran) != len(registered) || omitted != nil {
+		t.Errorf("expected a full assessment, got ran=%v omitted=%v", ran, omitted)
+	}
+}
+
+func TestMinFindings(t *testing.T) {
+	assessment := &assessmentv1alpha1.ClusterAssessment{}
+	if got := minFindings(assessment); got != defaultMinFindings {
+		t.Errorf("expected default %d, got %d", defaultMinFindings, got)
+	}
+	zero := int32(0)
+	assessment.Spec.MinFindings = &zero
+	if got := minFindings(assessment); got != 0 {
+		t.Errorf("expected 0, got %d", got)
+	}
+}
+
+func TestGenerateHTMLReport_CustomTemplate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	tmpl := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "branding", Namespace: reportNamespace},
+		Data: map[string]string{
+			defaultTemplateKey: `<h1>ACME report for {{ .ClusterInfo.ClusterID }}</h1>`,
+			"broken":           `{{ .Missing`,
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tmpl).Build()
+	r := &ClusterAssessmentReconciler{Client: c, Scheme: scheme}
+
+	tests := []struct {
+		name string
+		ref  *assessmentv1alpha1.TemplateReference
+		want string
+	}{
+		{name: "no template", ref: nil, want: "OpenShift Cluster Assessment Report"},
+		{name: "custom template", ref: &assessmentv1alpha1.TemplateReference{Name: "branding"}, want: "<h1>ACME report for abc</h1>"},
+		{name: "unparsable template", ref: &assessmentv1alpha1.TemplateReference{Name: "branding", Key: "broken"}, want: "OpenShift Cluster Assessment Report"},
+		{name: "missing key", ref: &assessmentv1alpha1.TemplateReference{Name: "branding", Key: "absent"}, want: "OpenShift Cluster Assessment Report"},
+		{name: "missing ConfigMap", ref: &assessmentv1alpha1.TemplateReference{Name: "absent"}, want: "OpenShift Cluster Assessment Report"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assessment := &assessmentv1alpha1.ClusterAssessment{
+				Spec: assessmentv1alpha1.ClusterAssessmentSpec{
+					ReportStorage: assessmentv1alpha1.ReportStorageSpec{
+						ConfigMap: &assessmentv1alpha1.ConfigMapStorageSpec{Enabled: true, TemplateRef: tt.ref},
+					},
+				},
+				Status: assessmentv1alpha1.ClusterAssessmentStatus{
+					ClusterInfo: assessmentv1alpha1.ClusterInfo{ClusterID: "abc"},
+				},
+			}
+
+			out, err := r.generateHTMLReport(context.Background(), assessment)
+			if err != nil {
+				t.Fatalf("generateHTMLReport() returned error: %v", err)
+			}
+			if !strings.Contains(string(out), tt.want) {
+				t.Errorf("Expected output to contain %q, got %q", tt.want, out)
+			}
+		})
+	}
+}
+
+func TestReconcileOneTime_RerunAnnotation(t *testing.T) {
+	// A saturated limiter makes any run requeue immediately, which shows
+	// whether reconcileOneTime decided to run without needing a cluster.
+	limiter := NewAssessmentLimiter(1)
+	limiter.TryAcquire()
+	defer limiter.Release()
+	r := &ClusterAssessmentReconciler{Limiter: limiter}
+
+	tests := []struct {
+		name       string
+		annotation string
+		lastRerun  string
+		wantRun    bool
+	}{
+		{name: "no annotation", wantRun: false},
+		{name: "annotation already handled", annotation: "1700000000", lastRerun: "1700000000", wantRun: false},
+		{name: "new annotation", annotation: "1700000000", wantRun: true},
+		{name: "changed annotation", annotation: "1700000500", lastRerun: "1700000000", wantRun: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assessment := &assessmentv1alpha1.ClusterAssessment{
+				ObjectMeta: metav1.ObjectMeta{Name: "adhoc"},
+				Status: assessmentv1alpha1.ClusterAssessmentStatus{
+					Phase:     assessmentv1alpha1.PhaseCompleted,
+					LastRerun: tt.lastRerun,
+				},
+			}
+			if tt.annotation != "" {
+				assessment.Annotations = map[string]string{assessmentv1alpha1.RerunAnnotation: tt.annotation}
+			}
+
+			result, err := r.reconcileOneTime(context.Background(), assessment)
+			if err != nil {
+				t.Fatalf("reconcileOneTime() returned error: %v", err)
+			}
+			if ran := result.RequeueAfter == assessmentLimitRequeueDelay; ran != tt.wantRun {
+				t.Errorf("ran = %v, want %v", ran, tt.wantRun)
+			}
+		})
+	}
+}
+
+func TestReconcile_CaptureBaselineAnnotation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = assessmentv1alpha1.AddToScheme(scheme)
+
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "adhoc",
+			Annotations: map[string]string{assessmentv1alpha1.CaptureBaselineAnnotation: "true"},
+		},
+		Spec: assessmentv1alpha1.ClusterAssessmentSpec{BaselineConfigMap: "approved"},
+		Status: assessmentv1alpha1.ClusterAssessmentStatus{
+			Phase: assessmentv1alpha1.PhaseCompleted,
+			Findings: []assessmentv1alpha1.Finding{
+				{ID: "nodes-count", Validator: "nodes", Status: assessmentv1alpha1.FindingStatusPass, Title: "Node count"},
+				{ID: "security-privileged", Validator: "security", Status: assessmentv1alpha1.FindingStatusWarn, Title: "Privileged pods"},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(assessment).WithStatusSubresource(assessment).Build()
+	recorder := record.NewFakeRecorder(1)
+	r := &ClusterAssessmentReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(assessment)}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() returned error: %v", err)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: "approved", Namespace: reportNamespace}, cm); err != nil {
+		t.Fatalf("Expected baseline ConfigMap: %v", err)
+	}
+	var got baseline
+	if err := json.Unmarshal([]byte(cm.Data[baselineKey]), &got); err != nil {
+		t.Fatalf("Failed to decode baseline: %v", err)
+	}
+	if got.Assessment != "adhoc" || len(got.Findings) != 2 || got.Findings[1].ID != "security-privileged" {
+		t.Errorf("Unexpected baseline: %+v", got)
+	}
+
+	updated := &assessmentv1alpha1.ClusterAssessment{}
+	if err := c.Get(context.Background(), req.NamespacedName, updated); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := updated.Annotations[assessmentv1alpha1.CaptureBaselineAnnotation]; ok {
+		t.Error("Expected capture-baseline annotation to be removed")
+	}
+	if event := <-recorder.Events; !strings.Contains(event, "BaselineCaptured") {
+		t.Errorf("Unexpected event %q", event)
+	}
+}
+
+func TestCaptureBaselineRequested(t *testing.T) {
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "adhoc",
+			Annotations: map[string]string{assessmentv1alpha1.CaptureBaselineAnnotation: "true"},
+		},
+		Status: assessmentv1alpha1.ClusterAssessmentStatus{Phase: assessmentv1alpha1.PhaseRunning},
+	}
+	if captureBaselineRequested(assessment) {
+		t.Error("Baseline should not be captured while the assessment is running")
+	}
+	assessment.Status.Phase = assessmentv1alpha1.PhaseCompleted
+	if !captureBaselineRequested(assessment) {
+		t.Error("Expected baseline capture on a completed assessment")
+	}
+	if baselineConfigMapName(assessment) != "adhoc-baseline" {
+		t.Errorf("Default baseline ConfigMap = %q", baselineConfigMapName(assessment))
+	}
+}
+
+func TestLoadBaseline_Compressed(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = assessmentv1alpha1.AddToScheme(scheme)
+
+	var findings []assessmentv1alpha1.Finding
+	for i := 0; i < 3000; i++ {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          fmt.Sprintf("security-privileged-%d", i),
+			Status:      assessmentv1alpha1.FindingStatusWarn,
+			Description: strings.Repeat("privileged container ", 20),
+		})
+	}
+	key, data, err := encodeBaseline(baseline{Assessment: "adhoc", Findings: findings})
+	if err != nil {
+		t.Fatalf("encodeBaseline() returned error: %v", err)
+	}
+	if key != baselineGzipKey || len(data) > maxBaselineSize {
+		t.Fatalf("Expected a compressed baseline, got key %s of %d bytes", key, len(data))
+	}
+
+	assessment := &assessmentv1alpha1.ClusterAssessment{ObjectMeta: metav1.ObjectMeta{Name: "adhoc"}}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "adhoc-baseline", Namespace: reportNamespace},
+		BinaryData: map[string][]byte{key: data},
+	}
+	r := &ClusterAssessmentReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm).Build(), Scheme: scheme}
+	got, err := r.loadBaseline(context.Background(), assessment)
+	if err != nil {
+		t.Fatalf("loadBaseline() returned error: %v", err)
+	}
+	if got == nil || len(got.Findings) != 3000 {
+		t.Errorf("Expected the 3000 baseline findings, got %+v", got)
+	}
+
+	r.Client = fake.NewClientBuilder().WithScheme(scheme).Build()
+	if got, err := r.loadBaseline(context.Background(), assessment); got != nil || err != nil {
+		t.Errorf("Expected no baseline without a ConfigMap, got %+v, %v", got, err)
+	}
+}
+
+func TestRegressedCondition(t *testing.T) {
+	base := &baseline{
+		CapturedAt: metav1.NewTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)),
+		Findings: []assessmentv1alpha1.Finding{
+			{ID: "nodes-count", Status: assessmentv1alpha1.FindingStatusPass},
+			{ID: "security-privileged", Status: assessmentv1alpha1.FindingStatusWarn},
+			{ID: "etcd-backup", Status: assessmentv1alpha1.FindingStatusFail},
+		},
+	}
+	now := metav1.Now()
+
+	unchanged := []assessmentv1alpha1.Finding{
+		{ID: "nodes-count", Status: assessmentv1alpha1.FindingStatusPass},
+		{ID: "security-privileged", Status: assessmentv1alpha1.FindingStatusWarn},
+		{ID: "etcd-backup", Status: assessmentv1alpha1.FindingStatusWarn},
+		{ID: "version-channel", Status: assessmentv1alpha1.FindingStatusInfo},
+	}
+	if cond := regressedCondition(base, unchanged, now); cond.Status != metav1.ConditionFalse || cond.Reason != "NoRegressions" {
+		t.Errorf("Expected no regressions, got %+v", cond)
+	}
+
+	regressed := []assessmentv1alpha1.Finding{
+		{ID: "nodes-count", Status: assessmentv1alpha1.FindingStatusWarn},
+		{ID: "security-privileged", Status: assessmentv1alpha1.FindingStatusFail},
+		{ID: "storage-default-class", Status: assessmentv1alpha1.FindingStatusWarn},
+	}
+	cond := regressedCondition(base, regressed, now)
+	if cond.Status != metav1.ConditionTrue || cond.Reason != "FindingsRegressed" {
+		t.Fatalf("Expected regressions, got %+v", cond)
+	}
+	if !strings.Contains(cond.Message, "3 finding(s)") || !strings.Contains(cond.Message, "2024-05-01T12:00:00Z: nodes-count, security-privileged, storage-default-class") {
+		t.Errorf("Unexpected message %q", cond.Message)
+	}
+
+	// A later run with the same regressions keeps the transition time.
+	later := metav1.NewTime(now.Add(time.Hour))
+	merged := mergeConditions([]metav1.Condition{cond}, []metav1.Condition{regressedCondition(base, regressed, later)})
+	if got := meta.FindStatusCondition(merged, "Regressed"); got == nil || !got.LastTransitionTime.Equal(&now) {
+		t.Errorf("Expected the Regressed condition to keep its transition time %v, got %+v", now, got)
+	}
+}
+
+func TestProfileComparison(t *testing.T) {
+	runs := []profileRun{
+		{profile: "development", findings: []assessmentv1alpha1.Finding{
+			{ID: "nodes-workers", Validator: "nodes", Status: assessmentv1alpha1.FindingStatusPass},
+			{ID: "security-privileged", Validator: "security", Namespace: "team-a", Resource: "api", Status: assessmentv1alpha1.FindingStatusInfo},
+			{ID: "security-privileged", Validator: "security", Namespace: "team-b", Resource: "api", Status: assessmentv1alpha1.FindingStatusWarn},
+		}},
+		{profile: "production", findings: []assessmentv1alpha1.Finding{
+			{ID: "nodes-workers", Validator: "nodes", Status: assessmentv1alpha1.FindingStatusFail},
+			{ID: "security-privileged", Validator: "security", Namespace: "team-a", Resource: "api", Status: assessmentv1alpha1.FindingStatusWarn},
+			{ID: "security-privileged", Validator: "security", Namespace: "team-b", Resource: "api", Status: assessmentv1alpha1.FindingStatusWarn},
+			{ID: "monitoring-retention", Validator: "monitoring", Status: assessmentv1alpha1.FindingStatusWarn},
+		}},
+	}
+
+	comparison := profileComparison(runs)
+	if len(comparison.Profiles) != 2 || comparison.Profiles[0].Profile != "development" || comparison.Profiles[1].FailCount != 1 {
+		t.Errorf("Unexpected profile results: %+v", comparison.Profiles)
+	}
+
+	var got []string
+	for _, d := range comparison.Differences {
+		got = append(got, fmt.Sprintf("%s %s/%s %s->%s", d.ID, d.Namespace, d.Resource, d.Statuses["development"], d.Statuses["production"]))
+	}
+	want := []string{
+		"monitoring-retention / ->WARN",
+		"nodes-workers / PASS->FAIL",
+		"security-privileged team-a/api INFO->WARN",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Differences = %q, want %q", got, want)
+	}
+}
+
+func TestRunAssessment_ProfileComparisonIgnoresEscalation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = assessmentv1alpha1.AddToScheme(scheme)
+
+	// The warning has persisted for as many runs as the production profile
+	// allows, so this run escalates it to FAIL.
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		ObjectMeta: metav1.ObjectMeta{Name: "escalated"},
+		Spec: assessmentv1alpha1.ClusterAssessmentSpec{
+			Profile:         string(profiles.ProfileProduction),
+			CompareProfiles: []string{string(profiles.ProfileDevelopment)},
+		},
+		Status: assessmentv1alpha1.ClusterAssessmentStatus{
+			WarnStreaks: map[string]int32{"nodes-count": int32(profiles.GetProfile(string(profiles.ProfileProduction)).Thresholds.EscalateAfterRuns)},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(assessment).
+		WithStatusSubresource(assessment).
+		Build()
+
+	registry := validator.NewRegistry()
+	_ = registry.Register(&stubValidator{name: "nodes", findings: []assessmentv1alpha1.Finding{
+		{ID: "nodes-count", Validator: "nodes", Title: "Node count", Status: assessmentv1alpha1.FindingStatusWarn},
+	}})
+
+	r := &ClusterAssessmentReconciler{Client: c, Scheme: scheme, Registry: registry}
+	if _, err := r.runAssessment(context.Background(), assessment); err != nil {
+		t.Fatalf("runAssessment returned error: %v", err)
+	}
+
+	got := &assessmentv1alpha1.ClusterAssessment{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(assessment), got); err != nil {
+		t.Fatalf("failed to get assessment: %v", err)
+	}
+	if len(got.Status.Findings) != 1 || got.Status.Findings[0].Status != assessmentv1alpha1.FindingStatusFail {
+		t.Fatalf("expected the warning to be escalated, got %+v", got.Status.Findings)
+	}
+	if got.Status.ProfileComparison == nil {
+		t.Fatal("expected a profile comparison")
+	}
+	if diffs := got.Status.ProfileComparison.Differences; len(diffs) != 0 {
+		t.Errorf("expected no differences between profiles, got %+v", diffs)
+	}
+}
+
+func TestStoreDigest(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = assessmentv1alpha1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &ClusterAssessmentReconciler{Client: c, Scheme: scheme}
+
+	runs := int32(2)
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		ObjectMeta: metav1.ObjectMeta{Name: "weekly", UID: "uid"},
+		Spec: assessmentv1alpha1.ClusterAssessmentSpec{
+			Schedule: "0 * * * *",
+			Digest:   &assessmentv1alpha1.DigestSpec{Schedule: "0 0 * * 1", Runs: &runs},
+		},
+	}
+	history := []assessmentv1alpha1.RunSummary{{}, {}, {}}
+	warn := []assessmentv1alpha1.Finding{{ID: "nodes-workers", Status: assessmentv1alpha1.FindingStatusWarn}}
+	key := client.ObjectKey{Name: "weekly-digest", Namespace: reportNamespace}
+	digestAt := func() report.Digest {
+		t.Helper()
+		cm := &corev1.ConfigMap{}
+		if err := c.Get(context.Background(), key, cm); err != nil {
+			t.Fatalf("Expected digest ConfigMap: %v", err)
+		}
+		if !strings.Contains(cm.Data[digestHTMLKey], "Assessment Digest: weekly") {
+			t.Error("Expected the HTML digest")
+		}
+		var digest report.Digest
+		if err := json.Unmarshal([]byte(cm.Data[digestJSONKey]), &digest); err != nil {
+			t.Fatal(err)
+		}
+		return digest
+	}
+
+	// Wednesday: the first digest is stored right away.
+	wednesday := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	if err := r.storeDigest(context.Background(), assessment, history, warn, wednesday); err != nil {
+		t.Fatalf("storeDigest() returned error: %v", err)
+	}
+	if digest := digestAt(); len(digest.Runs) != 2 || len(digest.Appeared) != 1 {
+		t.Errorf("Unexpected first digest: %+v", digest)
+	}
+
+	// Sunday: not due until Monday.
+	if err := r.storeDigest(context.Background(), assessment, history, nil, wednesday.Add(4*24*time.Hour)); err != nil {
+		t.Fatalf("storeDigest() returned error: %v", err)
+	}
+	if digest := digestAt(); !digest.GeneratedAt.Equal(wednesday) {
+		t.Errorf("Digest regenerated before it was due: %v", digest.GeneratedAt)
+	}
+
+	// Monday: the warning is resolved since the first digest.
+	monday := wednesday.Add(5 * 24 * time.Hour)
+	if err := r.storeDigest(context.Background(), assessment, history, nil, monday); err != nil {
+		t.Fatalf("storeDigest() returned error: %v", err)
+	}
+	if digest := digestAt(); !digest.GeneratedAt.Equal(monday) || len(digest.Resolved) != 1 {
+		t.Errorf("Unexpected second digest: %+v", digest)
+	}
+}
+
+func TestUpdateStatusBoard(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &ClusterAssessmentReconciler{Client: c, Scheme: scheme}
+
+	score := 91
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	for _, name := range []string{"daily", "weekly"} {
+		assessment := &assessmentv1alpha1.ClusterAssessment{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		assessment.Status.Summary = assessmentv1alpha1.AssessmentSummary{Score: &score, PassCount: 12}
+		if err := r.updateStatusBoard(context.Background(), assessment, now); err != nil {
+			t.Fatalf("updateStatusBoard() returned error: %v", err)
+		}
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: statusBoardName, Namespace: reportNamespace}, cm); err != nil {
+		t.Fatalf("Expected status board ConfigMap: %v", err)
+	}
+	if len(cm.Data) != 2 {
+		t.Fatalf("Expected 2 board entries, got %v", cm.Data)
+	}
+	var entry report.StatusBoardEntry
+	if err := json.Unmarshal([]byte(cm.Data["weekly"]), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Grade != "A" || entry.PassCount != 12 || !entry.CompletedAt.Equal(now) {
+		t.Errorf("Unexpected board entry: %+v", entry)
+	}
+}
+
+func TestEscalatePersistentWarnings(t *testing.T) {
+	run := func() []assessmentv1alpha1.Finding {
+		return []assessmentv1alpha1.Finding{
+			{ID: "a-warn", Status: assessmentv1alpha1.FindingStatusWarn},
+			{ID: "b-pass", Status: assessmentv1alpha1.FindingStatusPass},
+		}
+	}
+
+	var streaks map[string]int32
+	var findings []assessmentv1alpha1.Finding
+	for i := 1; i <= 3; i++ {
+		findings, streaks = escalatePersistentWarnings(run(), streaks, 2)
+		if streaks["a-warn"] != int32(i) {
+			t.Fatalf("Run %d: streak = %d, want %d", i, streaks["a-warn"], i)
+		}
+		if _, ok := streaks["b-pass"]; ok {
+			t.Fatalf("Run %d: PASS findings should not be tracked", i)
+		}
+	}
+
+	// The third consecutive run exceeds the limit of 2.
+	if findings[0].Status != assessmentv1alpha1.FindingStatusFail || findings[0].EscalatedFrom != assessmentv1alpha1.FindingStatusWarn {
+		t.Errorf("Expected escalation to FAIL, got %s (from %q)", findings[0].Status, findings[0].EscalatedFrom)
+	}
+	if findings[1].Status != assessmentv1alpha1.FindingStatusPass {
+		t.Errorf("PASS finding changed to %s", findings[1].Status)
+	}
+
+	// An escalated finding carried forward keeps counting as a warning.
+	findings, streaks = escalatePersistentWarnings(findings, streaks, 2)
+	if streaks["a-warn"] != 4 || findings[0].Status != assessmentv1alpha1.FindingStatusFail {
+		t.Errorf("Carried finding: streak = %d, status = %s", streaks["a-warn"], findings[0].Status)
+	}
+
+	// Resolving the warning resets its streak.
+	resolved := []assessmentv1alpha1.Finding{{ID: "a-warn", Status: assessmentv1alpha1.FindingStatusPass}}
+	if _, streaks = escalatePersistentWarnings(resolved, streaks, 2); streaks != nil {
+		t.Errorf("Expected no streaks after the warning resolved, got %v", streaks)
+	}
+
+	// Escalation is disabled with a limit of 0, but streaks are still tracked.
+	findings, streaks = escalatePersistentWarnings(run(), map[string]int32{"a-warn": 50}, 0)
+	if findings[0].Status != assessmentv1alpha1.FindingStatusWarn || streaks["a-warn"] != 51 {
+		t.Errorf("Disabled escalation: status = %s, streak = %d", findings[0].Status, streaks["a-warn"])
+	}
+}
+
+func TestPassedCondition(t *testing.T) {
+	score := func(v int) *int { return &v }
+	threshold := func(v int32) *int32 { return &v }
+
+	tests := []struct {
+		name         string
+		summary      assessmentv1alpha1.AssessmentSummary
+		passingScore *int32
+		want         metav1.ConditionStatus
+	}{
+		{"no failures", assessmentv1alpha1.AssessmentSummary{WarnCount: 3, Score: score(70)}, nil, metav1.ConditionTrue},
+		{"failures", assessmentv1alpha1.AssessmentSummary{FailCount: 1, Score: score(95)}, nil, metav1.ConditionFalse},
+		{"score at threshold", assessmentv1alpha1.AssessmentSummary{FailCount: 2, Score: score(80)}, threshold(80), metav1.ConditionTrue},
+		{"score below threshold", assessmentv1alpha1.AssessmentSummary{Score: score(79)}, threshold(80), metav1.ConditionFalse},
+		{"no score", assessmentv1alpha1.AssessmentSummary{}, threshold(1), metav1.ConditionFalse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cond := passedCondition(tt.summary, tt.passingScore, metav1.Now())
+			if cond.Type != "Passed" || cond.Status != tt.want {
+				t.Errorf("Got %s=%s (%s), want Passed=%s", cond.Type, cond.Status, cond.Reason, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeConditions(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(earlier.Add(time.Hour))
+	previous := []metav1.Condition{
+		{Type: "Ready", Status: metav1.ConditionTrue, LastTransitionTime: earlier, Reason: "AssessmentCompleted"},
+		{Type: "Passed", Status: metav1.ConditionTrue, LastTransitionTime: earlier, Reason: "NoFailedChecks"},
+		{Type: "ReportStored", Status: metav1.ConditionTrue, LastTransitionTime: earlier, Reason: "Stored"},
+	}
+
+	tests := []struct {
+		name     string
+		passed   metav1.ConditionStatus
+		wantTime metav1.Time
+	}{
+		{"status unchanged", metav1.ConditionTrue, earlier},
+		{"status flipped", metav1.ConditionFalse, now},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := mergeConditions(previous, []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionTrue, LastTransitionTime: now, Reason: "AssessmentCompleted"},
+				{Type: "Passed", Status: tt.passed, LastTransitionTime: now, Reason: "FailedChecks", Message: "1 check(s) failed"},
+			})
+			if len(merged) != 2 {
+				t.Fatalf("expected the Ready and Passed conditions, got %+v", merged)
+			}
+			passed := meta.FindStatusCondition(merged, "Passed")
+			if !passed.LastTransitionTime.Equal(&tt.wantTime) {
+				t.Errorf("LastTransitionTime = %v, want %v", passed.LastTransitionTime, tt.wantTime)
+			}
+			if passed.Status != tt.passed || passed.Reason != "FailedChecks" || passed.Message != "1 check(s) failed" {
+				t.Errorf("expected the new status, reason and message, got %+v", passed)
+			}
+			if ready := meta.FindStatusCondition(merged, "Ready"); !ready.LastTransitionTime.Equal(&earlier) {
+				t.Errorf("Ready LastTransitionTime = %v, want %v", ready.LastTransitionTime, earlier)
+			}
+		})
+	}
+}
+
+func TestFailureBackoff(t *testing.T) {
+	tests := []struct {
+		failures int32
+		want     time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{6, 16 * time.Minute},
+		{7, 30 * time.Minute},
+		{100, 30 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := failureBackoff(tt.failures); got != tt.want {
+			t.Errorf("failureBackoff(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestReconcileScheduled_FailedRunBacksOff(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = assessmentv1alpha1.AddToScheme(scheme)
+
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly"},
+		Spec:       assessmentv1alpha1.ClusterAssessmentSpec{Schedule: "* * * * *"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(assessment).
+		WithStatusSubresource(assessment).
+		Build()
+
+	registry := validator.NewRegistry()
+	_ = registry.Register(&stubValidator{name: "nodes", err: fmt.Errorf("nodes is forbidden")})
+
+	r := &ClusterAssessmentReconciler{Client: c, Scheme: scheme, Registry: registry}
+	result, err := r.runAssessment(context.Background(), assessment)
+	if err != nil {
+		t.Fatalf("runAssessment returned error: %v", err)
+	}
+	if result.RequeueAfter < failureBackoff(1) {
+		t.Errorf("expected a requeue after at least %v, got %v", failureBackoff(1), result.RequeueAfter)
+	}
+
+	got := &assessmentv1alpha1.ClusterAssessment{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(assessment), got); err != nil {
+		t.Fatalf("failed to get assessment: %v", err)
+	}
+	if got.Status.Phase != assessmentv1alpha1.PhaseFailed {
+		t.Fatalf("expected phase Failed, got %s", got.Status.Phase)
+	}
+	if got.Status.NextRunTime == nil || !got.Status.NextRunTime.After(time.Now()) {
+		t.Fatalf("expected a future NextRunTime, got %v", got.Status.NextRunTime)
+	}
+
+	// As in TestReconcileOneTime_FailedRetries, a saturated limiter shows
+	// whether reconcileScheduled decided to run.
+	r.Limiter = NewAssessmentLimiter(1)
+	r.Limiter.TryAcquire()
+	defer r.Limiter.Release()
+	result, err = r.reconcileScheduled(context.Background(), got)
+	if err != nil {
+		t.Fatalf("reconcileScheduled returned error: %v", err)
+	}
+	if result.RequeueAfter == assessmentLimitRequeueDelay || result.RequeueAfter <= 0 {
+		t.Errorf("expected the failed run to wait for its retry, got requeue after %v", result.RequeueAfter)
+	}
+}
+
+func TestReconcileOneTime_FailedRetries(t *testing.T) {
+	// As in TestReconcileOneTime_RerunAnnotation, a saturated limiter shows
+	// whether reconcileOneTime decided to run.
+	limiter := NewAssessmentLimiter(1)
+	limiter.TryAcquire()
+	defer limiter.Release()
+	r := &ClusterAssessmentReconciler{Limiter: limiter}
+
+	zero := int32(0)
+	tests := []struct {
+		name        string
+		failures    int32
+		failedAgo   time.Duration
+		maxRetries  *int32
+		annotation  string
+		wantRun     bool
+		wantRequeue bool
+	}{
+		{name: "backoff elapsed", failures: 1, failedAgo: time.Minute, wantRun: true},
+		{name: "within backoff", failures: 3, failedAgo: time.Minute, wantRequeue: true},
+		{name: "retries exhausted", failures: 6, failedAgo: time.Hour},
+		{name: "retries disabled", failures: 1, failedAgo: time.Hour, maxRetries: &zero},
+		{name: "rerun after exhausted retries", failures: 6, failedAgo: time.Second, annotation: "1700000000", wantRun: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failedAt := metav1.NewTime(time.Now().Add(-tt.failedAgo))
+			assessment := &assessmentv1alpha1.ClusterAssessment{
+				ObjectMeta: metav1.ObjectMeta{Name: "adhoc"},
+				Spec:       assessmentv1alpha1.ClusterAssessmentSpec{MaxRetries: tt.maxRetries},
+				Status: assessmentv1alpha1.ClusterAssessmentStatus{
+					Phase:           assessmentv1alpha1.PhaseFailed,
+					FailureCount:    tt.failures,
+					LastFailureTime: &failedAt,
+				},
+			}
+			if tt.annotation != "" {
+				assessment.Annotations = map[string]string{assessmentv1alpha1.RerunAnnotation: tt.annotation}
+			}
+
+			result, err := r.reconcileOneTime(context.Background(), assessment)
+			if err != nil {
+				t.Fatalf("reconcileOneTime() returned error: %v", err)
+			}
+			ran := result.RequeueAfter == assessmentLimitRequeueDelay
+			if ran != tt.wantRun {
+				t.Errorf("ran = %v, want %v", ran, tt.wantRun)
+			}
+			if requeued := !ran && result.RequeueAfter > 0; requeued != tt.wantRequeue {
+				t.Errorf("requeued = %v (after %v), want %v", requeued, result.RequeueAfter, tt.wantRequeue)
+			}
+		})
+	}
+}
+
+func TestReconcileOneTime_StuckRunning(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = assessmentv1alpha1.AddToScheme(scheme)
+
+	startedAt := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		ObjectMeta: metav1.ObjectMeta{Name: "adhoc"},
+		Status: assessmentv1alpha1.ClusterAssessmentStatus{
+			Phase:       assessmentv1alpha1.PhaseRunning,
+			LastRunTime: &startedAt,
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(assessment).
+		WithStatusSubresource(assessment).
+		Build()
+
+	r := &ClusterAssessmentReconciler{Client: c, Scheme: scheme}
+	result, err := r.reconcileOneTime(context.Background(), assessment)
+	if err != nil {
+		t.Fatalf("reconcileOneTime() returned error: %v", err)
+	}
+	if result.RequeueAfter != failureBackoff(1) {
+		t.Errorf("expected a requeue after %v, got %v", failureBackoff(1), result.RequeueAfter)
+	}
+
+	got := &assessmentv1alpha1.ClusterAssessment{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(assessment), got); err != nil {
+		t.Fatalf("failed to get assessment: %v", err)
+	}
+	if got.Status.Phase != assessmentv1alpha1.PhaseFailed || got.Status.FailureCount != 1 || got.Status.LastFailureTime == nil {
+		t.Errorf("expected a counted failure, got phase %s, %d failure(s), last at %v", got.Status.Phase, got.Status.FailureCount, got.Status.LastFailureTime)
+	}
+	if cond := meta.FindStatusCondition(got.Status.Conditions, "Passed"); cond == nil || cond.Status != metav1.ConditionFalse {
+		t.Errorf("expected a Passed=False condition, got %+v", cond)
+	}
+}
+
+func TestLoadExpectedState(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	cms := []client.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "expected", Namespace: reportNamespace},
+			Data:       map[string]string{validator.ExpectedStateKey: "allowedRegistries: [quay.io]\n"},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "typo", Namespace: reportNamespace},
+			Data:       map[string]string{validator.ExpectedStateKey: "allowedRegistry: [quay.io]\n"},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "wrong-key", Namespace: reportNamespace},
+			Data:       map[string]string{"expected.yaml": "{}"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cms...).Build()
+	r := &ClusterAssessmentReconciler{Client: c, Scheme: scheme}
+
+	load := func(name string) (*validator.ExpectedState, error) {
+		assessment := &assessmentv1alpha1.ClusterAssessment{
+			Spec: assessmentv1alpha1.ClusterAssessmentSpec{ExpectedStateConfigMap: name},
+		}
+		return r.loadExpectedState(context.Background(), assessment)
+	}
+
+	if state, err := load(""); state != nil || err != nil {
+		t.Errorf("expected no state without a ConfigMap, got %+v, %v", state, err)
+	}
+	state, err := load("expected")
+	if err != nil || len(state.AllowedRegistries) != 1 {
+		t.Errorf("expected the allowed registries to load, got %+v, %v", state, err)
+	}
+	for _, name := range []string{"typo", "wrong-key", "absent"} {
+		if _, err := load(name); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import "time"
+
+// DefaultMaxConcurrentAssessments is the default number of assessments that may
+// run at the same time.
+const DefaultMaxConcurrentAssessments = 2
+
+// assessmentLimitRequeueDelay is how long an assessment waits before retrying
+// when the concurrency limit is reached.
+const assessmentLimitRequeueDelay = 15 * time.Second
+
+// AssessmentLimiter caps the number of assessments running at the same time,
+// so that many schedules firing together do not overwhelm the API server. A
+// single limiter is shared by all assessment controllers. A nil limiter
+// allows one assessment at a time per controller.
+type AssessmentLimiter struct {
+	tokens chan struct{}
+}
+
+// NewAssessmentLimiter returns a limiter allowing up to max concurrent
+// assessments. Values below 1 are treated as 1.
+func NewAssessmentLimiter(max int) *AssessmentLimiter {
+	if max < 1 {
+		max = 1
+	}
+	return &AssessmentLimiter{tokens: make(chan struct{}, max)}
+}
+
+// TryAcquire takes a token without blocking and reports whether it succeeded.
+// Callers that acquire a token must Release it when the assessment finishes.
+func (l *AssessmentLimiter) TryAcquire() bool {
+	if l == nil {
+		return true
+	}
+	select {
+	case l.tokens <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Release returns a token taken by TryAcquire.
+func (l *AssessmentLimiter) Release() {
+	if l == nil {
+		return
+	}
+	<-l.tokens
+}
+
+// Limit returns the maximum number of concurrent assessments.
+func (l *AssessmentLimiter) Limit() int {
+	if l == nil {
+		return 1
+	}
+	return cap(l.tokens)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+func TestAssessmentLimiter_ConcurrentRuns(t *testing.T) {
+	limiter := NewAssessmentLimiter(2)
+	release := make(chan struct{})
+
+	var running, peak, rejected int32
+	var acquired, wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		acquired.Add(1)
+		go func() {
+			defer wg.Done()
+			if !limiter.TryAcquire() {
+				atomic.AddInt32(&rejected, 1)
+				acquired.Done()
+				return
+			}
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			acquired.Done()
+			<-release
+			atomic.AddInt32(&running, -1)
+			limiter.Release()
+		}()
+	}
+
+	acquired.Wait()
+	close(release)
+	wg.Wait()
+
+	if peak != 2 {
+		t.Errorf("Peak concurrent runs = %d, want 2", peak)
+	}
+	if rejected != 4 {
+		t.Errorf("Rejected runs = %d, want 4", rejected)
+	}
+	if !limiter.TryAcquire() {
+		t.Error("Expected a token to be available after all runs released")
+	}
+}
+
+func TestAssessmentLimiter_Defaults(t *testing.T) {
+	if got := NewAssessmentLimiter(0).Limit(); got != 1 {
+		t.Errorf("NewAssessmentLimiter(0).Limit() = %d, want 1", got)
+	}
+
+	var nilLimiter *AssessmentLimiter
+	if !nilLimiter.TryAcquire() || nilLimiter.Limit() != 1 {
+		t.Error("Expected a nil limiter to allow runs with a limit of 1")
+	}
+	nilLimiter.Release()
+}
+
+func TestRunAssessment_RequeuesAtLimit(t *testing.T) {
+	limiter := NewAssessmentLimiter(1)
+	if !limiter.TryAcquire() {
+		t.Fatal("TryAcquire() = false on an empty limiter")
+	}
+	defer limiter.Release()
+
+	// The reconciler has no client, so any work past the limiter would panic.
+	r := &ClusterAssessmentReconciler{Limiter: limiter}
+	assessment := &assessmentv1alpha1.ClusterAssessment{ObjectMeta: metav1.ObjectMeta{Name: "nightly"}}
+
+	result, err := r.runAssessment(context.Background(), assessment)
+	if err != nil {
+		t.Fatalf("runAssessment() returned error: %v", err)
+	}
+	if result.RequeueAfter != assessmentLimitRequeueDelay {
+		t.Errorf("RequeueAfter = %v, want %v", result.RequeueAfter, assessmentLimitRequeueDelay)
+	}
+}
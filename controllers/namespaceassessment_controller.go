@@ -0,0 +1,227 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/report"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator"
+)
+
+// NamespaceAssessmentReconciler reconciles a NamespaceAssessment object
+type NamespaceAssessmentReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Registry *validator.Registry
+
+	// Limiter caps how many assessments run at the same time.
+	Limiter *AssessmentLimiter
+
+	// Enricher adds organization-specific context to findings. Nil leaves
+	// findings unchanged.
+	Enricher report.Enricher
+}
+
+// +kubebuilder:rbac:groups=assessment.openshift.io,resources=namespaceassessments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=assessment.openshift.io,resources=namespaceassessments/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=assessment.openshift.io,resources=namespaceassessments/finalizers,verbs=update
+
+// Reconcile handles NamespaceAssessment reconciliation.
+func (r *NamespaceAssessmentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the NamespaceAssessment instance
+	assessment := &assessmentv1alpha1.NamespaceAssessment{}
+	if err := r.Get(ctx, req.NamespacedName, assessment); err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("NamespaceAssessment resource not found, ignoring")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get NamespaceAssessment")
+		return ctrl.Result{}, err
+	}
+
+	// Namespace assessments are one-time; skip if already finished or in progress
+	switch assessment.Status.Phase {
+	case assessmentv1alpha1.PhaseCompleted, assessmentv1alpha1.PhaseFailed:
+		return ctrl.Result{}, nil
+	case assessmentv1alpha1.PhaseRunning:
+		// A run interrupted by an operator restart stays Running; retry it
+		// once it has timed out
+		started := assessment.CreationTimestamp.Time
+		if assessment.Status.LastRunTime != nil {
+			started = assessment.Status.LastRunTime.Time
+		}
+		if wait := namespaceAssessmentTimeout - time.Since(started); wait > 0 {
+			logger.Info("Assessment already running, skipping", "runningFor", time.Since(started))
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+		logger.Info("Assessment appears stuck, restarting", "runningFor", time.Since(started))
+	}
+
+	return r.runAssessment(ctx, assessment)
+}
+
+// namespaceAssessmentTimeout is how long a NamespaceAssessment may stay
+// Running before it is considered stuck and run again.
+const namespaceAssessmentTimeout = 5 * time.Minute
+
+// runAssessment runs the namespace-scoped validators against the assessment's namespace.
+func (r *NamespaceAssessmentReconciler) runAssessment(ctx context.Context, assessment *assessmentv1alpha1.NamespaceAssessment) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Wait for a free slot if too many assessments are already running
+	if !r.Limiter.TryAcquire() {
+		logger.Info("Too many assessments running, requeuing", "limit", r.Limiter.Limit(), "requeueAfter", assessmentLimitRequeueDelay)
+		return ctrl.Result{RequeueAfter: assessmentLimitRequeueDelay}, nil
+	}
+	defer r.Limiter.Release()
+
+	if err := r.updatePhase(ctx, assessment, assessmentv1alpha1.PhaseRunning, "Assessment in progress"); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	validatorNames, unsupported := namespaceValidators(assessment.Spec.Validators)
+	if len(unsupported) > 0 {
+		logger.Info("Skipping validators that are not namespace-scoped", "validators", unsupported)
+	}
+	if len(validatorNames) == 0 {
+		return ctrl.Result{}, r.updatePhase(ctx, assessment, assessmentv1alpha1.PhaseFailed,
+			fmt.Sprintf("None of the requested validators are namespace-scoped; supported validators: %v", validator.NamespaceScopedValidators))
+	}
+
+	profile := profiles.GetProfile(assessment.Spec.Profile)
+	scopedClient := validator.NewNamespaceScopedClient(r.Client, assessment.Namespace)
+	runner := validator.NewRunner(r.Registry, scopedClient)
+
+	findings, err := runner.Run(ctx, profile, validatorNames)
+	if err != nil {
+		logger.Error(err, "Namespace assessment failed")
+		return ctrl.Result{}, r.updatePhase(ctx, assessment, assessmentv1alpha1.PhaseFailed,
+			fmt.Sprintf("Assessment failed: %v", err))
+	}
+
+	findings = enrichFindings(ctx, r.Enricher, findings)
+
+	if assessment.Spec.MinSeverity != "" {
+		findings = filterBySeverity(findings, assessment.Spec.MinSeverity)
+	}
+	if assessment.Spec.MinConfidence != "" {
+		findings = filterByConfidence(findings, assessment.Spec.MinConfidence)
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &assessmentv1alpha1.NamespaceAssessment{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(assessment), latest); err != nil {
+			return err
+		}
+
+		now := metav1.Now()
+		latest.Status.LastRunTime = &now
+		latest.Status.Phase = assessmentv1alpha1.PhaseCompleted
+		latest.Status.Message = fmt.Sprintf("Assessment of namespace %s completed with %d findings", assessment.Namespace, len(findings))
+		latest.Status.Findings = findings
+		latest.Status.Summary = calculateSummary(findings, string(profile.Name))
+		latest.Status.Conditions = []metav1.Condition{
+			{
+				Type:               "Ready",
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: now,
+				Reason:             "AssessmentCompleted",
+				Message:            latest.Status.Message,
+			},
+		}
+
+		return r.Status().Update(ctx, latest)
+	})
+	if err != nil {
+		logger.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("Namespace assessment completed", "namespace", assessment.Namespace, "findings", len(findings))
+	return ctrl.Result{}, nil
+}
+
+// namespaceValidators returns the requested validators that can run against a
+// single namespace, and those that were requested but are not namespace-scoped.
+// An empty request selects all namespace-scoped validators.
+func namespaceValidators(requested []string) (supported, unsupported []string) {
+	if len(requested) == 0 {
+		return validator.NamespaceScopedValidators, nil
+	}
+
+	allowed := make(map[string]bool, len(validator.NamespaceScopedValidators))
+	for _, name := range validator.NamespaceScopedValidators {
+		allowed[name] = true
+	}
+
+	for _, name := range requested {
+		if allowed[name] {
+			supported = append(supported, name)
+		} else {
+			unsupported = append(unsupported, name)
+		}
+	}
+	return supported, unsupported
+}
+
+// updatePhase updates the assessment phase and message with retry on conflict.
+func (r *NamespaceAssessmentReconciler) updatePhase(ctx context.Context, assessment *assessmentv1alpha1.NamespaceAssessment, phase, message string) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &assessmentv1alpha1.NamespaceAssessment{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(assessment), latest); err != nil {
+			return err
+		}
+		latest.Status.Phase = phase
+		latest.Status.Message = message
+		if phase == assessmentv1alpha1.PhaseRunning {
+			// Record when the run started, for the stuck run timeout
+			now := metav1.Now()
+			latest.Status.LastRunTime = &now
+		}
+		return r.Status().Update(ctx, latest)
+	})
+	if err != nil {
+		return err
+	}
+	assessment.Status.Phase = phase
+	assessment.Status.Message = message
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NamespaceAssessmentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&assessmentv1alpha1.NamespaceAssessment{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.Limiter.Limit()}).
+		Complete(r)
+}
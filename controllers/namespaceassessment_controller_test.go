@@ -0,0 +1,87 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+func TestNamespaceAssessmentReconcile_StuckRunning(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = assessmentv1alpha1.AddToScheme(scheme)
+
+	// As in TestReconcileOneTime_FailedRetries, a saturated limiter shows
+	// whether Reconcile decided to run.
+	limiter := NewAssessmentLimiter(1)
+	limiter.TryAcquire()
+	defer limiter.Release()
+
+	tests := []struct {
+		name       string
+		startedAgo time.Duration
+		createdAgo time.Duration
+		wantRun    bool
+	}{
+		{name: "running", startedAgo: time.Minute, wantRun: false},
+		{name: "timed out", startedAgo: 10 * time.Minute, wantRun: true},
+		{name: "no start time, recently created", createdAgo: time.Minute, wantRun: false},
+		{name: "no start time, created long ago", createdAgo: time.Hour, wantRun: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assessment := &assessmentv1alpha1.NamespaceAssessment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "team-a",
+					Namespace:         "team-a",
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-tt.createdAgo)),
+				},
+				Status: assessmentv1alpha1.NamespaceAssessmentStatus{Phase: assessmentv1alpha1.PhaseRunning},
+			}
+			if tt.startedAgo != 0 {
+				started := metav1.NewTime(time.Now().Add(-tt.startedAgo))
+				assessment.Status.LastRunTime = &started
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(assessment).
+				WithStatusSubresource(assessment).
+				Build()
+
+			r := &NamespaceAssessmentReconciler{Client: c, Scheme: scheme, Limiter: limiter}
+			result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(assessment)})
+			if err != nil {
+				t.Fatalf("Reconcile() returned error: %v", err)
+			}
+			if ran := result.RequeueAfter == assessmentLimitRequeueDelay; ran != tt.wantRun {
+				t.Errorf("ran = %v (requeue after %v), want %v", ran, result.RequeueAfter, tt.wantRun)
+			}
+			if !tt.wantRun && result.RequeueAfter <= 0 {
+				t.Errorf("expected a requeue to check the run again, got %v", result.RequeueAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+// Report Job settings.
+const (
+	// DefaultReportJobServiceAccount is the service account report Jobs run
+	// as, the one of the operator itself.
+	DefaultReportJobServiceAccount = "cluster-assessment-operator"
+
+	// GenerateReportFlag is the manager flag that makes it generate the report
+	// of the named ClusterAssessment and exit.
+	GenerateReportFlag = "generate-report"
+
+	reportJobBackoffLimit = 2
+	reportJobDeadline     = 30 * time.Minute
+	// Finished Jobs are kept for an hour so their pod logs can be inspected.
+	reportJobTTL = time.Hour
+	// A Job not found this soon after the run may not be in the cache yet.
+	reportJobGracePeriod = time.Minute
+	// Leaves room for the -report-YYYYMMDD-HHMMSS suffix within the 63
+	// characters allowed for the job-name label.
+	maxReportJobPrefix = 40
+)
+
+// offloadReport reports whether the ConfigMap report of the assessment is
+// generated by a Job. Without a configured image the report is generated in
+// the controller as usual.
+func (r *ClusterAssessmentReconciler) offloadReport(assessment *assessmentv1alpha1.ClusterAssessment) bool {
+	storage := assessment.Spec.ReportStorage
+	return storage.OffloadToJob && storage.ConfigMap != nil && storage.ConfigMap.Enabled && r.ReportJobImage != ""
+}
+
+// reportJobName returns the name of the report Job for a run completed at now.
+func reportJobName(assessmentName string, now time.Time) string {
+	if len(assessmentName) > maxReportJobPrefix {
+		assessmentName = assessmentName[:maxReportJobPrefix]
+	}
+	return fmt.Sprintf("%s-report-%s", assessmentName, now.Format("20060102-150405"))
+}
+
+// buildReportJob returns a Job running the operator image with the
+// generate-report flag for the assessment.
+func (r *ClusterAssessmentReconciler) buildReportJob(assessment *assessmentv1alpha1.ClusterAssessment, name string) (*batchv1.Job, error) {
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "cluster-assessment-operator",
+		"app.kubernetes.io/managed-by": "cluster-assessment-operator",
+		"app.kubernetes.io/component":  "report",
+		"assessment.openshift.io/name": assessment.Name,
+	}
+	serviceAccount := r.ReportJobServiceAccount
+	if serviceAccount == "" {
+		serviceAccount = DefaultReportJobServiceAccount
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: reportNamespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            ptr.To(int32(reportJobBackoffLimit)),
+			ActiveDeadlineSeconds:   ptr.To(int64(reportJobDeadline.Seconds())),
+			TTLSecondsAfterFinished: ptr.To(int32(reportJobTTL.Seconds())),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: serviceAccount,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: ptr.To(true),
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
+						},
+					},
+					Containers: []corev1.Container{{
+						Name:    "report",
+						Image:   r.ReportJobImage,
+						Command: []string{"/manager"},
+						Args:    []string{fmt.Sprintf("--%s=%s", GenerateReportFlag, assessment.Name)},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("100m"),
+								corev1.ResourceMemory: resource.MustParse("256Mi"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("2Gi"),
+							},
+						},
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: ptr.To(false),
+							Capabilities: &corev1.Capabilities{
+								Drop: []corev1.Capability{"ALL"},
+							},
+							ReadOnlyRootFilesystem: ptr.To(true),
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	// The Job, and with it its pod, is removed with the assessment
+	if err := ctrl.SetControllerReference(assessment, job, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set owner reference on Job: %w", err)
+	}
+	return job, nil
+}
+
+// startReportJob creates the report Job recorded in status.reportJob. If the
+// Job cannot be created the ReportStored condition is set to false and
+// status.reportJob is cleared.
+func (r *ClusterAssessmentReconciler) startReportJob(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment, name string) error {
+	job, err := r.buildReportJob(assessment, name)
+	if err == nil {
+		err = r.Create(ctx, job)
+	}
+	if err == nil {
+		log.FromContext(ctx).Info("Started report Job", "job", name)
+		return nil
+	}
+
+	message := fmt.Sprintf("Failed to create report Job %s: %v", name, err)
+	return r.finishReportJob(ctx, assessment, name, message)
+}
+
+// checkReportJob records the outcome of the report Job in status.reportJob
+// once it has finished. A successful Job has already stored the report and
+// its ConfigMap name, so only failures are recorded here.
+func (r *ClusterAssessmentReconciler) checkReportJob(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment) error {
+	name := assessment.Status.ReportJob
+	job := &batchv1.Job{}
+	err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: reportNamespace}, job)
+
+	var failure string
+	switch {
+	case errors.IsNotFound(err):
+		if last := assessment.Status.LastRunTime; last != nil && time.Since(last.Time) < reportJobGracePeriod {
+			return nil
+		}
+		failure = fmt.Sprintf("Report Job %s no longer exists", name)
+	case err != nil:
+		return fmt.Errorf("failed to get report Job %s: %w", name, err)
+	default:
+		if cond := jobCondition(job, batchv1.JobFailed); cond != nil {
+			failure = fmt.Sprintf("Report Job %s failed: %s", name, cond.Message)
+		} else if jobCondition(job, batchv1.JobComplete) == nil {
+			// Still running; its completion triggers another reconcile
+			return nil
+		}
+	}
+
+	log.FromContext(ctx).Info("Report Job finished", "job", name, "failed", failure != "")
+	return r.finishReportJob(ctx, assessment, name, failure)
+}
+
+// finishReportJob clears status.reportJob if it still names the Job, and sets
+// the ReportStored condition to false when failure is not empty and no
+// failure is recorded yet. assessment is updated to the stored object.
+func (r *ClusterAssessmentReconciler) finishReportJob(ctx context.Context, assessment *assessmentv1alpha1.ClusterAssessment, name, failure string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &assessmentv1alpha1.ClusterAssessment{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(assessment), latest); err != nil {
+			return err
+		}
+		if latest.Status.ReportJob != name {
+			// A newer run has started another Job
+			latest.DeepCopyInto(assessment)
+			return nil
+		}
+
+		latest.Status.ReportJob = ""
+		// A Job that failed to store the report has recorded why
+		if failure != "" && !meta.IsStatusConditionFalse(latest.Status.Conditions, "ReportStored") {
+			meta.SetStatusCondition(&latest.Status.Conditions, metav1.Condition{
+				Type:    "ReportStored",
+				Status:  metav1.ConditionFalse,
+				Reason:  "ReportJobFailed",
+				Message: failure,
+			})
+		}
+		if err := r.Status().Update(ctx, latest); err != nil {
+			return err
+		}
+		latest.DeepCopyInto(assessment)
+		return nil
+	})
+}
+
+// reportJobRunningCondition is the ReportStored condition while the report
+// Job runs.
+func reportJobRunningCondition(name string, now metav1.Time) metav1.Condition {
+	return metav1.Condition{
+		Type:               "ReportStored",
+		Status:             metav1.ConditionUnknown,
+		LastTransitionTime: now,
+		Reason:             "ReportJobRunning",
+		Message:            fmt.Sprintf("Report is being generated by Job %s/%s", reportNamespace, name),
+	}
+}
+
+// jobCondition returns the true condition of the given type, or nil.
+func jobCondition(job *batchv1.Job, condType batchv1.JobConditionType) *batchv1.JobCondition {
+	for i := range job.Status.Conditions {
+		if c := &job.Status.Conditions[i]; c.Type == condType && c.Status == corev1.ConditionTrue {
+			return c
+		}
+	}
+	return nil
+}
+
+// GenerateReport stores the ConfigMap report of the named ClusterAssessment
+// and records the ConfigMap name and the ReportStored condition in its status.
+// It is run by the report Jobs started for spec.reportStorage.offloadToJob.
+func GenerateReport(ctx context.Context, c client.Client, scheme *runtime.Scheme, name string) error {
+	r := &ClusterAssessmentReconciler{Client: c, Scheme: scheme}
+
+	assessment := &assessmentv1alpha1.ClusterAssessment{}
+	if err := r.Get(ctx, client.ObjectKey{Name: name}, assessment); err != nil {
+		return fmt.Errorf("failed to get ClusterAssessment %s: %w", name, err)
+	}
+	if cm := assessment.Spec.ReportStorage.ConfigMap; cm == nil || !cm.Enabled {
+		return fmt.Errorf("ClusterAssessment %s does not store its report in a ConfigMap", name)
+	}
+
+	storeErr := retryReportStorage(isTransientAPIError, func() error {
+		return r.storeReportInConfigMap(ctx, assessment)
+	})
+	var storageErrors []string
+	if storeErr != nil {
+		storageErrors = append(storageErrors, fmt.Sprintf("ConfigMap: %v", storeErr))
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &assessmentv1alpha1.ClusterAssessment{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(assessment), latest); err != nil {
+			return err
+		}
+		if storeErr == nil {
+			latest.Status.ReportConfigMap = assessment.Status.ReportConfigMap
+		}
+		// Keep a failure already recorded for another destination, e.g. Git
+		if storeErr != nil || !meta.IsStatusConditionFalse(latest.Status.Conditions, "ReportStored") {
+			meta.SetStatusCondition(&latest.Status.Conditions, reportStoredCondition(storageErrors, metav1.Now()))
+		}
+		return r.Status().Update(ctx, latest)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update ClusterAssessment %s status: %w", name, err)
+	}
+	return storeErr
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+func reportJobScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+	_ = assessmentv1alpha1.AddToScheme(scheme)
+	return scheme
+}
+
+func offloadedAssessment(reportJob string) *assessmentv1alpha1.ClusterAssessment {
+	lastRun := metav1.NewTime(time.Now().Add(-time.Hour))
+	return &assessmentv1alpha1.ClusterAssessment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "uid"},
+		Spec: assessmentv1alpha1.ClusterAssessmentSpec{
+			ReportStorage: assessmentv1alpha1.ReportStorageSpec{
+				ConfigMap:    &assessmentv1alpha1.ConfigMapStorageSpec{Enabled: true},
+				OffloadToJob: true,
+			},
+		},
+		Status: assessmentv1alpha1.ClusterAssessmentStatus{
+			Phase:       assessmentv1alpha1.PhaseCompleted,
+			LastRunTime: &lastRun,
+			ReportJob:   reportJob,
+			Conditions:  []metav1.Condition{reportJobRunningCondition(reportJob, lastRun)},
+			Findings: []assessmentv1alpha1.Finding{{
+				ID:        "version-current",
+				Validator: "version",
+				Category:  "Platform",
+				Status:    assessmentv1alpha1.FindingStatusPass,
+				Title:     "OpenShift Version",
+			}},
+		},
+	}
+}
+
+func TestReportJobName(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	if got := reportJobName("weekly", now); got != "weekly-report-20240501-123000" {
+		t.Errorf("Expected weekly-report-20240501-123000, got %s", got)
+	}
+	if got := reportJobName(strings.Repeat("a", 60), now); len(got) > 63 {
+		t.Errorf("Expected a valid job-name label value, got %d characters", len(got))
+	}
+}
+
+func TestBuildReportJob(t *testing.T) {
+	r := &ClusterAssessmentReconciler{Scheme: reportJobScheme(), ReportJobImage: "operator:v1"}
+	assessment := offloadedAssessment("")
+
+	job, err := r.buildReportJob(assessment, "test-report-1")
+	if err != nil {
+		t.Fatalf("buildReportJob returned an error: %v", err)
+	}
+	if job.Namespace != reportNamespace {
+		t.Errorf("Expected Job in %s, got %s", reportNamespace, job.Namespace)
+	}
+	if owner := metav1.GetControllerOf(job); owner == nil || owner.Name != "test" {
+		t.Errorf("Expected Job to be owned by the assessment, got %+v", owner)
+	}
+	pod := job.Spec.Template.Spec
+	if pod.ServiceAccountName != DefaultReportJobServiceAccount {
+		t.Errorf("Expected default service account, got %s", pod.ServiceAccountName)
+	}
+	container := pod.Containers[0]
+	if container.Image != "operator:v1" {
+		t.Errorf("Expected operator image, got %s", container.Image)
+	}
+	if len(container.Args) != 1 || container.Args[0] != "--generate-report=test" {
+		t.Errorf("Expected --generate-report=test, got %v", container.Args)
+	}
+}
+
+func TestOffloadReport_RequiresImage(t *testing.T) {
+	assessment := offloadedAssessment("")
+	if (&ClusterAssessmentReconciler{}).offloadReport(assessment) {
+		t.Error("Expected reports to be generated in the controller without a report Job image")
+	}
+	if !(&ClusterAssessmentReconciler{ReportJobImage: "operator:v1"}).offloadReport(assessment) {
+		t.Error("Expected the report to be offloaded")
+	}
+}
+
+func TestCheckReportJob(t *testing.T) {
+	tests := []struct {
+		name       string
+		jobStatus  *batchv1.JobStatus
+		wantJob    string
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "running",
+			jobStatus:  &batchv1.JobStatus{Active: 1},
+			wantJob:    "test-report-1",
+			wantStatus: metav1.ConditionUnknown,
+			wantReason: "ReportJobRunning",
+		},
+		{
+			name: "complete",
+			jobStatus: &batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			}},
+			wantStatus: metav1.ConditionUnknown,
+			wantReason: "ReportJobRunning",
+		},
+		{
+			name: "failed",
+			jobStatus: &batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "Job has reached the specified backoff limit"},
+			}},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "ReportJobFailed",
+		},
+		{
+			name:       "missing",
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "ReportJobFailed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := reportJobScheme()
+			assessment := offloadedAssessment("test-report-1")
+			builder := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(assessment).
+				WithStatusSubresource(assessment)
+			if tt.jobStatus != nil {
+				builder = builder.WithObjects(&batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-report-1", Namespace: reportNamespace},
+					Status:     *tt.jobStatus,
+				})
+			}
+			r := &ClusterAssessmentReconciler{Client: builder.Build(), Scheme: scheme}
+
+			if err := r.checkReportJob(context.Background(), assessment); err != nil {
+				t.Fatalf("checkReportJob returned an error: %v", err)
+			}
+
+			stored := &assessmentv1alpha1.ClusterAssessment{}
+			if err := r.Get(context.Background(), client.ObjectKeyFromObject(assessment), stored); err != nil {
+				t.Fatal(err)
+			}
+			if stored.Status.ReportJob != tt.wantJob {
+				t.Errorf("Expected status.reportJob %q, got %q", tt.wantJob, stored.Status.ReportJob)
+			}
+			cond := meta.FindStatusCondition(stored.Status.Conditions, "ReportStored")
+			if cond == nil || cond.Status != tt.wantStatus || cond.Reason != tt.wantReason {
+				t.Errorf("Expected ReportStored %s/%s, got %+v", tt.wantStatus, tt.wantReason, cond)
+			}
+		})
+	}
+}
+
+func TestGenerateReport(t *testing.T) {
+	scheme := reportJobScheme()
+	assessment := offloadedAssessment("test-report-1")
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(assessment).
+		WithStatusSubresource(assessment).
+		Build()
+
+	if err := GenerateReport(context.Background(), c, scheme, "test"); err != nil {
+		t.Fatalf("GenerateReport returned an error: %v", err)
+	}
+
+	stored := &assessmentv1alpha1.ClusterAssessment{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(assessment), stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status.ReportConfigMap == "" {
+		t.Fatal("Expected status.reportConfigMap to be set")
+	}
+	if !meta.IsStatusConditionTrue(stored.Status.Conditions, "ReportStored") {
+		t.Errorf("Expected ReportStored to be true, got %+v", stored.Status.Conditions)
+	}
+
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Name: stored.Status.ReportConfigMap, Namespace: reportNamespace}
+	if err := c.Get(context.Background(), key, cm); err != nil {
+		t.Fatalf("Expected report ConfigMap to exist: %v", err)
+	}
+	if !strings.Contains(cm.Data["report.json"], "version-current") {
+		t.Error("Expected the report to contain the stored findings")
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/report"
+)
+
+// SummaryHandlerPath is the path the summary handler is served on.
+const SummaryHandlerPath = "/assessments/{name}/summary"
+
+// SummaryHandler serves the compact JSON summary of a ClusterAssessment at
+// SummaryHandlerPath. The metrics server it is registered on does not
+// authenticate requests, so the handler requires the bearer token of a user
+// allowed to get the ClusterAssessment.
+func SummaryHandler(c client.Client) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		name := req.PathValue("name")
+		if code := authorizeSummary(req.Context(), c, req.Header.Get("Authorization"), name); code != http.StatusOK {
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+
+		assessment := &assessmentv1alpha1.ClusterAssessment{}
+		if err := c.Get(req.Context(), client.ObjectKey{Name: name}, assessment); err != nil {
+			if errors.IsNotFound(err) {
+				http.Error(w, "assessment not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		data, err := report.GenerateSummaryJSON(assessment)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	})
+}
+
+// authorizeSummary authenticates the bearer token in the Authorization header
+// with a TokenReview and checks with a SubjectAccessReview that its user may
+// get the named ClusterAssessment. It returns the HTTP status of the check.
+func authorizeSummary(ctx context.Context, c client.Client, authorization, name string) int {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok || token == "" {
+		return http.StatusUnauthorized
+	}
+
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}
+	if err := c.Create(ctx, review); err != nil {
+		return http.StatusInternalServerError
+	}
+	if !review.Status.Authenticated {
+		return http.StatusUnauthorized
+	}
+
+	user := review.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	access := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Group:    assessmentv1alpha1.GroupVersion.Group,
+				Resource: "clusterassessments",
+				Verb:     "get",
+				Name:     name,
+			},
+		},
+	}
+	if err := c.Create(ctx, access); err != nil {
+		return http.StatusInternalServerError
+	}
+	if !access.Status.Allowed {
+		return http.StatusForbidden
+	}
+	return http.StatusOK
+}
@@ -18,12 +18,15 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -33,6 +36,7 @@ import (
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
 	"github.com/openshift-assessment/cluster-assessment-operator/controllers"
 	"github.com/openshift-assessment/cluster-assessment-operator/pkg/machineconfig"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/report"
 	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator"
 
 	// Import validators to register them
@@ -54,6 +58,7 @@ import (
 	_ "github.com/openshift-assessment/cluster-assessment-operator/pkg/validators/security"
 	_ "github.com/openshift-assessment/cluster-assessment-operator/pkg/validators/storage"
 	_ "github.com/openshift-assessment/cluster-assessment-operator/pkg/validators/version"
+	_ "github.com/openshift-assessment/cluster-assessment-operator/pkg/validators/workloadhealth"
 )
 
 var (
@@ -72,12 +77,44 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var pprofAddr string
+	var printSchema bool
+	var maxConcurrentAssessments int
+	var enrichmentConfigMap string
+	var statusBoard bool
+	var reportJobImage string
+	var reportJobServiceAccount string
+	var generateReport string
+	var metricsQueryHosts string
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&pprofAddr, "pprof-bind-address", "",
+		"The address the pprof endpoint binds to, e.g. localhost:6060. Leave empty to disable profiling.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.IntVar(&maxConcurrentAssessments, "max-concurrent-assessments", controllers.DefaultMaxConcurrentAssessments,
+		"The maximum number of ClusterAssessments and NamespaceAssessments that run at the same time. "+
+			"Others are requeued until a slot frees up.")
+	flag.StringVar(&enrichmentConfigMap, "enrichment-configmap", "",
+		"A ConfigMap, as namespace/name, mapping finding IDs to extra references and impact text "+
+			"that are added to every assessment's findings. Leave empty to disable.")
+	flag.BoolVar(&statusBoard, "status-board", false,
+		"Maintain the assessment-status-board ConfigMap with the latest score, grade and finding counts "+
+			"of every ClusterAssessment.")
+	flag.StringVar(&reportJobImage, "report-job-image", os.Getenv("OPERATOR_IMAGE"),
+		"The image run by the Jobs generating reports of ClusterAssessments with spec.reportStorage.offloadToJob. "+
+			"Defaults to the OPERATOR_IMAGE environment variable; when empty, those reports are generated in the controller.")
+	flag.StringVar(&reportJobServiceAccount, "report-job-service-account", controllers.DefaultReportJobServiceAccount,
+		"The service account the report Jobs run as.")
+	flag.StringVar(&metricsQueryHosts, "metrics-query-hosts", "",
+		"Comma-separated hosts that spec.metricsEndpoint may name besides the in-cluster Thanos Querier. "+
+			"Metrics queries carry the operator's service account token, so only list trusted hosts.")
+	flag.StringVar(&generateReport, controllers.GenerateReportFlag, "",
+		"Generate and store the report of the named ClusterAssessment, then exit. Used by the report Jobs.")
+	flag.BoolVar(&printSchema, "print-schema", false,
+		"Print the JSON schema of the assessment report and its findings to stdout and exit.")
 
 	opts := zap.Options{
 		Development: true,
@@ -85,8 +122,32 @@ func main() {
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
+	if printSchema {
+		schema, err := report.GenerateSchemaJSON()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to generate schema: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(schema))
+		os.Exit(0)
+	}
+
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if generateReport != "" {
+		c, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+		if err != nil {
+			setupLog.Error(err, "unable to create client")
+			os.Exit(1)
+		}
+		ctx := ctrl.LoggerInto(ctrl.SetupSignalHandler(), ctrl.Log.WithName("report"))
+		if err := controllers.GenerateReport(ctx, c, scheme, generateReport); err != nil {
+			setupLog.Error(err, "unable to generate report", "clusterAssessment", generateReport)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	setupLog.Info("Starting Cluster Assessment Operator")
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
@@ -95,6 +156,7 @@ func main() {
 			BindAddress: metricsAddr,
 		},
 		HealthProbeBindAddress: probeAddr,
+		PprofBindAddress:       pprofAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "cluster-assessment-operator.openshift.io",
 	})
@@ -107,12 +169,58 @@ func main() {
 	registry := validator.DefaultRegistry()
 	setupLog.Info("Registered validators", "count", len(registry.Names()), "validators", registry.Names())
 
+	// Shared by both controllers so the limit applies to all assessments
+	limiter := controllers.NewAssessmentLimiter(maxConcurrentAssessments)
+
+	var enricher report.Enricher = report.NopEnricher{}
+	if enrichmentConfigMap != "" {
+		namespace, name, found := strings.Cut(enrichmentConfigMap, "/")
+		if !found || namespace == "" || name == "" {
+			setupLog.Error(fmt.Errorf("expected namespace/name, got %q", enrichmentConfigMap), "invalid --enrichment-configmap")
+			os.Exit(1)
+		}
+		enricher = report.NewConfigMapEnricher(mgr.GetClient(), namespace, name)
+		setupLog.Info("Enriching findings from ConfigMap", "configMap", enrichmentConfigMap)
+	}
+
+	var queryHosts []string
+	for _, host := range strings.Split(metricsQueryHosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			queryHosts = append(queryHosts, host)
+		}
+	}
+
 	if err = (&controllers.ClusterAssessmentReconciler{
+		Client:      mgr.GetClient(),
+		Scheme:      mgr.GetScheme(),
+		Registry:    registry,
+		Recorder:    mgr.GetEventRecorderFor("cluster-assessment-operator"),
+		APIReader:   mgr.GetAPIReader(),
+		Limiter:     limiter,
+		Enricher:    enricher,
+		StatusBoard: statusBoard,
+
+		ReportJobImage:          reportJobImage,
+		ReportJobServiceAccount: reportJobServiceAccount,
+		MetricsQueryHosts:       queryHosts,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterAssessment")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.NamespaceAssessmentReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
 		Registry: registry,
+		Limiter:  limiter,
+		Enricher: enricher,
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "ClusterAssessment")
+		setupLog.Error(err, "unable to create controller", "controller", "NamespaceAssessment")
+		os.Exit(1)
+	}
+
+	if err := mgr.AddMetricsServerExtraHandler(controllers.SummaryHandlerPath, controllers.SummaryHandler(mgr.GetClient())); err != nil {
+		setupLog.Error(err, "unable to register assessment summary handler")
 		os.Exit(1)
 	}
 
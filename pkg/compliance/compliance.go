@@ -0,0 +1,150 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package compliance maps assessment findings to the controls of compliance
+// frameworks such as CIS, NIST SP 800-53 and PCI DSS.
+package compliance
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+// Supported compliance packs.
+const (
+	PackCIS    = "cis"
+	PackNIST   = "nist-800-53"
+	PackPCIDSS = "pci-dss"
+)
+
+//go:embed controls.yaml
+var controlsYAML []byte
+
+// controlTable maps a finding ID to the control IDs of each pack.
+var controlTable = mustParseControls(controlsYAML)
+
+// mustParseControls parses the embedded control table, panicking on invalid
+// data since it is compiled into the binary.
+func mustParseControls(data []byte) map[string]map[string][]string {
+	table := make(map[string]map[string][]string)
+	if err := yaml.Unmarshal(data, &table); err != nil {
+		panic(fmt.Sprintf("invalid compliance control table: %v", err))
+	}
+	return table
+}
+
+// Packs returns the supported compliance pack names.
+func Packs() []string {
+	return []string{PackCIS, PackNIST, PackPCIDSS}
+}
+
+// Controls returns the controls of pack that the finding ID maps to.
+func Controls(pack, findingID string) []string {
+	return controlTable[findingID][pack]
+}
+
+// Apply returns the findings that map to at least one control of pack, each
+// annotated with its control IDs. The input slice is not modified.
+func Apply(pack string, findings []assessmentv1alpha1.Finding) []assessmentv1alpha1.Finding {
+	var mapped []assessmentv1alpha1.Finding
+	for _, f := range findings {
+		controls := Controls(pack, f.ID)
+		if len(controls) == 0 {
+			continue
+		}
+		f.Controls = append([]string(nil), controls...)
+		mapped = append(mapped, f)
+	}
+	return mapped
+}
+
+// ControlResult groups the findings that provide evidence for one control.
+type ControlResult struct {
+	// Control is the control ID within the pack.
+	Control string `json:"control"`
+
+	// Status is the most severe status of the control's findings.
+	Status assessmentv1alpha1.FindingStatus `json:"status"`
+
+	// Findings are the findings mapped to the control.
+	Findings []assessmentv1alpha1.Finding `json:"findings"`
+}
+
+// GroupByControl groups findings by the controls of pack they map to, sorted
+// by control ID. A finding mapped to several controls appears under each.
+func GroupByControl(pack string, findings []assessmentv1alpha1.Finding) []ControlResult {
+	byControl := make(map[string]*ControlResult)
+	for _, f := range findings {
+		for _, control := range Controls(pack, f.ID) {
+			result, ok := byControl[control]
+			if !ok {
+				result = &ControlResult{Control: control, Status: assessmentv1alpha1.FindingStatusInfo}
+				byControl[control] = result
+			}
+			result.Findings = append(result.Findings, f)
+			if statusRank(f.Status) > statusRank(result.Status) {
+				result.Status = f.Status
+			}
+		}
+	}
+
+	results := make([]ControlResult, 0, len(byControl))
+	for _, result := range byControl {
+		results = append(results, *result)
+	}
+	sort.Slice(results, func(i, j int) bool { return lessControl(results[i].Control, results[j].Control) })
+	return results
+}
+
+// statusRank orders statuses by severity for rolling up a control.
+func statusRank(status assessmentv1alpha1.FindingStatus) int {
+	switch status {
+	case assessmentv1alpha1.FindingStatusFail:
+		return 3
+	case assessmentv1alpha1.FindingStatusWarn:
+		return 2
+	case assessmentv1alpha1.FindingStatusPass:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// lessControl orders control IDs naturally, so that "1.2.9" sorts before
+// "1.2.19" and "AC-2" before "AC-12".
+func lessControl(a, b string) bool {
+	split := func(r rune) bool { return r == '.' || r == '-' || r == '(' || r == ')' }
+	as, bs := strings.FieldsFunc(a, split), strings.FieldsFunc(b, split)
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		if aErr == nil && bErr == nil {
+			return an < bn
+		}
+		return as[i] < bs[i]
+	}
+	return len(as) < len(bs)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compliance
+
+import (
+	"slices"
+	"testing"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+func TestControlTable(t *testing.T) {
+	if len(controlTable) == 0 {
+		t.Fatal("expected embedded control table to be populated")
+	}
+	for id, packs := range controlTable {
+		for pack, controls := range packs {
+			if !slices.Contains(Packs(), pack) {
+				t.Errorf("%s: unknown pack %q", id, pack)
+			}
+			if len(controls) == 0 {
+				t.Errorf("%s: pack %q has no controls", id, pack)
+			}
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	findings := []assessmentv1alpha1.Finding{
+		{ID: "security-privileged-pods", Status: assessmentv1alpha1.FindingStatusWarn},
+		{ID: "storage-sc-list", Status: assessmentv1alpha1.FindingStatusInfo},
+		{ID: "etcdbackup-not-configured", Status: assessmentv1alpha1.FindingStatusWarn},
+	}
+
+	got := Apply(PackCIS, findings)
+	if len(got) != 1 || got[0].ID != "security-privileged-pods" {
+		t.Fatalf("expected only security-privileged-pods for cis, got %+v", got)
+	}
+	if !slices.Equal(got[0].Controls, []string{"5.2.1"}) {
+		t.Errorf("unexpected controls %v", got[0].Controls)
+	}
+	if findings[0].Controls != nil {
+		t.Error("Apply must not modify its input")
+	}
+
+	if got := Apply(PackNIST, findings); len(got) != 2 {
+		t.Errorf("expected 2 findings for nist-800-53, got %d", len(got))
+	}
+}
+
+func TestGroupByControl(t *testing.T) {
+	findings := []assessmentv1alpha1.Finding{
+		{ID: "security-rbac-wildcard", Status: assessmentv1alpha1.FindingStatusWarn},
+		{ID: "security-cluster-admin-minimal", Status: assessmentv1alpha1.FindingStatusPass},
+		{ID: "security-privileged-pods", Status: assessmentv1alpha1.FindingStatusFail},
+		{ID: "compliance-psa-enforce", Status: assessmentv1alpha1.FindingStatusPass},
+	}
+
+	results := GroupByControl(PackNIST, findings)
+	var controls []string
+	for _, r := range results {
+		controls = append(controls, r.Control)
+	}
+	if !slices.Equal(controls, []string{"AC-6", "CM-7"}) {
+		t.Fatalf("unexpected controls %v", controls)
+	}
+	if results[0].Status != assessmentv1alpha1.FindingStatusFail || len(results[0].Findings) != 3 {
+		t.Errorf("expected AC-6 to be FAIL with 3 findings, got %s with %d", results[0].Status, len(results[0].Findings))
+	}
+}
+
+func TestLessControl(t *testing.T) {
+	ordered := []string{"1.2.9", "1.2.19", "5.1.1", "AC-2", "AC-12", "SC-7", "SC-7(5)"}
+	for i := 1; i < len(ordered); i++ {
+		if !lessControl(ordered[i-1], ordered[i]) {
+			t.Errorf("expected %s < %s", ordered[i-1], ordered[i])
+		}
+		if lessControl(ordered[i], ordered[i-1]) {
+			t.Errorf("expected %s > %s", ordered[i], ordered[i-1])
+		}
+	}
+}
@@ -22,6 +22,7 @@ package machineconfig
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // GroupVersion is the group version for MachineConfig resources.
@@ -49,7 +50,8 @@ type MachineConfigPoolSpec struct {
 	Paused bool `json:"paused,omitempty"`
 
 	// MaxUnavailable specifies the maximum number of nodes that can be unavailable during update.
-	MaxUnavailable *int32 `json:"maxUnavailable,omitempty"`
+	// It may be an absolute number or a percentage of the pool (e.g. "10%").
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 }
 
 // MachineConfigPoolStatus defines the status of a MachineConfigPool.
@@ -96,6 +96,7 @@ func init() {
 		AssessmentDuration,
 		ValidatorFindings,
 		ClusterInfo,
+		AssessmentTags,
 	)
 }
 
@@ -0,0 +1,216 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultQueryURL is the in-cluster Thanos Querier of OpenShift monitoring,
+// which serves the Prometheus query API for cluster and user workload metrics.
+const DefaultQueryURL = "https://thanos-querier.openshift-monitoring.svc:9091"
+
+const (
+	serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	// serviceCAFile is the service CA bundle OpenShift mounts into every pod,
+	// which signs the serving certificates of in-cluster services.
+	serviceCAFile = "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"
+
+	// maxQueryResponseSize bounds the query responses read into memory.
+	maxQueryResponseSize = 64 << 20
+)
+
+// QueryClient runs instant queries against a Prometheus-compatible query API.
+type QueryClient struct {
+	URL         string
+	BearerToken string
+	// BearerTokenFile is read before every query when BearerToken is empty,
+	// so that rotated service account tokens are picked up.
+	BearerTokenFile string
+	HTTPClient      *http.Client
+}
+
+// defaultQueryHosts are the hosts InClusterQueryClient accepts without an
+// allowlist: the in-cluster Thanos Querier, by service and cluster domain name.
+var defaultQueryHosts = []string{
+	"thanos-querier.openshift-monitoring.svc",
+	"thanos-querier.openshift-monitoring.svc.cluster.local",
+}
+
+// ValidateQueryEndpoint returns an error unless endpoint is an https URL of
+// the in-cluster Thanos Querier or of one of allowedHosts. The service
+// account token is sent to the endpoint, so it must not be an arbitrary host.
+func ValidateQueryEndpoint(endpoint string, allowedHosts []string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", endpoint, err)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("%s must use https", endpoint)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%s must not contain credentials", u.Redacted())
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range append(slices.Clone(defaultQueryHosts), allowedHosts...) {
+		if host == strings.ToLower(allowed) {
+			return nil
+		}
+	}
+	return fmt.Errorf("host %q is not the in-cluster Thanos Querier or an allowed metrics query host", host)
+}
+
+// InClusterQueryClient returns a QueryClient for endpoint, or DefaultQueryURL
+// if endpoint is empty, that authenticates with the pod's service account
+// token and trusts the service CA in addition to the system roots. endpoint
+// must pass ValidateQueryEndpoint, and redirects are not followed, so the
+// token is only sent to the in-cluster Thanos Querier or allowedHosts.
+func InClusterQueryClient(endpoint string, allowedHosts []string) (*QueryClient, error) {
+	if endpoint == "" {
+		endpoint = DefaultQueryURL
+	}
+	if err := ValidateQueryEndpoint(endpoint, allowedHosts); err != nil {
+		return nil, err
+	}
+
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if ca, err := os.ReadFile(serviceCAFile); err == nil {
+		roots.AppendCertsFromPEM(ca)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+
+	return &QueryClient{
+		URL:             endpoint,
+		BearerTokenFile: serviceAccountTokenFile,
+		HTTPClient: &http.Client{
+			Timeout:   60 * time.Second,
+			Transport: transport,
+			// A redirect is reported as an unexpected status instead
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}, nil
+}
+
+// queryResponse is the body of a Prometheus /api/v1/query response.
+type queryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Value  [2]interface{}    `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// Query evaluates query at the current time and returns the resulting
+// instant vector. Queries returning other result types are rejected.
+func (c *QueryClient) Query(ctx context.Context, query string) ([]TimeSeries, error) {
+	form := url.Values{"query": {query}}
+	endpoint := strings.TrimSuffix(c.URL, "/") + "/api/v1/query"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create query request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "cluster-assessment-operator")
+	token := c.BearerToken
+	if token == "" && c.BearerTokenFile != "" {
+		data, err := os.ReadFile(c.BearerTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bearer token: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send query request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxQueryResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read query response: %w", err)
+	}
+
+	var result queryResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		if resp.StatusCode/100 != 2 {
+			return nil, fmt.Errorf("query endpoint returned %s: %s", resp.Status, truncate(string(body), 512))
+		}
+		return nil, fmt.Errorf("failed to decode query response: %w", err)
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("query failed (%s): %s", result.ErrorType, result.Error)
+	}
+	if result.Data.ResultType != "vector" {
+		return nil, fmt.Errorf("query returned %q, expected an instant vector", result.Data.ResultType)
+	}
+
+	series := make([]TimeSeries, 0, len(result.Data.Result))
+	for _, r := range result.Data.Result {
+		raw, ok := r.Value[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("query returned a malformed sample for %v", r.Metric)
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("query returned a malformed sample for %v: %w", r.Metric, err)
+		}
+		series = append(series, TimeSeries{Labels: r.Metric, Value: value})
+	}
+	return series, nil
+}
+
+// truncate shortens s to at most n bytes for inclusion in error messages.
+func truncate(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if len(s) > n {
+		return s[:n] + "..."
+	}
+	return s
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// metricPrefix selects the operator's own metrics from a registry.
+const metricPrefix = "cluster_assessment_"
+
+// TimeSeries is a single sample with its labels, including __name__.
+type TimeSeries struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// Snapshot returns the current values of the operator's metrics for one
+// assessment, read from gatherer. Every series gets a cluster_id label so
+// that a central Prometheus can tell the clusters apart.
+func Snapshot(gatherer prometheus.Gatherer, assessmentName, clusterID string) ([]TimeSeries, error) {
+	families, err := gatherer.Gather()
+	if err != nil {
+		return nil, fmt.Errorf("failed to gather metrics: %w", err)
+	}
+
+	var series []TimeSeries
+	for _, family := range families {
+		if !strings.HasPrefix(family.GetName(), metricPrefix) || family.GetType() != dto.MetricType_GAUGE {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			labels := map[string]string{"__name__": family.GetName()}
+			for _, pair := range m.GetLabel() {
+				labels[pair.GetName()] = pair.GetValue()
+			}
+
+			// Keep this assessment's series and the info series of this cluster
+			if name, ok := labels["assessment_name"]; ok && name != assessmentName {
+				continue
+			}
+			if id, ok := labels["cluster_id"]; ok && id != clusterID {
+				continue
+			}
+			labels["cluster_id"] = clusterID
+
+			series = append(series, TimeSeries{Labels: labels, Value: m.GetGauge().GetValue()})
+		}
+	}
+	return series, nil
+}
+
+// RemoteWriteClient pushes samples to a Prometheus remote-write endpoint
+// using the remote write 1.0 protocol.
+type RemoteWriteClient struct {
+	URL         string
+	Username    string
+	Password    string
+	BearerToken string
+	HTTPClient  *http.Client
+}
+
+// Push sends series as samples taken at timestamp.
+func (c *RemoteWriteClient) Push(ctx context.Context, series []TimeSeries, timestamp time.Time) error {
+	body := snappyEncode(EncodeWriteRequest(series, timestamp))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create remote write request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	req.Header.Set("User-Agent", "cluster-assessment-operator")
+	if c.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.BearerToken)
+	} else if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send remote write request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("remote write endpoint returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// EncodeWriteRequest encodes series as a prometheus.WriteRequest protobuf
+// message, with labels sorted by name as the protocol requires.
+func EncodeWriteRequest(series []TimeSeries, timestamp time.Time) []byte {
+	var out []byte
+	for _, ts := range series {
+		names := make([]string, 0, len(ts.Labels))
+		for name := range ts.Labels {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		// TimeSeries: repeated Label labels = 1; repeated Sample samples = 2
+		var tsBytes []byte
+		for _, name := range names {
+			// Label: string name = 1; string value = 2
+			var label []byte
+			label = protowire.AppendTag(label, 1, protowire.BytesType)
+			label = protowire.AppendString(label, name)
+			label = protowire.AppendTag(label, 2, protowire.BytesType)
+			label = protowire.AppendString(label, ts.Labels[name])
+
+			tsBytes = protowire.AppendTag(tsBytes, 1, protowire.BytesType)
+			tsBytes = protowire.AppendBytes(tsBytes, label)
+		}
+
+		// Sample: double value = 1; int64 timestamp = 2 (milliseconds)
+		var sample []byte
+		sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+		sample = protowire.AppendFixed64(sample, math.Float64bits(ts.Value))
+		sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+		sample = protowire.AppendVarint(sample, uint64(timestamp.UnixMilli()))
+
+		tsBytes = protowire.AppendTag(tsBytes, 2, protowire.BytesType)
+		tsBytes = protowire.AppendBytes(tsBytes, sample)
+
+		// WriteRequest: repeated TimeSeries timeseries = 1
+		out = protowire.AppendTag(out, 1, protowire.BytesType)
+		out = protowire.AppendBytes(out, tsBytes)
+	}
+	return out
+}
+
+// snappyEncode returns data in the snappy block format required by remote
+// write. It only emits literals: the payload is small, so skipping
+// compression keeps the encoder trivial while staying readable by any
+// snappy decoder.
+func snappyEncode(data []byte) []byte {
+	out := binary.AppendUvarint(nil, uint64(len(data)))
+	for len(data) > 0 {
+		n := min(len(data), 1<<16)
+		if n <= 60 {
+			out = append(out, byte(n-1)<<2)
+		} else {
+			// Tag 61: literal length-1 in the next two bytes
+			out = append(out, 61<<2, byte(n-1), byte((n-1)>>8))
+		}
+		out = append(out, data[:n]...)
+		data = data[n:]
+	}
+	return out
+}
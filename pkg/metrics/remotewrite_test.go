@@ -0,0 +1,148 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestSnapshot(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	score := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "cluster_assessment_score"}, []string{"assessment_name", "profile"})
+	info := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "cluster_assessment_cluster_info"}, []string{"cluster_id"})
+	other := prometheus.NewGauge(prometheus.GaugeOpts{Name: "workqueue_depth"})
+	reg.MustRegister(score, info, other)
+
+	score.WithLabelValues("mine", "production").Set(87)
+	score.WithLabelValues("other", "production").Set(50)
+	info.WithLabelValues("cluster-a").Set(1)
+	info.WithLabelValues("cluster-b").Set(1)
+	other.Set(3)
+
+	series, err := Snapshot(reg, "mine", "cluster-a")
+	if err != nil {
+		t.Fatalf("Snapshot() error: %v", err)
+	}
+	if len(series) != 2 {
+		t.Fatalf("Expected 2 series, got %d: %+v", len(series), series)
+	}
+	for _, s := range series {
+		if s.Labels["cluster_id"] != "cluster-a" {
+			t.Errorf("Expected cluster_id label on %v", s.Labels)
+		}
+		if s.Labels["__name__"] == "cluster_assessment_score" && s.Value != 87 {
+			t.Errorf("Expected score 87, got %v", s.Value)
+		}
+	}
+}
+
+func TestRemoteWriteClient_Push(t *testing.T) {
+	series := []TimeSeries{{
+		Labels: map[string]string{"__name__": "cluster_assessment_score", "assessment_name": "mine", "cluster_id": "c1"},
+		Value:  87,
+	}}
+	now := time.UnixMilli(1700000000000)
+
+	var body []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c := &RemoteWriteClient{URL: server.URL, BearerToken: "secret"}
+	if err := c.Push(context.Background(), series, now); err != nil {
+		t.Fatalf("Push() error: %v", err)
+	}
+
+	if header.Get("Content-Encoding") != "snappy" || header.Get("Authorization") != "Bearer secret" {
+		t.Errorf("Unexpected headers: %v", header)
+	}
+
+	want := EncodeWriteRequest(series, now)
+	if got := decodeLiterals(t, body); !bytes.Equal(got, want) {
+		t.Errorf("Payload does not match encoded write request")
+	}
+
+	// The first label of the series is __name__, as labels must be sorted
+	_, _, n2 := protowire.ConsumeTag(want)
+	ts, _ := protowire.ConsumeBytes(want[n2:])
+	_, _, n3 := protowire.ConsumeTag(ts)
+	label, _ := protowire.ConsumeBytes(ts[n3:])
+	_, _, n4 := protowire.ConsumeTag(label)
+	name, _ := protowire.ConsumeString(label[n4:])
+	if name != "__name__" {
+		t.Errorf("Expected first label __name__, got %q", name)
+	}
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "out of order sample", http.StatusBadRequest)
+	})
+	if err := c.Push(context.Background(), series, now); err == nil {
+		t.Error("Expected error for non-2xx response")
+	}
+}
+
+func TestSnappyEncode_LongLiteral(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), 70000)
+	if decoded := decodeLiterals(t, snappyEncode(data)); !bytes.Equal(decoded, data) {
+		t.Error("Decoded data does not match input")
+	}
+}
+
+// decodeLiterals decodes a snappy block that only contains literals.
+func decodeLiterals(t *testing.T, block []byte) []byte {
+	t.Helper()
+	n, size := binary.Uvarint(block)
+	block = block[size:]
+
+	var decoded []byte
+	for len(block) > 0 {
+		tag := block[0]
+		var length int
+		switch {
+		case tag&3 != 0:
+			t.Fatalf("Unexpected non-literal tag %#x", tag)
+		case tag>>2 < 60:
+			length = int(tag>>2) + 1
+			block = block[1:]
+		case tag>>2 == 61:
+			length = int(block[1]) | int(block[2])<<8 + 1
+			block = block[3:]
+		default:
+			t.Fatalf("Unexpected literal tag %#x", tag)
+		}
+		decoded = append(decoded, block[:length]...)
+		block = block[length:]
+	}
+	if int(n) != len(decoded) {
+		t.Fatalf("Expected decoded length %d, got %d", n, len(decoded))
+	}
+	return decoded
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MaxTags is the maximum number of tags an assessment may set.
+const MaxTags = 10
+
+// maxTagValueLength is the maximum length of a tag value.
+const maxTagValueLength = 63
+
+// tagKeyPattern matches tag keys that are valid Prometheus label names.
+var tagKeyPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// ValidateTags returns an error if tags cannot be exported as metric labels.
+func ValidateTags(tags map[string]string) error {
+	if len(tags) > MaxTags {
+		return fmt.Errorf("%d tags set, at most %d are allowed", len(tags), MaxTags)
+	}
+	for key, value := range tags {
+		if !tagKeyPattern.MatchString(key) {
+			return fmt.Errorf("tag key %q must match %s", key, tagKeyPattern)
+		}
+		if len(value) > maxTagValueLength {
+			return fmt.Errorf("value of tag %q is longer than %d characters", key, maxTagValueLength)
+		}
+	}
+	return nil
+}
+
+// tagsDescHelp is the help text of the cluster_assessment_tags metric.
+const tagsDescHelp = "Assessment tags (always 1, each tag is a tag_<key> label)"
+
+// AssessmentTags exposes the tags of every assessment as labels of the
+// cluster_assessment_tags metric, so dashboards can join it with the other
+// metrics on assessment_name. The label names depend on the tags, so it is
+// an unchecked collector, like the kube-state-metrics *_labels metrics.
+var AssessmentTags = &tagsCollector{tags: make(map[string]map[string]string)}
+
+type tagsCollector struct {
+	mu   sync.Mutex
+	tags map[string]map[string]string
+}
+
+// Describe sends no descriptors, which makes the collector unchecked.
+func (c *tagsCollector) Describe(chan<- *prometheus.Desc) {}
+
+// Collect sends one metric per assessment.
+func (c *tagsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name, tags := range c.tags {
+		keys := make([]string, 0, len(tags))
+		for key := range tags {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		labelNames := []string{"assessment_name"}
+		labelValues := []string{name}
+		for _, key := range keys {
+			labelNames = append(labelNames, "tag_"+key)
+			labelValues = append(labelValues, tags[key])
+		}
+		desc := prometheus.NewDesc("cluster_assessment_tags", tagsDescHelp, labelNames, nil)
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, labelValues...)
+	}
+}
+
+// RecordAssessmentTags sets the tags of an assessment. Empty tags remove the
+// assessment from the metric.
+func RecordAssessmentTags(assessmentName string, tags map[string]string) {
+	AssessmentTags.mu.Lock()
+	defer AssessmentTags.mu.Unlock()
+
+	if len(tags) == 0 {
+		delete(AssessmentTags.tags, assessmentName)
+		return
+	}
+	copied := make(map[string]string, len(tags))
+	for key, value := range tags {
+		copied[key] = value
+	}
+	AssessmentTags.tags[assessmentName] = copied
+}
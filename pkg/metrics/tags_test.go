@@ -0,0 +1,87 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestValidateTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    map[string]string
+		wantErr string
+	}{
+		{name: "valid", tags: map[string]string{"environment": "prod", "cost_center": "4711"}},
+		{name: "invalid key", tags: map[string]string{"team-name": "a"}, wantErr: "must match"},
+		{name: "leading digit", tags: map[string]string{"1region": "eu"}, wantErr: "must match"},
+		{name: "long value", tags: map[string]string{"team": strings.Repeat("x", 64)}, wantErr: "longer than 63"},
+		{name: "too many", tags: func() map[string]string {
+			tags := map[string]string{}
+			for i := 0; i <= MaxTags; i++ {
+				tags["t"+strings.Repeat("x", i)] = "v"
+			}
+			return tags
+		}(), wantErr: "at most 10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTags(tt.tags)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateTags() error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateTags() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRecordAssessmentTags(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(AssessmentTags)
+	defer RecordAssessmentTags("weekly", nil)
+	defer RecordAssessmentTags("nightly", nil)
+
+	RecordAssessmentTags("nightly", map[string]string{"environment": "prod", "region": "eu"})
+	RecordAssessmentTags("weekly", map[string]string{"team": "payments"})
+
+	series, err := Snapshot(reg, "nightly", "c1")
+	if err != nil {
+		t.Fatalf("Snapshot() error: %v", err)
+	}
+	if len(series) != 1 {
+		t.Fatalf("Expected 1 series, got %d: %+v", len(series), series)
+	}
+	labels := series[0].Labels
+	if labels["__name__"] != "cluster_assessment_tags" || labels["tag_environment"] != "prod" || labels["tag_region"] != "eu" {
+		t.Errorf("Unexpected labels %v", labels)
+	}
+
+	RecordAssessmentTags("nightly", nil)
+	series, _ = Snapshot(reg, "nightly", "c1")
+	if len(series) != 0 {
+		t.Errorf("Expected tags to be removed, got %+v", series)
+	}
+}
@@ -45,6 +45,16 @@ type Profile struct {
 	// DisabledChecks lists specific checks to skip.
 	DisabledChecks []string `json:"disabledChecks,omitempty"`
 
+	// RequiredOperators lists operators whose ClusterServiceVersion must be
+	// present and Succeeded. Empty means no operator is required.
+	RequiredOperators []string `json:"requiredOperators,omitempty"`
+
+	// ExpectedClusterAdmins lists the subjects expected to hold cluster-admin,
+	// e.g. "Group:platform-admins" or "ServiceAccount:gitops/argocd". When set,
+	// cluster-admin drift is reported instead of applying
+	// MaxClusterAdminBindings.
+	ExpectedClusterAdmins []string `json:"expectedClusterAdmins,omitempty"`
+
 	// Thresholds configures check-specific thresholds.
 	Thresholds ProfileThresholds `json:"thresholds"`
 }
@@ -80,6 +90,36 @@ type ProfileThresholds struct {
 
 	// RequireDefaultStorageClass requires a default StorageClass.
 	RequireDefaultStorageClass bool `json:"requireDefaultStorageClass"`
+
+	// MinMonitoringRetentionDays is the minimum Prometheus metrics retention.
+	MinMonitoringRetentionDays int `json:"minMonitoringRetentionDays"`
+
+	// RequireReplicaSpread requires multi-replica workloads to declare pod
+	// anti-affinity or topology spread constraints.
+	RequireReplicaSpread bool `json:"requireReplicaSpread"`
+
+	// RequireBidirectionalDeny requires namespaces with a default-deny
+	// NetworkPolicy to deny both ingress and egress by default.
+	RequireBidirectionalDeny bool `json:"requireBidirectionalDeny"`
+
+	// EscalateAfterRuns escalates a WARN finding to FAIL once it has been
+	// reported for more than this many consecutive runs. 0 disables escalation.
+	EscalateAfterRuns int `json:"escalateAfterRuns"`
+
+	// CAExpiryCriticalDays is the number of days before a cluster CA expires
+	// from which its expiry is reported as a failure.
+	CAExpiryCriticalDays int `json:"caExpiryCriticalDays"`
+
+	// CertExpiryWarningDays is the number of days before an application
+	// certificate on a Route or Ingress expires from which it is reported.
+	CertExpiryWarningDays int `json:"certExpiryWarningDays"`
+
+	// MaxSecrets, MaxConfigMaps, MaxEvents and MaxPods are the cluster-wide
+	// object counts above which etcd is considered under strain.
+	MaxSecrets    int `json:"maxSecrets"`
+	MaxConfigMaps int `json:"maxConfigMaps"`
+	MaxEvents     int `json:"maxEvents"`
+	MaxPods       int `json:"maxPods"`
 }
 
 // GetProfile returns the profile configuration for the given profile name.
@@ -115,6 +155,16 @@ var productionProfile = Profile{
 		MaxDaysWithoutUpdate:       90,
 		AllowPrivilegedContainers:  false,
 		RequireDefaultStorageClass: true,
+		MinMonitoringRetentionDays: 15,
+		RequireReplicaSpread:       true,
+		RequireBidirectionalDeny:   true,
+		EscalateAfterRuns:          10,
+		CAExpiryCriticalDays:       90,
+		CertExpiryWarningDays:      30,
+		MaxSecrets:                 50000,
+		MaxConfigMaps:              50000,
+		MaxEvents:                  100000,
+		MaxPods:                    50000,
 	},
 }
 
@@ -134,5 +184,15 @@ var developmentProfile = Profile{
 		MaxDaysWithoutUpdate:       180,
 		AllowPrivilegedContainers:  true,
 		RequireDefaultStorageClass: false,
+		MinMonitoringRetentionDays: 1,
+		RequireReplicaSpread:       false,
+		RequireBidirectionalDeny:   false,
+		EscalateAfterRuns:          0,
+		CAExpiryCriticalDays:       30,
+		CertExpiryWarningDays:      14,
+		MaxSecrets:                 80000,
+		MaxConfigMaps:              90000,
+		MaxEvents:                  150000,
+		MaxPods:                    150000,
 	},
 }
@@ -0,0 +1,54 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/compliance"
+)
+
+// CompliancePackReport groups the findings of an assessment by the controls
+// of a compliance pack.
+type CompliancePackReport struct {
+	// Metadata about the report
+	Metadata ReportMetadata `json:"metadata"`
+
+	// Pack is the compliance pack the findings are grouped by
+	Pack string `json:"pack"`
+
+	// Controls lists each control with evidence, ordered by control ID
+	Controls []compliance.ControlResult `json:"controls"`
+}
+
+// GenerateCompliancePackReport generates a JSON report grouping the findings
+// of a ClusterAssessment by the controls of its spec.compliancePack.
+func GenerateCompliancePackReport(assessment *assessmentv1alpha1.ClusterAssessment) ([]byte, error) {
+	pack := assessment.Spec.CompliancePack
+	if pack == "" {
+		return nil, fmt.Errorf("assessment %s has no compliance pack", assessment.Name)
+	}
+
+	report := CompliancePackReport{
+		Metadata: newReportMetadata(assessment),
+		Pack:     pack,
+		Controls: compliance.GroupByControl(pack, assessment.Status.Findings),
+	}
+	return json.MarshalIndent(report, "", "  ")
+}
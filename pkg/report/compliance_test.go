@@ -0,0 +1,56 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+func TestGenerateCompliancePackReport(t *testing.T) {
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		ObjectMeta: metav1.ObjectMeta{Name: "pci"},
+		Spec:       assessmentv1alpha1.ClusterAssessmentSpec{CompliancePack: "pci-dss"},
+		Status: assessmentv1alpha1.ClusterAssessmentStatus{
+			Findings: []assessmentv1alpha1.Finding{
+				{ID: "apiserver-no-encryption", Status: assessmentv1alpha1.FindingStatusWarn},
+			},
+		},
+	}
+
+	data, err := GenerateCompliancePackReport(assessment)
+	if err != nil {
+		t.Fatalf("GenerateCompliancePackReport failed: %v", err)
+	}
+
+	var got CompliancePackReport
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got.Pack != "pci-dss" || len(got.Controls) != 1 || got.Controls[0].Control != "3.5.1" {
+		t.Errorf("unexpected report %+v", got)
+	}
+
+	assessment.Spec.CompliancePack = ""
+	if _, err := GenerateCompliancePackReport(assessment); err == nil {
+		t.Error("expected error without a compliance pack")
+	}
+}
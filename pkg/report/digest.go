@@ -0,0 +1,261 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+// Digest summarizes how a scheduled assessment developed over its recent runs
+// and since the previous digest.
+type Digest struct {
+	// AssessmentName is the name of the ClusterAssessment CR
+	AssessmentName string `json:"assessmentName"`
+
+	// GeneratedAt is when the digest was generated
+	GeneratedAt time.Time `json:"generatedAt"`
+
+	// Runs are the summaries of the covered runs, oldest first
+	Runs []assessmentv1alpha1.RunSummary `json:"runs"`
+
+	// ScoreChange is the score of the latest run minus that of the oldest
+	// covered run, if both were scored
+	ScoreChange *int `json:"scoreChange,omitempty"`
+
+	// Appeared lists the WARN and FAIL findings not reported by the previous digest
+	Appeared []DigestFinding `json:"appeared"`
+
+	// Resolved lists the findings of the previous digest that are no longer
+	// reported as WARN or FAIL
+	Resolved []DigestFinding `json:"resolved"`
+
+	// Chronic lists the WARN and FAIL findings already reported by the previous digest
+	Chronic []DigestFinding `json:"chronic"`
+
+	// Findings lists all current WARN and FAIL findings. The next digest
+	// compares against them.
+	Findings []DigestFinding `json:"findings"`
+}
+
+// DigestFinding identifies a WARN or FAIL finding in a Digest.
+type DigestFinding struct {
+	ID        string                           `json:"id"`
+	Validator string                           `json:"validator"`
+	Title     string                           `json:"title"`
+	Namespace string                           `json:"namespace,omitempty"`
+	Resource  string                           `json:"resource,omitempty"`
+	Status    assessmentv1alpha1.FindingStatus `json:"status"`
+}
+
+// key identifies the finding across runs.
+func (f DigestFinding) key() string {
+	return f.ID + "/" + f.Namespace + "/" + f.Resource
+}
+
+// GenerateDigest builds a digest of the given run history, newest first as
+// kept in status.history, and the current findings. previous holds the
+// findings of the last digest and is empty for the first one.
+func GenerateDigest(history []assessmentv1alpha1.RunSummary, previous []DigestFinding, findings []assessmentv1alpha1.Finding) Digest {
+	digest := Digest{
+		Runs:     make([]assessmentv1alpha1.RunSummary, 0, len(history)),
+		Appeared: []DigestFinding{},
+		Resolved: []DigestFinding{},
+		Chronic:  []DigestFinding{},
+		Findings: []DigestFinding{},
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		digest.Runs = append(digest.Runs, history[i])
+	}
+	if len(digest.Runs) > 1 {
+		first, last := digest.Runs[0].Score, digest.Runs[len(digest.Runs)-1].Score
+		if first != nil && last != nil {
+			change := *last - *first
+			digest.ScoreChange = &change
+		}
+	}
+
+	current := make(map[string]bool)
+	for _, f := range findings {
+		if f.Status != assessmentv1alpha1.FindingStatusWarn && f.Status != assessmentv1alpha1.FindingStatusFail {
+			continue
+		}
+		df := DigestFinding{ID: f.ID, Validator: f.Validator, Title: f.Title, Namespace: f.Namespace, Resource: f.Resource, Status: f.Status}
+		if current[df.key()] {
+			continue
+		}
+		current[df.key()] = true
+		digest.Findings = append(digest.Findings, df)
+	}
+	sortDigestFindings(digest.Findings)
+
+	before := make(map[string]bool)
+	for _, f := range previous {
+		before[f.key()] = true
+		if !current[f.key()] {
+			digest.Resolved = append(digest.Resolved, f)
+		}
+	}
+	for _, f := range digest.Findings {
+		if before[f.key()] {
+			digest.Chronic = append(digest.Chronic, f)
+		} else {
+			digest.Appeared = append(digest.Appeared, f)
+		}
+	}
+	sortDigestFindings(digest.Resolved)
+
+	return digest
+}
+
+// sortDigestFindings orders findings FAIL first, then by validator and ID.
+func sortDigestFindings(findings []DigestFinding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Status != b.Status {
+			return a.Status == assessmentv1alpha1.FindingStatusFail
+		}
+		if a.Validator != b.Validator {
+			return a.Validator < b.Validator
+		}
+		return a.key() < b.key()
+	})
+}
+
+// GenerateDigestHTML renders a digest as a standalone HTML page with a
+// sparkline of the scores.
+func GenerateDigestHTML(digest Digest) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>OpenShift Cluster Assessment Digest</title>
+    <style>
+        body { font-family: 'Segoe UI', Arial, sans-serif; margin: 40px; background: #f5f5f5; }
+        .container { max-width: 900px; margin: 0 auto; background: white; padding: 40px; box-shadow: 0 2px 10px rgba(0,0,0,0.1); }
+        h1 { color: #003366; border-bottom: 3px solid #003366; padding-bottom: 10px; }
+        h2 { color: #003366; margin-top: 30px; }
+        .runs-table { width: 100%; border-collapse: collapse; }
+        .runs-table th, .runs-table td { padding: 6px 8px; border-bottom: 1px solid #eee; text-align: center; }
+        .runs-table th:first-child, .runs-table td:first-child { text-align: left; }
+        .status-FAIL { color: #DC143C; font-weight: bold; }
+        .status-WARN { color: #FFA500; font-weight: bold; }
+        .finding-meta { font-size: 11px; color: #888; }
+    </style>
+</head>
+<body>
+<div class="container">
+`)
+
+	buf.WriteString(fmt.Sprintf(`<h1>Assessment Digest: %s</h1>
+<p style="color: #888;">Generated: %s, covering %d run(s)</p>
+`, html.EscapeString(digest.AssessmentName), digest.GeneratedAt.Format("January 2, 2006 at 15:04 MST"), len(digest.Runs)))
+
+	// Score trend
+	buf.WriteString(`<h2>Score Trend</h2>`)
+	if len(digest.Runs) > 0 {
+		if score := digest.Runs[len(digest.Runs)-1].Score; score != nil {
+			line := fmt.Sprintf("Latest score: %d", *score)
+			if digest.ScoreChange != nil {
+				line += fmt.Sprintf(" (%+d over the period)", *digest.ScoreChange)
+			}
+			buf.WriteString(fmt.Sprintf(`<p>%s</p>`, line))
+		}
+		buf.WriteString(sparkline(digest.Runs))
+		buf.WriteString(`<table class="runs-table"><tr><th>Run</th><th>Score</th><th>Pass</th><th>Warn</th><th>Fail</th></tr>`)
+		for _, run := range digest.Runs {
+			buf.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%d</td><td>%d</td><td>%d</td></tr>`,
+				run.Time.Format("2006-01-02 15:04"), describeScore(run.Score), run.PassCount, run.WarnCount, run.FailCount))
+		}
+		buf.WriteString(`</table>`)
+	} else {
+		buf.WriteString(`<p>No completed runs yet.</p>`)
+	}
+
+	writeDigestFindings(&buf, "Newly Appeared", digest.Appeared, "No new warnings or failures since the previous digest.")
+	writeDigestFindings(&buf, "Resolved", digest.Resolved, "No warnings or failures were resolved since the previous digest.")
+	writeDigestFindings(&buf, "Chronic Issues", digest.Chronic, "No warnings or failures persisted since the previous digest.")
+
+	buf.WriteString(`</div></body></html>`)
+	return buf.Bytes()
+}
+
+// writeDigestFindings renders a list of digest findings under a heading, or
+// the empty message when there are none.
+func writeDigestFindings(buf *bytes.Buffer, title string, findings []DigestFinding, empty string) {
+	buf.WriteString(fmt.Sprintf(`<h2>%s (%d)</h2>`, title, len(findings)))
+	if len(findings) == 0 {
+		buf.WriteString(fmt.Sprintf(`<p>%s</p>`, empty))
+		return
+	}
+	buf.WriteString(`<ul>`)
+	for _, f := range findings {
+		var resource []string
+		for _, part := range []string{f.Namespace, f.Resource} {
+			if part != "" {
+				resource = append(resource, part)
+			}
+		}
+		buf.WriteString(fmt.Sprintf(`<li><span class="status-%s">[%s]</span> %s <span class="finding-meta">%s`,
+			f.Status, f.Status, html.EscapeString(f.Title), html.EscapeString(f.Validator)))
+		if len(resource) > 0 {
+			buf.WriteString(fmt.Sprintf(` | %s`, html.EscapeString(strings.Join(resource, "/"))))
+		}
+		buf.WriteString(`</span></li>`)
+	}
+	buf.WriteString(`</ul>`)
+}
+
+// Sparkline dimensions in pixels.
+const (
+	sparklineWidth  = 300
+	sparklineHeight = 60
+)
+
+// sparkline renders the scores of runs, oldest first, as an inline SVG line
+// on a 0-100 scale. Unscored runs are skipped.
+func sparkline(runs []assessmentv1alpha1.RunSummary) string {
+	var scores []int
+	for _, run := range runs {
+		if run.Score != nil {
+			scores = append(scores, *run.Score)
+		}
+	}
+	if len(scores) == 0 {
+		return ""
+	}
+
+	points := make([]string, 0, len(scores))
+	for i, score := range scores {
+		x := sparklineWidth / 2
+		if len(scores) > 1 {
+			x = i * sparklineWidth / (len(scores) - 1)
+		}
+		y := sparklineHeight - score*sparklineHeight/100
+		points = append(points, fmt.Sprintf("%d,%d", x, y))
+	}
+	return fmt.Sprintf(`<svg class="sparkline" width="%d" height="%d" viewBox="-2 -2 %d %d"><polyline fill="none" stroke="#003366" stroke-width="2" points="%s"/></svg>`,
+		sparklineWidth, sparklineHeight, sparklineWidth+4, sparklineHeight+4, strings.Join(points, " "))
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+func TestGenerateDigest(t *testing.T) {
+	score := func(s int) *int { return &s }
+	start := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	history := []assessmentv1alpha1.RunSummary{
+		{Time: metav1.NewTime(start.Add(48 * time.Hour)), Score: score(85)},
+		{Time: metav1.NewTime(start.Add(24 * time.Hour)), Score: score(70)},
+		{Time: metav1.NewTime(start), Score: score(75)},
+	}
+	previous := []DigestFinding{
+		{ID: "nodes-workers", Validator: "nodes", Title: "Too Few Workers", Status: assessmentv1alpha1.FindingStatusFail},
+		{ID: "certificates-expiring", Validator: "certificates", Title: "Expiring Certificates", Status: assessmentv1alpha1.FindingStatusWarn},
+	}
+	findings := []assessmentv1alpha1.Finding{
+		{ID: "nodes-workers", Validator: "nodes", Title: "Too Few Workers", Status: assessmentv1alpha1.FindingStatusFail},
+		{ID: "security-privileged", Validator: "security", Title: "Privileged Pods", Namespace: "shop", Status: assessmentv1alpha1.FindingStatusWarn},
+		{ID: "storage-default", Validator: "storage", Title: "Default StorageClass", Status: assessmentv1alpha1.FindingStatusPass},
+	}
+
+	digest := GenerateDigest(history, previous, findings)
+	if len(digest.Runs) != 3 || *digest.Runs[0].Score != 75 || *digest.Runs[2].Score != 85 {
+		t.Errorf("expected runs oldest first, got %+v", digest.Runs)
+	}
+	if digest.ScoreChange == nil || *digest.ScoreChange != 10 {
+		t.Errorf("expected a score change of +10, got %v", digest.ScoreChange)
+	}
+	ids := func(findings []DigestFinding) string {
+		var out []string
+		for _, f := range findings {
+			out = append(out, f.ID)
+		}
+		return strings.Join(out, ",")
+	}
+	if got := ids(digest.Appeared); got != "security-privileged" {
+		t.Errorf("Appeared = %s", got)
+	}
+	if got := ids(digest.Resolved); got != "certificates-expiring" {
+		t.Errorf("Resolved = %s", got)
+	}
+	if got := ids(digest.Chronic); got != "nodes-workers" {
+		t.Errorf("Chronic = %s", got)
+	}
+	if got := ids(digest.Findings); got != "nodes-workers,security-privileged" {
+		t.Errorf("Findings = %s", got)
+	}
+
+	digest.AssessmentName = "weekly"
+	out := string(GenerateDigestHTML(digest))
+	for _, want := range []string{
+		"<h1>Assessment Digest: weekly</h1>",
+		"Latest score: 85 (+10 over the period)",
+		`<polyline fill="none" stroke="#003366" stroke-width="2" points="0,15 150,18 300,9"/>`,
+		"<h2>Newly Appeared (1)</h2>",
+		"Privileged Pods <span class=\"finding-meta\">security | shop</span>",
+		"<h2>Resolved (1)</h2>",
+		"<h2>Chronic Issues (1)</h2>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the digest HTML", want)
+		}
+	}
+}
+
+func TestGenerateDigest_First(t *testing.T) {
+	digest := GenerateDigest(nil, nil, []assessmentv1alpha1.Finding{
+		{ID: "nodes-workers", Status: assessmentv1alpha1.FindingStatusWarn},
+	})
+	if digest.ScoreChange != nil || len(digest.Appeared) != 1 || len(digest.Chronic) != 0 {
+		t.Errorf("unexpected first digest: %+v", digest)
+	}
+	if !strings.Contains(string(GenerateDigestHTML(digest)), "No completed runs yet.") {
+		t.Error("expected the empty trend message")
+	}
+}
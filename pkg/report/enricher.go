@@ -0,0 +1,137 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+// Enricher adds organization-specific context, such as internal runbook
+// links, to findings after they are collected.
+type Enricher interface {
+	// Enrich returns the findings with any extra context applied. It must be
+	// idempotent, since carried-forward findings may be enriched again.
+	Enrich(ctx context.Context, findings []assessmentv1alpha1.Finding) ([]assessmentv1alpha1.Finding, error)
+}
+
+// NopEnricher leaves findings unchanged.
+type NopEnricher struct{}
+
+// Enrich returns findings unchanged.
+func (NopEnricher) Enrich(_ context.Context, findings []assessmentv1alpha1.Finding) ([]assessmentv1alpha1.Finding, error) {
+	return findings, nil
+}
+
+// Enrichment is the extra context attached to a finding.
+type Enrichment struct {
+	// References are appended to the finding's references
+	References []string `json:"references,omitempty" yaml:"references,omitempty"`
+
+	// Impact is appended to the finding's impact
+	Impact string `json:"impact,omitempty" yaml:"impact,omitempty"`
+}
+
+// ConfigMapEnricher enriches findings from a ConfigMap that maps finding IDs
+// to an Enrichment, one YAML document per data key:
+//
+//	data:
+//	  etcdbackup-not-configured: |
+//	    references:
+//	      - https://runbooks.example.com/etcd-backup
+//	    impact: Our DR policy requires daily etcd backups.
+//
+// The ConfigMap is read on every call, so edits apply to the next run.
+type ConfigMapEnricher struct {
+	Reader    client.Reader
+	Namespace string
+	Name      string
+}
+
+// NewConfigMapEnricher returns an Enricher backed by the named ConfigMap.
+func NewConfigMapEnricher(reader client.Reader, namespace, name string) *ConfigMapEnricher {
+	return &ConfigMapEnricher{Reader: reader, Namespace: namespace, Name: name}
+}
+
+// Enrich applies the enrichments of the ConfigMap to matching findings.
+func (e *ConfigMapEnricher) Enrich(ctx context.Context, findings []assessmentv1alpha1.Finding) ([]assessmentv1alpha1.Finding, error) {
+	cm := &corev1.ConfigMap{}
+	if err := e.Reader.Get(ctx, client.ObjectKey{Namespace: e.Namespace, Name: e.Name}, cm); err != nil {
+		return findings, fmt.Errorf("reading enrichment ConfigMap %s/%s: %w", e.Namespace, e.Name, err)
+	}
+
+	enrichments, err := ParseEnrichments(cm.Data)
+	if err != nil {
+		return findings, fmt.Errorf("parsing enrichment ConfigMap %s/%s: %w", e.Namespace, e.Name, err)
+	}
+	return ApplyEnrichments(findings, enrichments), nil
+}
+
+// ParseEnrichments parses ConfigMap data keyed by finding ID.
+func ParseEnrichments(data map[string]string) (map[string]Enrichment, error) {
+	enrichments := make(map[string]Enrichment, len(data))
+	for id, text := range data {
+		var enrichment Enrichment
+		if err := yaml.Unmarshal([]byte(text), &enrichment); err != nil {
+			return nil, fmt.Errorf("finding %s: %w", id, err)
+		}
+		enrichments[id] = enrichment
+	}
+	return enrichments, nil
+}
+
+// ApplyEnrichments appends the references and impact text of the matching
+// enrichment to each finding. Text already present is not added twice.
+func ApplyEnrichments(findings []assessmentv1alpha1.Finding, enrichments map[string]Enrichment) []assessmentv1alpha1.Finding {
+	if len(enrichments) == 0 {
+		return findings
+	}
+
+	enriched := make([]assessmentv1alpha1.Finding, len(findings))
+	for i, f := range findings {
+		enrichment, ok := enrichments[f.ID]
+		if !ok {
+			enriched[i] = f
+			continue
+		}
+
+		f.References = slices.Clone(f.References)
+		for _, ref := range enrichment.References {
+			if !slices.Contains(f.References, ref) {
+				f.References = append(f.References, ref)
+			}
+		}
+
+		if impact := strings.TrimSpace(enrichment.Impact); impact != "" && !strings.Contains(f.Impact, impact) {
+			if f.Impact == "" {
+				f.Impact = impact
+			} else {
+				f.Impact = f.Impact + " " + impact
+			}
+		}
+		enriched[i] = f
+	}
+	return enriched
+}
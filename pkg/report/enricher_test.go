@@ -0,0 +1,99 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+func TestConfigMapEnricher(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "runbooks", Namespace: "cluster-assessment-operator"},
+		Data: map[string]string{
+			"etcdbackup-not-configured": "references:\n  - https://runbooks.example.com/etcd-backup\nimpact: Our DR policy requires daily etcd backups.\n",
+			"security-privileged-pods":  "references:\n  - https://docs.example.com/privileged\n",
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm).Build()
+
+	findings := []assessmentv1alpha1.Finding{
+		{ID: "etcdbackup-not-configured", Impact: "Recovery may not be possible.", References: []string{"https://docs.openshift.com/backup"}},
+		{ID: "security-privileged-pods", References: []string{"https://docs.example.com/privileged"}},
+		{ID: "nodes-count"},
+	}
+
+	enricher := NewConfigMapEnricher(c, "cluster-assessment-operator", "runbooks")
+	enriched, err := enricher.Enrich(context.Background(), findings)
+	if err != nil {
+		t.Fatalf("Enrich failed: %v", err)
+	}
+
+	backup := enriched[0]
+	if !slices.Equal(backup.References, []string{"https://docs.openshift.com/backup", "https://runbooks.example.com/etcd-backup"}) {
+		t.Errorf("unexpected references %v", backup.References)
+	}
+	if backup.Impact != "Recovery may not be possible. Our DR policy requires daily etcd backups." {
+		t.Errorf("unexpected impact %q", backup.Impact)
+	}
+	if len(enriched[1].References) != 1 {
+		t.Errorf("expected duplicate reference to be skipped, got %v", enriched[1].References)
+	}
+	if len(findings[0].References) != 1 {
+		t.Errorf("expected input findings to be left unchanged, got %v", findings[0].References)
+	}
+
+	// Enriching again must not duplicate anything.
+	again, err := enricher.Enrich(context.Background(), enriched)
+	if err != nil {
+		t.Fatalf("Enrich failed: %v", err)
+	}
+	if !slices.Equal(again[0].References, backup.References) || again[0].Impact != backup.Impact {
+		t.Errorf("expected enrichment to be idempotent, got %+v", again[0])
+	}
+}
+
+func TestConfigMapEnricher_Errors(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	findings := []assessmentv1alpha1.Finding{{ID: "nodes-count"}}
+
+	missing := NewConfigMapEnricher(fake.NewClientBuilder().WithScheme(scheme).Build(), "ns", "absent")
+	if got, err := missing.Enrich(context.Background(), findings); err == nil || len(got) != 1 {
+		t.Errorf("expected an error and unchanged findings for a missing ConfigMap, got %v, %v", got, err)
+	}
+
+	invalid := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: "ns"},
+		Data:       map[string]string{"nodes-count": "references: [unterminated"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(invalid).Build()
+	if _, err := NewConfigMapEnricher(c, "ns", "invalid").Enrich(context.Background(), findings); err == nil {
+		t.Error("expected an error for invalid YAML")
+	}
+}
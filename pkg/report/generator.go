@@ -45,6 +45,9 @@ type Report struct {
 
 	// FindingsByStatus groups findings by status
 	FindingsByStatus map[string][]assessmentv1alpha1.Finding `json:"findingsByStatus" yaml:"findingsByStatus"`
+
+	// ProfileComparison compares the findings under other profiles, if requested
+	ProfileComparison *assessmentv1alpha1.ProfileComparison `json:"profileComparison,omitempty" yaml:"profileComparison,omitempty"`
 }
 
 // ReportMetadata contains report metadata.
@@ -60,6 +63,15 @@ type ReportMetadata struct {
 
 	// OperatorVersion is the version of the operator
 	OperatorVersion string `json:"operatorVersion" yaml:"operatorVersion"`
+
+	// Owner is the person or team that requested the assessment
+	Owner string `json:"owner,omitempty" yaml:"owner,omitempty"`
+
+	// Contact is where to send questions about the findings
+	Contact string `json:"contact,omitempty" yaml:"contact,omitempty"`
+
+	// Tags categorize the assessment, e.g. by environment, region or team
+	Tags map[string]string `json:"tags,omitempty" yaml:"tags,omitempty"`
 }
 
 // GenerateJSON generates a JSON report from a ClusterAssessment.
@@ -74,31 +86,55 @@ func GenerateYAML(assessment *assessmentv1alpha1.ClusterAssessment) ([]byte, err
 	return yaml.Marshal(report)
 }
 
+// newReportMetadata returns the metadata shared by all reports of an assessment.
+func newReportMetadata(assessment *assessmentv1alpha1.ClusterAssessment) ReportMetadata {
+	return ReportMetadata{
+		GeneratedAt:     time.Now(),
+		AssessmentName:  assessment.Name,
+		Profile:         assessment.Spec.Profile,
+		OperatorVersion: version.Version,
+		Owner:           assessment.Spec.ReportMetadata.Owner,
+		Contact:         assessment.Spec.ReportMetadata.Contact,
+		Tags:            assessment.Spec.Tags,
+	}
+}
+
 // buildReport constructs the Report from a ClusterAssessment.
 func buildReport(assessment *assessmentv1alpha1.ClusterAssessment) Report {
+	findings := withConfidence(assessment.Status.Findings)
 	report := Report{
-		Metadata: ReportMetadata{
-			GeneratedAt:     time.Now(),
-			AssessmentName:  assessment.Name,
-			Profile:         assessment.Spec.Profile,
-			OperatorVersion: version.Version,
-		},
+		Metadata:           newReportMetadata(assessment),
 		ClusterInfo:        assessment.Status.ClusterInfo,
 		Summary:            assessment.Status.Summary,
-		Findings:           assessment.Status.Findings,
+		Findings:           findings,
 		FindingsByCategory: make(map[string][]assessmentv1alpha1.Finding),
 		FindingsByStatus:   make(map[string][]assessmentv1alpha1.Finding),
+		ProfileComparison:  assessment.Status.ProfileComparison,
 	}
 
 	// Group findings by category
-	for _, f := range assessment.Status.Findings {
+	for _, f := range findings {
 		report.FindingsByCategory[f.Category] = append(report.FindingsByCategory[f.Category], f)
 	}
 
 	// Group findings by status
-	for _, f := range assessment.Status.Findings {
+	for _, f := range findings {
 		report.FindingsByStatus[string(f.Status)] = append(report.FindingsByStatus[string(f.Status)], f)
 	}
 
 	return report
 }
+
+// withConfidence returns a copy of findings in which findings without a
+// confidence, such as those stored before confidence levels existed, are
+// marked high, so that every finding in the report states its confidence.
+func withConfidence(findings []assessmentv1alpha1.Finding) []assessmentv1alpha1.Finding {
+	out := make([]assessmentv1alpha1.Finding, len(findings))
+	for i, f := range findings {
+		if f.Confidence == "" {
+			f.Confidence = assessmentv1alpha1.FindingConfidenceHigh
+		}
+		out[i] = f
+	}
+	return out
+}
@@ -1,6 +1,7 @@
 package report
 
 import (
+	"strings"
 	"testing"
 
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
@@ -32,3 +33,130 @@ func TestBuildReportPopulatesVersion(t *testing.T) {
 		t.Errorf("Expected OperatorVersion to be %q, got %q", testVersion, report.Metadata.OperatorVersion)
 	}
 }
+
+func TestBuildReport_OwnerAndContact(t *testing.T) {
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		Spec: assessmentv1alpha1.ClusterAssessmentSpec{
+			ReportMetadata: assessmentv1alpha1.ReportMetadataSpec{Owner: "platform-team", Contact: "#platform"},
+			Tags:           map[string]string{"region": "eu-west"},
+		},
+	}
+
+	metadata := buildReport(assessment).Metadata
+	if metadata.Owner != "platform-team" || metadata.Contact != "#platform" {
+		t.Errorf("expected owner and contact in metadata, got %+v", metadata)
+	}
+	if metadata.Tags["region"] != "eu-west" {
+		t.Errorf("expected tags in metadata, got %+v", metadata.Tags)
+	}
+}
+
+func TestGenerateJSON_Confidence(t *testing.T) {
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		Status: assessmentv1alpha1.ClusterAssessmentStatus{
+			Findings: []assessmentv1alpha1.Finding{
+				{ID: "legacy", Status: assessmentv1alpha1.FindingStatusPass},
+				{ID: "heuristic", Status: assessmentv1alpha1.FindingStatusWarn, Confidence: assessmentv1alpha1.FindingConfidenceLow},
+			},
+		},
+	}
+
+	data, err := GenerateJSON(assessment)
+	if err != nil {
+		t.Fatalf("GenerateJSON returned error: %v", err)
+	}
+	for _, want := range []string{`"confidence": "high"`, `"confidence": "low"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in the JSON report", want)
+		}
+	}
+	if assessment.Status.Findings[0].Confidence != "" {
+		t.Error("expected the assessment findings to be left unchanged")
+	}
+}
+
+func TestDescribeScope(t *testing.T) {
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		Status: assessmentv1alpha1.ClusterAssessmentStatus{
+			Summary: assessmentv1alpha1.AssessmentSummary{
+				RanValidators: []string{"certificates", "security"},
+			},
+			Findings: []assessmentv1alpha1.Finding{
+				{Validator: "security", Category: "Security"},
+				{Validator: "certificates", Category: "Security"},
+			},
+		},
+	}
+	if scope := describeScope(assessment); scope != "" {
+		t.Errorf("expected no scope note for a full assessment, got %q", scope)
+	}
+
+	assessment.Status.Summary.OmittedValidators = []string{"nodes", "version"}
+	want := "Scope of this report: partial assessment of 2 of 4 validators (certificates, security), covering Security. Not assessed: nodes, version."
+	if scope := describeScope(assessment); scope != want {
+		t.Errorf("describeScope() = %q, want %q", scope, want)
+	}
+
+	assessment.Spec.ResourceLabelSelector = map[string]string{"app.kubernetes.io/part-of": "payments"}
+	want += " Workload checks were limited to resources labeled app.kubernetes.io/part-of=payments."
+	if scope := describeScope(assessment); scope != want {
+		t.Errorf("describeScope() = %q, want %q", scope, want)
+	}
+
+	htmlBytes, err := GenerateHTML(assessment)
+	if err != nil {
+		t.Fatalf("GenerateHTML failed: %v", err)
+	}
+	if !strings.Contains(string(htmlBytes), want) {
+		t.Error("expected the scope note in the HTML report")
+	}
+}
+
+func TestGenerateHTML_ProfileComparison(t *testing.T) {
+	score := 90
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		Status: assessmentv1alpha1.ClusterAssessmentStatus{
+			ProfileComparison: &assessmentv1alpha1.ProfileComparison{
+				Profiles: []assessmentv1alpha1.ProfileResult{
+					{Profile: "development", Score: &score, WarnCount: 1},
+					{Profile: "production", WarnCount: 1, FailCount: 2},
+				},
+				Differences: []assessmentv1alpha1.ProfileDifference{{
+					ID:        "nodes-workers",
+					Validator: "nodes",
+					Title:     "Too Few Workers",
+					Namespace: "team-a",
+					Resource:  "api",
+					Statuses: map[string]assessmentv1alpha1.FindingStatus{
+						"development": assessmentv1alpha1.FindingStatusPass,
+						"production":  assessmentv1alpha1.FindingStatusFail,
+					},
+				}},
+				OmittedDifferences: 3,
+			},
+		},
+	}
+
+	out, err := GenerateHTML(assessment)
+	if err != nil {
+		t.Fatalf("GenerateHTML() returned error: %v", err)
+	}
+	report := string(out)
+	for _, want := range []string{
+		"<h2>Profile Comparison</h2>",
+		"<tr><td>development</td><td>90</td><td>1</td><td>0</td></tr>",
+		"<tr><td>production</td><td>-</td><td>1</td><td>2</td></tr>",
+		"<tr><td>Too Few Workers (team-a/api)</td><td>PASS</td><td>FAIL</td></tr>",
+		"... and 3 more differences.",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected %q in the HTML report", want)
+		}
+	}
+	if _, err := GeneratePDF(assessment); err != nil {
+		t.Fatalf("GeneratePDF() returned error: %v", err)
+	}
+	if buildReport(assessment).ProfileComparison == nil {
+		t.Error("expected the comparison in the JSON report")
+	}
+}
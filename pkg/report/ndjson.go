@@ -0,0 +1,74 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+// NDJSONRecord is a single line of the NDJSON report. Each record carries
+// the assessment context so it is self-describing once shipped to a log
+// store such as Loki, Elasticsearch or Splunk.
+type NDJSONRecord struct {
+	// Timestamp is when the assessment ran
+	Timestamp time.Time `json:"timestamp"`
+
+	// AssessmentName is the name of the ClusterAssessment CR
+	AssessmentName string `json:"assessmentName"`
+
+	// ClusterID is the unique identifier of the assessed cluster
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// Profile is the baseline profile used
+	Profile string `json:"profile,omitempty"`
+
+	// Tags categorize the assessment, e.g. by environment, region or team
+	Tags map[string]string `json:"tags,omitempty"`
+
+	assessmentv1alpha1.Finding
+}
+
+// GenerateNDJSON generates a newline-delimited JSON report with one record
+// per finding.
+func GenerateNDJSON(assessment *assessmentv1alpha1.ClusterAssessment) ([]byte, error) {
+	timestamp := time.Now()
+	if assessment.Status.LastRunTime != nil {
+		timestamp = assessment.Status.LastRunTime.Time
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, f := range withConfidence(assessment.Status.Findings) {
+		record := NDJSONRecord{
+			Timestamp:      timestamp.UTC(),
+			AssessmentName: assessment.Name,
+			ClusterID:      assessment.Status.ClusterInfo.ClusterID,
+			Profile:        assessment.Spec.Profile,
+			Tags:           assessment.Spec.Tags,
+			Finding:        f,
+		}
+		// Encode terminates every record with a newline.
+		if err := enc.Encode(record); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGenerateNDJSON(t *testing.T) {
+	runTime := metav1.NewTime(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly"},
+		Spec:       assessmentv1alpha1.ClusterAssessmentSpec{Profile: "production", Tags: map[string]string{"environment": "prod"}},
+		Status: assessmentv1alpha1.ClusterAssessmentStatus{
+			LastRunTime: &runTime,
+			ClusterInfo: assessmentv1alpha1.ClusterInfo{ClusterID: "abc-123"},
+			Findings: []assessmentv1alpha1.Finding{
+				{ID: "nodes-count", Validator: "nodes", Status: assessmentv1alpha1.FindingStatusPass, Title: "Node count"},
+				{ID: "security-privileged", Validator: "security", Status: assessmentv1alpha1.FindingStatusWarn, Title: "Privileged pods"},
+			},
+		},
+	}
+
+	data, err := GenerateNDJSON(assessment)
+	if err != nil {
+		t.Fatalf("GenerateNDJSON failed: %v", err)
+	}
+
+	var records []map[string]interface{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var record map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("line %q is not valid JSON: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	for _, r := range records {
+		if r["assessmentName"] != "nightly" || r["clusterID"] != "abc-123" || r["profile"] != "production" {
+			t.Errorf("record is missing assessment context: %v", r)
+		}
+		if tags, _ := r["tags"].(map[string]interface{}); tags["environment"] != "prod" {
+			t.Errorf("expected tags in record, got %v", r["tags"])
+		}
+		if r["timestamp"] != "2026-03-01T12:00:00Z" {
+			t.Errorf("expected run timestamp, got %v", r["timestamp"])
+		}
+	}
+	if records[1]["id"] != "security-privileged" || records[1]["status"] != "WARN" {
+		t.Errorf("expected finding fields inline, got %v", records[1])
+	}
+}
+
+func TestGenerateNDJSONNoFindings(t *testing.T) {
+	data, err := GenerateNDJSON(&assessmentv1alpha1.ClusterAssessment{})
+	if err != nil {
+		t.Fatalf("GenerateNDJSON failed: %v", err)
+	}
+	if len(data) != 0 {
+		t.Errorf("expected empty output, got %q", data)
+	}
+}
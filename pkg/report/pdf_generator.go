@@ -20,10 +20,13 @@ import (
 	"bytes"
 	"fmt"
 	"html"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/jung-kurt/gofpdf"
+	"k8s.io/apimachinery/pkg/labels"
 
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
 )
@@ -54,6 +57,15 @@ func GeneratePDF(assessment *assessmentv1alpha1.ClusterAssessment) ([]byte, erro
 	pdf.SetFont("Helvetica", "", 12)
 	pdf.SetTextColor(100, 100, 100)
 	pdf.CellFormat(0, 8, fmt.Sprintf("Generated: %s", time.Now().Format("January 2, 2006 at 15:04 MST")), "", 1, "C", false, 0, "")
+	if responsible := describeResponsible(assessment); responsible != "" {
+		pdf.CellFormat(0, 8, responsible, "", 1, "C", false, 0, "")
+	}
+	if scope := describeScope(assessment); scope != "" {
+		pdf.Ln(2)
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.SetTextColor(colorWarn[0], colorWarn[1], colorWarn[2])
+		pdf.MultiCell(0, 5, scope, "", "C", false)
+	}
 	pdf.Ln(10)
 
 	// Cluster Info Box
@@ -76,6 +88,20 @@ func GeneratePDF(assessment *assessmentv1alpha1.ClusterAssessment) ([]byte, erro
 	addSectionTitle(pdf, "Findings by Category")
 	addFindingsByCategory(pdf, assessment)
 
+	// Top Actions
+	if actions := TopRecommendations(assessment.Status.Findings, topActionsCount); len(actions) > 0 {
+		pdf.AddPage()
+		addSectionTitle(pdf, "Top Actions")
+		addTopActions(pdf, actions)
+	}
+
+	// Profile Comparison
+	if comparison := assessment.Status.ProfileComparison; comparison != nil {
+		pdf.AddPage()
+		addSectionTitle(pdf, "Profile Comparison")
+		addProfileComparison(pdf, comparison)
+	}
+
 	// Detailed Findings
 	pdf.AddPage()
 	addSectionTitle(pdf, "Detailed Findings")
@@ -90,6 +116,61 @@ func GeneratePDF(assessment *assessmentv1alpha1.ClusterAssessment) ([]byte, erro
 	return buf.Bytes(), nil
 }
 
+// describeResponsible returns the report header line naming the owner and
+// contact of the assessment, or an empty string if neither is set.
+func describeResponsible(assessment *assessmentv1alpha1.ClusterAssessment) string {
+	var parts []string
+	if owner := assessment.Spec.ReportMetadata.Owner; owner != "" {
+		parts = append(parts, "Requested by: "+owner)
+	}
+	if contact := assessment.Spec.ReportMetadata.Contact; contact != "" {
+		parts = append(parts, "Contact: "+contact)
+	}
+	return strings.Join(parts, " | ")
+}
+
+// describeScope returns the report header note stating that the report covers
+// only some validators or only labeled workloads, or an empty string for a
+// full assessment.
+func describeScope(assessment *assessmentv1alpha1.ClusterAssessment) string {
+	var notes []string
+	if note := describeCoverage(assessment); note != "" {
+		notes = append(notes, note)
+	}
+	if selector := assessment.Spec.ResourceLabelSelector; len(selector) > 0 {
+		notes = append(notes, fmt.Sprintf("Workload checks were limited to resources labeled %s.", labels.SelectorFromSet(selector)))
+	}
+	return strings.Join(notes, " ")
+}
+
+// describeCoverage describes which validators ran when some were omitted.
+func describeCoverage(assessment *assessmentv1alpha1.ClusterAssessment) string {
+	summary := assessment.Status.Summary
+	if len(summary.OmittedValidators) == 0 {
+		return ""
+	}
+
+	ran := make(map[string]bool)
+	for _, name := range summary.RanValidators {
+		ran[name] = true
+	}
+	var categories []string
+	for _, f := range assessment.Status.Findings {
+		if ran[f.Validator] && f.Category != "" && !slices.Contains(categories, f.Category) {
+			categories = append(categories, f.Category)
+		}
+	}
+	sort.Strings(categories)
+
+	total := len(summary.RanValidators) + len(summary.OmittedValidators)
+	scope := fmt.Sprintf("Scope of this report: partial assessment of %d of %d validators (%s)",
+		len(summary.RanValidators), total, strings.Join(summary.RanValidators, ", "))
+	if len(categories) > 0 {
+		scope += fmt.Sprintf(", covering %s", strings.Join(categories, ", "))
+	}
+	return scope + fmt.Sprintf(". Not assessed: %s.", strings.Join(summary.OmittedValidators, ", "))
+}
+
 func addSectionTitle(pdf *gofpdf.Fpdf, title string) {
 	pdf.SetFont("Helvetica", "B", 14)
 	pdf.SetTextColor(0, 51, 102)
@@ -172,6 +253,12 @@ func addSummarySection(pdf *gofpdf.Fpdf, assessment *assessmentv1alpha1.ClusterA
 	// Total checks
 	pdf.SetFont("Helvetica", "", 10)
 	pdf.CellFormat(0, 6, fmt.Sprintf("Total Checks: %d", summary.TotalChecks), "", 1, "L", false, 0, "")
+
+	// Fleet comparison
+	if summary.Score != nil && summary.FleetComparison != nil {
+		pdf.CellFormat(0, 6, fmt.Sprintf("Score %d, fleet average %d across %d other assessment(s)",
+			*summary.Score, summary.FleetComparison.AverageScore, summary.FleetComparison.AssessmentCount), "", 1, "L", false, 0, "")
+	}
 }
 
 func addScoreVisualization(pdf *gofpdf.Fpdf, score int) {
@@ -213,37 +300,144 @@ func addScoreVisualization(pdf *gofpdf.Fpdf, score int) {
 }
 
 func addFindingsByCategory(pdf *gofpdf.Fpdf, assessment *assessmentv1alpha1.ClusterAssessment) {
-	// Group findings by category
-	categories := make(map[string][]assessmentv1alpha1.Finding)
-	for _, f := range assessment.Status.Findings {
-		categories[f.Category] = append(categories[f.Category], f)
+	// Table header
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFillColor(240, 240, 240)
+	for i, header := range []string{"Category", "Pass", "Warn", "Fail", "Info", "Pass Rate"} {
+		width, align := 20.0, "C"
+		if i == 0 {
+			width, align = 60.0, "L"
+		}
+		pdf.CellFormat(width, 7, header, "1", 0, align, true, 0, "")
 	}
+	pdf.Ln(-1)
 
 	pdf.SetFont("Helvetica", "", 10)
-	pdf.SetTextColor(0, 0, 0)
+	for _, stat := range categoryStats(assessment) {
+		pdf.CellFormat(60, 6, stat.Category, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(20, 6, fmt.Sprintf("%d", stat.PassCount), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(20, 6, fmt.Sprintf("%d", stat.WarnCount), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(20, 6, fmt.Sprintf("%d", stat.FailCount), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(20, 6, fmt.Sprintf("%d", stat.InfoCount), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(20, 6, fmt.Sprintf("%d%%", stat.PassRate), "1", 1, "C", false, 0, "")
+	}
+}
 
-	for category, findings := range categories {
-		pass, warn, fail, info := 0, 0, 0, 0
-		for _, f := range findings {
-			switch f.Status {
-			case assessmentv1alpha1.FindingStatusPass:
-				pass++
-			case assessmentv1alpha1.FindingStatusWarn:
-				warn++
-			case assessmentv1alpha1.FindingStatusFail:
-				fail++
-			case assessmentv1alpha1.FindingStatusInfo:
-				info++
-			}
+func addTopActions(pdf *gofpdf.Fpdf, actions []Recommendation) {
+	for i, action := range actions {
+		color := colorWarn
+		if action.Status == assessmentv1alpha1.FindingStatusFail {
+			color = colorFail
 		}
-
 		pdf.SetFont("Helvetica", "B", 10)
-		pdf.CellFormat(50, 6, category+":", "", 0, "L", false, 0, "")
+		pdf.SetTextColor(color[0], color[1], color[2])
+		pdf.MultiCell(0, 6, fmt.Sprintf("%d. [%s] %s", i+1, action.Status, describeAction(action)), "", "L", false)
 		pdf.SetFont("Helvetica", "", 10)
+		pdf.SetTextColor(0, 0, 0)
+		pdf.MultiCell(0, 5, action.Text, "", "L", false)
+		pdf.Ln(3)
+	}
+}
 
-		statusStr := fmt.Sprintf("%d pass, %d warn, %d fail, %d info", pass, warn, fail, info)
-		pdf.CellFormat(0, 6, statusStr, "", 1, "L", false, 0, "")
+// describeAction names the findings behind a recommendation and how many
+// resources they affect.
+func describeAction(action Recommendation) string {
+	titles := strings.Join(action.Titles, ", ")
+	switch {
+	case action.Count > 1:
+		return fmt.Sprintf("%s (%d findings, %d resources)", titles, action.Count, action.Resources)
+	case action.Resources > 1:
+		return fmt.Sprintf("%s (%d resources)", titles, action.Resources)
 	}
+	return titles
+}
+
+// addProfileComparison renders the score of the run under each compared
+// profile and the findings whose status differs between them.
+func addProfileComparison(pdf *gofpdf.Fpdf, comparison *assessmentv1alpha1.ProfileComparison) {
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFillColor(240, 240, 240)
+	for i, header := range []string{"Profile", "Score", "Warn", "Fail"} {
+		width, align := 25.0, "C"
+		if i == 0 {
+			width, align = 60.0, "L"
+		}
+		pdf.CellFormat(width, 7, header, "1", 0, align, true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 10)
+	for _, result := range comparison.Profiles {
+		pdf.CellFormat(60, 6, result.Profile, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(25, 6, describeScore(result.Score), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(25, 6, fmt.Sprintf("%d", result.WarnCount), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(25, 6, fmt.Sprintf("%d", result.FailCount), "1", 1, "C", false, 0, "")
+	}
+	pdf.Ln(6)
+
+	if len(comparison.Differences) == 0 {
+		pdf.MultiCell(0, 5, "All findings have the same status under every profile.", "", "L", false)
+		return
+	}
+
+	statusWidth := 25.0
+	findingWidth := 180.0 - statusWidth*float64(len(comparison.Profiles))
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(findingWidth, 7, "Finding", "1", 0, "L", true, 0, "")
+	for _, result := range comparison.Profiles {
+		pdf.CellFormat(statusWidth, 7, result.Profile, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, d := range comparison.Differences {
+		label := describeDifference(d)
+		if maxLen := int(findingWidth / 2); len(label) > maxLen {
+			label = label[:maxLen-3] + "..."
+		}
+		pdf.CellFormat(findingWidth, 6, label, "1", 0, "L", false, 0, "")
+		for _, result := range comparison.Profiles {
+			pdf.CellFormat(statusWidth, 6, differenceStatus(d, result.Profile), "1", 0, "C", false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+	if comparison.OmittedDifferences > 0 {
+		pdf.Ln(2)
+		pdf.MultiCell(0, 5, fmt.Sprintf("... and %d more differences.", comparison.OmittedDifferences), "", "L", false)
+	}
+}
+
+// describeScore formats an optional score.
+func describeScore(score *int) string {
+	if score == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d", *score)
+}
+
+// describeDifference names the finding of a profile difference.
+func describeDifference(d assessmentv1alpha1.ProfileDifference) string {
+	resource := d.Resource
+	if d.Namespace != "" && resource != "" {
+		resource = d.Namespace + "/" + resource
+	} else if d.Namespace != "" {
+		resource = d.Namespace
+	}
+	if resource == "" {
+		return d.Title
+	}
+	return fmt.Sprintf("%s (%s)", d.Title, resource)
+}
+
+// differenceStatus returns the status of a profile difference under profile,
+// or "-" if the finding was not reported under it.
+func differenceStatus(d assessmentv1alpha1.ProfileDifference, profile string) string {
+	if status, ok := d.Statuses[profile]; ok {
+		return string(status)
+	}
+	return "-"
 }
 
 func addDetailedFindings(pdf *gofpdf.Fpdf, assessment *assessmentv1alpha1.ClusterAssessment) {
@@ -356,7 +550,11 @@ func addFindingCard(pdf *gofpdf.Fpdf, f assessmentv1alpha1.Finding) {
 	pdf.SetXY(28, startY+18)
 	pdf.SetFont("Helvetica", "", 7)
 	pdf.SetTextColor(120, 120, 120)
-	pdf.CellFormat(0, 4, fmt.Sprintf("Category: %s | Validator: %s", f.Category, f.Validator), "", 1, "L", false, 0, "")
+	meta := fmt.Sprintf("Category: %s | Validator: %s", f.Category, f.Validator)
+	if f.Confidence != "" && f.Confidence != assessmentv1alpha1.FindingConfidenceHigh {
+		meta += fmt.Sprintf(" | Confidence: %s", f.Confidence)
+	}
+	pdf.CellFormat(0, 4, meta, "", 1, "L", false, 0, "")
 
 	// Add recommendation if FAIL or WARN
 	if (f.Status == assessmentv1alpha1.FindingStatusFail || f.Status == assessmentv1alpha1.FindingStatusWarn) && f.Recommendation != "" {
@@ -414,6 +612,10 @@ func GenerateHTML(assessment *assessmentv1alpha1.ClusterAssessment) ([]byte, err
         .info-table { width: 100%; border-collapse: collapse; }
         .info-table td { padding: 8px; border-bottom: 1px solid #eee; }
         .info-table td:first-child { font-weight: bold; width: 200px; }
+        .category-table { width: 100%; border-collapse: collapse; }
+        .category-table th, .category-table td { padding: 6px 8px; border-bottom: 1px solid #eee; text-align: center; }
+        .category-table th:first-child, .category-table td:first-child { text-align: left; }
+        .top-actions li { margin-bottom: 10px; }
         .score-bar { background: #ddd; height: 30px; border-radius: 15px; overflow: hidden; margin: 10px 0; }
         .score-fill { height: 100%; display: flex; align-items: center; justify-content: center; color: white; font-weight: bold; }
     </style>
@@ -426,6 +628,14 @@ func GenerateHTML(assessment *assessmentv1alpha1.ClusterAssessment) ([]byte, err
 	buf.WriteString(fmt.Sprintf(`<h1>OpenShift Cluster Assessment Report</h1>
 <p style="color: #888;">Generated: %s</p>
 `, time.Now().Format("January 2, 2006 at 15:04 MST")))
+	if responsible := describeResponsible(assessment); responsible != "" {
+		buf.WriteString(fmt.Sprintf(`<p style="color: #888;">%s</p>
+`, html.EscapeString(responsible)))
+	}
+	if scope := describeScope(assessment); scope != "" {
+		buf.WriteString(fmt.Sprintf(`<p class="recommendation" style="font-style: normal; font-weight: bold;">%s</p>
+`, html.EscapeString(scope)))
+	}
 
 	// Cluster Info
 	info := assessment.Status.ClusterInfo
@@ -451,6 +661,10 @@ func GenerateHTML(assessment *assessmentv1alpha1.ClusterAssessment) ([]byte, err
 	buf.WriteString(fmt.Sprintf(`<div class="summary-box info"><div class="count">%d</div><div class="label">INFO</div></div>`, summary.InfoCount))
 	buf.WriteString(`</div>`)
 	buf.WriteString(fmt.Sprintf(`<p>Total Checks: %d</p>`, summary.TotalChecks))
+	if summary.Score != nil && summary.FleetComparison != nil {
+		buf.WriteString(fmt.Sprintf(`<p>Score %d, fleet average %d across %d other assessment(s)</p>`,
+			*summary.Score, summary.FleetComparison.AverageScore, summary.FleetComparison.AssessmentCount))
+	}
 
 	// Score bar
 	if summary.Score != nil {
@@ -463,6 +677,59 @@ func GenerateHTML(assessment *assessmentv1alpha1.ClusterAssessment) ([]byte, err
 		buf.WriteString(fmt.Sprintf(`<div class="score-bar"><div class="score-fill" style="width: %d%%; background: %s;">%d%%</div></div>`, *summary.Score, scoreColor, *summary.Score))
 	}
 
+	// Category breakdown
+	if breakdown := categoryStats(assessment); len(breakdown) > 0 {
+		buf.WriteString(`<h2>Results by Category</h2>
+<table class="category-table"><tr><th>Category</th><th>Pass</th><th>Warn</th><th>Fail</th><th>Info</th><th>Pass Rate</th></tr>`)
+		for _, stat := range breakdown {
+			buf.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td><td>%d%%</td></tr>`,
+				html.EscapeString(stat.Category), stat.PassCount, stat.WarnCount, stat.FailCount, stat.InfoCount, stat.PassRate))
+		}
+		buf.WriteString(`</table>`)
+	}
+
+	// Top Actions
+	if actions := TopRecommendations(assessment.Status.Findings, topActionsCount); len(actions) > 0 {
+		buf.WriteString(`<h2>Top Actions</h2>
+<ol class="top-actions">`)
+		for _, action := range actions {
+			buf.WriteString(fmt.Sprintf(`<li><div class="finding-title">[%s] %s</div><div class="finding-desc">%s</div></li>`,
+				action.Status, html.EscapeString(describeAction(action)), html.EscapeString(action.Text)))
+		}
+		buf.WriteString(`</ol>`)
+	}
+
+	// Profile Comparison
+	if comparison := assessment.Status.ProfileComparison; comparison != nil {
+		buf.WriteString(`<h2>Profile Comparison</h2>
+<table class="category-table"><tr><th>Profile</th><th>Score</th><th>Warn</th><th>Fail</th></tr>`)
+		for _, result := range comparison.Profiles {
+			buf.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%d</td><td>%d</td></tr>`,
+				html.EscapeString(result.Profile), describeScore(result.Score), result.WarnCount, result.FailCount))
+		}
+		buf.WriteString(`</table>`)
+		if len(comparison.Differences) == 0 {
+			buf.WriteString(`<p>All findings have the same status under every profile.</p>`)
+		} else {
+			buf.WriteString(`<table class="category-table" style="margin-top: 15px;"><tr><th>Finding</th>`)
+			for _, result := range comparison.Profiles {
+				buf.WriteString(fmt.Sprintf(`<th>%s</th>`, html.EscapeString(result.Profile)))
+			}
+			buf.WriteString(`</tr>`)
+			for _, d := range comparison.Differences {
+				buf.WriteString(fmt.Sprintf(`<tr><td>%s</td>`, html.EscapeString(describeDifference(d))))
+				for _, result := range comparison.Profiles {
+					buf.WriteString(fmt.Sprintf(`<td>%s</td>`, html.EscapeString(differenceStatus(d, result.Profile))))
+				}
+				buf.WriteString(`</tr>`)
+			}
+			buf.WriteString(`</table>`)
+			if comparison.OmittedDifferences > 0 {
+				buf.WriteString(fmt.Sprintf(`<p>... and %d more differences.</p>`, comparison.OmittedDifferences))
+			}
+		}
+	}
+
 	// Detailed Findings
 	buf.WriteString(`<h2>Detailed Findings</h2>`)
 
@@ -484,7 +751,14 @@ func GenerateHTML(assessment *assessmentv1alpha1.ClusterAssessment) ([]byte, err
 			buf.WriteString(fmt.Sprintf(`<div class="finding status-%s">`, f.Status))
 			buf.WriteString(fmt.Sprintf(`<div class="finding-title">[%s] %s</div>`, f.Status, html.EscapeString(f.Title)))
 			buf.WriteString(fmt.Sprintf(`<div class="finding-desc">%s</div>`, html.EscapeString(f.Description)))
-			buf.WriteString(fmt.Sprintf(`<div class="finding-meta">Category: %s | Validator: %s</div>`, html.EscapeString(f.Category), html.EscapeString(f.Validator)))
+			buf.WriteString(fmt.Sprintf(`<div class="finding-meta">Category: %s | Validator: %s`, html.EscapeString(f.Category), html.EscapeString(f.Validator)))
+			if f.EscalatedFrom != "" {
+				buf.WriteString(fmt.Sprintf(` | Escalated from %s after persisting across runs`, html.EscapeString(string(f.EscalatedFrom))))
+			}
+			if f.Confidence != "" && f.Confidence != assessmentv1alpha1.FindingConfidenceHigh {
+				buf.WriteString(fmt.Sprintf(` | Confidence: %s`, html.EscapeString(string(f.Confidence))))
+			}
+			buf.WriteString(`</div>`)
 			if f.Recommendation != "" && (f.Status == assessmentv1alpha1.FindingStatusFail || f.Status == assessmentv1alpha1.FindingStatusWarn) {
 				buf.WriteString(fmt.Sprintf(`<div class="recommendation">💡 %s</div>`, html.EscapeString(f.Recommendation)))
 			}
@@ -505,6 +779,9 @@ func GenerateHTML(assessment *assessmentv1alpha1.ClusterAssessment) ([]byte, err
 				}
 				buf.WriteString(`</div>`)
 			}
+			if f.Evidence != "" {
+				buf.WriteString(fmt.Sprintf(`<details class="finding-meta" style="margin-top: 5px;"><summary>Evidence</summary><pre>%s</pre></details>`, html.EscapeString(f.Evidence)))
+			}
 			buf.WriteString(`</div>`)
 		}
 	}
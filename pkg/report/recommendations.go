@@ -0,0 +1,115 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+// topActionsCount is the number of recommendations listed in the Top Actions
+// section of the HTML and PDF reports.
+const topActionsCount = 10
+
+// Recommendation is a distinct recommendation made by FAIL or WARN findings.
+type Recommendation struct {
+	// Text is the recommendation as written by the findings.
+	Text string
+	// Status is the most severe status of the findings making it.
+	Status assessmentv1alpha1.FindingStatus
+	// Count is the number of findings making it.
+	Count int
+	// Resources is the number of resources affected by those findings; see
+	// affectedResources.
+	Resources int
+	// Titles are the distinct titles of those findings, in report order.
+	Titles []string
+}
+
+// TopRecommendations returns at most n distinct recommendations from the FAIL
+// and WARN findings, most important first: recommendations made by a FAIL
+// finding come before the others, then those affecting more resources, then
+// those made by more findings. Ties keep the order of the findings.
+// Recommendations are compared ignoring surrounding whitespace.
+func TopRecommendations(findings []assessmentv1alpha1.Finding, n int) []Recommendation {
+	var recommendations []Recommendation
+	index := make(map[string]int)
+	for _, f := range findings {
+		if f.Status != assessmentv1alpha1.FindingStatusFail && f.Status != assessmentv1alpha1.FindingStatusWarn {
+			continue
+		}
+		text := strings.TrimSpace(f.Recommendation)
+		if text == "" {
+			continue
+		}
+		i, ok := index[text]
+		if !ok {
+			i = len(recommendations)
+			index[text] = i
+			recommendations = append(recommendations, Recommendation{Text: text, Status: f.Status})
+		}
+		r := &recommendations[i]
+		r.Count++
+		r.Resources += affectedResources(f)
+		if f.Status == assessmentv1alpha1.FindingStatusFail {
+			r.Status = f.Status
+		}
+		if f.Title != "" && !slices.Contains(r.Titles, f.Title) {
+			r.Titles = append(r.Titles, f.Title)
+		}
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if a.Status != b.Status {
+			return a.Status == assessmentv1alpha1.FindingStatusFail
+		}
+		if a.Resources != b.Resources {
+			return a.Resources > b.Resources
+		}
+		return a.Count > b.Count
+	})
+	if len(recommendations) > n {
+		recommendations = recommendations[:n]
+	}
+	return recommendations
+}
+
+// affectedResources returns the number of resources a finding is about. A
+// finding about a single resource names it; aggregate findings state the
+// number of resources at the start of their description, as in "Found 12
+// pod(s) without ..." or "3 node(s) are ...". Findings stating no number
+// count as one.
+func affectedResources(f assessmentv1alpha1.Finding) int {
+	if f.Resource != "" {
+		return 1
+	}
+	description := strings.TrimPrefix(f.Description, "Found ")
+	end := strings.IndexFunc(description, func(r rune) bool { return r < '0' || r > '9' })
+	if end <= 0 {
+		return 1
+	}
+	count, err := strconv.Atoi(description[:end])
+	if err != nil || count < 1 {
+		return 1
+	}
+	return count
+}
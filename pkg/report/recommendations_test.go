@@ -0,0 +1,94 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+func TestTopRecommendations(t *testing.T) {
+	const (
+		addNodes   = "Add worker nodes."
+		fixQuota   = "Define a ResourceQuota."
+		renewCerts = "Renew the certificates."
+	)
+	findings := []assessmentv1alpha1.Finding{
+		{ID: "a", Status: assessmentv1alpha1.FindingStatusWarn, Title: "Missing quota", Resource: "team-a", Recommendation: fixQuota},
+		{ID: "b", Status: assessmentv1alpha1.FindingStatusWarn, Title: "Missing quota", Resource: "team-b", Recommendation: fixQuota + " "},
+		{ID: "c", Status: assessmentv1alpha1.FindingStatusWarn, Title: "Quota exceeded", Description: "Quota exceeded in team-c", Recommendation: fixQuota},
+		// One finding about more resources ranks above several about fewer
+		{ID: "d", Status: assessmentv1alpha1.FindingStatusWarn, Title: "Few workers", Description: "Found 12 node(s) under pressure", Recommendation: addNodes},
+		{ID: "e", Status: assessmentv1alpha1.FindingStatusFail, Title: "Expiring CA", Recommendation: renewCerts},
+		{ID: "f", Status: assessmentv1alpha1.FindingStatusInfo, Title: "Informational", Recommendation: "Consider it."},
+		{ID: "g", Status: assessmentv1alpha1.FindingStatusFail, Title: "No recommendation"},
+	}
+
+	got := TopRecommendations(findings, 10)
+	want := []Recommendation{
+		{Text: renewCerts, Status: assessmentv1alpha1.FindingStatusFail, Count: 1, Resources: 1, Titles: []string{"Expiring CA"}},
+		{Text: addNodes, Status: assessmentv1alpha1.FindingStatusWarn, Count: 1, Resources: 12, Titles: []string{"Few workers"}},
+		{Text: fixQuota, Status: assessmentv1alpha1.FindingStatusWarn, Count: 3, Resources: 3, Titles: []string{"Missing quota", "Quota exceeded"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TopRecommendations() = %+v, want %+v", got, want)
+	}
+
+	if got := TopRecommendations(findings, 2); len(got) != 2 || got[1].Text != addNodes {
+		t.Errorf("expected the top 2 recommendations, got %+v", got)
+	}
+
+	// The reports list the actions in the same order.
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		Status: assessmentv1alpha1.ClusterAssessmentStatus{Findings: findings},
+	}
+	out, err := GenerateHTML(assessment)
+	if err != nil {
+		t.Fatalf("GenerateHTML() returned error: %v", err)
+	}
+	report := string(out)
+	section := report[strings.Index(report, "<h2>Top Actions</h2>"):strings.Index(report, "<h2>Detailed Findings</h2>")]
+	if !strings.Contains(section, "[WARN] Missing quota, Quota exceeded (3 findings, 3 resources)") ||
+		!strings.Contains(section, "[WARN] Few workers (12 resources)") ||
+		strings.Index(section, renewCerts) > strings.Index(section, fixQuota) {
+		t.Errorf("unexpected Top Actions section: %s", section)
+	}
+	if _, err := GeneratePDF(assessment); err != nil {
+		t.Fatalf("GeneratePDF() returned error: %v", err)
+	}
+}
+
+func TestAffectedResources(t *testing.T) {
+	tests := []struct {
+		finding assessmentv1alpha1.Finding
+		want    int
+	}{
+		{assessmentv1alpha1.Finding{Resource: "web", Description: "Found 4 issue(s)"}, 1},
+		{assessmentv1alpha1.Finding{Description: "Found 12 pod(s) without limits"}, 12},
+		{assessmentv1alpha1.Finding{Description: "3 node(s) are not ready"}, 3},
+		{assessmentv1alpha1.Finding{Description: "Cluster version is out of date"}, 1},
+		{assessmentv1alpha1.Finding{Description: "Found 0 pod(s)"}, 1},
+	}
+	for _, tt := range tests {
+		if got := affectedResources(tt.finding); got != tt.want {
+			t.Errorf("affectedResources(%q) = %d, want %d", tt.finding.Description, got, tt.want)
+		}
+	}
+}
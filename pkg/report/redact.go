@@ -0,0 +1,203 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator"
+)
+
+// qualifiedNamePattern matches "namespace/name" references in finding text.
+var qualifiedNamePattern = regexp.MustCompile(`([a-z0-9](?:[a-z0-9.-]*[a-z0-9])?)/([a-z0-9](?:[a-z0-9.-]*[a-z0-9])?)`)
+
+// namePattern matches a single DNS-style name in finding text. Only matches
+// that are whole words are considered; see replaceNames.
+var namePattern = regexp.MustCompile(`[a-z0-9](?:[a-z0-9.-]*[a-z0-9])?`)
+
+// redactedPattern matches names produced by Redactor.hash. Findings carried
+// forward by incremental scans are already redacted and must not be hashed
+// again.
+var redactedPattern = regexp.MustCompile(`^(ns|res)-[0-9a-f]{8}$`)
+
+// Redactor replaces namespace and resource names in findings with stable,
+// salted hashes so reports can be shared without exposing internal naming.
+// The same name always maps to the same hash for a given salt, so findings
+// can still be correlated across runs.
+type Redactor struct {
+	salt       string
+	namespaces bool
+	resources  bool
+	known      map[string]bool
+}
+
+// NewRedactor returns a Redactor for the given namespaces. Platform
+// namespaces (default, openshift, openshift-*, kube-*) are not sensitive and
+// are left as is.
+func NewRedactor(salt string, redactNamespaces, redactResourceNames bool, namespaces []string) *Redactor {
+	known := make(map[string]bool, len(namespaces))
+	for _, ns := range namespaces {
+		if !isPlatformNamespace(ns) && !redactedPattern.MatchString(ns) {
+			known[ns] = true
+		}
+	}
+	return &Redactor{salt: salt, namespaces: redactNamespaces, resources: redactResourceNames, known: known}
+}
+
+// Redact returns a copy of findings with names redacted from the Namespace
+// and Resource fields and from names in the title, description, impact,
+// recommendation and remediation command. In free text, resource names are
+// only recognized in "namespace/name" form. Evidence is dropped, as the names
+// in it cannot be redacted reliably. IDs that embed the finding's namespace
+// or resource name have them redacted as well. References are left unchanged,
+// and already redacted names are kept, so Redact is idempotent.
+func (r *Redactor) Redact(findings []assessmentv1alpha1.Finding) []assessmentv1alpha1.Finding {
+	if !r.namespaces && !r.resources {
+		return findings
+	}
+
+	redacted := make([]assessmentv1alpha1.Finding, len(findings))
+	for i, f := range findings {
+		f.ID = r.redactID(f.ID, f.Namespace, f.Resource)
+		if r.resources && f.Resource != "" && !redactedPattern.MatchString(f.Resource) {
+			f.Resource = r.hash("res", f.Resource)
+		}
+		if r.namespaces && f.Namespace != "" && !isPlatformNamespace(f.Namespace) && !redactedPattern.MatchString(f.Namespace) {
+			f.Namespace = r.hash("ns", f.Namespace)
+		}
+		f.Title = r.redactText(f.Title)
+		f.Description = r.redactText(f.Description)
+		f.Impact = r.redactText(f.Impact)
+		f.Recommendation = r.redactText(f.Recommendation)
+		f.RemediationCommand = r.redactText(f.RemediationCommand)
+		f.Evidence = ""
+		redacted[i] = f
+	}
+	return redacted
+}
+
+// redactText redacts known namespaces and the names qualified by them.
+func (r *Redactor) redactText(text string) string {
+	if text == "" || len(r.known) == 0 {
+		return text
+	}
+
+	text = replaceNames(qualifiedNamePattern, text, func(match string) string {
+		ns, name, _ := strings.Cut(match, "/")
+		if !r.known[ns] {
+			return match
+		}
+		if r.namespaces {
+			ns = r.hash("ns", ns)
+		}
+		if r.resources && !redactedPattern.MatchString(name) {
+			name = r.hash("res", name)
+		}
+		return ns + "/" + name
+	})
+
+	if r.namespaces {
+		text = replaceNames(namePattern, text, func(match string) string {
+			if r.known[match] {
+				return r.hash("ns", match)
+			}
+			return match
+		})
+	}
+	return text
+}
+
+// redactID redacts the namespace and resource name that some validators append
+// to finding IDs, such as etcdbackup-cronjob-<namespace>-<name>, optionally
+// followed by an index. Only the names recorded in the Namespace and Resource
+// fields of the finding are recognized.
+func (r *Redactor) redactID(id, namespace, resource string) string {
+	redactNamespace := r.namespaces && namespace != "" && !isPlatformNamespace(namespace) && !redactedPattern.MatchString(namespace)
+	redactResource := r.resources && resource != "" && !redactedPattern.MatchString(resource)
+	if !redactNamespace && !redactResource {
+		return id
+	}
+
+	base, index := id, ""
+	if i := strings.LastIndex(id, "-"); i >= 0 && isIndex(id[i+1:]) {
+		base, index = id[:i], id[i:]
+	}
+
+	switch {
+	case redactNamespace && redactResource && strings.HasSuffix(base, "-"+namespace+"-"+resource):
+		base = strings.TrimSuffix(base, namespace+"-"+resource) + r.hash("ns", namespace) + "-" + r.hash("res", resource)
+	case redactResource && strings.HasSuffix(base, "-"+resource):
+		base = strings.TrimSuffix(base, resource) + r.hash("res", resource)
+	case redactNamespace && strings.HasSuffix(base, "-"+namespace):
+		base = strings.TrimSuffix(base, namespace) + r.hash("ns", namespace)
+	}
+	return base + index
+}
+
+// isIndex reports whether s is a non-empty string of digits.
+func isIndex(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// replaceNames replaces the matches of pattern in text with the result of
+// replace. Matches that are part of a longer word, i.e. preceded or followed
+// by a letter, digit, underscore or dash, are left unchanged.
+func replaceNames(pattern *regexp.Regexp, text string, replace func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range pattern.FindAllStringIndex(text, -1) {
+		start, end := loc[0], loc[1]
+		if start > 0 && isWordByte(text[start-1]) || end < len(text) && isWordByte(text[end]) {
+			continue
+		}
+		b.WriteString(text[last:start])
+		b.WriteString(replace(text[start:end]))
+		last = end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// isWordByte reports whether c can be part of a name or of a word around it.
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+// hash returns a short, salted digest of name prefixed with kind.
+func (r *Redactor) hash(kind, name string) string {
+	sum := sha256.Sum256([]byte(r.salt + "/" + kind + "/" + name))
+	return kind + "-" + hex.EncodeToString(sum[:])[:8]
+}
+
+// isPlatformNamespace reports whether ns is created by the platform. Unlike
+// validator.IsPlatformNamespace, it includes default: validators check the
+// workloads users deploy there, but its name reveals nothing to redact.
+func isPlatformNamespace(ns string) bool {
+	return ns == "default" || validator.IsPlatformNamespace(ns)
+}
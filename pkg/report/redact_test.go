@@ -0,0 +1,167 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+func TestRedact(t *testing.T) {
+	findings := []assessmentv1alpha1.Finding{{
+		ID:          "workloadhealth-missing-config-refs",
+		Resource:    "payments-api",
+		Namespace:   "team-payments",
+		Title:       "Missing references in team-payments",
+		Description: "Deployment team-payments/payments-api references a missing Secret.",
+		Evidence:    "metadata:\n  name: payments-api\n  namespace: team-payments\n",
+	}, {
+		ID:          "networking-router",
+		Resource:    "router-default",
+		Namespace:   "openshift-ingress",
+		Description: "Deployment openshift-ingress/router-default is healthy.",
+	}}
+	namespaces := []string{"team-payments", "openshift-ingress"}
+
+	redacted := NewRedactor("uid-1", true, true, namespaces).Redact(findings)
+
+	f := redacted[0]
+	if f.ID != findings[0].ID {
+		t.Errorf("ID changed to %q", f.ID)
+	}
+	if !strings.HasPrefix(f.Namespace, "ns-") || !strings.HasPrefix(f.Resource, "res-") {
+		t.Errorf("expected hashed namespace and resource, got %q and %q", f.Namespace, f.Resource)
+	}
+	for _, text := range []string{f.Title, f.Description} {
+		if strings.Contains(text, "team-payments") || strings.Contains(text, "payments-api") {
+			t.Errorf("name not redacted from %q", text)
+		}
+	}
+	if want := "Deployment " + f.Namespace + "/"; !strings.HasPrefix(f.Description, want) {
+		t.Errorf("description %q does not start with %q", f.Description, want)
+	}
+	if f.Evidence != "" {
+		t.Errorf("evidence not dropped: %q", f.Evidence)
+	}
+	if findings[0].Namespace != "team-payments" {
+		t.Error("input findings were modified")
+	}
+
+	// Platform namespaces are kept. The Resource field is still hashed, but
+	// free text only redacts names qualified by a known namespace, so names
+	// in platform namespaces are left in the description.
+	if redacted[1].Namespace != "openshift-ingress" {
+		t.Errorf("platform namespace redacted to %q", redacted[1].Namespace)
+	}
+	if !strings.HasPrefix(redacted[1].Resource, "res-") {
+		t.Errorf("resource in platform namespace not hashed: %q", redacted[1].Resource)
+	}
+	if redacted[1].Description != findings[1].Description {
+		t.Errorf("platform namespace description changed to %q", redacted[1].Description)
+	}
+
+	// Hashes are stable for a salt and differ across salts
+	again := NewRedactor("uid-1", true, true, namespaces).Redact(findings)
+	if again[0].Namespace != f.Namespace {
+		t.Errorf("hash not stable: %q != %q", again[0].Namespace, f.Namespace)
+	}
+	twice := NewRedactor("uid-1", true, true, append(namespaces, f.Namespace)).Redact(redacted)
+	if !reflect.DeepEqual(twice[0], f) {
+		t.Errorf("redacting again changed finding:\n%+v\n%+v", twice[0], f)
+	}
+	other := NewRedactor("uid-2", true, true, namespaces).Redact(findings)
+	if other[0].Namespace == f.Namespace {
+		t.Error("hash did not change with salt")
+	}
+}
+
+func TestRedactNamespacesOnly(t *testing.T) {
+	findings := []assessmentv1alpha1.Finding{{
+		Resource:    "payments-api",
+		Namespace:   "team-payments",
+		Description: "Deployment team-payments/payments-api is not spread.",
+	}}
+
+	f := NewRedactor("uid-1", true, false, []string{"team-payments"}).Redact(findings)[0]
+
+	if f.Resource != "payments-api" {
+		t.Errorf("resource redacted to %q", f.Resource)
+	}
+	if !strings.HasPrefix(f.Namespace, "ns-") {
+		t.Errorf("namespace not redacted: %q", f.Namespace)
+	}
+	if want := "Deployment " + f.Namespace + "/payments-api is not spread."; f.Description != want {
+		t.Errorf("description = %q, want %q", f.Description, want)
+	}
+}
+
+func TestRedactIDs(t *testing.T) {
+	findings := []assessmentv1alpha1.Finding{{
+		ID:        "etcdbackup-cronjob-team-payments-backup",
+		Namespace: "team-payments",
+		Resource:  "backup",
+	}, {
+		ID:        "certificates-expired-payments-tls",
+		Namespace: "team-payments",
+		Resource:  "payments-tls",
+	}, {
+		ID:        "etcdbackup-destination-oadp-velero-0",
+		Namespace: "openshift-adp",
+		Resource:  "velero",
+	}, {
+		ID:       "security-backup-policy",
+		Resource: "backup",
+	}}
+	r := NewRedactor("uid-1", true, true, []string{"team-payments"})
+
+	redacted := r.Redact(findings)
+
+	want := "etcdbackup-cronjob-" + r.hash("ns", "team-payments") + "-" + r.hash("res", "backup")
+	if redacted[0].ID != want {
+		t.Errorf("ID = %q, want %q", redacted[0].ID, want)
+	}
+	if want := "certificates-expired-" + r.hash("res", "payments-tls"); redacted[1].ID != want {
+		t.Errorf("ID = %q, want %q", redacted[1].ID, want)
+	}
+	if want := "etcdbackup-destination-oadp-" + r.hash("res", "velero") + "-0"; redacted[2].ID != want {
+		t.Errorf("ID = %q, want %q", redacted[2].ID, want)
+	}
+	// Names are only recognized at the end of the ID
+	if redacted[3].ID != "security-backup-policy" {
+		t.Errorf("ID without the resource name changed to %q", redacted[3].ID)
+	}
+	if again := r.Redact(redacted); !reflect.DeepEqual(again, redacted) {
+		t.Errorf("redacting IDs again changed them: %+v", again)
+	}
+}
+
+func TestRedactWholeWordsOnly(t *testing.T) {
+	findings := []assessmentv1alpha1.Finding{{
+		Description: "Namespace team and Team-x and team_b and my-team/api, but not teams or team_ or xteam.",
+	}}
+
+	f := NewRedactor("uid-1", true, false, []string{"team"}).Redact(findings)[0]
+
+	hashed := NewRedactor("uid-1", true, false, nil).hash("ns", "team")
+	want := "Namespace " + hashed + " and Team-x and team_b and my-team/api, but not teams or team_ or xteam."
+	if f.Description != want {
+		t.Errorf("description = %q, want %q", f.Description, want)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+// RemediationItem is one entry of a remediation plan. It describes what to
+// fix for a FAIL or WARN finding without running anything.
+type RemediationItem struct {
+	// FindingID is the ID of the finding to remediate
+	FindingID string `json:"findingID" yaml:"findingID"`
+
+	// Title is the title of the finding
+	Title string `json:"title" yaml:"title"`
+
+	// Severity is the finding status, FAIL or WARN
+	Severity assessmentv1alpha1.FindingStatus `json:"severity" yaml:"severity"`
+
+	// Validator is the validator that produced the finding
+	Validator string `json:"validator" yaml:"validator"`
+
+	// Namespace and Resource identify the affected resource, if known
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Resource  string `json:"resource,omitempty" yaml:"resource,omitempty"`
+
+	// Recommendation describes the fix
+	Recommendation string `json:"recommendation,omitempty" yaml:"recommendation,omitempty"`
+
+	// Command is a suggested oc command that applies the fix, if available
+	Command string `json:"command,omitempty" yaml:"command,omitempty"`
+
+	// Documentation is a link to the relevant documentation, if available
+	Documentation string `json:"documentation,omitempty" yaml:"documentation,omitempty"`
+}
+
+// GenerateRemediationPlan generates a YAML list with one RemediationItem per
+// FAIL or WARN finding, FAIL findings first. It is meant to be consumed by
+// ticketing systems or GitOps tooling; commands are suggestions and are
+// never run by the operator.
+func GenerateRemediationPlan(assessment *assessmentv1alpha1.ClusterAssessment) ([]byte, error) {
+	return yaml.Marshal(buildRemediationPlan(assessment.Status.Findings))
+}
+
+// buildRemediationPlan constructs the remediation items from findings.
+func buildRemediationPlan(findings []assessmentv1alpha1.Finding) []RemediationItem {
+	items := []RemediationItem{}
+	for _, f := range findings {
+		if f.Status != assessmentv1alpha1.FindingStatusFail && f.Status != assessmentv1alpha1.FindingStatusWarn {
+			continue
+		}
+		item := RemediationItem{
+			FindingID:      f.ID,
+			Title:          f.Title,
+			Severity:       f.Status,
+			Validator:      f.Validator,
+			Namespace:      f.Namespace,
+			Resource:       f.Resource,
+			Recommendation: f.Recommendation,
+			Command:        f.RemediationCommand,
+		}
+		if len(f.References) > 0 {
+			item.Documentation = f.References[0]
+		}
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Severity == assessmentv1alpha1.FindingStatusFail && items[j].Severity != assessmentv1alpha1.FindingStatusFail
+	})
+	return items
+}
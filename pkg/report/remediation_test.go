@@ -0,0 +1,66 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+func TestGenerateRemediationPlan(t *testing.T) {
+	assessment := &assessmentv1alpha1.ClusterAssessment{}
+	assessment.Status.Findings = []assessmentv1alpha1.Finding{
+		{ID: "nodes-ok", Status: assessmentv1alpha1.FindingStatusPass, Title: "Fine"},
+		{ID: "mcp-paused", Validator: "machineconfig", Status: assessmentv1alpha1.FindingStatusWarn, Title: "Paused",
+			Recommendation: "Unpause the pool.", RemediationCommand: "oc patch mcp worker --type merge -p '{\"spec\":{\"paused\":false}}'"},
+		{ID: "kubeadmin-exists", Validator: "compliance", Status: assessmentv1alpha1.FindingStatusFail, Title: "Kubeadmin",
+			References: []string{"https://docs.example.com/kubeadmin", "https://docs.example.com/other"}},
+		{ID: "pruner-suspended", Status: assessmentv1alpha1.FindingStatusInfo, RemediationCommand: "oc patch imagepruner/cluster"},
+	}
+
+	data, err := GenerateRemediationPlan(assessment)
+	if err != nil {
+		t.Fatalf("GenerateRemediationPlan failed: %v", err)
+	}
+
+	var items []RemediationItem
+	if err := yaml.Unmarshal(data, &items); err != nil {
+		t.Fatalf("invalid YAML: %v\n%s", err, data)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %+v", items)
+	}
+	if items[0].FindingID != "kubeadmin-exists" || items[0].Documentation != "https://docs.example.com/kubeadmin" {
+		t.Errorf("expected the FAIL finding first with its first reference, got %+v", items[0])
+	}
+	if items[1].FindingID != "mcp-paused" || items[1].Severity != assessmentv1alpha1.FindingStatusWarn || items[1].Command == "" {
+		t.Errorf("unexpected WARN item: %+v", items[1])
+	}
+}
+
+func TestGenerateRemediationPlan_Empty(t *testing.T) {
+	data, err := GenerateRemediationPlan(&assessmentv1alpha1.ClusterAssessment{})
+	if err != nil {
+		t.Fatalf("GenerateRemediationPlan failed: %v", err)
+	}
+	if string(data) != "[]\n" {
+		t.Errorf("expected an empty list, got %q", data)
+	}
+}
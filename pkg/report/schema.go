@@ -0,0 +1,151 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+// schemaDraft is the JSON Schema dialect of the generated schema.
+const schemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document or subschema.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// schemaEnums lists the allowed values of string types that Go reflection
+// cannot discover on its own.
+var schemaEnums = map[reflect.Type][]string{
+	reflect.TypeOf(assessmentv1alpha1.FindingStatus("")): {
+		string(assessmentv1alpha1.FindingStatusPass),
+		string(assessmentv1alpha1.FindingStatusWarn),
+		string(assessmentv1alpha1.FindingStatusFail),
+		string(assessmentv1alpha1.FindingStatusInfo),
+	},
+}
+
+// GenerateSchemaJSON returns the JSON Schema of the JSON report. The Report
+// envelope is the root and every nested struct, including Finding, is
+// available under $defs so integrators can generate typed clients.
+func GenerateSchemaJSON() ([]byte, error) {
+	return json.MarshalIndent(BuildSchema(), "", "  ")
+}
+
+// BuildSchema builds the JSON Schema of the Report envelope by reflection.
+func BuildSchema() *Schema {
+	b := &schemaBuilder{defs: make(map[string]*Schema)}
+	root := b.schemaFor(reflect.TypeOf(Report{}))
+	// Finding is always referenced by Report, but include it explicitly so the
+	// definition stays available if the envelope changes.
+	b.schemaFor(reflect.TypeOf(assessmentv1alpha1.Finding{}))
+
+	root.Schema = schemaDraft
+	root.Title = "ClusterAssessmentReport"
+	root.Defs = b.defs
+	return root
+}
+
+// schemaBuilder accumulates named struct definitions while walking types.
+type schemaBuilder struct {
+	defs map[string]*Schema
+}
+
+// schemaFor returns the schema of t, registering named structs under $defs.
+func (b *schemaBuilder) schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == reflect.TypeOf(time.Time{}) || t == reflect.TypeOf(metav1.Time{}) {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	if enum, ok := schemaEnums[t]; ok {
+		return &Schema{Type: "string", Enum: enum}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: b.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		if _, ok := b.defs[t.Name()]; !ok {
+			// Register before walking the fields so recursive types terminate.
+			b.defs[t.Name()] = &Schema{}
+			*b.defs[t.Name()] = *b.structSchema(t)
+		}
+		return &Schema{Ref: "#/$defs/" + t.Name()}
+	default:
+		return &Schema{}
+	}
+}
+
+// structSchema builds an object schema from the exported, JSON-visible fields
+// of t. Fields without omitempty are required.
+func (b *schemaBuilder) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		s.Properties[name] = b.schemaFor(field.Type)
+		if !strings.Contains(opts, "omitempty") && field.Type.Kind() != reflect.Pointer {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestBuildSchema(t *testing.T) {
+	schema := BuildSchema()
+
+	if schema.Ref != "#/$defs/Report" {
+		t.Errorf("expected root to reference Report, got %q", schema.Ref)
+	}
+
+	finding, ok := schema.Defs["Finding"]
+	if !ok {
+		t.Fatal("expected Finding definition")
+	}
+	for _, name := range []string{"id", "validator", "category", "status", "title", "description"} {
+		if !slices.Contains(finding.Required, name) {
+			t.Errorf("expected %q to be required", name)
+		}
+	}
+	if slices.Contains(finding.Required, "recommendation") {
+		t.Error("expected omitempty field recommendation to be optional")
+	}
+	if got := finding.Properties["status"].Enum; !slices.Equal(got, []string{"PASS", "WARN", "FAIL", "INFO"}) {
+		t.Errorf("unexpected status enum %v", got)
+	}
+	if refs := finding.Properties["references"]; refs.Type != "array" || refs.Items.Type != "string" {
+		t.Errorf("unexpected references schema %+v", refs)
+	}
+
+	metadata := schema.Defs["ReportMetadata"]
+	if got := metadata.Properties["generatedAt"]; got.Type != "string" || got.Format != "date-time" {
+		t.Errorf("expected generatedAt to be a date-time string, got %+v", got)
+	}
+
+	byCategory := schema.Defs["Report"].Properties["findingsByCategory"]
+	if byCategory.Type != "object" || byCategory.AdditionalProperties.Items.Ref != "#/$defs/Finding" {
+		t.Errorf("unexpected findingsByCategory schema %+v", byCategory)
+	}
+}
+
+func TestGenerateSchemaJSON(t *testing.T) {
+	data, err := GenerateSchemaJSON()
+	if err != nil {
+		t.Fatalf("GenerateSchemaJSON failed: %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+	if doc["$schema"] != schemaDraft {
+		t.Errorf("unexpected $schema %v", doc["$schema"])
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+// StatusBoardEntry is the latest completed result of one ClusterAssessment on
+// the cluster-wide status board. The board stores one entry per assessment name.
+type StatusBoardEntry struct {
+	// Profile is the baseline profile that was used
+	Profile string `json:"profile,omitempty"`
+
+	// Score is the overall score (0-100), if calculated
+	Score *int `json:"score,omitempty"`
+
+	// Grade is the letter grade derived from Score
+	Grade string `json:"grade,omitempty"`
+
+	// Counts per finding status
+	PassCount int `json:"passCount"`
+	WarnCount int `json:"warnCount"`
+	FailCount int `json:"failCount"`
+	InfoCount int `json:"infoCount"`
+
+	// CompletedAt is when the assessment run completed
+	CompletedAt time.Time `json:"completedAt"`
+}
+
+// NewStatusBoardEntry builds the status board entry of a completed assessment.
+func NewStatusBoardEntry(assessment *assessmentv1alpha1.ClusterAssessment, completedAt time.Time) StatusBoardEntry {
+	s := assessment.Status.Summary
+	entry := StatusBoardEntry{
+		Profile:     s.ProfileUsed,
+		Score:       s.Score,
+		PassCount:   s.PassCount,
+		WarnCount:   s.WarnCount,
+		FailCount:   s.FailCount,
+		InfoCount:   s.InfoCount,
+		CompletedAt: completedAt.UTC(),
+	}
+	if s.Score != nil {
+		entry.Grade = Grade(*s.Score)
+	}
+	return entry
+}
+
+// UpsertStatusBoard stores entry under name in the status board data and then
+// evicts the entries that completed longest ago until at most limit remain.
+// Entries that cannot be decoded are evicted first. A limit of zero or less
+// disables the cap.
+func UpsertStatusBoard(data map[string]string, name string, entry StatusBoardEntry, limit int) error {
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode status board entry: %w", err)
+	}
+	data[name] = string(encoded)
+
+	for limit > 0 && len(data) > limit {
+		oldest := ""
+		var oldestAt time.Time
+		for key, value := range data {
+			var e StatusBoardEntry
+			if err := json.Unmarshal([]byte(value), &e); err != nil {
+				oldest = key
+				break
+			}
+			if oldest == "" || e.CompletedAt.Before(oldestAt) || (e.CompletedAt.Equal(oldestAt) && key < oldest) {
+				oldest, oldestAt = key, e.CompletedAt
+			}
+		}
+		delete(data, oldest)
+	}
+	return nil
+}
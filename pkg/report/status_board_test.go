@@ -0,0 +1,69 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+func TestNewStatusBoardEntry(t *testing.T) {
+	score := 85
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		Status: assessmentv1alpha1.ClusterAssessmentStatus{
+			Summary: assessmentv1alpha1.AssessmentSummary{Score: &score, PassCount: 20, WarnCount: 3, ProfileUsed: "production"},
+		},
+	}
+
+	entry := NewStatusBoardEntry(assessment, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	if entry.Grade != "B" || entry.PassCount != 20 || entry.WarnCount != 3 || entry.Profile != "production" {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+}
+
+func TestUpsertStatusBoard(t *testing.T) {
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	data := map[string]string{"broken": "not json"}
+
+	for i, name := range []string{"daily", "weekly", "monthly"} {
+		entry := StatusBoardEntry{PassCount: i, CompletedAt: base.Add(time.Duration(i) * time.Hour)}
+		if err := UpsertStatusBoard(data, name, entry, 2); err != nil {
+			t.Fatalf("UpsertStatusBoard failed: %v", err)
+		}
+	}
+	if len(data) != 2 {
+		t.Fatalf("expected 2 entries, got %v", data)
+	}
+	if _, ok := data["daily"]; ok {
+		t.Errorf("expected the oldest entry to be evicted, got %v", data)
+	}
+
+	// Updating an existing entry replaces it in place
+	if err := UpsertStatusBoard(data, "weekly", StatusBoardEntry{FailCount: 4, CompletedAt: base.Add(5 * time.Hour)}, 2); err != nil {
+		t.Fatalf("UpsertStatusBoard failed: %v", err)
+	}
+	var weekly StatusBoardEntry
+	if err := json.Unmarshal([]byte(data["weekly"]), &weekly); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(data) != 2 || weekly.FailCount != 4 {
+		t.Errorf("expected weekly to be updated in place, got %v", data)
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"sort"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+// maxSummaryFailures is the number of failed findings included in a Summary.
+const maxSummaryFailures = 5
+
+// Summary is a compact, machine-readable view of an assessment result for
+// scripts and CLIs that do not need the full findings list.
+type Summary struct {
+	// Name is the name of the ClusterAssessment CR
+	Name string `json:"name"`
+
+	// Phase is the current assessment phase
+	Phase string `json:"phase"`
+
+	// Score is the overall score (0-100), if calculated
+	Score *int `json:"score,omitempty"`
+
+	// Grade is the letter grade derived from Score
+	Grade string `json:"grade,omitempty"`
+
+	// Counts per finding status
+	PassCount int `json:"passCount"`
+	WarnCount int `json:"warnCount"`
+	FailCount int `json:"failCount"`
+	InfoCount int `json:"infoCount"`
+
+	// TopFailures lists up to five failed findings
+	TopFailures []SummaryFailure `json:"topFailures"`
+}
+
+// SummaryFailure identifies a failed finding in a Summary.
+type SummaryFailure struct {
+	ID        string `json:"id"`
+	Validator string `json:"validator"`
+	Title     string `json:"title"`
+}
+
+// Grade converts a score (0-100) into a letter grade.
+func Grade(score int) string {
+	switch {
+	case score >= 90:
+		return "A"
+	case score >= 80:
+		return "B"
+	case score >= 70:
+		return "C"
+	case score >= 60:
+		return "D"
+	default:
+		return "F"
+	}
+}
+
+// GenerateSummaryJSON generates a compact JSON summary from a ClusterAssessment.
+func GenerateSummaryJSON(assessment *assessmentv1alpha1.ClusterAssessment) ([]byte, error) {
+	return json.Marshal(buildSummary(assessment))
+}
+
+// buildSummary constructs the Summary from a ClusterAssessment.
+func buildSummary(assessment *assessmentv1alpha1.ClusterAssessment) Summary {
+	s := assessment.Status.Summary
+	summary := Summary{
+		Name:        assessment.Name,
+		Phase:       assessment.Status.Phase,
+		Score:       s.Score,
+		PassCount:   s.PassCount,
+		WarnCount:   s.WarnCount,
+		FailCount:   s.FailCount,
+		InfoCount:   s.InfoCount,
+		TopFailures: []SummaryFailure{},
+	}
+	if s.Score != nil {
+		summary.Grade = Grade(*s.Score)
+	}
+
+	for _, f := range assessment.Status.Findings {
+		if f.Status != assessmentv1alpha1.FindingStatusFail {
+			continue
+		}
+		summary.TopFailures = append(summary.TopFailures, SummaryFailure{ID: f.ID, Validator: f.Validator, Title: f.Title})
+		if len(summary.TopFailures) == maxSummaryFailures {
+			break
+		}
+	}
+
+	return summary
+}
+
+// CategoryBreakdown computes finding counts and pass rate per category, sorted
+// by category. INFO findings are counted but are not part of the pass rate.
+func CategoryBreakdown(findings []assessmentv1alpha1.Finding) []assessmentv1alpha1.CategoryStat {
+	byCategory := make(map[string]*assessmentv1alpha1.CategoryStat)
+	for _, f := range findings {
+		stat, ok := byCategory[f.Category]
+		if !ok {
+			stat = &assessmentv1alpha1.CategoryStat{Category: f.Category}
+			byCategory[f.Category] = stat
+		}
+		switch f.Status {
+		case assessmentv1alpha1.FindingStatusPass:
+			stat.PassCount++
+		case assessmentv1alpha1.FindingStatusWarn:
+			stat.WarnCount++
+		case assessmentv1alpha1.FindingStatusFail:
+			stat.FailCount++
+		case assessmentv1alpha1.FindingStatusInfo:
+			stat.InfoCount++
+		}
+	}
+
+	breakdown := make([]assessmentv1alpha1.CategoryStat, 0, len(byCategory))
+	for _, stat := range byCategory {
+		if checks := stat.PassCount + stat.WarnCount + stat.FailCount; checks > 0 {
+			stat.PassRate = stat.PassCount * 100 / checks
+		}
+		breakdown = append(breakdown, *stat)
+	}
+	sort.Slice(breakdown, func(i, j int) bool {
+		return breakdown[i].Category < breakdown[j].Category
+	})
+
+	return breakdown
+}
+
+// categoryStats returns the category breakdown of the assessment summary, or
+// computes it from the findings for assessments stored without one.
+func categoryStats(assessment *assessmentv1alpha1.ClusterAssessment) []assessmentv1alpha1.CategoryStat {
+	if breakdown := assessment.Status.Summary.CategoryBreakdown; breakdown != nil {
+		return breakdown
+	}
+	return CategoryBreakdown(assessment.Status.Findings)
+}
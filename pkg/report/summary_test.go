@@ -0,0 +1,100 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+func TestGenerateSummaryJSON(t *testing.T) {
+	score := 72
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		Status: assessmentv1alpha1.ClusterAssessmentStatus{
+			Phase:   assessmentv1alpha1.PhaseCompleted,
+			Summary: assessmentv1alpha1.AssessmentSummary{Score: &score, PassCount: 10, FailCount: 7},
+		},
+	}
+	assessment.Name = "weekly"
+	assessment.Status.Findings = append(assessment.Status.Findings,
+		assessmentv1alpha1.Finding{ID: "pass-1", Status: assessmentv1alpha1.FindingStatusPass, Title: "Fine"})
+	for i := 0; i < 7; i++ {
+		assessment.Status.Findings = append(assessment.Status.Findings, assessmentv1alpha1.Finding{
+			ID: fmt.Sprintf("fail-%d", i), Validator: "nodes", Status: assessmentv1alpha1.FindingStatusFail, Title: "Broken",
+		})
+	}
+
+	data, err := GenerateSummaryJSON(assessment)
+	if err != nil {
+		t.Fatalf("GenerateSummaryJSON failed: %v", err)
+	}
+
+	var summary Summary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if summary.Name != "weekly" || summary.Grade != "C" || summary.FailCount != 7 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if len(summary.TopFailures) != maxSummaryFailures || summary.TopFailures[0].ID != "fail-0" {
+		t.Errorf("expected first %d failures, got %+v", maxSummaryFailures, summary.TopFailures)
+	}
+}
+
+func TestGrade(t *testing.T) {
+	tests := map[int]string{100: "A", 90: "A", 89: "B", 75: "C", 60: "D", 59: "F", 0: "F"}
+	for score, want := range tests {
+		if got := Grade(score); got != want {
+			t.Errorf("Grade(%d) = %q, want %q", score, got, want)
+		}
+	}
+}
+
+func TestCategoryBreakdown(t *testing.T) {
+	findings := []assessmentv1alpha1.Finding{
+		{Category: "Security", Status: assessmentv1alpha1.FindingStatusPass},
+		{Category: "Security", Status: assessmentv1alpha1.FindingStatusFail},
+		{Category: "Security", Status: assessmentv1alpha1.FindingStatusWarn},
+		{Category: "Platform", Status: assessmentv1alpha1.FindingStatusPass},
+		{Category: "Platform", Status: assessmentv1alpha1.FindingStatusPass},
+		{Category: "Platform", Status: assessmentv1alpha1.FindingStatusInfo},
+		{Category: "Networking", Status: assessmentv1alpha1.FindingStatusInfo},
+	}
+
+	got := CategoryBreakdown(findings)
+	want := []assessmentv1alpha1.CategoryStat{
+		{Category: "Networking", InfoCount: 1, PassRate: 0},
+		{Category: "Platform", PassCount: 2, InfoCount: 1, PassRate: 100},
+		{Category: "Security", PassCount: 1, WarnCount: 1, FailCount: 1, PassRate: 33},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d categories, got %d: %+v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("category %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestCategoryBreakdown_Empty(t *testing.T) {
+	if got := CategoryBreakdown(nil); len(got) != 0 {
+		t.Errorf("expected empty breakdown, got %+v", got)
+	}
+}
+
+func TestCategoryStats_ComputedWithoutBreakdown(t *testing.T) {
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		Status: assessmentv1alpha1.ClusterAssessmentStatus{
+			Findings: []assessmentv1alpha1.Finding{
+				{Category: "Security", Status: assessmentv1alpha1.FindingStatusPass},
+			},
+		},
+	}
+
+	stats := categoryStats(assessment)
+	if len(stats) != 1 || stats[0].Category != "Security" || stats[0].PassRate != 100 {
+		t.Errorf("expected the breakdown to be computed from the findings, got %+v", stats)
+	}
+}
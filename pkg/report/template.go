@@ -0,0 +1,60 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+// templateFuncs are the helper functions available to custom report templates.
+var templateFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"join":  strings.Join,
+}
+
+// ParseHTMLTemplate parses a custom HTML report template. Templates are
+// executed with a Report as data, exposing .Metadata, .ClusterInfo, .Summary,
+// .Findings, .FindingsByCategory and .FindingsByStatus.
+func ParseHTMLTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("report").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parsing report template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// GenerateHTMLFromTemplate renders an HTML report with a custom template
+// instead of the built-in layout. html/template escapes all values according
+// to their context, so finding text cannot inject markup or scripts.
+func GenerateHTMLFromTemplate(assessment *assessmentv1alpha1.ClusterAssessment, text string) ([]byte, error) {
+	tmpl, err := ParseHTMLTemplate(text)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, buildReport(assessment)); err != nil {
+		return nil, fmt.Errorf("executing report template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"strings"
+	"testing"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+)
+
+func TestGenerateHTMLFromTemplate(t *testing.T) {
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		Status: assessmentv1alpha1.ClusterAssessmentStatus{
+			ClusterInfo: assessmentv1alpha1.ClusterInfo{ClusterVersion: "4.16.3"},
+			Summary:     assessmentv1alpha1.AssessmentSummary{FailCount: 1},
+			Findings: []assessmentv1alpha1.Finding{{
+				ID:         "x-check",
+				Status:     assessmentv1alpha1.FindingStatusFail,
+				Title:      "<script>alert('title')</script>",
+				References: []string{"javascript:alert(1)"},
+			}},
+		},
+	}
+
+	tmpl := `<h1>{{ .ClusterInfo.ClusterVersion }}</h1><p>{{ .Summary.FailCount }} failed</p>
+{{ range .Findings }}<div class="{{ lower (print .Status) }}">{{ .Title }}{{ range .References }}<a href="{{ . }}">ref</a>{{ end }}</div>{{ end }}`
+
+	out, err := GenerateHTMLFromTemplate(assessment, tmpl)
+	if err != nil {
+		t.Fatalf("GenerateHTMLFromTemplate() returned error: %v", err)
+	}
+	html := string(out)
+
+	for _, want := range []string{"<h1>4.16.3</h1>", "<p>1 failed</p>", `class="fail"`, "&lt;script&gt;"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected output to contain %q, got %q", want, html)
+		}
+	}
+	if strings.Contains(html, "<script>") || strings.Contains(html, "javascript:") {
+		t.Errorf("Template output is not escaped: %q", html)
+	}
+}
+
+func TestGenerateHTMLFromTemplate_Errors(t *testing.T) {
+	assessment := &assessmentv1alpha1.ClusterAssessment{}
+
+	if _, err := GenerateHTMLFromTemplate(assessment, "{{ .Summary"); err == nil {
+		t.Error("Expected an error for an unparsable template")
+	}
+	if _, err := GenerateHTMLFromTemplate(assessment, "{{ .NoSuchField }}"); err == nil {
+		t.Error("Expected an error for a template referencing an unknown field")
+	}
+}
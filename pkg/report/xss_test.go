@@ -64,3 +64,27 @@ func TestGenerateHTML_XSS(t *testing.T) {
 		t.Errorf("VULNERABILITY CONFIRMED: HTML contains javascript: URL in href")
 	}
 }
+
+func TestGenerateHTML_EscapesReportMetadata(t *testing.T) {
+	assessment := &assessmentv1alpha1.ClusterAssessment{
+		Spec: assessmentv1alpha1.ClusterAssessmentSpec{
+			ReportMetadata: assessmentv1alpha1.ReportMetadataSpec{
+				Owner:   "<script>alert('owner')</script>",
+				Contact: "sre@example.com <img src=x>",
+			},
+		},
+	}
+
+	htmlBytes, err := GenerateHTML(assessment)
+	if err != nil {
+		t.Fatalf("GenerateHTML failed: %v", err)
+	}
+	htmlStr := string(htmlBytes)
+
+	if strings.Contains(htmlStr, "<script>") || strings.Contains(htmlStr, "<img src=x>") {
+		t.Errorf("HTML contains unescaped owner or contact")
+	}
+	if !strings.Contains(htmlStr, "Requested by: &lt;script&gt;") || !strings.Contains(htmlStr, "Contact: sre@example.com &lt;img src=x&gt;") {
+		t.Errorf("HTML should contain the escaped owner and contact")
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ResourceCache holds the collections that many validators list, so that each
+// is fetched from the API server at most once per assessment. The Runner
+// creates a new cache at the start of every Run and passes it to validators
+// through the context, unless one was set with SetResourceCache. The
+// controller does that to share the cache of an assessment run with the runs
+// that compare it with other profiles, so those see the same data. The cache
+// is discarded once the assessment completes, so findings never reflect data
+// from an earlier assessment.
+//
+// Validators opt in by listing through ListPods, ListNamespaces,
+// ListDeployments, ListNetworkPolicies or ForEachPod instead of calling the
+// client directly. Without a cache in the context these fall back to listing
+// through the client. The returned slices are shared between validators and
+// must not be modified.
+//
+// A ResourceCache is safe for concurrent use. A failed list is cached as well,
+// so every validator in the run sees the same error instead of retrying it.
+type ResourceCache struct {
+	client client.Client
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+// cacheEntry is a collection that is fetched once.
+type cacheEntry struct {
+	once  sync.Once
+	items interface{}
+	err   error
+}
+
+// NewResourceCache returns an empty cache that lists through c.
+func NewResourceCache(c client.Client) *ResourceCache {
+	return &ResourceCache{
+		client:  c,
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// resourceCacheContextKey is the context key for the run's resource cache.
+type resourceCacheContextKey struct{}
+
+// WithResourceCache returns a copy of ctx carrying cache.
+func WithResourceCache(ctx context.Context, cache *ResourceCache) context.Context {
+	return context.WithValue(ctx, resourceCacheContextKey{}, cache)
+}
+
+// ResourceCacheFromContext returns the resource cache carried by ctx, if any.
+func ResourceCacheFromContext(ctx context.Context) *ResourceCache {
+	cache, _ := ctx.Value(resourceCacheContextKey{}).(*ResourceCache)
+	return cache
+}
+
+// load returns the collection stored under key, calling fetch to populate it
+// on first use. Concurrent callers wait for a single fetch.
+func (rc *ResourceCache) load(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	rc.mu.Lock()
+	entry, ok := rc.entries[key]
+	if !ok {
+		entry = &cacheEntry{}
+		rc.entries[key] = entry
+	}
+	rc.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.items, entry.err = fetch()
+	})
+	return entry.items, entry.err
+}
+
+// Pods returns all pods in the cluster, paged through the API reader in ctx
+// when there is one; see WithAPIReader.
+func (rc *ResourceCache) Pods(ctx context.Context) ([]corev1.Pod, error) {
+	items, err := rc.load("pods", func() (interface{}, error) {
+		var pods []corev1.Pod
+		err := forEachPodPage(ctx, rc.client, func(pod *corev1.Pod) {
+			pods = append(pods, *pod)
+		})
+		return pods, err
+	})
+	pods, _ := items.([]corev1.Pod)
+	return pods, err
+}
+
+// Namespaces returns all namespaces in the cluster.
+func (rc *ResourceCache) Namespaces(ctx context.Context) ([]corev1.Namespace, error) {
+	items, err := rc.load("namespaces", func() (interface{}, error) {
+		list := &corev1.NamespaceList{}
+		err := rc.client.List(ctx, list)
+		return list.Items, err
+	})
+	namespaces, _ := items.([]corev1.Namespace)
+	return namespaces, err
+}
+
+// Deployments returns all deployments in the cluster.
+func (rc *ResourceCache) Deployments(ctx context.Context) ([]appsv1.Deployment, error) {
+	items, err := rc.load("deployments", func() (interface{}, error) {
+		list := &appsv1.DeploymentList{}
+		err := rc.client.List(ctx, list)
+		return list.Items, err
+	})
+	deployments, _ := items.([]appsv1.Deployment)
+	return deployments, err
+}
+
+// NetworkPolicies returns all NetworkPolicies in the cluster.
+func (rc *ResourceCache) NetworkPolicies(ctx context.Context) ([]networkingv1.NetworkPolicy, error) {
+	items, err := rc.load("networkpolicies", func() (interface{}, error) {
+		list := &networkingv1.NetworkPolicyList{}
+		err := rc.client.List(ctx, list)
+		return list.Items, err
+	})
+	policies, _ := items.([]networkingv1.NetworkPolicy)
+	return policies, err
+}
+
+// ListPods returns all pods, from the run's resource cache when ctx carries one.
+func ListPods(ctx context.Context, c client.Client) ([]corev1.Pod, error) {
+	if cache := ResourceCacheFromContext(ctx); cache != nil {
+		return cache.Pods(ctx)
+	}
+	list := &corev1.PodList{}
+	err := c.List(ctx, list)
+	return list.Items, err
+}
+
+// ListNamespaces returns all namespaces, from the run's resource cache when
+// ctx carries one.
+func ListNamespaces(ctx context.Context, c client.Client) ([]corev1.Namespace, error) {
+	if cache := ResourceCacheFromContext(ctx); cache != nil {
+		return cache.Namespaces(ctx)
+	}
+	list := &corev1.NamespaceList{}
+	err := c.List(ctx, list)
+	return list.Items, err
+}
+
+// ListDeployments returns all deployments, from the run's resource cache when
+// ctx carries one.
+func ListDeployments(ctx context.Context, c client.Client) ([]appsv1.Deployment, error) {
+	if cache := ResourceCacheFromContext(ctx); cache != nil {
+		return cache.Deployments(ctx)
+	}
+	list := &appsv1.DeploymentList{}
+	err := c.List(ctx, list)
+	return list.Items, err
+}
+
+// ListNetworkPolicies returns all NetworkPolicies, from the run's resource
+// cache when ctx carries one.
+func ListNetworkPolicies(ctx context.Context, c client.Client) ([]networkingv1.NetworkPolicy, error) {
+	if cache := ResourceCacheFromContext(ctx); cache != nil {
+		return cache.NetworkPolicies(ctx)
+	}
+	list := &networkingv1.NetworkPolicyList{}
+	err := c.List(ctx, list)
+	return list.Items, err
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validators/costoptimization"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validators/deprecation"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validators/security"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validators/workloadhealth"
+)
+
+// BenchmarkResourceCache runs the validators that list pods and deployments
+// with and without a shared resource cache, reporting the List calls made per
+// assessment run.
+func BenchmarkResourceCache(b *testing.B) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = networkingv1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+
+	var objs []client.Object
+	for i := 0; i < 50; i++ {
+		ns := fmt.Sprintf("team-%d", i%10)
+		objs = append(objs,
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pod-%d", i), Namespace: ns}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("deploy-%d", i), Namespace: ns}},
+		)
+	}
+
+	var lists int64
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			atomic.AddInt64(&lists, 1)
+			return c.List(ctx, list, opts...)
+		},
+	}).Build()
+
+	validators := []validator.Validator{
+		&security.SecurityValidator{},
+		&costoptimization.CostOptimizationValidator{},
+		&deprecation.DeprecationValidator{},
+		&workloadhealth.WorkloadHealthValidator{},
+	}
+	profile := profiles.GetProfile("production")
+
+	for _, cached := range []bool{false, true} {
+		b.Run(fmt.Sprintf("cached=%t", cached), func(b *testing.B) {
+			atomic.StoreInt64(&lists, 0)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				ctx := context.Background()
+				if cached {
+					ctx = validator.WithResourceCache(ctx, validator.NewResourceCache(c))
+				}
+				for _, v := range validators {
+					if _, err := v.Validate(ctx, c, profile); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+			b.ReportMetric(float64(atomic.LoadInt64(&lists))/float64(b.N), "lists/op")
+		})
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+)
+
+// newCountingClient returns a fake client holding objs that counts its List calls.
+func newCountingClient(lists *int64, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			atomic.AddInt64(lists, 1)
+			return c.List(ctx, list, opts...)
+		},
+	}).Build()
+}
+
+func TestResourceCache_ListsOnce(t *testing.T) {
+	var lists int64
+	c := newCountingClient(&lists,
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "team-a"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "team-b"}},
+	)
+	ctx := WithResourceCache(context.Background(), NewResourceCache(c))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pods, err := ListPods(ctx, c)
+			if err != nil || len(pods) != 2 {
+				t.Errorf("ListPods() = %d pods, %v; want 2 pods", len(pods), err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	count := 0
+	if err := ForEachPod(ctx, c, func(pod *corev1.Pod) { count++ }); err != nil || count != 2 {
+		t.Errorf("ForEachPod() visited %d pods, %v; want 2", count, err)
+	}
+	if lists != 1 {
+		t.Errorf("Expected pods to be listed once, got %d List calls", lists)
+	}
+
+	// Without a cache every call reaches the client.
+	if _, err := ListPods(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if lists != 2 {
+		t.Errorf("Expected an uncached List call, got %d List calls", lists)
+	}
+}
+
+// podListingValidator lists pods through the resource cache.
+type podListingValidator struct {
+	name string
+}
+
+func (v *podListingValidator) Name() string        { return v.name }
+func (v *podListingValidator) Description() string { return "test validator" }
+func (v *podListingValidator) Category() string    { return "Test" }
+
+func (v *podListingValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
+	_, err := ListPods(ctx, c)
+	return nil, err
+}
+
+func TestRunner_SharesResourceCachePerRun(t *testing.T) {
+	var lists int64
+	c := newCountingClient(&lists, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "team-a"}})
+
+	registry := NewRegistry()
+	_ = registry.Register(&podListingValidator{name: "first"})
+	_ = registry.Register(&podListingValidator{name: "second"})
+	runner := NewRunner(registry, c)
+
+	if _, err := runner.RunAll(context.Background(), profiles.GetProfile("production")); err != nil {
+		t.Fatal(err)
+	}
+	if lists != 1 {
+		t.Errorf("Expected validators in one run to share a List call, got %d", lists)
+	}
+
+	// The cache lives for one run only.
+	if _, err := runner.RunAll(context.Background(), profiles.GetProfile("production")); err != nil {
+		t.Fatal(err)
+	}
+	if lists != 2 {
+		t.Errorf("Expected a new run to list again, got %d List calls", lists)
+	}
+}
+
+func TestRunner_SetResourceCache(t *testing.T) {
+	var lists int64
+	c := newCountingClient(&lists, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "team-a"}})
+
+	registry := NewRegistry()
+	_ = registry.Register(&podListingValidator{name: "pods"})
+	runner := NewRunner(registry, c)
+	if _, err := runner.RunAll(context.Background(), profiles.GetProfile("production")); err != nil {
+		t.Fatal(err)
+	}
+
+	// A run sharing the cache of the first one does not list again.
+	other := NewRunner(registry, c)
+	other.SetResourceCache(runner.ResourceCache())
+	if _, err := other.RunAll(context.Background(), profiles.GetProfile("development")); err != nil {
+		t.Fatal(err)
+	}
+	if lists != 1 {
+		t.Errorf("Expected the runs to share one List call, got %d", lists)
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ConfigurableValidator is implemented by validators that accept per-assessment
+// overrides through the ClusterAssessment spec.validatorConfig field.
+type ConfigurableValidator interface {
+	Validator
+
+	// ConfigKeys returns the override keys understood by this validator.
+	ConfigKeys() []string
+}
+
+// configContextKey is the context key for validator overrides.
+type configContextKey struct{}
+
+// WithConfig returns a copy of ctx carrying the given validator overrides.
+func WithConfig(ctx context.Context, config map[string]string) context.Context {
+	return context.WithValue(ctx, configContextKey{}, config)
+}
+
+// ConfigFromContext returns the validator overrides carried by ctx, if any.
+func ConfigFromContext(ctx context.Context) map[string]string {
+	config, _ := ctx.Value(configContextKey{}).(map[string]string)
+	return config
+}
+
+// ConfigInt returns the integer override for key, or def if the key is
+// absent or not a valid integer.
+func ConfigInt(ctx context.Context, key string, def int) int {
+	value, ok := ConfigFromContext(ctx)[key]
+	if !ok {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+// ConfigString returns the string override for key, or def if the key is absent.
+func ConfigString(ctx context.Context, key, def string) string {
+	if value, ok := ConfigFromContext(ctx)[key]; ok {
+		return value
+	}
+	return def
+}
+
+// ConfigList returns the comma-separated override for key as a list with
+// surrounding whitespace and empty entries removed, or nil if the key is absent.
+func ConfigList(ctx context.Context, key string) []string {
+	var list []string
+	for _, item := range strings.Split(ConfigFromContext(ctx)[key], ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// unknownConfigKeys returns the override keys not understood by v, sorted.
+func unknownConfigKeys(v Validator, config map[string]string) []string {
+	known := make(map[string]bool)
+	if cv, ok := v.(ConfigurableValidator); ok {
+		for _, k := range cv.ConfigKeys() {
+			known[k] = true
+		}
+	}
+
+	var unknown []string
+	for k := range config {
+		if !known[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
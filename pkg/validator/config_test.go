@@ -0,0 +1,99 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+)
+
+// configurableValidator records the sample size it receives through the context.
+type configurableValidator struct {
+	gotSampleSize int
+}
+
+func (v *configurableValidator) Name() string        { return "configurable" }
+func (v *configurableValidator) Description() string { return "test validator" }
+func (v *configurableValidator) Category() string    { return "Test" }
+func (v *configurableValidator) ConfigKeys() []string {
+	return []string{"sampleSize"}
+}
+
+func (v *configurableValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
+	v.gotSampleSize = ConfigInt(ctx, "sampleSize", 5)
+	return nil, nil
+}
+
+func TestRunner_ValidatorConfig(t *testing.T) {
+	v := &configurableValidator{}
+	registry := NewRegistry()
+	if err := registry.Register(v); err != nil {
+		t.Fatalf("Register() returned error: %v", err)
+	}
+
+	runner := NewRunner(registry, nil)
+	runner.SetValidatorConfig(map[string]map[string]string{
+		"configurable": {"sampleSize": "10", "typo": "1"},
+	})
+
+	findings, err := runner.RunAll(context.Background(), profiles.GetProfile("production"))
+	if err != nil {
+		t.Fatalf("RunAll() returned error: %v", err)
+	}
+
+	if v.gotSampleSize != 10 {
+		t.Errorf("Expected sampleSize override 10, got %d", v.gotSampleSize)
+	}
+
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 finding for unknown keys, got %d", len(findings))
+	}
+	if findings[0].ID != "configurable-config-unknown-keys" || findings[0].Status != assessmentv1alpha1.FindingStatusInfo {
+		t.Errorf("Unexpected finding: %s (%s)", findings[0].ID, findings[0].Status)
+	}
+}
+
+func TestConfigInt_Defaults(t *testing.T) {
+	ctx := WithConfig(context.Background(), map[string]string{"bad": "abc"})
+
+	if got := ConfigInt(ctx, "missing", 3); got != 3 {
+		t.Errorf("Expected default 3 for missing key, got %d", got)
+	}
+	if got := ConfigInt(ctx, "bad", 3); got != 3 {
+		t.Errorf("Expected default 3 for invalid value, got %d", got)
+	}
+	if got := ConfigInt(context.Background(), "missing", 7); got != 7 {
+		t.Errorf("Expected default 7 without config, got %d", got)
+	}
+}
+
+func TestConfigList(t *testing.T) {
+	ctx := WithConfig(context.Background(), map[string]string{"admins": " User:alice, ,Group:ops "})
+
+	got := ConfigList(ctx, "admins")
+	if len(got) != 2 || got[0] != "User:alice" || got[1] != "Group:ops" {
+		t.Errorf("Expected [User:alice Group:ops], got %v", got)
+	}
+	if got := ConfigList(ctx, "missing"); got != nil {
+		t.Errorf("Expected nil for missing key, got %v", got)
+	}
+}
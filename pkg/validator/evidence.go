@@ -0,0 +1,113 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MaxEvidenceBytes caps the size of the YAML attached to a finding as
+// evidence. Longer objects are truncated at a line boundary.
+const MaxEvidenceBytes = 2048
+
+// redactedValue replaces Secret values in evidence.
+const redactedValue = "<redacted>"
+
+// lastAppliedAnnotation holds a full copy of the applied object, including
+// any Secret data, so it is never included in evidence.
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// evidenceContextKey is the context key that enables evidence collection.
+type evidenceContextKey struct{}
+
+// WithEvidence returns a copy of ctx in which Evidence renders objects.
+func WithEvidence(ctx context.Context) context.Context {
+	return context.WithValue(ctx, evidenceContextKey{}, true)
+}
+
+// EvidenceEnabled reports whether ctx asks validators to attach evidence.
+func EvidenceEnabled(ctx context.Context) bool {
+	enabled, _ := ctx.Value(evidenceContextKey{}).(bool)
+	return enabled
+}
+
+// Evidence returns a YAML rendering of obj for Finding.Evidence, or an empty
+// string when evidence is not enabled in ctx. Managed fields and the
+// last-applied-configuration annotation are dropped, the values of a Secret's
+// data and stringData are redacted, and the result is truncated to
+// MaxEvidenceBytes.
+func Evidence(ctx context.Context, obj client.Object) string {
+	if !EvidenceEnabled(ctx) || obj == nil {
+		return ""
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return ""
+	}
+
+	if metadata, ok := content["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "managedFields")
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			delete(annotations, lastAppliedAnnotation)
+			if len(annotations) == 0 {
+				delete(metadata, "annotations")
+			}
+		}
+	}
+
+	// Typed objects read through the client usually have an empty TypeMeta,
+	// so a Secret is recognized by its Go type as well as its kind.
+	if _, isSecret := obj.(*corev1.Secret); isSecret || obj.GetObjectKind().GroupVersionKind().Kind == "Secret" {
+		for _, field := range []string{"data", "stringData"} {
+			if values, ok := content[field].(map[string]interface{}); ok {
+				for key := range values {
+					values[key] = redactedValue
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(content); err != nil {
+		return ""
+	}
+	return truncateEvidence(buf.String())
+}
+
+// truncateEvidence cuts text to at most MaxEvidenceBytes, ending at a line
+// boundary and marking that the evidence was truncated.
+func truncateEvidence(text string) string {
+	if len(text) <= MaxEvidenceBytes {
+		return text
+	}
+	const marker = "# ... truncated\n"
+	cut := text[:MaxEvidenceBytes-len(marker)]
+	if i := strings.LastIndexByte(cut, '\n'); i >= 0 {
+		cut = cut[:i+1]
+	}
+	return cut + marker
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEvidence(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "db-credentials",
+			Namespace:     "team-a",
+			Annotations:   map[string]string{lastAppliedAnnotation: `{"data":{"password":"aHVudGVyMg=="}}`},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+		StringData: map[string]string{"user": "admin"},
+	}
+
+	if got := Evidence(context.Background(), secret); got != "" {
+		t.Errorf("Expected no evidence without WithEvidence, got %q", got)
+	}
+
+	got := Evidence(WithEvidence(context.Background()), secret)
+	for _, want := range []string{"name: db-credentials", "password: <redacted>", "user: <redacted>"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected evidence to contain %q, got:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"aHVudGVyMg", "admin", "managedFields", lastAppliedAnnotation} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("Evidence should not contain %q, got:\n%s", unwanted, got)
+		}
+	}
+	if secret.Data["password"] == nil || secret.Annotations[lastAppliedAnnotation] == "" {
+		t.Error("Evidence modified the object")
+	}
+}
+
+func TestEvidence_Truncated(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "large", Namespace: "team-a"},
+		Data:       map[string]string{},
+	}
+	for i := 0; i < 200; i++ {
+		cm.Data[strings.Repeat("k", 10)+string(rune('a'+i%26))+strings.Repeat("x", i)] = "value"
+	}
+
+	got := Evidence(WithEvidence(context.Background()), cm)
+	if len(got) > MaxEvidenceBytes {
+		t.Errorf("Expected at most %d bytes, got %d", MaxEvidenceBytes, len(got))
+	}
+	if !strings.HasSuffix(got, "# ... truncated\n") {
+		t.Errorf("Expected a truncation marker, got:\n%s", got[len(got)-50:])
+	}
+}
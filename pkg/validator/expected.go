@@ -0,0 +1,110 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExpectedStateKey is the ConfigMap data key holding the expected state
+// document. The document may be written as YAML or JSON.
+const ExpectedStateKey = "expected-state.yaml"
+
+// ExpectedState describes what an organization expects the cluster to look
+// like. It is loaded once per assessment from spec.expectedStateConfigMap and
+// consulted by the validators that own each expectation:
+//
+//	nodes:
+//	  controlPlane: 3
+//	  workers: 6
+//	requiredOperators:
+//	  - openshift-gitops-operator
+//	clusterAdmins:
+//	  - Group:platform-admins
+//	requiredNamespaceLabels:
+//	  - cost-center
+//	allowedRegistries:
+//	  - registry.redhat.io
+//	  - quay.io/acme
+type ExpectedState struct {
+	// Nodes are the expected node counts, checked by the nodes validator.
+	Nodes *ExpectedNodes `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+
+	// RequiredOperators replaces the profile's required operators in the
+	// operators validator.
+	RequiredOperators []string `json:"requiredOperators,omitempty" yaml:"requiredOperators,omitempty"`
+
+	// ClusterAdmins are the expected cluster-admin subjects, in the format of
+	// spec.expectedClusterAdmins, which takes precedence when both are set.
+	ClusterAdmins []string `json:"clusterAdmins,omitempty" yaml:"clusterAdmins,omitempty"`
+
+	// RequiredNamespaceLabels are label keys every user namespace must carry,
+	// checked by the compliance validator.
+	RequiredNamespaceLabels []string `json:"requiredNamespaceLabels,omitempty" yaml:"requiredNamespaceLabels,omitempty"`
+
+	// AllowedRegistries are the registries, optionally with a repository
+	// prefix, that user workload images may come from. Checked by the
+	// security validator.
+	AllowedRegistries []string `json:"allowedRegistries,omitempty" yaml:"allowedRegistries,omitempty"`
+}
+
+// ExpectedNodes are expected node counts. Unset counts are not checked.
+type ExpectedNodes struct {
+	ControlPlane *int `json:"controlPlane,omitempty" yaml:"controlPlane,omitempty"`
+	Workers      *int `json:"workers,omitempty" yaml:"workers,omitempty"`
+}
+
+// ParseExpectedState parses an expected state document. Unknown fields are
+// rejected so that misspelled expectations are not silently ignored.
+func ParseExpectedState(data string) (*ExpectedState, error) {
+	decoder := yaml.NewDecoder(bytes.NewBufferString(data))
+	decoder.KnownFields(true)
+
+	state := &ExpectedState{}
+	if err := decoder.Decode(state); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing expected state: %w", err)
+	}
+	if nodes := state.Nodes; nodes != nil {
+		if nodes.ControlPlane != nil && *nodes.ControlPlane < 0 || nodes.Workers != nil && *nodes.Workers < 0 {
+			return nil, fmt.Errorf("parsing expected state: node counts must not be negative")
+		}
+	}
+	return state, nil
+}
+
+// expectedStateContextKey is the context key for the expected state.
+type expectedStateContextKey struct{}
+
+// WithExpectedState returns a copy of ctx carrying the expected state.
+func WithExpectedState(ctx context.Context, state *ExpectedState) context.Context {
+	return context.WithValue(ctx, expectedStateContextKey{}, state)
+}
+
+// ExpectedStateFromContext returns the expected state carried by ctx. It
+// never returns nil, so validators can read fields without checking.
+func ExpectedStateFromContext(ctx context.Context) *ExpectedState {
+	if state, _ := ctx.Value(expectedStateContextKey{}).(*ExpectedState); state != nil {
+		return state
+	}
+	return &ExpectedState{}
+}
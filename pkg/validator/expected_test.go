@@ -0,0 +1,72 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParseExpectedState(t *testing.T) {
+	state, err := ParseExpectedState(`
+nodes:
+  controlPlane: 3
+  workers: 6
+requiredOperators: [openshift-gitops-operator]
+clusterAdmins: ["Group:platform-admins"]
+requiredNamespaceLabels: [cost-center]
+allowedRegistries: [registry.redhat.io, quay.io/acme]
+`)
+	if err != nil {
+		t.Fatalf("ParseExpectedState() returned error: %v", err)
+	}
+	if state.Nodes == nil || *state.Nodes.ControlPlane != 3 || *state.Nodes.Workers != 6 {
+		t.Errorf("unexpected nodes %+v", state.Nodes)
+	}
+	if len(state.AllowedRegistries) != 2 || state.ClusterAdmins[0] != "Group:platform-admins" {
+		t.Errorf("unexpected state %+v", state)
+	}
+
+	state, err = ParseExpectedState(`{"nodes": {"workers": 2}, "requiredOperators": ["cluster-logging"]}`)
+	if err != nil {
+		t.Fatalf("ParseExpectedState() returned error for JSON: %v", err)
+	}
+	if state.Nodes.ControlPlane != nil || *state.Nodes.Workers != 2 || state.RequiredOperators[0] != "cluster-logging" {
+		t.Errorf("unexpected state from JSON %+v", state)
+	}
+
+	if _, err := ParseExpectedState(""); err != nil {
+		t.Errorf("expected an empty document to parse, got %v", err)
+	}
+	if _, err := ParseExpectedState("allowedRegistry: [quay.io]"); err == nil || !strings.Contains(err.Error(), "allowedRegistry") {
+		t.Errorf("expected an error naming the unknown field, got %v", err)
+	}
+	if _, err := ParseExpectedState("nodes: {workers: -1}"); err == nil {
+		t.Error("expected an error for a negative node count")
+	}
+}
+
+func TestExpectedStateFromContext(t *testing.T) {
+	if state := ExpectedStateFromContext(context.Background()); state == nil || state.Nodes != nil {
+		t.Errorf("expected an empty state without one in the context, got %+v", state)
+	}
+	ctx := WithExpectedState(context.Background(), &ExpectedState{AllowedRegistries: []string{"quay.io"}})
+	if got := ExpectedStateFromContext(ctx).AllowedRegistries; len(got) != 1 {
+		t.Errorf("expected the state from the context, got %v", got)
+	}
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import "strings"
+
+// maxSample is the number of affected resources named in a finding.
+const maxSample = 5
+
+// SampleOf returns at most the first five entries of items, the resources a
+// finding names out of all those it counts.
+func SampleOf(items []string) []string {
+	if len(items) > maxSample {
+		return items[:maxSample]
+	}
+	return items
+}
+
+// IsPlatformNamespace reports whether ns belongs to the platform: openshift,
+// or a namespace prefixed with openshift- or kube-.
+func IsPlatformNamespace(ns string) bool {
+	return strings.HasPrefix(ns, "openshift-") || strings.HasPrefix(ns, "kube-") || ns == "openshift"
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSampleOf(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e", "f", "g"}
+	if got := SampleOf(items); !reflect.DeepEqual(got, items[:5]) {
+		t.Errorf("SampleOf() = %v, want the first five items", got)
+	}
+	if got := SampleOf(items[:2]); !reflect.DeepEqual(got, items[:2]) {
+		t.Errorf("SampleOf() = %v, want all items", got)
+	}
+}
+
+func TestIsPlatformNamespace(t *testing.T) {
+	tests := map[string]bool{
+		"openshift":            true,
+		"openshift-monitoring": true,
+		"kube-system":          true,
+		"default":              false,
+		"payments":             false,
+		"openshiftish":         false,
+	}
+	for ns, want := range tests {
+		if got := IsPlatformNamespace(ns); got != want {
+			t.Errorf("IsPlatformNamespace(%q) = %v, want %v", ns, got, want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WatchingValidator is implemented by validators whose findings depend only on
+// the listed resource types. Incremental runs skip such a validator, carrying
+// forward its previous findings, when none of those resources changed.
+type WatchingValidator interface {
+	Validator
+
+	// WatchedResources returns the kinds of every object the validator reads.
+	WatchedResources() []schema.GroupVersionKind
+}
+
+// Fingerprint returns a digest of the name and resourceVersion of every object
+// of the given kinds, plus salt. Objects are listed by metadata only. Kinds
+// not served by the cluster contribute a fixed marker.
+func Fingerprint(ctx context.Context, c client.Client, kinds []schema.GroupVersionKind, salt string) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "salt=%s\n", salt)
+
+	for _, gvk := range kinds {
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := c.List(ctx, list); err != nil {
+			if meta.IsNoMatchError(err) {
+				fmt.Fprintf(h, "%s absent\n", gvk)
+				continue
+			}
+			return "", fmt.Errorf("listing %s: %w", gvk.Kind, err)
+		}
+
+		entries := make([]string, 0, len(list.Items))
+		for _, item := range list.Items {
+			entries = append(entries, fmt.Sprintf("%s/%s@%s", item.Namespace, item.Name, item.ResourceVersion))
+		}
+		sort.Strings(entries)
+
+		fmt.Fprintf(h, "%s\n", gvk)
+		for _, entry := range entries {
+			fmt.Fprintf(h, "%s\n", entry)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+)
+
+// watchingValidator counts its runs and reads ConfigMaps only.
+type watchingValidator struct {
+	runs int
+}
+
+func (v *watchingValidator) Name() string        { return "watching" }
+func (v *watchingValidator) Description() string { return "test validator" }
+func (v *watchingValidator) Category() string    { return "Test" }
+func (v *watchingValidator) WatchedResources() []schema.GroupVersionKind {
+	return []schema.GroupVersionKind{corev1.SchemeGroupVersion.WithKind("ConfigMap")}
+}
+
+func (v *watchingValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
+	v.runs++
+	return []assessmentv1alpha1.Finding{{
+		ID:        "watching-check",
+		Validator: v.Name(),
+		Status:    assessmentv1alpha1.FindingStatusPass,
+	}}, nil
+}
+
+func TestRunner_Incremental(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "team-a"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm).Build()
+
+	v := &watchingValidator{}
+	registry := NewRegistry()
+	if err := registry.Register(v); err != nil {
+		t.Fatalf("Register() returned error: %v", err)
+	}
+	profile := profiles.GetProfile("production")
+	ctx := context.Background()
+
+	run := func(salt string, fingerprints map[string]string, findings []assessmentv1alpha1.Finding) (*Runner, []assessmentv1alpha1.Finding) {
+		t.Helper()
+		runner := NewRunner(registry, c)
+		runner.SetIncremental(salt, fingerprints, findings)
+		got, err := runner.RunAll(ctx, profile)
+		if err != nil {
+			t.Fatalf("RunAll() returned error: %v", err)
+		}
+		return runner, got
+	}
+
+	first, findings := run("1", nil, nil)
+	if v.runs != 1 || len(first.Fingerprints()) != 1 {
+		t.Fatalf("First run: runs = %d, fingerprints = %v", v.runs, first.Fingerprints())
+	}
+
+	second, carried := run("1", first.Fingerprints(), findings)
+	if v.runs != 1 {
+		t.Errorf("Validator re-ran with unchanged inputs")
+	}
+	if len(second.Skipped()) != 1 || len(second.Executed()) != 0 {
+		t.Errorf("Skipped = %v, Executed = %v", second.Skipped(), second.Executed())
+	}
+	if len(carried) != 1 || carried[0].ID != "watching-check" {
+		t.Errorf("Expected the previous finding to be carried forward, got %v", carried)
+	}
+	if second.Fingerprints()["watching"] != first.Fingerprints()["watching"] {
+		t.Errorf("Fingerprint changed without input changes")
+	}
+
+	run("2", second.Fingerprints(), carried)
+	if v.runs != 2 {
+		t.Errorf("Validator did not re-run after the salt changed")
+	}
+
+	cm.Data = map[string]string{"key": "value"}
+	if err := c.Update(ctx, cm); err != nil {
+		t.Fatalf("Update() returned error: %v", err)
+	}
+	run("1", second.Fingerprints(), carried)
+	if v.runs != 3 {
+		t.Errorf("Validator did not re-run after a watched resource changed")
+	}
+}
+
+func TestRunner_NotIncremental(t *testing.T) {
+	v := &watchingValidator{}
+	registry := NewRegistry()
+	if err := registry.Register(v); err != nil {
+		t.Fatalf("Register() returned error: %v", err)
+	}
+
+	runner := NewRunner(registry, nil)
+	if _, err := runner.RunAll(context.Background(), profiles.GetProfile("production")); err != nil {
+		t.Fatalf("RunAll() returned error: %v", err)
+	}
+	if v.runs != 1 || runner.Fingerprints() != nil {
+		t.Errorf("runs = %d, fingerprints = %v; want 1 run and no fingerprints", v.runs, runner.Fingerprints())
+	}
+}
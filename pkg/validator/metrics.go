@@ -0,0 +1,44 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/metrics"
+)
+
+// MetricsQuerier runs PromQL instant queries against the cluster's metrics.
+// It is implemented by metrics.QueryClient.
+type MetricsQuerier interface {
+	Query(ctx context.Context, query string) ([]metrics.TimeSeries, error)
+}
+
+// metricsQuerierContextKey is the context key for the metrics querier.
+type metricsQuerierContextKey struct{}
+
+// WithMetricsQuerier returns a copy of ctx carrying the metrics querier.
+func WithMetricsQuerier(ctx context.Context, querier MetricsQuerier) context.Context {
+	return context.WithValue(ctx, metricsQuerierContextKey{}, querier)
+}
+
+// MetricsQuerierFromContext returns the metrics querier carried by ctx, or
+// nil when metrics analysis is not enabled for the assessment.
+func MetricsQuerierFromContext(ctx context.Context) MetricsQuerier {
+	querier, _ := ctx.Value(metricsQuerierContextKey{}).(MetricsQuerier)
+	return querier
+}
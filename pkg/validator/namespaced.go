@@ -0,0 +1,151 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// NamespaceScopedValidators lists the validators that only inspect
+// namespace-scoped resources and can run against a single namespace.
+var NamespaceScopedValidators = []string{
+	"resourcequotas",
+	"networkpolicyaudit",
+	"workloadhealth",
+	"costoptimization",
+}
+
+// clusterScopedReads are the cluster-scoped kinds a namespace-scoped client
+// may read. They describe the nodes and cluster-wide policies that apply to
+// the namespace; Namespaces are further filtered to the scoped namespace.
+// Reads of any other cluster-scoped kind are rejected.
+var clusterScopedReads = map[string]bool{
+	"Namespace":                  true,
+	"Node":                       true,
+	"AdminNetworkPolicy":         true,
+	"BaselineAdminNetworkPolicy": true,
+}
+
+// namespaceScopedClient restricts reads to a single namespace so that
+// validators written for the whole cluster only see that namespace. Objects
+// in other namespaces are not found, and only the cluster-scoped kinds in
+// clusterScopedReads can be read.
+type namespaceScopedClient struct {
+	client.Client
+	namespace string
+}
+
+// NewNamespaceScopedClient returns a client that reads namespaced resources
+// only in namespace, filters Namespace reads down to that namespace and
+// rejects reads of cluster-scoped kinds not in clusterScopedReads.
+func NewNamespaceScopedClient(c client.Client, namespace string) client.Client {
+	return &namespaceScopedClient{
+		Client:    c,
+		namespace: namespace,
+	}
+}
+
+// Get gets an object in the scoped namespace. Objects in other namespaces,
+// and Namespaces other than the scoped one, are reported as not found.
+func (c *namespaceScopedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	gvk, namespaced, err := c.scope(obj)
+	if err != nil {
+		return err
+	}
+	outside := key.Name != c.namespace
+	if namespaced {
+		outside = key.Namespace != c.namespace
+	}
+	if (namespaced || gvk.Kind == "Namespace") && outside {
+		return errors.NewNotFound(schema.GroupResource{Group: gvk.Group, Resource: strings.ToLower(gvk.Kind)}, key.Name)
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+// List lists objects in the scoped namespace. Namespace lists are filtered
+// to the scoped namespace itself.
+func (c *namespaceScopedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	_, namespaced, err := c.scope(list)
+	if err != nil {
+		return err
+	}
+	if namespaced {
+		opts = append(opts, client.InNamespace(c.namespace))
+	}
+
+	if err := c.Client.List(ctx, list, opts...); err != nil {
+		return err
+	}
+
+	switch l := list.(type) {
+	case *corev1.NamespaceList:
+		var items []corev1.Namespace
+		for _, ns := range l.Items {
+			if ns.Name == c.namespace {
+				items = append(items, ns)
+			}
+		}
+		l.Items = items
+	case *metav1.PartialObjectMetadataList:
+		if l.GetObjectKind().GroupVersionKind().Kind != "NamespaceList" {
+			return nil
+		}
+		var items []metav1.PartialObjectMetadata
+		for _, ns := range l.Items {
+			if ns.Name == c.namespace {
+				items = append(items, ns)
+			}
+		}
+		l.Items = items
+	}
+	return nil
+}
+
+// scope returns the kind of obj, or of the items of a list, and whether it is
+// namespaced. Cluster-scoped kinds not in clusterScopedReads are forbidden.
+func (c *namespaceScopedClient) scope(obj runtime.Object) (schema.GroupVersionKind, bool, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return gvk, false, err
+	}
+	// Scope is resolved on the item kind; REST mappings do not cover list kinds.
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	if clusterScopedReads[gvk.Kind] {
+		// Optional kinds such as AdminNetworkPolicy may not be installed;
+		// the read itself reports that
+		return gvk, false, nil
+	}
+	namespaced, err := apiutil.IsGVKNamespaced(gvk, c.RESTMapper())
+	if err != nil {
+		return gvk, false, fmt.Errorf("failed to determine scope of %s: %w", gvk.Kind, err)
+	}
+	if !namespaced {
+		return gvk, false, errors.NewForbidden(schema.GroupResource{Group: gvk.Group, Resource: strings.ToLower(gvk.Kind)}, "",
+			fmt.Errorf("cluster-scoped %s cannot be read in a NamespaceAssessment of %s", gvk.Kind, c.namespace))
+	}
+	return gvk, true, nil
+}
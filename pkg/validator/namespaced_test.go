@@ -0,0 +1,122 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNamespaceScopedClient_List(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	objs := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "team-a"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "team-b"}},
+	}
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion})
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(objs...).Build()
+	c := NewNamespaceScopedClient(fakeClient, "team-a")
+	ctx := context.Background()
+
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods); err != nil {
+		t.Fatalf("List pods returned error: %v", err)
+	}
+	if len(pods.Items) != 1 || pods.Items[0].Namespace != "team-a" {
+		t.Errorf("Expected only the team-a pod, got %d pods", len(pods.Items))
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	if err := c.List(ctx, namespaces); err != nil {
+		t.Fatalf("List namespaces returned error: %v", err)
+	}
+	if len(namespaces.Items) != 1 || namespaces.Items[0].Name != "team-a" {
+		t.Errorf("Expected only namespace team-a, got %d namespaces", len(namespaces.Items))
+	}
+
+	partial := &metav1.PartialObjectMetadataList{}
+	partial.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "NamespaceList"})
+	if err := c.List(ctx, partial); err != nil {
+		t.Fatalf("List namespace metadata returned error: %v", err)
+	}
+	if len(partial.Items) != 1 || partial.Items[0].Name != "team-a" {
+		t.Errorf("Expected only namespace metadata for team-a, got %d items", len(partial.Items))
+	}
+}
+
+func TestNamespaceScopedClient_Get(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = storagev1.AddToScheme(scheme)
+
+	objs := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-0"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "s", Namespace: "team-a"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "s", Namespace: "team-b"}},
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "standard"}},
+	}
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion, storagev1.SchemeGroupVersion})
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Secret"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Node"), meta.RESTScopeRoot)
+	mapper.Add(storagev1.SchemeGroupVersion.WithKind("StorageClass"), meta.RESTScopeRoot)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(objs...).Build()
+	c := NewNamespaceScopedClient(fakeClient, "team-a")
+	ctx := context.Background()
+
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "team-a", Name: "s"}, &corev1.Secret{}); err != nil {
+		t.Errorf("Expected the team-a secret, got error: %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "team-b", Name: "s"}, &corev1.Secret{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected NotFound for the team-b secret, got %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKey{Name: "team-a"}, &corev1.Namespace{}); err != nil {
+		t.Errorf("Expected namespace team-a, got error: %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKey{Name: "team-b"}, &corev1.Namespace{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected NotFound for namespace team-b, got %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKey{Name: "worker-0"}, &corev1.Node{}); err != nil {
+		t.Errorf("Expected nodes to be readable, got error: %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKey{Name: "standard"}, &storagev1.StorageClass{}); !errors.IsForbidden(err) {
+		t.Errorf("Expected Forbidden for a cluster-scoped StorageClass, got %v", err)
+	}
+	if err := c.List(ctx, &storagev1.StorageClassList{}); !errors.IsForbidden(err) {
+		t.Errorf("Expected Forbidden listing StorageClasses, got %v", err)
+	}
+}
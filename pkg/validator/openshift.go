@@ -0,0 +1,51 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OpenShiftValidator is implemented by validators that only apply to OpenShift
+// clusters. The Runner skips them with a single INFO finding when the cluster
+// does not serve the OpenShift APIs.
+type OpenShiftValidator interface {
+	Validator
+
+	// RequiresOpenShift reports whether the validator needs OpenShift APIs.
+	RequiresOpenShift() bool
+}
+
+// clusterVersionGroupKind identifies the ClusterVersion API, which every
+// OpenShift 4 cluster serves.
+var clusterVersionGroupKind = schema.GroupKind{Group: "config.openshift.io", Kind: "ClusterVersion"}
+
+// IsOpenShift reports whether the cluster serves the OpenShift config API.
+// A missing API is not an error; other discovery failures are returned.
+func IsOpenShift(ctx context.Context, c client.Client) (bool, error) {
+	if _, err := c.RESTMapper().RESTMapping(clusterVersionGroupKind, "v1"); err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+)
+
+// openShiftOnlyValidator records whether it was run.
+type openShiftOnlyValidator struct {
+	ran bool
+}
+
+func (v *openShiftOnlyValidator) Name() string            { return "openshiftonly" }
+func (v *openShiftOnlyValidator) Description() string     { return "test validator" }
+func (v *openShiftOnlyValidator) Category() string        { return "Test" }
+func (v *openShiftOnlyValidator) RequiresOpenShift() bool { return true }
+
+func (v *openShiftOnlyValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
+	v.ran = true
+	return nil, nil
+}
+
+func TestIsOpenShift(t *testing.T) {
+	vanilla := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	if ok, err := IsOpenShift(context.Background(), vanilla); ok || err != nil {
+		t.Errorf("expected vanilla cluster to not be OpenShift, got %v, %v", ok, err)
+	}
+
+	scheme := runtime.NewScheme()
+	_ = configv1.Install(scheme)
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(configv1.GroupVersion.WithKind("ClusterVersion"), meta.RESTScopeRoot)
+	openShift := fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).Build()
+	if ok, err := IsOpenShift(context.Background(), openShift); !ok || err != nil {
+		t.Errorf("expected OpenShift cluster, got %v, %v", ok, err)
+	}
+}
+
+func TestRunner_SkipsOpenShiftValidatorsOnVanillaKubernetes(t *testing.T) {
+	v := &openShiftOnlyValidator{}
+	registry := NewRegistry()
+	if err := registry.Register(v); err != nil {
+		t.Fatalf("Register() returned error: %v", err)
+	}
+
+	c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	findings, err := NewRunner(registry, c).RunAll(context.Background(), profiles.GetProfile("production"))
+	if err != nil {
+		t.Fatalf("RunAll() returned error: %v", err)
+	}
+	if v.ran {
+		t.Error("expected OpenShift-only validator to be skipped")
+	}
+	if len(findings) != 1 || findings[0].ID != "openshiftonly-not-openshift" || findings[0].Status != assessmentv1alpha1.FindingStatusInfo {
+		t.Errorf("expected a single INFO skip finding, got %+v", findings)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PodPageSize is the number of pods requested per page by ForEachPod.
+const PodPageSize = 500
+
+// CountPageSize is the number of objects requested per page by CountObjects.
+const CountPageSize = 500
+
+// apiReaderContextKey is the context key for the API reader.
+type apiReaderContextKey struct{}
+
+// WithAPIReader returns a copy of ctx carrying reader, a client that reads
+// directly from the API server. ForEachPod and CountObjects page their lists
+// through it; the manager's client reads from its informer cache, which does
+// not support paging.
+func WithAPIReader(ctx context.Context, reader client.Reader) context.Context {
+	return context.WithValue(ctx, apiReaderContextKey{}, reader)
+}
+
+// APIReaderFromContext returns the API reader carried by ctx, or nil.
+func APIReaderFromContext(ctx context.Context) client.Reader {
+	reader, _ := ctx.Value(apiReaderContextKey{}).(client.Reader)
+	return reader
+}
+
+// ForEachPod calls fn for each pod. With an API reader in ctx pods are listed
+// in pages of PodPageSize, so that large clusters are not loaded into memory
+// in a single response; otherwise they are listed at once through c. When ctx
+// carries a ResourceCache and no options are given, the cached pods are used
+// instead.
+func ForEachPod(ctx context.Context, c client.Client, fn func(pod *corev1.Pod), opts ...client.ListOption) error {
+	if cache := ResourceCacheFromContext(ctx); cache != nil && len(opts) == 0 {
+		pods, err := cache.Pods(ctx)
+		if err != nil {
+			return err
+		}
+		for i := range pods {
+			fn(&pods[i])
+		}
+		return nil
+	}
+	return forEachPodPage(ctx, c, fn, opts...)
+}
+
+// forEachPodPage lists pods page by page through the API reader in ctx, or
+// at once through c without one.
+func forEachPodPage(ctx context.Context, c client.Client, fn func(pod *corev1.Pod), opts ...client.ListOption) error {
+	reader := APIReaderFromContext(ctx)
+	if reader == nil {
+		pods := &corev1.PodList{}
+		if err := c.List(ctx, pods, opts...); err != nil {
+			return err
+		}
+		for i := range pods.Items {
+			fn(&pods.Items[i])
+		}
+		return nil
+	}
+
+	continueToken := ""
+	for {
+		pods := &corev1.PodList{}
+		pageOpts := append([]client.ListOption{client.Limit(PodPageSize), client.Continue(continueToken)}, opts...)
+		if err := reader.List(ctx, pods, pageOpts...); err != nil {
+			return err
+		}
+		for i := range pods.Items {
+			fn(&pods.Items[i])
+		}
+		continueToken = pods.Continue
+		if continueToken == "" {
+			return nil
+		}
+	}
+}
+
+// CountObjects returns the number of objects of kind gvk in the cluster. It
+// lists metadata only. With an API reader in ctx it lists page by page and
+// stops early when the API server reports how many objects remain; otherwise
+// it lists at once through c.
+func CountObjects(ctx context.Context, c client.Client, gvk schema.GroupVersionKind) (int, error) {
+	listKind := gvk.GroupVersion().WithKind(gvk.Kind + "List")
+	reader := APIReaderFromContext(ctx)
+	if reader == nil {
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(listKind)
+		if err := c.List(ctx, list); err != nil {
+			return 0, err
+		}
+		return len(list.Items), nil
+	}
+
+	count := 0
+	continueToken := ""
+	for {
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(listKind)
+		if err := reader.List(ctx, list, client.Limit(CountPageSize), client.Continue(continueToken)); err != nil {
+			return 0, err
+		}
+		count += len(list.Items)
+		if remaining := list.RemainingItemCount; remaining != nil {
+			return count + int(*remaining), nil
+		}
+		continueToken = list.Continue
+		if continueToken == "" {
+			return count, nil
+		}
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// podAPIServer serves count pods at /api/v1/pods, enough for an informer to
+// list and watch them, whole or as metadata.
+func podAPIServer(t *testing.T, count int) *httptest.Server {
+	t.Helper()
+	metadata := func(i int) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: fmt.Sprintf("pod-%d", i), Namespace: "default", ResourceVersion: "1"}
+	}
+	object := func(req *http.Request, i int) interface{} {
+		if strings.Contains(req.Header.Get("Accept"), "as=PartialObjectMetadata") {
+			return &metav1.PartialObjectMetadata{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "meta.k8s.io/v1", Kind: "PartialObjectMetadata"},
+				ObjectMeta: metadata(i),
+			}
+		}
+		return &corev1.Pod{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"}, ObjectMeta: metadata(i)}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/pods" {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		query := req.URL.Query()
+		if query.Get("watch") != "true" {
+			kind, apiVersion := "PodList", "v1"
+			if strings.Contains(req.Header.Get("Accept"), "as=PartialObjectMetadataList") {
+				kind, apiVersion = "PartialObjectMetadataList", "meta.k8s.io/v1"
+			}
+			items := make([]interface{}, count)
+			for i := range items {
+				items[i] = object(req, i)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"kind": kind, "apiVersion": apiVersion,
+				"metadata": map[string]string{"resourceVersion": "1"},
+				"items":    items,
+			})
+			return
+		}
+
+		encoder := json.NewEncoder(w)
+		if query.Get("sendInitialEvents") == "true" {
+			for i := 0; i < count; i++ {
+				_ = encoder.Encode(map[string]interface{}{"type": "ADDED", "object": object(req, i)})
+			}
+			bookmark := object(req, count)
+			if pod, ok := bookmark.(*corev1.Pod); ok {
+				pod.Annotations = map[string]string{metav1.InitialEventsAnnotationKey: "true"}
+			} else {
+				bookmark.(*metav1.PartialObjectMetadata).Annotations = map[string]string{metav1.InitialEventsAnnotationKey: "true"}
+			}
+			_ = encoder.Encode(map[string]interface{}{"type": "BOOKMARK", "object": bookmark})
+		}
+		w.(http.Flusher).Flush()
+		<-req.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// cachedPodClient returns a client that reads pods from an informer cache of
+// the pods served by server, like the manager's client.
+func cachedPodClient(t *testing.T, server *httptest.Server) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = metav1.AddMetaToScheme(scheme)
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion})
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), meta.RESTScopeNamespace)
+
+	cfg := &rest.Config{Host: server.URL}
+	informers, err := cache.New(cfg, cache.Options{Scheme: scheme, Mapper: mapper, HTTPClient: server.Client()})
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = informers.Start(ctx) }()
+	if !informers.WaitForCacheSync(ctx) {
+		t.Fatal("cache did not start")
+	}
+
+	c, err := client.New(cfg, client.Options{
+		Scheme:     scheme,
+		Mapper:     mapper,
+		HTTPClient: server.Client(),
+		Cache:      &client.CacheOptions{Reader: informers},
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return c
+}
+
+func TestForEachPod_CacheBackedClient(t *testing.T) {
+	c := cachedPodClient(t, podAPIServer(t, PodPageSize+100))
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	seen := 0
+	if err := ForEachPod(ctx, c, func(*corev1.Pod) { seen++ }); err != nil {
+		t.Fatalf("ForEachPod returned error: %v", err)
+	}
+	if seen != PodPageSize+100 {
+		t.Errorf("expected %d pods, got %d", PodPageSize+100, seen)
+	}
+
+	pods, err := ListPods(WithResourceCache(ctx, NewResourceCache(c)), c)
+	if err != nil {
+		t.Fatalf("ListPods returned error: %v", err)
+	}
+	if len(pods) != PodPageSize+100 {
+		t.Errorf("expected %d cached pods, got %d", PodPageSize+100, len(pods))
+	}
+
+	count, err := CountObjects(ctx, c, corev1.SchemeGroupVersion.WithKind("Pod"))
+	if err != nil {
+		t.Fatalf("CountObjects returned error: %v", err)
+	}
+	if count != PodPageSize+100 {
+		t.Errorf("expected %d pods counted, got %d", PodPageSize+100, count)
+	}
+}
+
+// pagingReader serves pods one page per List call, as the API server does.
+type pagingReader struct {
+	client.Reader
+	total int
+	calls int
+}
+
+func (r *pagingReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	r.calls++
+	listOpts := (&client.ListOptions{}).ApplyOptions(opts)
+	start := 0
+	if listOpts.Continue != "" {
+		_, _ = fmt.Sscanf(listOpts.Continue, "%d", &start)
+	}
+	end := min(start+int(listOpts.Limit), r.total)
+	pods := list.(*corev1.PodList)
+	for i := start; i < end; i++ {
+		pods.Items = append(pods.Items, corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pod-%d", i)}})
+	}
+	if end < r.total {
+		pods.Continue = fmt.Sprintf("%d", end)
+	}
+	return nil
+}
+
+func TestForEachPod_PagesThroughAPIReader(t *testing.T) {
+	reader := &pagingReader{total: 2*PodPageSize + 1}
+	ctx := WithAPIReader(context.Background(), reader)
+	c := fake.NewClientBuilder().Build()
+
+	seen := 0
+	if err := ForEachPod(ctx, c, func(*corev1.Pod) { seen++ }); err != nil {
+		t.Fatalf("ForEachPod returned error: %v", err)
+	}
+	if seen != reader.total {
+		t.Errorf("expected %d pods, got %d", reader.total, seen)
+	}
+	if reader.calls != 3 {
+		t.Errorf("expected 3 pages, got %d", reader.calls)
+	}
+}
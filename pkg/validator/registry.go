@@ -19,6 +19,7 @@ package validator
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
@@ -89,8 +90,32 @@ func (r *Registry) Names() []string {
 
 // Runner executes validators and collects findings.
 type Runner struct {
-	registry *Registry
-	client   client.Client
+	registry        *Registry
+	client          client.Client
+	validatorConfig map[string]map[string]string
+	scope           string
+	includeEvidence bool
+	labelSelector   map[string]string
+	expectedState   *ExpectedState
+	metricsQuerier  MetricsQuerier
+	apiReader       client.Reader
+	sharedCache     *ResourceCache
+
+	// incremental enables skipping watching validators whose inputs are
+	// unchanged since the previous run; see SetIncremental.
+	incremental      bool
+	salt             string
+	prevFingerprints map[string]string
+	prevFindings     []assessmentv1alpha1.Finding
+
+	// executed, failed, errors, skipped, fingerprints and resourceCache
+	// record the outcome of the last Run call.
+	executed      []string
+	failed        []string
+	errors        map[string]error
+	skipped       []string
+	fingerprints  map[string]string
+	resourceCache *ResourceCache
 }
 
 // NewRunner creates a new validator runner.
@@ -101,6 +126,72 @@ func NewRunner(registry *Registry, client client.Client) *Runner {
 	}
 }
 
+// SetValidatorConfig sets per-validator overrides, keyed by validator name.
+// Each validator receives its own overrides through the context passed to Validate.
+func (r *Runner) SetValidatorConfig(config map[string]map[string]string) {
+	r.validatorConfig = config
+}
+
+// SetScope restricts Run to the validators of the given scope (ScopeCluster,
+// ScopeWorkloads or ScopeAll). An empty scope is treated as ScopeAll.
+func (r *Runner) SetScope(scope string) {
+	r.scope = scope
+}
+
+// SetIncludeEvidence makes Run ask validators to attach evidence to their
+// findings; see Evidence.
+func (r *Runner) SetIncludeEvidence(include bool) {
+	r.includeEvidence = include
+}
+
+// SetResourceLabelSelector makes Run restrict validators to resources carrying
+// all of the given labels; see Selected.
+func (r *Runner) SetResourceLabelSelector(selector map[string]string) {
+	r.labelSelector = selector
+}
+
+// SetResourceCache makes Run list shared resources through cache, such as
+// the cache of an earlier run of the same assessment, instead of a new one.
+func (r *Runner) SetResourceCache(cache *ResourceCache) {
+	r.sharedCache = cache
+}
+
+// ResourceCache returns the resource cache of the last Run call, for later
+// runs to share through SetResourceCache.
+func (r *Runner) ResourceCache() *ResourceCache {
+	return r.resourceCache
+}
+
+// SetExpectedState makes Run pass the expected state to validators; see
+// ExpectedStateFromContext.
+func (r *Runner) SetExpectedState(state *ExpectedState) {
+	r.expectedState = state
+}
+
+// SetMetricsQuerier makes Run pass the metrics querier to validators, which
+// enables their usage-based checks; see MetricsQuerierFromContext.
+func (r *Runner) SetMetricsQuerier(querier MetricsQuerier) {
+	r.metricsQuerier = querier
+}
+
+// SetAPIReader makes Run page large lists through reader, which reads directly
+// from the API server; see WithAPIReader. It must not be set when the Runner's
+// client restricts what validators can read, as reader bypasses it.
+func (r *Runner) SetAPIReader(reader client.Reader) {
+	r.apiReader = reader
+}
+
+// SetIncremental makes Run skip any WatchingValidator whose watched resources
+// fingerprint matches prevFingerprints, carrying forward its entries from
+// prevFindings instead. salt is mixed into every fingerprint so that changes
+// to the assessment itself, such as its generation, force a full run.
+func (r *Runner) SetIncremental(salt string, prevFingerprints map[string]string, prevFindings []assessmentv1alpha1.Finding) {
+	r.incremental = true
+	r.salt = salt
+	r.prevFingerprints = prevFingerprints
+	r.prevFindings = prevFindings
+}
+
 // RunAll executes all registered validators.
 func (r *Runner) RunAll(ctx context.Context, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
 	return r.Run(ctx, profile, nil)
@@ -110,11 +201,19 @@ func (r *Runner) RunAll(ctx context.Context, profile profiles.Profile) ([]assess
 func (r *Runner) Run(ctx context.Context, profile profiles.Profile, validatorNames []string) ([]assessmentv1alpha1.Finding, error) {
 	logger := log.FromContext(ctx)
 
+	if len(validatorNames) == 0 {
+		validatorNames = ScopeValidators(r.scope)
+	}
+
 	var validators []Validator
 	if len(validatorNames) == 0 {
 		validators = r.registry.List()
 	} else {
 		for _, name := range validatorNames {
+			if !inScope(r.scope, name) {
+				logger.Info("Validator outside of assessment scope, skipping", "validator", name, "scope", r.scope)
+				continue
+			}
 			v, ok := r.registry.Get(name)
 			if !ok {
 				logger.Info("Validator not found, skipping", "validator", name)
@@ -125,12 +224,80 @@ func (r *Runner) Run(ctx context.Context, profile profiles.Profile, validatorNam
 	}
 
 	var allFindings []assessmentv1alpha1.Finding
+	r.executed, r.failed, r.skipped = nil, nil, nil
+	r.errors = make(map[string]error)
+	r.fingerprints = nil
+
+	// Validators share one resource cache for the duration of this run, or
+	// the one set with SetResourceCache.
+	r.resourceCache = r.sharedCache
+	if r.resourceCache == nil {
+		r.resourceCache = NewResourceCache(r.client)
+	}
+	ctx = WithResourceCache(ctx, r.resourceCache)
+	if r.includeEvidence {
+		ctx = WithEvidence(ctx)
+	}
+	ctx = WithResourceLabelSelector(ctx, r.labelSelector)
+	if r.expectedState != nil {
+		ctx = WithExpectedState(ctx, r.expectedState)
+	}
+	if r.metricsQuerier != nil {
+		ctx = WithMetricsQuerier(ctx, r.metricsQuerier)
+	}
+	if r.apiReader != nil {
+		ctx = WithAPIReader(ctx, r.apiReader)
+	}
+
+	openShift := r.detectOpenShift(ctx, validators)
 
 	for _, v := range validators {
+		if ov, ok := v.(OpenShiftValidator); ok && ov.RequiresOpenShift() && !openShift {
+			logger.Info("Skipping validator on non-OpenShift cluster", "validator", v.Name())
+			allFindings = append(allFindings, assessmentv1alpha1.Finding{
+				ID:          fmt.Sprintf("%s-not-openshift", v.Name()),
+				Validator:   v.Name(),
+				Category:    v.Category(),
+				Status:      assessmentv1alpha1.FindingStatusInfo,
+				Title:       "Not an OpenShift Cluster, Skipping",
+				Description: fmt.Sprintf("The %s validator requires OpenShift APIs that this cluster does not serve, so it was skipped.", v.Name()),
+			})
+			continue
+		}
+
+		fingerprint := r.fingerprint(ctx, v, profile)
+		if fingerprint != "" && fingerprint == r.prevFingerprints[v.Name()] {
+			logger.Info("Inputs unchanged, reusing previous findings", "validator", v.Name())
+			r.skipped = append(r.skipped, v.Name())
+			r.recordFingerprint(v.Name(), fingerprint)
+			allFindings = append(allFindings, findingsFor(r.prevFindings, v.Name())...)
+			continue
+		}
+
 		logger.Info("Running validator", "validator", v.Name(), "category", v.Category())
 
-		findings, err := v.Validate(ctx, r.client, profile)
+		validatorCtx := ctx
+		if overrides := r.validatorConfig[v.Name()]; len(overrides) > 0 {
+			if unknown := unknownConfigKeys(v, overrides); len(unknown) > 0 {
+				logger.Info("Ignoring unknown validator config keys", "validator", v.Name(), "keys", unknown)
+				allFindings = append(allFindings, assessmentv1alpha1.Finding{
+					ID:             fmt.Sprintf("%s-config-unknown-keys", v.Name()),
+					Validator:      v.Name(),
+					Category:       v.Category(),
+					Status:         assessmentv1alpha1.FindingStatusInfo,
+					Title:          "Unknown Validator Configuration Keys Ignored",
+					Description:    fmt.Sprintf("The following validatorConfig keys are not recognized by the %s validator and were ignored: %s", v.Name(), strings.Join(unknown, ", ")),
+					Recommendation: "Check spec.validatorConfig for typos or remove keys that are not supported.",
+				})
+			}
+			validatorCtx = WithConfig(ctx, overrides)
+		}
+
+		r.executed = append(r.executed, v.Name())
+		findings, err := v.Validate(validatorCtx, r.client, profile)
 		if err != nil {
+			r.failed = append(r.failed, v.Name())
+			r.errors[v.Name()] = err
 			// Log error but continue with other validators
 			logger.Error(err, "Validator failed", "validator", v.Name())
 			// Add a finding for the failed validator
@@ -146,13 +313,108 @@ func (r *Runner) Run(ctx context.Context, profile profiles.Profile, validatorNam
 			continue
 		}
 
+		r.recordFingerprint(v.Name(), fingerprint)
 		allFindings = append(allFindings, findings...)
 		logger.Info("Validator completed", "validator", v.Name(), "findings", len(findings))
 	}
 
+	// Findings are definitive unless their validator marked them otherwise
+	for i := range allFindings {
+		if allFindings[i].Confidence == "" {
+			allFindings[i].Confidence = assessmentv1alpha1.FindingConfidenceHigh
+		}
+	}
+
 	return allFindings, nil
 }
 
+// Executed returns the names of the validators executed by the last Run,
+// excluding validators that were skipped.
+func (r *Runner) Executed() []string {
+	return r.executed
+}
+
+// Failed returns the names of the validators that returned an error during
+// the last Run.
+func (r *Runner) Failed() []string {
+	return r.failed
+}
+
+// Errors returns the errors of the validators that failed during the last
+// Run, keyed by validator name.
+func (r *Runner) Errors() map[string]error {
+	return r.errors
+}
+
+// Skipped returns the names of the validators whose previous findings were
+// reused by the last Run because their inputs were unchanged.
+func (r *Runner) Skipped() []string {
+	return r.skipped
+}
+
+// Fingerprints returns the input fingerprints of the watching validators that
+// completed or were skipped during the last Run, keyed by validator name.
+// Pass them to SetIncremental on the next run.
+func (r *Runner) Fingerprints() map[string]string {
+	return r.fingerprints
+}
+
+// fingerprint returns the input fingerprint of v for incremental runs, or an
+// empty string if v must always run.
+func (r *Runner) fingerprint(ctx context.Context, v Validator, profile profiles.Profile) string {
+	wv, ok := v.(WatchingValidator)
+	if !r.incremental || !ok {
+		return ""
+	}
+
+	salt := fmt.Sprintf("%s/%s/%v", r.salt, profile.Name, r.validatorConfig[v.Name()])
+	fingerprint, err := Fingerprint(ctx, r.client, wv.WatchedResources(), salt)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to fingerprint validator inputs, running validator", "validator", v.Name())
+		return ""
+	}
+	return fingerprint
+}
+
+// recordFingerprint stores a non-empty fingerprint for the validator.
+func (r *Runner) recordFingerprint(name, fingerprint string) {
+	if fingerprint == "" {
+		return
+	}
+	if r.fingerprints == nil {
+		r.fingerprints = make(map[string]string)
+	}
+	r.fingerprints[name] = fingerprint
+}
+
+// findingsFor returns the findings produced by the named validator.
+func findingsFor(findings []assessmentv1alpha1.Finding, name string) []assessmentv1alpha1.Finding {
+	var matched []assessmentv1alpha1.Finding
+	for _, f := range findings {
+		if f.Validator == name {
+			matched = append(matched, f)
+		}
+	}
+	return matched
+}
+
+// detectOpenShift reports whether OpenShift-only validators can run. Detection
+// is skipped when none of the validators need it, and a failed lookup is
+// treated as OpenShift so that validators report their own errors.
+func (r *Runner) detectOpenShift(ctx context.Context, validators []Validator) bool {
+	for _, v := range validators {
+		if ov, ok := v.(OpenShiftValidator); ok && ov.RequiresOpenShift() {
+			openShift, err := IsOpenShift(ctx, r.client)
+			if err != nil {
+				log.FromContext(ctx).Error(err, "Failed to detect OpenShift APIs, assuming OpenShift")
+				return true
+			}
+			return openShift
+		}
+	}
+	return true
+}
+
 // defaultRegistry is the global validator registry.
 var defaultRegistry = NewRegistry()
 
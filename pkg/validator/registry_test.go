@@ -0,0 +1,102 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+)
+
+// failingValidator always returns an error.
+type failingValidator struct {
+	name string
+}
+
+func (v *failingValidator) Name() string        { return v.name }
+func (v *failingValidator) Description() string { return "test validator" }
+func (v *failingValidator) Category() string    { return "Test" }
+
+func (v *failingValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
+	return nil, errors.New("forbidden")
+}
+
+func TestRunner_ValidatorErrors(t *testing.T) {
+	registry := NewRegistry()
+	for _, name := range []string{"critical", "optional"} {
+		if err := registry.Register(&failingValidator{name: name}); err != nil {
+			t.Fatalf("Register() returned error: %v", err)
+		}
+	}
+
+	runner := NewRunner(registry, nil)
+	findings, err := runner.RunAll(context.Background(), profiles.GetProfile("production"))
+	if err != nil {
+		t.Fatalf("RunAll() returned error: %v", err)
+	}
+	for _, f := range findings {
+		if f.Status != assessmentv1alpha1.FindingStatusFail {
+			t.Errorf("Expected validator error %s to FAIL, got %s", f.ID, f.Status)
+		}
+	}
+	if len(findings) != 2 {
+		t.Errorf("Expected an error finding per validator, got %d", len(findings))
+	}
+	if errs := runner.Errors(); len(errs) != 2 || errs["critical"] == nil {
+		t.Errorf("Expected errors for both validators, got %v", errs)
+	}
+}
+
+// staticValidator returns a fixed set of findings.
+type staticValidator struct {
+	findings []assessmentv1alpha1.Finding
+}
+
+func (v *staticValidator) Name() string        { return "static" }
+func (v *staticValidator) Description() string { return "test validator" }
+func (v *staticValidator) Category() string    { return "Test" }
+
+func (v *staticValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
+	return v.findings, nil
+}
+
+func TestRunner_DefaultsConfidence(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Register(&staticValidator{findings: []assessmentv1alpha1.Finding{
+		{ID: "definitive", Status: assessmentv1alpha1.FindingStatusFail},
+		{ID: "heuristic", Status: assessmentv1alpha1.FindingStatusInfo, Confidence: assessmentv1alpha1.FindingConfidenceLow},
+	}}); err != nil {
+		t.Fatalf("Register() returned error: %v", err)
+	}
+
+	findings, err := NewRunner(registry, nil).RunAll(context.Background(), profiles.GetProfile("production"))
+	if err != nil {
+		t.Fatalf("RunAll() returned error: %v", err)
+	}
+	got := make(map[string]assessmentv1alpha1.FindingConfidence)
+	for _, f := range findings {
+		got[f.ID] = f.Confidence
+	}
+	if got["definitive"] != assessmentv1alpha1.FindingConfidenceHigh || got["heuristic"] != assessmentv1alpha1.FindingConfidenceLow {
+		t.Errorf("Expected unset confidence to default to high and set confidence to be kept, got %v", got)
+	}
+}
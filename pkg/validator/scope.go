@@ -0,0 +1,88 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+// Assessment scopes select a subset of validators for a distinct audience.
+const (
+	// ScopeCluster runs only the platform validators, for platform teams.
+	ScopeCluster = "cluster"
+
+	// ScopeWorkloads runs only the workload validators, for application
+	// teams.
+	ScopeWorkloads = "workloads"
+
+	// ScopeAll runs every validator.
+	ScopeAll = "all"
+)
+
+// ClusterScopedValidators lists the validators that inspect singleton,
+// operator and platform configuration rather than user workloads.
+var ClusterScopedValidators = []string{
+	"version",
+	"apiserver",
+	"operators",
+	"networking",
+	"machineconfig",
+	"etcdbackup",
+	"monitoring",
+	"nodes",
+	"security",
+	"storage",
+	"certificates",
+	"imageregistry",
+	"logging",
+	"compliance",
+}
+
+// WorkloadValidators lists the validators that inspect user workloads: the
+// namespace-scoped validators and those that need to read across namespaces.
+// Every registered validator belongs to either this list or
+// ClusterScopedValidators.
+var WorkloadValidators = []string{
+	"resourcequotas",
+	"networkpolicyaudit",
+	"workloadhealth",
+	"costoptimization",
+	"deprecation",
+}
+
+// ScopeValidators returns the validators belonging to scope, or nil if the
+// scope places no restriction on the validators to run.
+func ScopeValidators(scope string) []string {
+	switch scope {
+	case ScopeCluster:
+		return ClusterScopedValidators
+	case ScopeWorkloads:
+		return WorkloadValidators
+	default:
+		return nil
+	}
+}
+
+// inScope reports whether the named validator belongs to scope.
+func inScope(scope, name string) bool {
+	allowed := ScopeValidators(scope)
+	if allowed == nil {
+		return true
+	}
+	for _, n := range allowed {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+)
+
+// namedValidator is a no-op validator with a configurable name.
+type namedValidator struct {
+	name string
+}
+
+func (v *namedValidator) Name() string        { return v.name }
+func (v *namedValidator) Description() string { return "test validator" }
+func (v *namedValidator) Category() string    { return "Test" }
+
+func (v *namedValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
+	return nil, nil
+}
+
+func TestRunner_Scope(t *testing.T) {
+	registry := NewRegistry()
+	for _, name := range []string{"version", "apiserver", "nodes", "workloadhealth", "resourcequotas"} {
+		if err := registry.Register(&namedValidator{name: name}); err != nil {
+			t.Fatalf("Register() returned error: %v", err)
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+
+	tests := []struct {
+		scope     string
+		requested []string
+		want      []string
+	}{
+		{scope: "", want: []string{"apiserver", "nodes", "resourcequotas", "version", "workloadhealth"}},
+		{scope: ScopeAll, want: []string{"apiserver", "nodes", "resourcequotas", "version", "workloadhealth"}},
+		{scope: ScopeCluster, want: []string{"apiserver", "nodes", "version"}},
+		{scope: ScopeWorkloads, want: []string{"resourcequotas", "workloadhealth"}},
+		{scope: ScopeCluster, requested: []string{"version", "workloadhealth"}, want: []string{"version"}},
+	}
+	for _, tt := range tests {
+		runner := NewRunner(registry, c)
+		runner.SetScope(tt.scope)
+		if _, err := runner.Run(context.Background(), profiles.GetProfile("production"), tt.requested); err != nil {
+			t.Fatalf("Run() returned error: %v", err)
+		}
+
+		got := append([]string(nil), runner.Executed()...)
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("scope %q with %v: executed %v, want %v", tt.scope, tt.requested, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// selectorContextKey is the context key of the resource label selector.
+type selectorContextKey struct{}
+
+// WithResourceLabelSelector returns a copy of ctx that restricts validators
+// to resources carrying all of the given labels. An empty selector selects
+// everything.
+func WithResourceLabelSelector(ctx context.Context, selector map[string]string) context.Context {
+	if len(selector) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, selectorContextKey{}, selector)
+}
+
+// ResourceLabelSelector returns the resource label selector stored in ctx,
+// or nil if none is set.
+func ResourceLabelSelector(ctx context.Context) map[string]string {
+	selector, _ := ctx.Value(selectorContextKey{}).(map[string]string)
+	return selector
+}
+
+// Selected reports whether obj matches the resource label selector in ctx.
+// Validators list every object, as the shared ListPods and ListDeployments
+// helpers do, and skip the assessed objects that are not selected; objects
+// they only refer to, such as the pods mounting a PVC, are not filtered.
+func Selected(ctx context.Context, obj metav1.Object) bool {
+	selector := ResourceLabelSelector(ctx)
+	if len(selector) == 0 {
+		return true
+	}
+	return labels.SelectorFromSet(selector).Matches(labels.Set(obj.GetLabels()))
+}
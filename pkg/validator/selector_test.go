@@ -0,0 +1,49 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSelected(t *testing.T) {
+	labeled := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+		"app.kubernetes.io/part-of": "payments",
+		"app":                       "ledger",
+	}}}
+	other := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "catalog"}}}
+
+	ctx := context.Background()
+	if !Selected(ctx, labeled) || !Selected(ctx, other) {
+		t.Error("expected every object to be selected without a selector")
+	}
+	if ResourceLabelSelector(WithResourceLabelSelector(ctx, map[string]string{})) != nil {
+		t.Error("expected an empty selector to leave the context unchanged")
+	}
+
+	ctx = WithResourceLabelSelector(ctx, map[string]string{"app.kubernetes.io/part-of": "payments"})
+	if !Selected(ctx, labeled) {
+		t.Error("expected the labeled pod to be selected")
+	}
+	if Selected(ctx, other) {
+		t.Error("expected the unlabeled pod not to be selected")
+	}
+}
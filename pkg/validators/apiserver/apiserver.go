@@ -19,8 +19,16 @@ package apiserver
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
 	configv1 "github.com/openshift/api/config/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
@@ -56,6 +64,11 @@ func (v *APIServerValidator) Category() string {
 	return validatorCategory
 }
 
+// RequiresOpenShift reports that this validator needs OpenShift APIs.
+func (v *APIServerValidator) RequiresOpenShift() bool {
+	return true
+}
+
 // Validate performs API server and etcd checks.
 func (v *APIServerValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
 	var findings []assessmentv1alpha1.Finding
@@ -72,6 +85,12 @@ func (v *APIServerValidator) Validate(ctx context.Context, c client.Client, prof
 	// Check 4: Audit logging configuration
 	findings = append(findings, v.checkAuditPolicy(ctx, c)...)
 
+	// Check 5: Object counts stored in etcd
+	findings = append(findings, v.checkObjectCounts(ctx, c, profile)...)
+
+	// Check 6: Admission webhooks that can block the API
+	findings = append(findings, v.checkAdmissionWebhooks(ctx, c)...)
+
 	return findings, nil
 }
 
@@ -325,3 +344,287 @@ func (v *APIServerValidator) checkAuditPolicy(ctx context.Context, c client.Clie
 
 	return findings
 }
+
+// objectCount is the number of objects of one kind and the profile's limit.
+type objectCount struct {
+	kind  string
+	count int
+	limit int
+}
+
+// checkObjectCounts counts high-cardinality object types cluster-wide and
+// warns about those above the profile's limits. Large object counts slow
+// down etcd compaction, defragmentation and every full list, including
+// those of controllers starting up.
+func (v *APIServerValidator) checkObjectCounts(ctx context.Context, c client.Client, profile profiles.Profile) []assessmentv1alpha1.Finding {
+	kinds := []objectCount{
+		{kind: "Secret", limit: profile.Thresholds.MaxSecrets},
+		{kind: "ConfigMap", limit: profile.Thresholds.MaxConfigMaps},
+		{kind: "Event", limit: profile.Thresholds.MaxEvents},
+		{kind: "Pod", limit: profile.Thresholds.MaxPods},
+	}
+
+	var counts []objectCount
+	var failed []string
+	for _, k := range kinds {
+		count, err := validator.CountObjects(ctx, c, corev1.SchemeGroupVersion.WithKind(k.kind))
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s (%v)", k.kind, err))
+			continue
+		}
+		k.count = count
+		counts = append(counts, k)
+	}
+
+	var findings []assessmentv1alpha1.Finding
+	if len(failed) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "apiserver-object-count-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Count Some Objects",
+			Description: fmt.Sprintf("Failed to count: %s", strings.Join(failed, "; ")),
+		})
+	}
+	if len(counts) == 0 {
+		return findings
+	}
+
+	sort.SliceStable(counts, func(i, j int) bool { return counts[i].count > counts[j].count })
+	var summary, high []string
+	for _, k := range counts {
+		summary = append(summary, fmt.Sprintf("%ss: %d", k.kind, k.count))
+		if k.limit > 0 && k.count > k.limit {
+			high = append(high, fmt.Sprintf("%ss: %d (limit %d)", k.kind, k.count, k.limit))
+		}
+	}
+
+	if len(high) > 0 {
+		return append(findings, assessmentv1alpha1.Finding{
+			ID:             "apiserver-object-count-high",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "High Object Counts in etcd",
+			Description:    fmt.Sprintf("%d object type(s) exceed the %s profile's limits: %s. Object counts: %s.", len(high), profile.Name, strings.Join(high, ", "), strings.Join(summary, ", ")),
+			Impact:         "Large numbers of objects grow the etcd database, slow down compaction, defragmentation and backups, and make every full list more expensive, which delays controllers and can overload the API server when they restart.",
+			Recommendation: "Find the namespaces holding most of these objects and clean up leftovers, such as Secrets and ConfigMaps of old deployments and Helm releases, completed pods and Jobs without a TTL, or noisy Events.",
+			References: []string{
+				"https://docs.openshift.com/container-platform/latest/scalability_and_performance/planning-your-environment-according-to-object-maximums.html",
+				"https://docs.openshift.com/container-platform/latest/scalability_and_performance/recommended-performance-scale-practices/recommended-etcd-practices.html",
+			},
+		})
+	}
+
+	return append(findings, assessmentv1alpha1.Finding{
+		ID:          "apiserver-object-counts",
+		Validator:   validatorName,
+		Category:    validatorCategory,
+		Status:      assessmentv1alpha1.FindingStatusPass,
+		Title:       "Object Counts Within Limits",
+		Description: fmt.Sprintf("Object counts are within the %s profile's limits: %s.", profile.Name, strings.Join(summary, ", ")),
+	})
+}
+
+// webhook is the part of a validating or mutating admission webhook the
+// webhook checks need.
+type webhook struct {
+	ref               string
+	failurePolicy     admissionregistrationv1.FailurePolicyType
+	service           *admissionregistrationv1.ServiceReference
+	rules             []admissionregistrationv1.RuleWithOperations
+	namespaceSelector *metav1.LabelSelector
+	objectSelector    *metav1.LabelSelector
+}
+
+// checkAdmissionWebhooks flags admission webhooks that can block API requests
+// cluster-wide: webhooks with failurePolicy Fail whose Service has no ready
+// endpoints (FAIL), and webhooks outside the platform that intercept every
+// resource or every core resource in all namespaces (WARN).
+func (v *APIServerValidator) checkAdmissionWebhooks(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	webhooks, err := listWebhooks(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "apiserver-webhook-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Admission Webhooks",
+			Description: fmt.Sprintf("Failed to list webhook configurations: %v", err),
+		}}
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	serviceReady := make(map[string]string)
+	var unavailable, broad []string
+	for _, w := range webhooks {
+		if w.service == nil {
+			continue
+		}
+		service := w.service.Namespace + "/" + w.service.Name
+
+		// A nil failure policy defaults to Fail.
+		if w.failurePolicy != admissionregistrationv1.Ignore {
+			problem, ok := serviceReady[service]
+			if !ok {
+				problem = serviceProblem(ctx, c, w.service)
+				serviceReady[service] = problem
+			}
+			if problem != "" {
+				unavailable = append(unavailable, fmt.Sprintf("%s -> service %s (%s)", w.ref, service, problem))
+			}
+		}
+
+		if !validator.IsPlatformNamespace(w.service.Namespace) && isEmptySelector(w.namespaceSelector) && isEmptySelector(w.objectSelector) {
+			if scope := broadRuleScope(w.rules); scope != "" {
+				broad = append(broad, fmt.Sprintf("%s -> service %s (%s)", w.ref, service, scope))
+			}
+		}
+	}
+
+	var findings []assessmentv1alpha1.Finding
+	if len(unavailable) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "apiserver-webhook-unavailable",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusFail,
+			Title:          "Failing Admission Webhooks Without a Ready Backend",
+			Description:    fmt.Sprintf("%d webhook(s) with failurePolicy Fail point at a Service that cannot serve them: %s", len(unavailable), strings.Join(validator.SampleOf(unavailable), ", ")),
+			Impact:         "The API server rejects every request these webhooks intercept while their backend is down. Webhooks on pods, namespaces or other core resources can block deployments, node updates and even the recovery of the webhook's own pods.",
+			Recommendation: "Restore the webhook's backing pods, or delete the webhook configuration if its operator was removed. Consider failurePolicy Ignore for webhooks that are not security critical, and a namespaceSelector that excludes system namespaces.",
+			References: []string{
+				"https://kubernetes.io/docs/reference/access-authn-authz/extensible-admission-controllers/#failure-policy",
+			},
+		})
+	}
+	if len(broad) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "apiserver-webhook-broad-rules",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
+			Title:          "Admission Webhooks With Broad Rules",
+			Description:    fmt.Sprintf("%d webhook(s) intercept all resources or all core resources in every namespace: %s", len(broad), strings.Join(validator.SampleOf(broad), ", ")),
+			Impact:         "Every matching request, including those of platform components, waits for the webhook. Its latency and availability become those of the whole API, and a webhook that intercepts its own dependencies can deadlock the cluster.",
+			Recommendation: "Limit the rules to the API groups and resources the webhook needs, and add a namespaceSelector or objectSelector that excludes openshift-* and kube-* namespaces.",
+			References: []string{
+				"https://kubernetes.io/docs/reference/access-authn-authz/extensible-admission-controllers/#avoiding-operating-on-the-kube-system-namespace",
+			},
+		})
+	}
+
+	if len(findings) == 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "apiserver-webhooks-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Admission Webhooks Healthy",
+			Description: fmt.Sprintf("All %d admission webhook(s) that fail closed have a ready backend, and none outside the platform intercepts all resources in every namespace.", len(webhooks)),
+		})
+	}
+
+	return findings
+}
+
+// listWebhooks returns the webhooks of all validating and mutating webhook
+// configurations.
+func listWebhooks(ctx context.Context, c client.Client) ([]webhook, error) {
+	var webhooks []webhook
+
+	validating := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	if err := c.List(ctx, validating); err != nil {
+		return nil, err
+	}
+	for _, config := range validating.Items {
+		for _, w := range config.Webhooks {
+			webhooks = append(webhooks, webhook{
+				ref:               fmt.Sprintf("ValidatingWebhookConfiguration %s/%s", config.Name, w.Name),
+				failurePolicy:     failurePolicy(w.FailurePolicy),
+				service:           w.ClientConfig.Service,
+				rules:             w.Rules,
+				namespaceSelector: w.NamespaceSelector,
+				objectSelector:    w.ObjectSelector,
+			})
+		}
+	}
+
+	mutating := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+	if err := c.List(ctx, mutating); err != nil {
+		return nil, err
+	}
+	for _, config := range mutating.Items {
+		for _, w := range config.Webhooks {
+			webhooks = append(webhooks, webhook{
+				ref:               fmt.Sprintf("MutatingWebhookConfiguration %s/%s", config.Name, w.Name),
+				failurePolicy:     failurePolicy(w.FailurePolicy),
+				service:           w.ClientConfig.Service,
+				rules:             w.Rules,
+				namespaceSelector: w.NamespaceSelector,
+				objectSelector:    w.ObjectSelector,
+			})
+		}
+	}
+
+	return webhooks, nil
+}
+
+// failurePolicy returns the effective failure policy, which defaults to Fail.
+func failurePolicy(policy *admissionregistrationv1.FailurePolicyType) admissionregistrationv1.FailurePolicyType {
+	if policy == nil {
+		return admissionregistrationv1.Fail
+	}
+	return *policy
+}
+
+// serviceProblem returns why a webhook Service cannot serve requests, or an
+// empty string if it has at least one ready endpoint.
+func serviceProblem(ctx context.Context, c client.Client, ref *admissionregistrationv1.ServiceReference) string {
+	service := &corev1.Service{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, service); err != nil {
+		if errors.IsNotFound(err) {
+			return "service not found"
+		}
+		return ""
+	}
+
+	endpointSlices := &discoveryv1.EndpointSliceList{}
+	if err := c.List(ctx, endpointSlices, client.InNamespace(ref.Namespace), client.MatchingLabels{discoveryv1.LabelServiceName: ref.Name}); err != nil {
+		return ""
+	}
+	for _, slice := range endpointSlices.Items {
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				return ""
+			}
+		}
+	}
+	return "no ready endpoints"
+}
+
+// broadRuleScope describes the first rule that matches every resource, or
+// every resource of the core API group, or returns an empty string.
+func broadRuleScope(rules []admissionregistrationv1.RuleWithOperations) string {
+	for _, rule := range rules {
+		if !slices.Contains(rule.Resources, "*") && !slices.Contains(rule.Resources, "*/*") {
+			continue
+		}
+		if slices.Contains(rule.APIGroups, "*") {
+			return "all resources"
+		}
+		if slices.Contains(rule.APIGroups, "") {
+			return "all core resources"
+		}
+	}
+	return ""
+}
+
+// isEmptySelector reports whether a webhook selector matches everything.
+func isEmptySelector(selector *metav1.LabelSelector) bool {
+	return selector == nil || (len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0)
+}
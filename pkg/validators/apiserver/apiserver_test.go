@@ -0,0 +1,154 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+)
+
+func TestCheckObjectCounts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	var objs []client.Object
+	for i := 0; i < 4; i++ {
+		objs = append(objs, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("s%d", i), Namespace: "app"}})
+	}
+	objs = append(objs, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "app"}})
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	profile := profiles.GetProfile(string(profiles.ProfileProduction))
+	profile.Thresholds.MaxSecrets = 3
+	profile.Thresholds.MaxConfigMaps = 3
+
+	v := &APIServerValidator{}
+	findings := v.checkObjectCounts(context.Background(), c, profile)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %+v", len(findings), findings)
+	}
+	f := findings[0]
+	if f.ID != "apiserver-object-count-high" || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("unexpected finding %s/%s", f.ID, f.Status)
+	}
+	if !strings.Contains(f.Description, "Secrets: 4 (limit 3)") || strings.Contains(f.Description, "ConfigMaps: 1 (limit") {
+		t.Errorf("unexpected description: %s", f.Description)
+	}
+	if !strings.Contains(f.Description, "Object counts: Secrets: 4, ConfigMaps: 1") {
+		t.Errorf("expected counts sorted by size, got: %s", f.Description)
+	}
+
+	profile.Thresholds.MaxSecrets = 10
+	findings = v.checkObjectCounts(context.Background(), c, profile)
+	if len(findings) != 1 || findings[0].ID != "apiserver-object-counts" || findings[0].Status != assessmentv1alpha1.FindingStatusPass {
+		t.Fatalf("expected a single pass finding, got %+v", findings)
+	}
+}
+
+func TestCheckAdmissionWebhooks(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = admissionregistrationv1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	fail := admissionregistrationv1.Fail
+	ignore := admissionregistrationv1.Ignore
+	ready, notReady := true, false
+	hook := func(name, ns, service string, policy *admissionregistrationv1.FailurePolicyType, groups, resources []string) admissionregistrationv1.ValidatingWebhook {
+		return admissionregistrationv1.ValidatingWebhook{
+			Name:          name,
+			FailurePolicy: policy,
+			ClientConfig: admissionregistrationv1.WebhookClientConfig{
+				Service: &admissionregistrationv1.ServiceReference{Namespace: ns, Name: service},
+			},
+			Rules: []admissionregistrationv1.RuleWithOperations{{
+				Rule: admissionregistrationv1.Rule{APIGroups: groups, APIVersions: []string{"*"}, Resources: resources},
+			}},
+		}
+	}
+	endpoints := func(ns, service string, ready *bool) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta:  metav1.ObjectMeta{Namespace: ns, Name: service + "-abcde", Labels: map[string]string{discoveryv1.LabelServiceName: service}},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"10.128.0.10"}, Conditions: discoveryv1.EndpointConditions{Ready: ready}}},
+		}
+	}
+	service := func(ns, name string) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name}}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{
+				// Down and fails closed.
+				hook("pods.policy.example.com", "policy", "policy-webhook", nil, []string{""}, []string{"pods"}),
+				// Down but ignored on failure.
+				hook("routes.policy.example.com", "policy", "policy-webhook", &ignore, []string{"route.openshift.io"}, []string{"routes"}),
+				// Healthy, but intercepts everything.
+				hook("all.audit.example.com", "audit", "audit-webhook", &fail, []string{"*"}, []string{"*"}),
+				// Missing service.
+				hook("gone.example.com", "removed", "removed-webhook", &fail, []string{"apps"}, []string{"deployments"}),
+				// Platform webhooks may be broad.
+				hook("core.platform.openshift.io", "openshift-platform", "platform", &fail, []string{""}, []string{"*"}),
+			},
+		},
+		service("policy", "policy-webhook"),
+		endpoints("policy", "policy-webhook", &notReady),
+		service("audit", "audit-webhook"),
+		endpoints("audit", "audit-webhook", &ready),
+		service("openshift-platform", "platform"),
+		endpoints("openshift-platform", "platform", nil),
+	).Build()
+
+	findings := (&APIServerValidator{}).checkAdmissionWebhooks(context.Background(), c)
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %+v", len(findings), findings)
+	}
+	unavailable, broad := findings[0], findings[1]
+	if unavailable.ID != "apiserver-webhook-unavailable" || unavailable.Status != assessmentv1alpha1.FindingStatusFail {
+		t.Fatalf("unexpected finding %s/%s", unavailable.ID, unavailable.Status)
+	}
+	for _, want := range []string{
+		"2 webhook(s)",
+		"ValidatingWebhookConfiguration policy/pods.policy.example.com -> service policy/policy-webhook (no ready endpoints)",
+		"ValidatingWebhookConfiguration policy/gone.example.com -> service removed/removed-webhook (service not found)",
+	} {
+		if !strings.Contains(unavailable.Description, want) {
+			t.Errorf("expected %q in %q", want, unavailable.Description)
+		}
+	}
+	if broad.ID != "apiserver-webhook-broad-rules" || broad.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("unexpected finding %s/%s", broad.ID, broad.Status)
+	}
+	if !strings.Contains(broad.Description, "1 webhook(s)") || !strings.Contains(broad.Description, "policy/all.audit.example.com -> service audit/audit-webhook (all resources)") {
+		t.Errorf("unexpected description: %s", broad.Description)
+	}
+}
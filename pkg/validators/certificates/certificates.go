@@ -18,10 +18,20 @@ package certificates
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	configv1 "github.com/openshift/api/config/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
@@ -31,7 +41,7 @@ import (
 
 const (
 	validatorName        = "certificates"
-	validatorDescription = "Validates certificate expiration for critical cluster certificates"
+	validatorDescription = "Validates certificate expiration for critical cluster certificates, CAs and Route and Ingress certificates"
 	validatorCategory    = "Security"
 )
 
@@ -57,6 +67,11 @@ func (v *CertificatesValidator) Category() string {
 	return validatorCategory
 }
 
+// RequiresOpenShift reports that this validator needs OpenShift APIs.
+func (v *CertificatesValidator) RequiresOpenShift() bool {
+	return true
+}
+
 // Validate performs certificate expiration checks.
 func (v *CertificatesValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
 	var findings []assessmentv1alpha1.Finding
@@ -70,7 +85,13 @@ func (v *CertificatesValidator) Validate(ctx context.Context, c client.Client, p
 	// Check ingress certificates
 	findings = append(findings, v.checkIngressCerts(ctx, c)...)
 
-	// Summary finding if all checks pass
+	// Check cluster CA bundles and signers
+	findings = append(findings, v.checkCAExpiry(ctx, c, profile)...)
+
+	// Check service CA rotation
+	findings = append(findings, v.checkServiceCA(ctx, c)...)
+
+	// Summary finding if all cluster certificate checks pass
 	if len(findings) == 0 {
 		findings = append(findings, assessmentv1alpha1.Finding{
 			ID:          "certificates-all-valid",
@@ -82,6 +103,10 @@ func (v *CertificatesValidator) Validate(ctx context.Context, c client.Client, p
 		})
 	}
 
+	// Check application certificates on Routes and Ingresses. They are
+	// reported on their own and do not affect the summary above.
+	findings = append(findings, v.checkRouteCerts(ctx, c, profile)...)
+
 	return findings, nil
 }
 
@@ -117,6 +142,7 @@ func (v *CertificatesValidator) checkRouterCerts(ctx context.Context, c client.C
 			Validator:   validatorName,
 			Category:    validatorCategory,
 			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Confidence:  assessmentv1alpha1.FindingConfidenceLow,
 			Title:       "Custom Router Certificate Configured",
 			Description: "A custom TLS certificate is configured for the default ingress router.",
 		})
@@ -199,7 +225,9 @@ func (v *CertificatesValidator) checkIngressCerts(ctx context.Context, c client.
 						Category:       validatorCategory,
 						Status:         assessmentv1alpha1.FindingStatusFail,
 						Title:          "Expired Certificate",
-						Description:    fmt.Sprintf("Certificate secret %s has expired on %s", secret.Name, expiry),
+						Description:    fmt.Sprintf("Certificate secret %s/%s has expired on %s", secret.Namespace, secret.Name, expiry),
+						Resource:       secret.Name,
+						Namespace:      secret.Namespace,
 						Recommendation: "Renew the certificate immediately.",
 					})
 				} else if expiryTime.Before(warningThreshold) {
@@ -209,7 +237,9 @@ func (v *CertificatesValidator) checkIngressCerts(ctx context.Context, c client.
 						Category:       validatorCategory,
 						Status:         assessmentv1alpha1.FindingStatusWarn,
 						Title:          "Certificate Expiring Soon",
-						Description:    fmt.Sprintf("Certificate secret %s expires on %s", secret.Name, expiry),
+						Description:    fmt.Sprintf("Certificate secret %s/%s expires on %s", secret.Namespace, secret.Name, expiry),
+						Resource:       secret.Name,
+						Namespace:      secret.Namespace,
 						Recommendation: "Plan certificate renewal before expiration.",
 					})
 				}
@@ -219,3 +249,410 @@ func (v *CertificatesValidator) checkIngressCerts(ctx context.Context, c client.
 
 	return findings
 }
+
+// caBundle is a ConfigMap key holding a CA bundle distributed to workloads.
+type caBundle struct {
+	namespace string
+	name      string
+	key       string
+}
+
+// caBundles are the CA bundles every pod trusts. While a CA rotates, a bundle
+// holds both the old and the new CA, so a bundle only stops working when its
+// last CA expires.
+var caBundles = []caBundle{
+	{namespace: "default", name: "kube-root-ca.crt", key: "ca.crt"},
+	{namespace: "default", name: "openshift-service-ca.crt", key: "service-ca.crt"},
+}
+
+// caSignerNamespaces hold the signer secrets of the control plane, such as
+// kube-control-plane-signer and kube-apiserver-to-kubelet-signer.
+var caSignerNamespaces = []string{
+	"openshift-kube-apiserver-operator",
+	"openshift-kube-controller-manager-operator",
+}
+
+// serviceCASecret holds the service CA signing certificate.
+var serviceCASecret = client.ObjectKey{Namespace: "openshift-service-ca", Name: "signing-key"}
+
+// caExpiry is the expiry of a CA bundle or signer.
+type caExpiry struct {
+	source   string
+	notAfter time.Time
+}
+
+// checkCAExpiry checks when the CA bundles distributed to workloads and the
+// control plane signers expire. CAs expiring within the profile's critical
+// window fail, as an expired CA breaks TLS across the cluster.
+func (v *CertificatesValidator) checkCAExpiry(ctx context.Context, c client.Client, profile profiles.Profile) []assessmentv1alpha1.Finding {
+	var expiries []caExpiry
+	var unreadable []string
+
+	for _, bundle := range caBundles {
+		source := fmt.Sprintf("ConfigMap %s/%s", bundle.namespace, bundle.name)
+		cm := &corev1.ConfigMap{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: bundle.namespace, Name: bundle.name}, cm); err != nil {
+			if !errors.IsNotFound(err) {
+				unreadable = append(unreadable, fmt.Sprintf("%s (%v)", source, err))
+			}
+			continue
+		}
+		certs := parseCertificates([]byte(cm.Data[bundle.key]))
+		if len(certs) == 0 {
+			unreadable = append(unreadable, fmt.Sprintf("%s (no certificates in %s)", source, bundle.key))
+			continue
+		}
+		latest := certs[0].NotAfter
+		for _, cert := range certs[1:] {
+			if cert.NotAfter.After(latest) {
+				latest = cert.NotAfter
+			}
+		}
+		expiries = append(expiries, caExpiry{source: source, notAfter: latest})
+	}
+
+	for _, ns := range caSignerNamespaces {
+		secrets := &corev1.SecretList{}
+		if err := c.List(ctx, secrets, client.InNamespace(ns)); err != nil {
+			unreadable = append(unreadable, fmt.Sprintf("Secrets in %s (%v)", ns, err))
+			continue
+		}
+		for _, secret := range secrets.Items {
+			if secret.Type != corev1.SecretTypeTLS || !strings.HasSuffix(secret.Name, "-signer") {
+				continue
+			}
+			if certs := parseCertificates(secret.Data[corev1.TLSCertKey]); len(certs) > 0 {
+				expiries = append(expiries, caExpiry{source: fmt.Sprintf("Secret %s/%s", ns, secret.Name), notAfter: certs[0].NotAfter})
+			}
+		}
+	}
+
+	var findings []assessmentv1alpha1.Finding
+	if len(unreadable) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "certificates-ca-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Some Cluster CAs",
+			Description: fmt.Sprintf("Could not read: %s", strings.Join(unreadable, "; ")),
+		})
+	}
+	if len(expiries) == 0 {
+		return findings
+	}
+
+	sort.Slice(expiries, func(i, j int) bool { return expiries[i].notAfter.Before(expiries[j].notAfter) })
+
+	now := time.Now()
+	critical := now.AddDate(0, 0, profile.Thresholds.CAExpiryCriticalDays)
+	var expiring []string
+	for _, e := range expiries {
+		if e.notAfter.Before(critical) {
+			expiring = append(expiring, fmt.Sprintf("%s (%s)", e.source, describeExpiry(e.notAfter, now)))
+		}
+	}
+
+	if len(expiring) > 0 {
+		sample := expiring
+		if len(sample) > 5 {
+			sample = sample[:5]
+		}
+		return append(findings, assessmentv1alpha1.Finding{
+			ID:             "certificates-ca-expiring",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusFail,
+			Title:          "Cluster CAs Expiring",
+			Description:    fmt.Sprintf("%d cluster CA(s) expire within the %s profile's %d-day critical window: %s", len(expiring), profile.Name, profile.Thresholds.CAExpiryCriticalDays, strings.Join(sample, "; ")),
+			Impact:         "When a cluster CA expires, every certificate it signed stops being trusted. API access, kubelets, service-to-service TLS and the console fail across the cluster, and recovery is manual.",
+			Recommendation: "CAs normally rotate automatically. Check why the owning operator has not rotated them, including whether the cluster was shut down for a long time, and follow the certificate recovery procedure if needed.",
+			References: []string{
+				"https://docs.openshift.com/container-platform/latest/security/certificate_types_descriptions/control-plane-certificates.html",
+				"https://docs.openshift.com/container-platform/latest/security/certificates/service-serving-certificate.html",
+			},
+		})
+	}
+
+	return append(findings, assessmentv1alpha1.Finding{
+		ID:          "certificates-ca-valid",
+		Validator:   validatorName,
+		Category:    validatorCategory,
+		Status:      assessmentv1alpha1.FindingStatusPass,
+		Title:       "Cluster CAs Valid",
+		Description: fmt.Sprintf("All %d cluster CA bundle(s) and signer(s) are valid for more than %d days. The first to expire is %s (%s).", len(expiries), profile.Thresholds.CAExpiryCriticalDays, expiries[0].source, describeExpiry(expiries[0].notAfter, now)),
+	})
+}
+
+// serviceCARotationWindow is the remaining validity at which the service-ca
+// operator rotates the service CA. The CA is valid for 26 months.
+const serviceCARotationWindow = 13 * 30 * 24 * time.Hour
+
+// checkServiceCA reports the health of the service-ca operator and whether the
+// service CA is rotated on schedule.
+func (v *CertificatesValidator) checkServiceCA(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	var findings []assessmentv1alpha1.Finding
+
+	co := &configv1.ClusterOperator{}
+	if err := c.Get(ctx, client.ObjectKey{Name: "service-ca"}, co); err == nil {
+		var available, degraded bool
+		for _, condition := range co.Status.Conditions {
+			switch condition.Type {
+			case configv1.OperatorAvailable:
+				available = condition.Status == configv1.ConditionTrue
+			case configv1.OperatorDegraded:
+				degraded = condition.Status == configv1.ConditionTrue
+			}
+		}
+		if degraded || !available {
+			findings = append(findings, assessmentv1alpha1.Finding{
+				ID:             "certificates-service-ca-operator-unhealthy",
+				Validator:      validatorName,
+				Category:       validatorCategory,
+				Status:         assessmentv1alpha1.FindingStatusFail,
+				Title:          "Service CA Operator Unhealthy",
+				Description:    fmt.Sprintf("The service-ca ClusterOperator is Available=%t, Degraded=%t.", available, degraded),
+				Impact:         "The service CA and the serving certificates it signs are not rotated, so service-to-service TLS will break when they expire.",
+				Recommendation: "Check 'oc get co service-ca' and the openshift-service-ca-operator logs.",
+			})
+		}
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, serviceCASecret, secret); err != nil {
+		if !errors.IsNotFound(err) {
+			findings = append(findings, assessmentv1alpha1.Finding{
+				ID:          "certificates-service-ca-error",
+				Validator:   validatorName,
+				Category:    validatorCategory,
+				Status:      assessmentv1alpha1.FindingStatusInfo,
+				Title:       "Unable to Check Service CA",
+				Description: fmt.Sprintf("Could not read Secret %s: %v", serviceCASecret, err),
+			})
+		}
+		return findings
+	}
+	certs := parseCertificates(secret.Data[corev1.TLSCertKey])
+	if len(certs) == 0 {
+		return findings
+	}
+
+	now := time.Now()
+	notAfter := certs[0].NotAfter
+	if notAfter.Sub(now) < serviceCARotationWindow {
+		return append(findings, assessmentv1alpha1.Finding{
+			ID:             "certificates-service-ca-rotation-overdue",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Service CA Rotation Overdue",
+			Description:    fmt.Sprintf("The service CA %s, but it is normally rotated once less than 13 months of validity remain.", describeExpiry(notAfter, now)),
+			Impact:         "Automatic rotation of the service CA has not happened, so service serving certificates will stop being trusted when it expires.",
+			Recommendation: "Check the service-ca operator. The CA can be rotated manually by deleting the signing-key secret in openshift-service-ca, after which pods must be restarted to trust the new CA.",
+			References: []string{
+				"https://docs.openshift.com/container-platform/latest/security/certificates/service-serving-certificate.html#manually-rotate-service-ca_service-serving-certificate",
+			},
+		})
+	}
+
+	return append(findings, assessmentv1alpha1.Finding{
+		ID:          "certificates-service-ca",
+		Validator:   validatorName,
+		Category:    validatorCategory,
+		Status:      assessmentv1alpha1.FindingStatusInfo,
+		Title:       "Service CA Rotation",
+		Description: fmt.Sprintf("The service CA %s and will be rotated automatically around %s.", describeExpiry(notAfter, now), notAfter.Add(-serviceCARotationWindow).Format("2006-01-02")),
+	})
+}
+
+// appCert is the leaf certificate served for a Route or Ingress host.
+type appCert struct {
+	source   string
+	host     string
+	notAfter time.Time
+}
+
+// checkRouteCerts checks when the certificates served for Routes and Ingresses
+// expire. Route certificates are read inline from spec.tls.certificate or from
+// the Secret named by spec.tls.externalCertificate, Ingress certificates from
+// the Secrets named in spec.tls. Routes without their own certificate are
+// served with the router's default certificate and are skipped. Expired
+// certificates fail, and those expiring within the profile's warning window
+// warn.
+func (v *CertificatesValidator) checkRouteCerts(ctx context.Context, c client.Client, profile profiles.Profile) []assessmentv1alpha1.Finding {
+	var certs []appCert
+	var unreadable []string
+
+	secretCert := func(namespace, name string) (*x509.Certificate, error) {
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+			return nil, err
+		}
+		parsed := parseCertificates(secret.Data[corev1.TLSCertKey])
+		if len(parsed) == 0 {
+			return nil, fmt.Errorf("no certificate in %s", corev1.TLSCertKey)
+		}
+		return parsed[0], nil
+	}
+
+	routes := &unstructured.UnstructuredList{}
+	routes.SetGroupVersionKind(schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "RouteList"})
+	if err := c.List(ctx, routes); err != nil && !meta.IsNoMatchError(err) {
+		unreadable = append(unreadable, fmt.Sprintf("Routes (%v)", err))
+	}
+	for _, route := range routes.Items {
+		source := fmt.Sprintf("route %s/%s", route.GetNamespace(), route.GetName())
+		host, _, _ := unstructured.NestedString(route.Object, "spec", "host")
+		inline, _, _ := unstructured.NestedString(route.Object, "spec", "tls", "certificate")
+		external, _, _ := unstructured.NestedString(route.Object, "spec", "tls", "externalCertificate", "name")
+		switch {
+		case inline != "":
+			parsed := parseCertificates([]byte(inline))
+			if len(parsed) == 0 {
+				unreadable = append(unreadable, fmt.Sprintf("%s (no certificate in spec.tls.certificate)", source))
+				continue
+			}
+			certs = append(certs, appCert{source: source, host: host, notAfter: parsed[0].NotAfter})
+		case external != "":
+			cert, err := secretCert(route.GetNamespace(), external)
+			if err != nil {
+				unreadable = append(unreadable, fmt.Sprintf("%s (Secret %s: %v)", source, external, err))
+				continue
+			}
+			certs = append(certs, appCert{source: source, host: host, notAfter: cert.NotAfter})
+		}
+	}
+
+	ingresses := &networkingv1.IngressList{}
+	if err := c.List(ctx, ingresses); err != nil {
+		unreadable = append(unreadable, fmt.Sprintf("Ingresses (%v)", err))
+	}
+	for _, ingress := range ingresses.Items {
+		for _, tls := range ingress.Spec.TLS {
+			if tls.SecretName == "" {
+				continue
+			}
+			source := fmt.Sprintf("ingress %s/%s", ingress.Namespace, ingress.Name)
+			cert, err := secretCert(ingress.Namespace, tls.SecretName)
+			if err != nil {
+				unreadable = append(unreadable, fmt.Sprintf("%s (Secret %s: %v)", source, tls.SecretName, err))
+				continue
+			}
+			certs = append(certs, appCert{source: source, host: strings.Join(tls.Hosts, ","), notAfter: cert.NotAfter})
+		}
+	}
+
+	var findings []assessmentv1alpha1.Finding
+	if len(unreadable) > 0 {
+		sample := unreadable
+		if len(sample) > 5 {
+			sample = sample[:5]
+		}
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "certificates-route-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Some Route Certificates",
+			Description: fmt.Sprintf("Could not read %d Route or Ingress certificate(s): %s", len(unreadable), strings.Join(sample, "; ")),
+		})
+	}
+	if len(certs) == 0 {
+		return findings
+	}
+
+	sort.Slice(certs, func(i, j int) bool { return certs[i].notAfter.Before(certs[j].notAfter) })
+
+	now := time.Now()
+	warning := now.AddDate(0, 0, profile.Thresholds.CertExpiryWarningDays)
+	var expired, expiring []string
+	for _, cert := range certs {
+		entry := fmt.Sprintf("%s (%s)", cert.source, describeExpiry(cert.notAfter, now))
+		if cert.host != "" {
+			entry = fmt.Sprintf("%s, host %s (%s)", cert.source, cert.host, describeExpiry(cert.notAfter, now))
+		}
+		switch {
+		case cert.notAfter.Before(now):
+			expired = append(expired, entry)
+		case cert.notAfter.Before(warning):
+			expiring = append(expiring, entry)
+		}
+	}
+
+	references := []string{
+		"https://docs.openshift.com/container-platform/latest/networking/routes/secured-routes.html",
+	}
+	if len(expired) > 0 {
+		sample := expired
+		if len(sample) > 5 {
+			sample = sample[:5]
+		}
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "certificates-route-expired",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusFail,
+			Title:          "Expired Route Certificates",
+			Description:    fmt.Sprintf("%d Route or Ingress certificate(s) have expired: %s", len(expired), strings.Join(sample, "; ")),
+			Impact:         "Clients reject the expired certificate, so these applications are unreachable over HTTPS for their users.",
+			Recommendation: "Renew the certificates and update the Route's spec.tls or the referenced Secret. Consider cert-manager to renew them automatically.",
+			References:     references,
+		})
+	}
+	if len(expiring) > 0 {
+		sample := expiring
+		if len(sample) > 5 {
+			sample = sample[:5]
+		}
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "certificates-route-expiring",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Route Certificates Expiring Soon",
+			Description:    fmt.Sprintf("%d Route or Ingress certificate(s) expire within the %s profile's %d-day warning window: %s", len(expiring), profile.Name, profile.Thresholds.CertExpiryWarningDays, strings.Join(sample, "; ")),
+			Impact:         "Once a certificate expires, clients reject it and the application becomes unreachable over HTTPS.",
+			Recommendation: "Renew the certificates before they expire and update the Route's spec.tls or the referenced Secret. Consider cert-manager to renew them automatically.",
+			References:     references,
+		})
+	}
+	if len(expired) == 0 && len(expiring) == 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "certificates-route-valid",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Route Certificates Valid",
+			Description: fmt.Sprintf("All %d Route and Ingress certificate(s) are valid for more than %d days. The first to expire is %s (%s).", len(certs), profile.Thresholds.CertExpiryWarningDays, certs[0].source, describeExpiry(certs[0].notAfter, now)),
+		})
+	}
+	return findings
+}
+
+// parseCertificates returns the certificates in PEM data, skipping anything
+// that does not parse.
+func parseCertificates(data []byte) []*x509.Certificate {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			certs = append(certs, cert)
+		}
+	}
+}
+
+// describeExpiry describes when notAfter is, relative to now.
+func describeExpiry(notAfter, now time.Time) string {
+	days := int(notAfter.Sub(now).Hours() / 24)
+	if days < 0 {
+		return fmt.Sprintf("expired on %s", notAfter.Format("2006-01-02"))
+	}
+	return fmt.Sprintf("expires on %s, in %d days", notAfter.Format("2006-01-02"), days)
+}
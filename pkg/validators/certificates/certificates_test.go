@@ -0,0 +1,249 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator/validatortest"
+)
+
+// newCAPEM returns a PEM encoded self-signed CA expiring after validFor.
+func newCAPEM(t *testing.T, validFor time.Duration) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(validFor),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = configv1.AddToScheme(scheme)
+	return scheme
+}
+
+func newSigner(ns, name, certPEM string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{corev1.TLSCertKey: []byte(certPEM)},
+	}
+}
+
+func TestCheckCAExpiry(t *testing.T) {
+	day := 24 * time.Hour
+	objs := []client.Object{
+		// A bundle mid-rotation is judged by its newest CA.
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-root-ca.crt", Namespace: "default"},
+			Data:       map[string]string{"ca.crt": newCAPEM(t, 10*day) + newCAPEM(t, 3650*day)},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "openshift-service-ca.crt", Namespace: "default"},
+			Data:       map[string]string{"service-ca.crt": newCAPEM(t, 400*day)},
+		},
+		newSigner("openshift-kube-apiserver-operator", "kube-apiserver-to-kubelet-signer", newCAPEM(t, 60*day)),
+		newSigner("openshift-kube-apiserver-operator", "aggregator-client", newCAPEM(t, 1*day)),
+	}
+	c := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(objs...).Build()
+	v := &CertificatesValidator{}
+
+	findings := v.checkCAExpiry(context.Background(), c, profiles.GetProfile("production"))
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 finding, got %d: %+v", len(findings), findings)
+	}
+	f := findings[0]
+	if f.ID != "certificates-ca-expiring" || f.Status != assessmentv1alpha1.FindingStatusFail {
+		t.Errorf("Unexpected finding %s (%s)", f.ID, f.Status)
+	}
+	if !strings.Contains(f.Description, "kube-apiserver-to-kubelet-signer") {
+		t.Errorf("Expected the signer in the description, got %q", f.Description)
+	}
+	if strings.Contains(f.Description, "kube-root-ca.crt") || strings.Contains(f.Description, "aggregator-client") {
+		t.Errorf("Unexpected CA in the description: %q", f.Description)
+	}
+
+	// The development profile's critical window is shorter.
+	findings = v.checkCAExpiry(context.Background(), c, profiles.GetProfile("development"))
+	if len(findings) != 1 || findings[0].ID != "certificates-ca-valid" {
+		t.Fatalf("Expected certificates-ca-valid, got %+v", findings)
+	}
+	if !strings.Contains(findings[0].Description, "kube-apiserver-to-kubelet-signer") {
+		t.Errorf("Expected the first CA to expire in the description, got %q", findings[0].Description)
+	}
+}
+
+func TestCheckServiceCA(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		name     string
+		validFor time.Duration
+		degraded configv1.ConditionStatus
+		wantIDs  []string
+	}{
+		{
+			name:     "rotated on schedule",
+			validFor: 700 * day,
+			degraded: configv1.ConditionFalse,
+			wantIDs:  []string{"certificates-service-ca"},
+		},
+		{
+			name:     "rotation overdue",
+			validFor: 100 * day,
+			degraded: configv1.ConditionFalse,
+			wantIDs:  []string{"certificates-service-ca-rotation-overdue"},
+		},
+		{
+			name:     "operator degraded",
+			validFor: 700 * day,
+			degraded: configv1.ConditionTrue,
+			wantIDs:  []string{"certificates-service-ca-operator-unhealthy", "certificates-service-ca"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			co := &configv1.ClusterOperator{
+				ObjectMeta: metav1.ObjectMeta{Name: "service-ca"},
+				Status: configv1.ClusterOperatorStatus{
+					Conditions: []configv1.ClusterOperatorStatusCondition{
+						{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+						{Type: configv1.OperatorDegraded, Status: tt.degraded},
+					},
+				},
+			}
+			secret := newSigner(serviceCASecret.Namespace, serviceCASecret.Name, newCAPEM(t, tt.validFor))
+			c := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(co, secret).Build()
+
+			findings := (&CertificatesValidator{}).checkServiceCA(context.Background(), c)
+			if len(findings) != len(tt.wantIDs) {
+				t.Fatalf("Expected %d findings, got %+v", len(tt.wantIDs), findings)
+			}
+			for i, id := range tt.wantIDs {
+				if findings[i].ID != id {
+					t.Errorf("Expected finding %d to be %s, got %s", i, id, findings[i].ID)
+				}
+			}
+		})
+	}
+}
+
+func newRoute(ns, name, host string, tls map[string]interface{}) *unstructured.Unstructured {
+	route := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"host": host, "tls": tls},
+	}}
+	route.SetAPIVersion("route.openshift.io/v1")
+	route.SetKind("Route")
+	route.SetNamespace(ns)
+	route.SetName(name)
+	return route
+}
+
+func TestCheckRouteCerts(t *testing.T) {
+	day := 24 * time.Hour
+	scheme := newScheme()
+	_ = networkingv1.AddToScheme(scheme)
+
+	objs := []client.Object{
+		newRoute("shop", "frontend", "shop.apps.example.com", map[string]interface{}{"termination": "edge", "certificate": newCAPEM(t, -2*day)}),
+		newRoute("shop", "api", "api.apps.example.com", map[string]interface{}{"termination": "reencrypt", "externalCertificate": map[string]interface{}{"name": "api-tls"}}),
+		newSigner("shop", "api-tls", newCAPEM(t, 20*day)),
+		newRoute("shop", "default-cert", "plain.apps.example.com", map[string]interface{}{"termination": "edge"}),
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "blog", Namespace: "blog"},
+			Spec:       networkingv1.IngressSpec{TLS: []networkingv1.IngressTLS{{Hosts: []string{"blog.example.com"}, SecretName: "blog-tls"}}},
+		},
+		newSigner("blog", "blog-tls", newCAPEM(t, 200*day)),
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	v := &CertificatesValidator{}
+
+	findings := v.checkRouteCerts(context.Background(), c, profiles.GetProfile("production"))
+	if len(findings) != 2 {
+		t.Fatalf("Expected 2 findings, got %d: %+v", len(findings), findings)
+	}
+	if f := findings[0]; f.ID != "certificates-route-expired" || f.Status != assessmentv1alpha1.FindingStatusFail ||
+		!strings.Contains(f.Description, "route shop/frontend, host shop.apps.example.com (expired on") {
+		t.Errorf("Unexpected expired finding: %+v", f)
+	}
+	if f := findings[1]; f.ID != "certificates-route-expiring" || f.Status != assessmentv1alpha1.FindingStatusWarn ||
+		!strings.Contains(f.Description, "route shop/api, host api.apps.example.com") || strings.Contains(f.Description, "blog") {
+		t.Errorf("Unexpected expiring finding: %+v", f)
+	}
+
+	// The development profile's warning window is shorter.
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs[1], objs[2], objs[4], objs[5]).Build()
+	findings = v.checkRouteCerts(context.Background(), c, profiles.GetProfile("development"))
+	if len(findings) != 1 || findings[0].ID != "certificates-route-valid" || !strings.Contains(findings[0].Description, "route shop/api") {
+		t.Errorf("Expected certificates-route-valid, got %+v", findings)
+	}
+}
+
+func TestValidate_RouteCertsDoNotReplaceSummary(t *testing.T) {
+	scheme := newScheme()
+	_ = networkingv1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "router-certs-default", Namespace: "openshift-ingress"}},
+		newRoute("shop", "frontend", "shop.apps.example.com", map[string]interface{}{"termination": "edge", "certificate": newCAPEM(t, 200*24*time.Hour)}),
+	).Build()
+
+	findings, err := (&CertificatesValidator{}).Validate(context.Background(), c, profiles.GetProfile("production"))
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	for _, id := range []string{"certificates-all-valid", "certificates-route-valid"} {
+		if validatortest.FindingByID(findings, id) == nil {
+			t.Errorf("Expected %s, got %+v", id, findings)
+		}
+	}
+}
@@ -21,7 +21,12 @@ import (
 	"fmt"
 	"strings"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -59,6 +64,11 @@ func (v *ComplianceValidator) Category() string {
 	return validatorCategory
 }
 
+// RequiresOpenShift reports that this validator needs OpenShift APIs.
+func (v *ComplianceValidator) RequiresOpenShift() bool {
+	return true
+}
+
 // Validate performs compliance checks.
 func (v *ComplianceValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
 	var findings []assessmentv1alpha1.Finding
@@ -72,6 +82,15 @@ func (v *ComplianceValidator) Validate(ctx context.Context, c client.Client, pro
 	// Check 3: Kubeadmin user
 	findings = append(findings, v.checkKubeadminUser(ctx, c, profile)...)
 
+	// Check 4: Workloads in the default namespace
+	findings = append(findings, v.checkDefaultNamespace(ctx, c)...)
+
+	// Check 5: Namespace labels required by the expected state
+	findings = append(findings, v.checkRequiredNamespaceLabels(ctx, c)...)
+
+	// Check 6: Project request template
+	findings = append(findings, v.checkProjectTemplate(ctx, c, profile)...)
+
 	return findings, nil
 }
 
@@ -79,8 +98,8 @@ func (v *ComplianceValidator) Validate(ctx context.Context, c client.Client, pro
 func (v *ComplianceValidator) checkPodSecurityAdmission(ctx context.Context, c client.Client, profile profiles.Profile) []assessmentv1alpha1.Finding {
 	var findings []assessmentv1alpha1.Finding
 
-	namespaces := &corev1.NamespaceList{}
-	if err := c.List(ctx, namespaces); err != nil {
+	namespaces, err := validator.ListNamespaces(ctx, c)
+	if err != nil {
 		return []assessmentv1alpha1.Finding{{
 			ID:          "compliance-psa-error",
 			Validator:   validatorName,
@@ -94,7 +113,7 @@ func (v *ComplianceValidator) checkPodSecurityAdmission(ctx context.Context, c c
 	var namespacesWithEnforce []string
 	var userNamespacesWithoutPSA []string
 
-	for _, ns := range namespaces.Items {
+	for _, ns := range namespaces {
 		// Skip system namespaces
 		if strings.HasPrefix(ns.Name, "openshift-") || strings.HasPrefix(ns.Name, "kube-") || ns.Name == "default" {
 			continue
@@ -275,14 +294,15 @@ func (v *ComplianceValidator) checkKubeadminUser(ctx context.Context, c client.C
 		}
 
 		findings = append(findings, assessmentv1alpha1.Finding{
-			ID:             "compliance-kubeadmin-exists",
-			Validator:      validatorName,
-			Category:       validatorCategory,
-			Status:         status,
-			Title:          "Kubeadmin User Still Exists",
-			Description:    "The kubeadmin user has not been removed.",
-			Impact:         "Kubeadmin provides cluster-admin access with a static password.",
-			Recommendation: "After configuring identity providers, remove the kubeadmin user: oc delete secret kubeadmin -n kube-system",
+			ID:                 "compliance-kubeadmin-exists",
+			Validator:          validatorName,
+			Category:           validatorCategory,
+			Status:             status,
+			Title:              "Kubeadmin User Still Exists",
+			Description:        "The kubeadmin user has not been removed.",
+			Impact:             "Kubeadmin provides cluster-admin access with a static password.",
+			Recommendation:     "After configuring identity providers, remove the kubeadmin user: oc delete secret kubeadmin -n kube-system",
+			RemediationCommand: "oc delete secret kubeadmin -n kube-system",
 			References: []string{
 				"https://docs.openshift.com/container-platform/latest/authentication/remove-kubeadmin.html",
 			},
@@ -300,3 +320,306 @@ func (v *ComplianceValidator) checkKubeadminUser(ctx context.Context, c client.C
 
 	return findings
 }
+
+// checkDefaultNamespace flags workloads running in the default namespace,
+// which has no dedicated RBAC, quotas or network policies and is easily
+// shared by accident. Pods created by a listed controller are not reported
+// separately.
+func (v *ComplianceValidator) checkDefaultNamespace(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	inDefault := client.InNamespace(corev1.NamespaceDefault)
+	var workloads []string
+
+	deployments := &appsv1.DeploymentList{}
+	statefulSets := &appsv1.StatefulSetList{}
+	daemonSets := &appsv1.DaemonSetList{}
+	jobs := &batchv1.JobList{}
+	pods := &corev1.PodList{}
+
+	lists := []struct {
+		kind string
+		list client.ObjectList
+	}{
+		{"Deployment", deployments},
+		{"StatefulSet", statefulSets},
+		{"DaemonSet", daemonSets},
+		{"Job", jobs},
+		{"Pod", pods},
+	}
+	for _, l := range lists {
+		if err := c.List(ctx, l.list, inDefault); err != nil {
+			return []assessmentv1alpha1.Finding{{
+				ID:          "compliance-default-namespace-error",
+				Validator:   validatorName,
+				Category:    validatorCategory,
+				Status:      assessmentv1alpha1.FindingStatusInfo,
+				Title:       "Unable to Check the default Namespace",
+				Description: fmt.Sprintf("Failed to list %ss in the default namespace: %v", l.kind, err),
+			}}
+		}
+	}
+
+	for _, d := range deployments.Items {
+		workloads = append(workloads, "Deployment "+d.Name)
+	}
+	for _, sts := range statefulSets.Items {
+		workloads = append(workloads, "StatefulSet "+sts.Name)
+	}
+	for _, ds := range daemonSets.Items {
+		workloads = append(workloads, "DaemonSet "+ds.Name)
+	}
+	for _, job := range jobs.Items {
+		// Jobs created by a CronJob are reported through their CronJob's name.
+		if owner := metav1.GetControllerOf(&job); owner != nil && owner.Kind == "CronJob" {
+			workloads = appendUnique(workloads, "CronJob "+owner.Name)
+			continue
+		}
+		workloads = append(workloads, "Job "+job.Name)
+	}
+	for _, pod := range pods.Items {
+		// Pods owned by a controller are covered by the controllers above.
+		if metav1.GetControllerOf(&pod) != nil {
+			continue
+		}
+		workloads = append(workloads, "Pod "+pod.Name)
+	}
+
+	if len(workloads) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "compliance-default-namespace-empty",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "No Workloads in the default Namespace",
+			Description: "No Deployments, StatefulSets, DaemonSets, Jobs or standalone pods run in the default namespace.",
+		}}
+	}
+
+	sample := workloads
+	if len(sample) > 5 {
+		sample = sample[:5]
+	}
+
+	return []assessmentv1alpha1.Finding{{
+		ID:             "compliance-default-namespace-workloads",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Title:          "Workloads in the default Namespace",
+		Namespace:      corev1.NamespaceDefault,
+		Description:    fmt.Sprintf("Found %d workload(s) in the default namespace: %s", len(workloads), strings.Join(sample, ", ")),
+		Impact:         "The default namespace is shared, is skipped by most namespace-level policies, and makes ownership, quotas and access control hard to enforce.",
+		Recommendation: "Move these workloads into a dedicated project with its own RBAC, ResourceQuota and NetworkPolicies.",
+		References: []string{
+			"https://kubernetes.io/docs/concepts/security/multi-tenancy/",
+		},
+	}}
+}
+
+// checkRequiredNamespaceLabels reports user namespaces missing any of the
+// label keys required by the expected state.
+func (v *ComplianceValidator) checkRequiredNamespaceLabels(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	required := validator.ExpectedStateFromContext(ctx).RequiredNamespaceLabels
+	if len(required) == 0 {
+		return nil
+	}
+
+	namespaces, err := validator.ListNamespaces(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "compliance-namespace-labels-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Namespace Labels",
+			Description: fmt.Sprintf("Failed to list namespaces: %v", err),
+		}}
+	}
+
+	var unlabeled []string
+	var evidence string
+	userNamespaces := 0
+	for i := range namespaces {
+		ns := &namespaces[i]
+		if strings.HasPrefix(ns.Name, "openshift") || strings.HasPrefix(ns.Name, "kube-") || ns.Name == "default" {
+			continue
+		}
+		userNamespaces++
+		var missing []string
+		for _, key := range required {
+			if _, ok := ns.Labels[key]; !ok {
+				missing = append(missing, key)
+			}
+		}
+		if len(missing) > 0 {
+			unlabeled = append(unlabeled, fmt.Sprintf("%s (missing %s)", ns.Name, strings.Join(missing, ", ")))
+			if evidence == "" {
+				evidence = validator.Evidence(ctx, ns)
+			}
+		}
+	}
+
+	if len(unlabeled) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "compliance-namespace-labels-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Namespaces Carry Required Labels",
+			Description: fmt.Sprintf("All %d user namespace(s) carry the required labels: %s", userNamespaces, strings.Join(required, ", ")),
+		}}
+	}
+
+	sample := unlabeled
+	if len(sample) > 5 {
+		sample = sample[:5]
+	}
+	return []assessmentv1alpha1.Finding{{
+		ID:             "compliance-namespace-labels-missing",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Title:          "Namespaces Missing Required Labels",
+		Description:    fmt.Sprintf("%d of %d user namespace(s) lack required labels: %s", len(unlabeled), userNamespaces, strings.Join(sample, "; ")),
+		Impact:         "Ownership, cost allocation and label-based policies cannot be applied to these namespaces.",
+		Recommendation: "Add the labels to the namespaces, and to the project request template so that new projects carry them.",
+		Evidence:       evidence,
+	}}
+}
+
+// checkProjectTemplate reports whether new projects are created from a custom
+// project request template that gives them a baseline NetworkPolicy and a
+// ResourceQuota or LimitRange. The built-in template creates bare projects,
+// which is why new namespaces start without any isolation or limits; relying
+// on it is reported with the production profile only.
+func (v *ComplianceValidator) checkProjectTemplate(ctx context.Context, c client.Client, profile profiles.Profile) []assessmentv1alpha1.Finding {
+	project := &unstructured.Unstructured{}
+	project.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "config.openshift.io",
+		Version: "v1",
+		Kind:    "Project",
+	})
+	if err := c.Get(ctx, client.ObjectKey{Name: "cluster"}, project); err != nil && !apierrors.IsNotFound(err) {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return []assessmentv1alpha1.Finding{{
+			ID:          "compliance-project-template-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Project Template",
+			Description: fmt.Sprintf("Failed to get the project configuration: %v", err),
+		}}
+	}
+
+	name, _, _ := unstructured.NestedString(project.Object, "spec", "projectRequestTemplate", "name")
+	if name == "" {
+		if profile.Name != profiles.ProfileProduction {
+			return nil
+		}
+		return []assessmentv1alpha1.Finding{{
+			ID:             "compliance-project-template-default",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusInfo,
+			Title:          "Default Project Template in Use",
+			Description:    "No project request template is configured, so projects requested by users are created from the built-in template, which only adds the requester's admin role binding.",
+			Impact:         "New projects start without NetworkPolicies, quotas or LimitRanges, so their workloads accept traffic from any namespace and can consume unbounded resources until an administrator adds them.",
+			Recommendation: "Create a project request template in openshift-config that adds a default NetworkPolicy and a ResourceQuota or LimitRange (oc adm create-bootstrap-project-template), and reference it from projectRequestTemplate in the project.config.openshift.io/cluster resource.",
+			References: []string{
+				"https://docs.openshift.com/container-platform/latest/applications/projects/configuring-project-creation.html",
+			},
+		}}
+	}
+
+	template := &unstructured.Unstructured{}
+	template.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "template.openshift.io",
+		Version: "v1",
+		Kind:    "Template",
+	})
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "openshift-config", Name: name}, template); err != nil {
+		if apierrors.IsNotFound(err) {
+			return []assessmentv1alpha1.Finding{{
+				ID:             "compliance-project-template-missing",
+				Validator:      validatorName,
+				Category:       validatorCategory,
+				Status:         assessmentv1alpha1.FindingStatusFail,
+				Title:          "Project Template Not Found",
+				Description:    fmt.Sprintf("The project configuration references the project request template %q, which does not exist in openshift-config.", name),
+				Impact:         "Project requests fail while the referenced template is missing, so users cannot create projects.",
+				Recommendation: "Recreate the template in openshift-config, or remove projectRequestTemplate from the project.config.openshift.io/cluster resource.",
+				References: []string{
+					"https://docs.openshift.com/container-platform/latest/applications/projects/configuring-project-creation.html",
+				},
+			}}
+		}
+		return []assessmentv1alpha1.Finding{{
+			ID:          "compliance-project-template-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Project Template",
+			Description: fmt.Sprintf("Failed to get project request template openshift-config/%s: %v", name, err),
+		}}
+	}
+
+	kinds := make(map[string]bool)
+	objects, _, _ := unstructured.NestedSlice(template.Object, "objects")
+	for _, obj := range objects {
+		if m, ok := obj.(map[string]interface{}); ok {
+			if kind, ok := m["kind"].(string); ok {
+				kinds[kind] = true
+			}
+		}
+	}
+
+	var missing []string
+	if !kinds["NetworkPolicy"] {
+		missing = append(missing, "a NetworkPolicy")
+	}
+	if !kinds["ResourceQuota"] && !kinds["LimitRange"] {
+		missing = append(missing, "a ResourceQuota or LimitRange")
+	}
+	if len(missing) > 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:             "compliance-project-template-incomplete",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusInfo,
+			Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
+			Title:          "Project Template Lacks Baseline Policies",
+			Description:    fmt.Sprintf("The project request template openshift-config/%s does not create %s.", name, strings.Join(missing, " and ")),
+			Impact:         "Projects created from the template start without network isolation or resource limits until an administrator adds them.",
+			Recommendation: "Add a default NetworkPolicy and a ResourceQuota or LimitRange to the template's objects so that every new project receives them.",
+			References: []string{
+				"https://docs.openshift.com/container-platform/latest/networking/network_policy/default-network-policy.html",
+			},
+		}}
+	}
+
+	var applied []string
+	for _, kind := range []string{"NetworkPolicy", "ResourceQuota", "LimitRange"} {
+		if kinds[kind] {
+			applied = append(applied, kind)
+		}
+	}
+	return []assessmentv1alpha1.Finding{{
+		ID:          "compliance-project-template-baseline",
+		Validator:   validatorName,
+		Category:    validatorCategory,
+		Status:      assessmentv1alpha1.FindingStatusPass,
+		Title:       "Project Template Applies Baseline Policies",
+		Description: fmt.Sprintf("New projects are created from openshift-config/%s, which creates %s objects.", name, strings.Join(applied, ", ")),
+	}}
+}
+
+// appendUnique appends s to list unless it is already present.
+func appendUnique(list []string, s string) []string {
+	for _, existing := range list {
+		if existing == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
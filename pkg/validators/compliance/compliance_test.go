@@ -0,0 +1,210 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compliance
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator"
+)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{corev1.AddToScheme, appsv1.AddToScheme, batchv1.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatalf("failed to build scheme: %v", err)
+		}
+	}
+	return scheme
+}
+
+func controlledBy(kind, name string) []metav1.OwnerReference {
+	controller := true
+	return []metav1.OwnerReference{{APIVersion: "v1", Kind: kind, Name: name, Controller: &controller}}
+}
+
+func TestCheckDefaultNamespace(t *testing.T) {
+	objects := []client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "nightly-123", Namespace: "default", OwnerReferences: controlledBy("CronJob", "nightly")}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "nightly-456", Namespace: "default", OwnerReferences: controlledBy("CronJob", "nightly")}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-abc", Namespace: "default", OwnerReferences: controlledBy("ReplicaSet", "web-5d4")}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "default"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "my-app"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(objects...).Build()
+
+	findings := (&ComplianceValidator{}).checkDefaultNamespace(context.Background(), c)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+	f := findings[0]
+	if f.ID != "compliance-default-namespace-workloads" || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("unexpected finding %s (%s)", f.ID, f.Status)
+	}
+	if !strings.Contains(f.Description, "Found 3 workload(s)") {
+		t.Errorf("expected 3 workloads, got %q", f.Description)
+	}
+	for _, want := range []string{"Deployment web", "CronJob nightly", "Pod debug"} {
+		if !strings.Contains(f.Description, want) {
+			t.Errorf("expected description to mention %q, got %q", want, f.Description)
+		}
+	}
+	if strings.Contains(f.Description, "web-abc") || strings.Contains(f.Description, "api") {
+		t.Errorf("controller-owned pods and other namespaces must not be reported: %q", f.Description)
+	}
+}
+
+func TestCheckDefaultNamespaceEmpty(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(newScheme(t)).Build()
+
+	findings := (&ComplianceValidator{}).checkDefaultNamespace(context.Background(), c)
+	if len(findings) != 1 || findings[0].ID != "compliance-default-namespace-empty" {
+		t.Fatalf("expected a single pass finding, got %+v", findings)
+	}
+}
+
+func TestCheckRequiredNamespaceLabels(t *testing.T) {
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(
+		namespace("payments", map[string]string{"cost-center": "42", "owner": "team-a"}),
+		namespace("search", map[string]string{"owner": "team-b"}),
+		namespace("sandbox", nil),
+		namespace("openshift-monitoring", nil),
+	).Build()
+	v := &ComplianceValidator{}
+
+	if findings := v.checkRequiredNamespaceLabels(context.Background(), c); findings != nil {
+		t.Fatalf("expected no findings without required labels, got %+v", findings)
+	}
+
+	ctx := validator.WithExpectedState(context.Background(), &validator.ExpectedState{
+		RequiredNamespaceLabels: []string{"cost-center", "owner"},
+	})
+	findings := v.checkRequiredNamespaceLabels(ctx, c)
+	if len(findings) != 1 || findings[0].ID != "compliance-namespace-labels-missing" || findings[0].Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("expected a missing labels WARN, got %+v", findings)
+	}
+	want := "2 of 3 user namespace(s) lack required labels: sandbox (missing cost-center, owner); search (missing cost-center)"
+	if !strings.Contains(findings[0].Description, want) {
+		t.Errorf("expected %q in description: %s", want, findings[0].Description)
+	}
+}
+
+func TestCheckProjectTemplate(t *testing.T) {
+	projectConfig := func(template string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "config.openshift.io/v1",
+			"kind":       "Project",
+			"metadata":   map[string]interface{}{"name": "cluster"},
+		}}
+		if template != "" {
+			_ = unstructured.SetNestedField(obj.Object, template, "spec", "projectRequestTemplate", "name")
+		}
+		return obj
+	}
+	template := func(name string, kinds ...string) *unstructured.Unstructured {
+		objects := []interface{}{map[string]interface{}{"apiVersion": "v1", "kind": "Project"}}
+		for _, kind := range kinds {
+			objects = append(objects, map[string]interface{}{"kind": kind})
+		}
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "template.openshift.io/v1",
+			"kind":       "Template",
+			"metadata":   map[string]interface{}{"name": name, "namespace": "openshift-config"},
+			"objects":    objects,
+		}}
+	}
+	production := profiles.GetProfile(string(profiles.ProfileProduction))
+
+	tests := []struct {
+		name    string
+		objects []client.Object
+		profile profiles.Profile
+		wantID  string
+		wantIn  string
+	}{
+		{
+			name:    "default template in production",
+			objects: []client.Object{projectConfig("")},
+			profile: production,
+			wantID:  "compliance-project-template-default",
+		},
+		{
+			name:    "missing template",
+			objects: []client.Object{projectConfig("project-request")},
+			profile: production,
+			wantID:  "compliance-project-template-missing",
+			wantIn:  `"project-request"`,
+		},
+		{
+			name:    "template without a NetworkPolicy",
+			objects: []client.Object{projectConfig("project-request"), template("project-request", "LimitRange")},
+			profile: production,
+			wantID:  "compliance-project-template-incomplete",
+			wantIn:  "does not create a NetworkPolicy.",
+		},
+		{
+			name:    "template without baseline policies",
+			objects: []client.Object{projectConfig("project-request"), template("project-request", "RoleBinding")},
+			profile: production,
+			wantID:  "compliance-project-template-incomplete",
+			wantIn:  "does not create a NetworkPolicy and a ResourceQuota or LimitRange.",
+		},
+		{
+			name:    "baseline template",
+			objects: []client.Object{projectConfig("project-request"), template("project-request", "NetworkPolicy", "ResourceQuota")},
+			profile: production,
+			wantID:  "compliance-project-template-baseline",
+			wantIn:  "NetworkPolicy, ResourceQuota objects",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(tt.objects...).Build()
+			findings := (&ComplianceValidator{}).checkProjectTemplate(context.Background(), c, tt.profile)
+			if len(findings) != 1 || findings[0].ID != tt.wantID {
+				t.Fatalf("expected a single %s finding, got %+v", tt.wantID, findings)
+			}
+			if !strings.Contains(findings[0].Description, tt.wantIn) {
+				t.Errorf("expected %q in description: %s", tt.wantIn, findings[0].Description)
+			}
+		})
+	}
+
+	c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(projectConfig("")).Build()
+	if findings := (&ComplianceValidator{}).checkProjectTemplate(context.Background(), c, profiles.GetProfile(string(profiles.ProfileDevelopment))); findings != nil {
+		t.Errorf("expected no findings for the default template in development, got %+v", findings)
+	}
+}
@@ -19,11 +19,15 @@ package costoptimization
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
@@ -37,6 +41,56 @@ const (
 	validatorCategory    = "Infrastructure"
 )
 
+const (
+	// configLargeObjectThresholdKB overrides the size above which a Secret or
+	// ConfigMap is reported as oversized.
+	configLargeObjectThresholdKB = "largeObjectThresholdKB"
+
+	// configHelmMaxRevisions overrides the number of stored revisions above
+	// which a Helm release is reported as a cleanup opportunity.
+	configHelmMaxRevisions = "helmMaxRevisions"
+
+	// configAbandonedNamespaceDays overrides the age in days above which a
+	// namespace without running pods or recent pod activity is reported.
+	configAbandonedNamespaceDays = "abandonedNamespaceDays"
+
+	defaultLargeObjectThresholdKB = 500
+	// defaultHelmMaxRevisions matches Helm's default --history-max.
+	defaultHelmMaxRevisions = 10
+
+	defaultAbandonedNamespaceDays = 90
+
+	// configMetricsWindow overrides the Prometheus duration over which peak
+	// usage is measured when metrics analysis is enabled.
+	configMetricsWindow = "metricsWindow"
+
+	// configOverProvisionedRatio overrides how many times its peak usage a
+	// container must request to be reported as over-provisioned.
+	configOverProvisionedRatio = "overProvisionedRatio"
+
+	// configLimitUsagePercent overrides the percentage of its limit at which a
+	// container's peak usage is reported as running near the limit.
+	configLimitUsagePercent = "limitUsagePercent"
+
+	defaultMetricsWindow        = "7d"
+	defaultOverProvisionedRatio = 4
+	defaultLimitUsagePercent    = 90
+
+	// Requests below these are too small for right-sizing to save anything.
+	minRightSizingCPUCores    = 0.1
+	minRightSizingMemoryBytes = 128 << 20
+)
+
+// metricsWindowPattern matches a single-unit Prometheus duration.
+var metricsWindowPattern = regexp.MustCompile(`^[1-9][0-9]*[smhdwy]$`)
+
+// Peak usage queries, aggregated per container. Container-level series
+// without a container name belong to the pod cgroup and pause container.
+const (
+	cpuUsageQuery    = `max by (namespace, pod, container) (max_over_time(rate(container_cpu_usage_seconds_total{container!="",container!="POD",namespace!~"openshift-.*|kube-.*"}[5m])[%s:5m]))`
+	memoryUsageQuery = `max by (namespace, pod, container) (max_over_time(container_memory_working_set_bytes{container!="",container!="POD",namespace!~"openshift-.*|kube-.*"}[%s]))`
+)
+
 func init() {
 	_ = validator.Register(&CostOptimizationValidator{})
 }
@@ -59,6 +113,12 @@ func (v *CostOptimizationValidator) Category() string {
 	return validatorCategory
 }
 
+// ConfigKeys returns the override keys understood by this validator.
+func (v *CostOptimizationValidator) ConfigKeys() []string {
+	return []string{configLargeObjectThresholdKB, configHelmMaxRevisions, configAbandonedNamespaceDays,
+		configMetricsWindow, configOverProvisionedRatio, configLimitUsagePercent}
+}
+
 // Validate performs cost optimization checks.
 func (v *CostOptimizationValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
 	var findings []assessmentv1alpha1.Finding
@@ -72,6 +132,21 @@ func (v *CostOptimizationValidator) Validate(ctx context.Context, c client.Clien
 	// Check 3: Pods without resource specifications
 	findings = append(findings, v.checkResourceSpecifications(ctx, c)...)
 
+	// Check 4: Oversized Secrets and ConfigMaps
+	findings = append(findings, v.checkLargeObjects(ctx, c)...)
+
+	// Check 5: Helm release history and stuck releases
+	findings = append(findings, v.checkHelmReleases(ctx, c)...)
+
+	// Check 6: Pod QoS classes
+	findings = append(findings, v.checkQoSClasses(ctx, c, profile)...)
+
+	// Check 7: Old namespaces without running pods or recent activity
+	findings = append(findings, v.checkAbandonedNamespaces(ctx, c)...)
+
+	// Check 8: Requests and limits against historical usage
+	findings = append(findings, v.checkResourceUsage(ctx, c)...)
+
 	return findings, nil
 }
 
@@ -93,14 +168,14 @@ func (v *CostOptimizationValidator) checkOrphanPVCs(ctx context.Context, c clien
 	}
 
 	// Get all pods to find which PVCs are in use
-	pods := &corev1.PodList{}
-	if err := c.List(ctx, pods); err != nil {
+	pods, err := validator.ListPods(ctx, c)
+	if err != nil {
 		return findings
 	}
 
 	// Build map of PVCs in use
 	pvcInUse := make(map[string]bool)
-	for _, pod := range pods.Items {
+	for _, pod := range pods {
 		for _, volume := range pod.Spec.Volumes {
 			if volume.PersistentVolumeClaim != nil {
 				key := fmt.Sprintf("%s/%s", pod.Namespace, volume.PersistentVolumeClaim.ClaimName)
@@ -115,7 +190,7 @@ func (v *CostOptimizationValidator) checkOrphanPVCs(ctx context.Context, c clien
 
 	for _, pvc := range pvcs.Items {
 		// Skip system namespaces
-		if strings.HasPrefix(pvc.Namespace, "openshift-") || strings.HasPrefix(pvc.Namespace, "kube-") {
+		if strings.HasPrefix(pvc.Namespace, "openshift-") || strings.HasPrefix(pvc.Namespace, "kube-") || !validator.Selected(ctx, &pvc) {
 			continue
 		}
 
@@ -146,6 +221,7 @@ func (v *CostOptimizationValidator) checkOrphanPVCs(ctx context.Context, c clien
 			Validator:      validatorName,
 			Category:       validatorCategory,
 			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
 			Title:          "Orphan PVCs Detected",
 			Description:    fmt.Sprintf("Found %d bound PVC(s) not attached to any pod (total size: %s): %s...", len(orphanPVCs), totalOrphanSize.String(), strings.Join(sample, ", ")),
 			Impact:         "Orphan PVCs consume storage resources without being used.",
@@ -169,16 +245,16 @@ func (v *CostOptimizationValidator) checkOrphanPVCs(ctx context.Context, c clien
 func (v *CostOptimizationValidator) checkIdleDeployments(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
 	var findings []assessmentv1alpha1.Finding
 
-	deployments := &appsv1.DeploymentList{}
-	if err := c.List(ctx, deployments); err != nil {
+	deployments, err := validator.ListDeployments(ctx, c)
+	if err != nil {
 		return findings
 	}
 
 	var idleDeployments []string
 
-	for _, deploy := range deployments.Items {
+	for _, deploy := range deployments {
 		// Skip system namespaces
-		if strings.HasPrefix(deploy.Namespace, "openshift-") || strings.HasPrefix(deploy.Namespace, "kube-") {
+		if strings.HasPrefix(deploy.Namespace, "openshift-") || strings.HasPrefix(deploy.Namespace, "kube-") || !validator.Selected(ctx, &deploy) {
 			continue
 		}
 
@@ -213,17 +289,17 @@ func (v *CostOptimizationValidator) checkIdleDeployments(ctx context.Context, c
 func (v *CostOptimizationValidator) checkResourceSpecifications(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
 	var findings []assessmentv1alpha1.Finding
 
-	pods := &corev1.PodList{}
-	if err := c.List(ctx, pods); err != nil {
+	pods, err := validator.ListPods(ctx, c)
+	if err != nil {
 		return findings
 	}
 
 	var podsWithoutRequests []string
 	var podsWithoutLimits []string
 
-	for _, pod := range pods.Items {
+	for _, pod := range pods {
 		// Skip system namespaces
-		if strings.HasPrefix(pod.Namespace, "openshift-") || strings.HasPrefix(pod.Namespace, "kube-") {
+		if strings.HasPrefix(pod.Namespace, "openshift-") || strings.HasPrefix(pod.Namespace, "kube-") || !validator.Selected(ctx, &pod) {
 			continue
 		}
 
@@ -308,3 +384,649 @@ func (v *CostOptimizationValidator) checkResourceSpecifications(ctx context.Cont
 
 	return findings
 }
+
+// checkQoSClasses reports the QoS class distribution of active pods in user
+// namespaces and flags BestEffort pods, which the kubelet evicts first when a
+// node runs low on memory or disk. BestEffort pods are a warning with the
+// production profile and informational otherwise.
+func (v *CostOptimizationValidator) checkQoSClasses(ctx context.Context, c client.Client, profile profiles.Profile) []assessmentv1alpha1.Finding {
+	pods, err := validator.ListPods(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "costoptimization-pod-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Pod QoS Classes",
+			Description: fmt.Sprintf("Failed to list pods: %v", err),
+		}}
+	}
+
+	counts := make(map[corev1.PodQOSClass]int)
+	var bestEffort []string
+	var total int
+	for _, pod := range pods {
+		if strings.HasPrefix(pod.Namespace, "openshift-") || strings.HasPrefix(pod.Namespace, "kube-") || !validator.Selected(ctx, &pod) {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		total++
+		class := qosClass(pod)
+		counts[class]++
+		if class == corev1.PodQOSBestEffort {
+			bestEffort = append(bestEffort, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+		}
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	distribution := fmt.Sprintf("Guaranteed %d, Burstable %d, BestEffort %d",
+		counts[corev1.PodQOSGuaranteed], counts[corev1.PodQOSBurstable], counts[corev1.PodQOSBestEffort])
+
+	if len(bestEffort) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "costoptimization-no-besteffort-pods",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "No BestEffort Pods",
+			Description: fmt.Sprintf("None of the %d active pod(s) in user namespaces is BestEffort. QoS classes: %s.", total, distribution),
+		}}
+	}
+
+	status := assessmentv1alpha1.FindingStatusInfo
+	if profile.Name == profiles.ProfileProduction {
+		status = assessmentv1alpha1.FindingStatusWarn
+	}
+	sample := bestEffort
+	if len(sample) > 5 {
+		sample = sample[:5]
+	}
+
+	return []assessmentv1alpha1.Finding{{
+		ID:             "costoptimization-besteffort-pods",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         status,
+		Title:          "BestEffort Pods",
+		Description:    fmt.Sprintf("%d of %d active pod(s) in user namespaces have the BestEffort QoS class (QoS classes: %s): %s", len(bestEffort), total, distribution, strings.Join(sample, ", ")),
+		Impact:         "BestEffort pods set no requests or limits on any container. They are the first pods evicted when a node runs low on memory or disk, and the first killed by the out-of-memory killer, so they can disappear whenever a node is under pressure.",
+		Recommendation: "Set CPU and memory requests on every container to make the pods Burstable, or set limits equal to requests to make them Guaranteed. A LimitRange with default requests covers pods created without them.",
+		References: []string{
+			"https://kubernetes.io/docs/concepts/workloads/pods/pod-qos/",
+			"https://kubernetes.io/docs/concepts/scheduling-eviction/node-pressure-eviction/",
+		},
+	}}
+}
+
+// qosClass returns the QoS class the API server assigned to pod, or derives
+// it from the container resources when the status does not carry one.
+func qosClass(pod corev1.Pod) corev1.PodQOSClass {
+	if pod.Status.QOSClass != "" {
+		return pod.Status.QOSClass
+	}
+
+	containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	anySet := false
+	guaranteed := true
+	for _, container := range containers {
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			request, hasRequest := container.Resources.Requests[name]
+			limit, hasLimit := container.Resources.Limits[name]
+			if (hasRequest && !request.IsZero()) || (hasLimit && !limit.IsZero()) {
+				anySet = true
+			}
+			// A missing request defaults to the limit.
+			if !hasLimit || limit.IsZero() || (hasRequest && request.Cmp(limit) != 0) {
+				guaranteed = false
+			}
+		}
+	}
+
+	switch {
+	case !anySet:
+		return corev1.PodQOSBestEffort
+	case guaranteed:
+		return corev1.PodQOSGuaranteed
+	default:
+		return corev1.PodQOSBurstable
+	}
+}
+
+// largeObject is a Secret or ConfigMap and the size of its data.
+type largeObject struct {
+	ref  string
+	size int
+}
+
+// checkLargeObjects flags Secrets and ConfigMaps in user namespaces whose data
+// exceeds the size threshold. Objects are enumerated by metadata only and the
+// data of those in user namespaces is then fetched one at a time, through the
+// run's API reader when it has one; see dataSize.
+func (v *CostOptimizationValidator) checkLargeObjects(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	thresholdKB := validator.ConfigInt(ctx, configLargeObjectThresholdKB, defaultLargeObjectThresholdKB)
+	threshold := thresholdKB * 1024
+
+	var large []largeObject
+	for _, kind := range []string{"Secret", "ConfigMap"} {
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(kind + "List"))
+		if err := c.List(ctx, list); err != nil {
+			return []assessmentv1alpha1.Finding{{
+				ID:          "costoptimization-large-objects-error",
+				Validator:   validatorName,
+				Category:    validatorCategory,
+				Status:      assessmentv1alpha1.FindingStatusInfo,
+				Title:       "Unable to Check Object Sizes",
+				Description: fmt.Sprintf("Failed to list %ss: %v", kind, err),
+			}}
+		}
+
+		for _, item := range list.Items {
+			if strings.HasPrefix(item.Namespace, "openshift-") || strings.HasPrefix(item.Namespace, "kube-") || !validator.Selected(ctx, &item) {
+				continue
+			}
+			// Assessment reports stored by this operator are expected to be large.
+			if item.Labels["app.kubernetes.io/managed-by"] == "cluster-assessment-operator" {
+				continue
+			}
+			size, err := dataSize(ctx, c, kind, client.ObjectKeyFromObject(&item))
+			if err != nil || size <= threshold {
+				continue
+			}
+			large = append(large, largeObject{
+				ref:  fmt.Sprintf("%s %s/%s", kind, item.Namespace, item.Name),
+				size: size,
+			})
+		}
+	}
+
+	if len(large) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "costoptimization-no-large-objects",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "No Oversized Secrets or ConfigMaps",
+			Description: fmt.Sprintf("No Secrets or ConfigMaps in user namespaces exceed %d KiB.", thresholdKB),
+		}}
+	}
+
+	sort.Slice(large, func(i, j int) bool { return large[i].size > large[j].size })
+	var sample []string
+	for i, obj := range large {
+		if i == 5 {
+			break
+		}
+		sample = append(sample, fmt.Sprintf("%s (%d KiB)", obj.ref, obj.size/1024))
+	}
+
+	return []assessmentv1alpha1.Finding{{
+		ID:             "costoptimization-large-objects",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Title:          "Oversized Secrets or ConfigMaps",
+		Description:    fmt.Sprintf("Found %d Secret(s)/ConfigMap(s) larger than %d KiB, largest first: %s", len(large), thresholdKB, strings.Join(sample, ", ")),
+		Impact:         "Large objects bloat etcd, slow down watches and informer resyncs for every client, and approach the 1 MiB object size limit.",
+		Recommendation: "Store large files in an image, a PersistentVolume or object storage instead of Secrets and ConfigMaps.",
+		References: []string{
+			"https://kubernetes.io/docs/concepts/configuration/configmap/",
+		},
+	}}
+}
+
+// dataSize fetches a single Secret or ConfigMap and returns the size of its
+// data in bytes. It reads through the API reader in ctx when there is one: a
+// Get through the manager's cached client would start an informer holding
+// every Secret or ConfigMap of the cluster in memory.
+func dataSize(ctx context.Context, c client.Client, kind string, key client.ObjectKey) (int, error) {
+	var reader client.Reader = c
+	if apiReader := validator.APIReaderFromContext(ctx); apiReader != nil {
+		reader = apiReader
+	}
+
+	size := 0
+	switch kind {
+	case "Secret":
+		secret := &corev1.Secret{}
+		if err := reader.Get(ctx, key, secret); err != nil {
+			return 0, err
+		}
+		for k, v := range secret.Data {
+			size += len(k) + len(v)
+		}
+	case "ConfigMap":
+		cm := &corev1.ConfigMap{}
+		if err := reader.Get(ctx, key, cm); err != nil {
+			return 0, err
+		}
+		for k, v := range cm.Data {
+			size += len(k) + len(v)
+		}
+		for k, v := range cm.BinaryData {
+			size += len(k) + len(v)
+		}
+	}
+	return size, nil
+}
+
+// helmRelease summarizes the release Secrets Helm stores for one release.
+type helmRelease struct {
+	ref       string
+	revisions int
+	latest    int
+	status    string
+}
+
+// checkHelmReleases inspects the Secrets Helm 3 stores for each release
+// revision (type helm.sh/release.v1, labeled owner=helm). Only labels are
+// read, so the encoded release manifests are never fetched. Releases that
+// keep many revisions are reported as cleanup opportunities, and releases
+// whose latest revision is still pending are reported as stuck.
+func (v *CostOptimizationValidator) checkHelmReleases(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	maxRevisions := validator.ConfigInt(ctx, configHelmMaxRevisions, defaultHelmMaxRevisions)
+
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("SecretList"))
+	if err := c.List(ctx, list, client.MatchingLabels{"owner": "helm"}); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "costoptimization-helm-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Helm Releases",
+			Description: fmt.Sprintf("Failed to list Helm release Secrets: %v", err),
+		}}
+	}
+
+	releases := make(map[string]*helmRelease)
+	for _, item := range list.Items {
+		name := item.Labels["name"]
+		if name == "" {
+			continue
+		}
+		ref := item.Namespace + "/" + name
+		release, ok := releases[ref]
+		if !ok {
+			release = &helmRelease{ref: ref}
+			releases[ref] = release
+		}
+		release.revisions++
+		revision, _ := strconv.Atoi(item.Labels["version"])
+		if revision >= release.latest {
+			release.latest = revision
+			release.status = item.Labels["status"]
+		}
+	}
+	if len(releases) == 0 {
+		return nil
+	}
+
+	var retained, stuck []*helmRelease
+	for _, release := range releases {
+		if release.revisions > maxRevisions {
+			retained = append(retained, release)
+		}
+		if strings.HasPrefix(release.status, "pending-") {
+			stuck = append(stuck, release)
+		}
+	}
+
+	var findings []assessmentv1alpha1.Finding
+	if len(stuck) > 0 {
+		sort.Slice(stuck, func(i, j int) bool { return stuck[i].ref < stuck[j].ref })
+		var sample []string
+		for i, release := range stuck {
+			if i == 5 {
+				break
+			}
+			sample = append(sample, fmt.Sprintf("%s (revision %d, %s)", release.ref, release.latest, release.status))
+		}
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "costoptimization-helm-stuck-releases",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Helm Releases Stuck in a Pending State",
+			Description:    fmt.Sprintf("%d Helm release(s) have a latest revision that is still pending: %s", len(stuck), strings.Join(sample, ", ")),
+			Impact:         "Helm refuses to upgrade or roll back a release while another operation appears to be in progress, so fixes to these releases cannot be deployed.",
+			Recommendation: "Check whether the Helm operation is still running. If it was interrupted, roll the release back with 'helm rollback' or delete the Secret of the pending revision before retrying.",
+			References: []string{
+				"https://helm.sh/docs/helm/helm_rollback/",
+			},
+		})
+	}
+
+	if len(retained) > 0 {
+		sort.Slice(retained, func(i, j int) bool {
+			if retained[i].revisions != retained[j].revisions {
+				return retained[i].revisions > retained[j].revisions
+			}
+			return retained[i].ref < retained[j].ref
+		})
+		namespaces := make(map[string]bool)
+		var sample []string
+		for i, release := range retained {
+			namespaces[strings.SplitN(release.ref, "/", 2)[0]] = true
+			if i < 5 {
+				sample = append(sample, fmt.Sprintf("%s (%d revisions)", release.ref, release.revisions))
+			}
+		}
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "costoptimization-helm-revisions",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusInfo,
+			Title:          "Helm Releases Retaining Many Revisions",
+			Description:    fmt.Sprintf("%d Helm release(s) in %d namespace(s) keep more than %d revisions, most first: %s", len(retained), len(namespaces), maxRevisions, strings.Join(sample, ", ")),
+			Impact:         "Every revision is stored as a Secret holding the full rendered release, which adds to etcd size and Secret sprawl without being used.",
+			Recommendation: "Set --history-max on helm upgrade (or the equivalent setting of your GitOps tool) to limit the revisions kept per release.",
+			References: []string{
+				"https://helm.sh/docs/helm/helm_upgrade/",
+			},
+		})
+	}
+
+	if len(findings) == 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "costoptimization-helm-releases-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Helm Releases Healthy",
+			Description: fmt.Sprintf("%d Helm release(s) found; none is pending or keeps more than %d revisions.", len(releases), maxRevisions),
+		})
+	}
+
+	return findings
+}
+
+// abandonedNamespace is a namespace reported by checkAbandonedNamespaces.
+type abandonedNamespace struct {
+	name string
+	age  time.Duration
+}
+
+// checkAbandonedNamespaces reports user namespaces older than the configured
+// threshold that have no running pods and no pod created within the
+// threshold, as candidates for cleanup.
+func (v *CostOptimizationValidator) checkAbandonedNamespaces(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	days := validator.ConfigInt(ctx, configAbandonedNamespaceDays, defaultAbandonedNamespaceDays)
+	threshold := time.Duration(days) * 24 * time.Hour
+	now := time.Now()
+
+	namespaces, err := validator.ListNamespaces(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "costoptimization-namespace-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Namespace Activity",
+			Description: fmt.Sprintf("Failed to list namespaces: %v", err),
+		}}
+	}
+	pods, err := validator.ListPods(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "costoptimization-namespace-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Namespace Activity",
+			Description: fmt.Sprintf("Failed to list pods: %v", err),
+		}}
+	}
+
+	// A namespace is active if it runs a pod or created one recently, for
+	// example a CronJob whose pods have already completed.
+	active := make(map[string]bool)
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodRunning || now.Sub(pod.CreationTimestamp.Time) < threshold {
+			active[pod.Namespace] = true
+		}
+	}
+
+	var abandoned []abandonedNamespace
+	for _, ns := range namespaces {
+		if strings.HasPrefix(ns.Name, "openshift") || strings.HasPrefix(ns.Name, "kube-") || ns.Name == "default" {
+			continue
+		}
+		if ns.Status.Phase == corev1.NamespaceTerminating || active[ns.Name] {
+			continue
+		}
+		if age := now.Sub(ns.CreationTimestamp.Time); age >= threshold {
+			abandoned = append(abandoned, abandonedNamespace{name: ns.Name, age: age})
+		}
+	}
+
+	if len(abandoned) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "costoptimization-no-abandoned-namespaces",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "No Abandoned Namespaces",
+			Description: fmt.Sprintf("Every user namespace older than %d days runs pods or created one within that time.", days),
+		}}
+	}
+
+	sort.Slice(abandoned, func(i, j int) bool {
+		if abandoned[i].age != abandoned[j].age {
+			return abandoned[i].age > abandoned[j].age
+		}
+		return abandoned[i].name < abandoned[j].name
+	})
+	var sample []string
+	for i, ns := range abandoned {
+		if i == 5 {
+			break
+		}
+		sample = append(sample, fmt.Sprintf("%s (%d days old)", ns.name, int(ns.age.Hours()/24)))
+	}
+
+	return []assessmentv1alpha1.Finding{{
+		ID:             "costoptimization-abandoned-namespaces",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusInfo,
+		Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
+		Title:          "Namespaces Without Recent Activity",
+		Description:    fmt.Sprintf("Found %d namespace(s) older than %d days with no running pods and no pods created in that time, oldest first: %s", len(abandoned), days, strings.Join(sample, ", ")),
+		Impact:         "Abandoned projects keep their PVCs, Secrets, quotas and role bindings, which cost storage and widen access without serving any workload.",
+		Recommendation: "Confirm with the namespace owners whether these projects are still needed and delete the ones that are not.",
+	}}
+}
+
+// checkResourceUsage compares the peak CPU and memory usage of running
+// containers in user namespaces, queried from the cluster's metrics, with
+// their requests and limits. Containers requesting several times their peak
+// usage are reported as savings opportunities, and containers peaking close
+// to a limit as at risk of CPU throttling or OOM kills. It only runs when
+// metrics analysis is enabled for the assessment.
+func (v *CostOptimizationValidator) checkResourceUsage(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	querier := validator.MetricsQuerierFromContext(ctx)
+	if querier == nil {
+		return nil
+	}
+
+	window := validator.ConfigString(ctx, configMetricsWindow, defaultMetricsWindow)
+	if !metricsWindowPattern.MatchString(window) {
+		window = defaultMetricsWindow
+	}
+	ratio := validator.ConfigInt(ctx, configOverProvisionedRatio, defaultOverProvisionedRatio)
+	if ratio < 2 {
+		ratio = defaultOverProvisionedRatio
+	}
+	limitPercent := validator.ConfigInt(ctx, configLimitUsagePercent, defaultLimitUsagePercent)
+	if limitPercent < 1 || limitPercent > 100 {
+		limitPercent = defaultLimitUsagePercent
+	}
+
+	pods, err := validator.ListPods(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{usageError(fmt.Sprintf("Failed to list pods: %v", err))}
+	}
+	cpuUsage, err := peakUsage(ctx, querier, fmt.Sprintf(cpuUsageQuery, window))
+	if err != nil {
+		return []assessmentv1alpha1.Finding{usageError(fmt.Sprintf("Failed to query CPU usage: %v", err))}
+	}
+	memoryUsage, err := peakUsage(ctx, querier, fmt.Sprintf(memoryUsageQuery, window))
+	if err != nil {
+		return []assessmentv1alpha1.Finding{usageError(fmt.Sprintf("Failed to query memory usage: %v", err))}
+	}
+
+	var overProvisioned, nearLimit []string
+	var candidates, analyzed int
+	var excessCPU, excessMemory float64
+	for _, pod := range pods {
+		if strings.HasPrefix(pod.Namespace, "openshift-") || strings.HasPrefix(pod.Namespace, "kube-") || !validator.Selected(ctx, &pod) {
+			continue
+		}
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			candidates++
+			key := fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, container.Name)
+			cpu, hasCPU := cpuUsage[key]
+			memory, hasMemory := memoryUsage[key]
+			if !hasCPU && !hasMemory {
+				continue
+			}
+			analyzed++
+
+			var excess, limited []string
+			if hasCPU {
+				request := container.Resources.Requests.Cpu().AsApproximateFloat64()
+				limit := container.Resources.Limits.Cpu().AsApproximateFloat64()
+				if request >= minRightSizingCPUCores && cpu*float64(ratio) <= request {
+					excess = append(excess, fmt.Sprintf("CPU request %s, peak %s", formatCores(request), formatCores(cpu)))
+					excessCPU += request - cpu
+				}
+				if limit > 0 && cpu >= limit*float64(limitPercent)/100 {
+					limited = append(limited, fmt.Sprintf("CPU limit %s, peak %s", formatCores(limit), formatCores(cpu)))
+				}
+			}
+			if hasMemory {
+				request := container.Resources.Requests.Memory().AsApproximateFloat64()
+				limit := container.Resources.Limits.Memory().AsApproximateFloat64()
+				if request >= minRightSizingMemoryBytes && memory*float64(ratio) <= request {
+					excess = append(excess, fmt.Sprintf("memory request %s, peak %s", formatBytes(request), formatBytes(memory)))
+					excessMemory += request - memory
+				}
+				if limit > 0 && memory >= limit*float64(limitPercent)/100 {
+					limited = append(limited, fmt.Sprintf("memory limit %s, peak %s", formatBytes(limit), formatBytes(memory)))
+				}
+			}
+
+			if len(excess) > 0 {
+				overProvisioned = append(overProvisioned, fmt.Sprintf("%s (%s)", key, strings.Join(excess, ", ")))
+			}
+			if len(limited) > 0 {
+				nearLimit = append(nearLimit, fmt.Sprintf("%s (%s)", key, strings.Join(limited, ", ")))
+			}
+		}
+	}
+
+	if candidates == 0 {
+		return nil
+	}
+	if analyzed == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:             "costoptimization-usage-no-data",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusInfo,
+			Title:          "No Resource Usage Data",
+			Description:    fmt.Sprintf("The metrics endpoint returned no usage over the last %s for any of the %d running container(s) in user namespaces.", window, candidates),
+			Recommendation: "Check that spec.metricsEndpoint serves the cAdvisor container metrics collected by cluster monitoring.",
+		}}
+	}
+
+	var findings []assessmentv1alpha1.Finding
+	if len(overProvisioned) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "costoptimization-overprovisioned",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusInfo,
+			Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
+			Title:          "Over-Provisioned Containers",
+			Description:    fmt.Sprintf("%d of %d analyzed container(s) request at least %d times their peak usage over the last %s, reserving %s CPU and %s of memory more than they used: %s", len(overProvisioned), analyzed, ratio, window, formatCores(excessCPU), formatBytes(excessMemory), strings.Join(validator.SampleOf(overProvisioned), ", ")),
+			Impact:         "The scheduler reserves requested capacity whether or not it is used, so over-sized requests strand node capacity and drive up the number of nodes needed.",
+			Recommendation: "Lower the requests towards the observed peak plus headroom for spikes and growth, or let the Vertical Pod Autoscaler recommend them.",
+			References: []string{
+				"https://docs.openshift.com/container-platform/latest/nodes/pods/nodes-pods-vertical-autoscaler.html",
+			},
+		})
+	}
+	if len(nearLimit) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "costoptimization-near-limits",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
+			Title:          "Containers Running Near Their Limits",
+			Description:    fmt.Sprintf("%d of %d analyzed container(s) peaked at %d%% or more of a CPU or memory limit over the last %s: %s", len(nearLimit), analyzed, limitPercent, window, strings.Join(validator.SampleOf(nearLimit), ", ")),
+			Impact:         "Containers at their CPU limit are throttled, slowing requests down, and containers reaching their memory limit are OOM killed and restarted.",
+			Recommendation: "Raise the limits, and the requests with them, to cover the observed peak with headroom, or reduce the workload's resource consumption.",
+			References: []string{
+				"https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/",
+			},
+		})
+	}
+	if len(findings) == 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "costoptimization-usage-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Resource Requests Match Usage",
+			Description: fmt.Sprintf("None of the %d analyzed container(s) requests %d times its peak usage over the last %s or peaks at %d%% of a limit.", analyzed, ratio, window, limitPercent),
+		})
+	}
+	return findings
+}
+
+// usageError returns the finding reported when usage cannot be analyzed.
+func usageError(description string) assessmentv1alpha1.Finding {
+	return assessmentv1alpha1.Finding{
+		ID:             "costoptimization-usage-error",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusInfo,
+		Title:          "Unable to Analyze Resource Usage",
+		Description:    description,
+		Recommendation: "Check spec.metricsEndpoint and that the operator's service account may query it, for example by binding it to the cluster-monitoring-view ClusterRole.",
+	}
+}
+
+// peakUsage runs query and returns its values keyed by namespace/pod/container.
+func peakUsage(ctx context.Context, querier validator.MetricsQuerier, query string) (map[string]float64, error) {
+	series, err := querier.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	usage := make(map[string]float64, len(series))
+	for _, s := range series {
+		usage[fmt.Sprintf("%s/%s/%s", s.Labels["namespace"], s.Labels["pod"], s.Labels["container"])] = s.Value
+	}
+	return usage, nil
+}
+
+// formatCores formats a CPU amount in cores as millicores.
+func formatCores(cores float64) string {
+	return fmt.Sprintf("%.0fm", cores*1000)
+}
+
+// formatBytes formats a memory amount in bytes as mebibytes.
+func formatBytes(bytes float64) string {
+	return fmt.Sprintf("%.0fMi", bytes/(1<<20))
+}
@@ -0,0 +1,434 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package costoptimization
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/metrics"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator/validatortest"
+)
+
+func TestCheckLargeObjects(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	kib := func(n int) string { return strings.Repeat("x", n*1024) }
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "firmware"},
+			Data:       map[string]string{"image.bin": kib(900)},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "bundle"},
+			Data:       map[string][]byte{"ca.crt": []byte(kib(600))},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "small"},
+			Data:       map[string]string{"app.yaml": kib(10)},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-config", Name: "big-system"},
+			Data:       map[string]string{"data": kib(900)},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "cluster-assessment-operator",
+				Name:      "weekly-report-20260101-000000",
+				Labels:    map[string]string{"app.kubernetes.io/managed-by": "cluster-assessment-operator"},
+			},
+			BinaryData: map[string][]byte{"report.pdf": []byte(kib(800))},
+		},
+	).Build()
+
+	v := &CostOptimizationValidator{}
+	findings := v.checkLargeObjects(context.Background(), c)
+	f := validatortest.FindingByID(findings, "costoptimization-large-objects")
+	if f == nil || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("expected large objects WARN, got %+v", findings)
+	}
+	if !strings.Contains(f.Description, "Found 2 ") ||
+		!strings.Contains(f.Description, "largest first: ConfigMap team-a/firmware (900 KiB), Secret team-b/bundle (600 KiB)") {
+		t.Errorf("unexpected description: %s", f.Description)
+	}
+
+	// A higher threshold excludes the Secret.
+	ctx := validator.WithConfig(context.Background(), map[string]string{configLargeObjectThresholdKB: "800"})
+	findings = v.checkLargeObjects(ctx, c)
+	if f := validatortest.FindingByID(findings, "costoptimization-large-objects"); f == nil || !strings.Contains(f.Description, "Found 1 ") {
+		t.Errorf("expected one object above 800 KiB, got %+v", findings)
+	}
+}
+
+func TestCheckLargeObjects_ReadsDataThroughAPIReader(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "bundle"},
+		Data:       map[string][]byte{"ca.crt": []byte(strings.Repeat("x", 600*1024))},
+	}
+	// The cached client only serves lists
+	cached := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return fmt.Errorf("unexpected Get of %s through the cached client", key)
+			},
+		}).Build()
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+
+	ctx := validator.WithAPIReader(context.Background(), reader)
+	findings := (&CostOptimizationValidator{}).checkLargeObjects(ctx, cached)
+	if f := validatortest.FindingByID(findings, "costoptimization-large-objects"); f == nil || !strings.Contains(f.Description, "Secret team-a/bundle") {
+		t.Errorf("expected the Secret to be read through the API reader, got %+v", findings)
+	}
+}
+
+func TestCheckLargeObjects_None(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	findings := (&CostOptimizationValidator{}).checkLargeObjects(context.Background(), c)
+	if validatortest.FindingByID(findings, "costoptimization-no-large-objects") == nil {
+		t.Errorf("expected PASS finding, got %+v", findings)
+	}
+}
+
+func TestCheckHelmReleases(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	release := func(ns, name string, revision int, status string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns,
+				Name:      fmt.Sprintf("sh.helm.release.v1.%s.v%d", name, revision),
+				Labels: map[string]string{
+					"owner":   "helm",
+					"name":    name,
+					"version": fmt.Sprint(revision),
+					"status":  status,
+				},
+			},
+			Type: "helm.sh/release.v1",
+		}
+	}
+
+	var objs []client.Object
+	for i := 1; i <= 12; i++ {
+		status := "superseded"
+		if i == 12 {
+			status = "deployed"
+		}
+		objs = append(objs, release("team-a", "web", i, status))
+	}
+	objs = append(objs,
+		release("team-b", "db", 1, "deployed"),
+		release("team-b", "db", 2, "pending-upgrade"),
+		release("team-b", "cache", 1, "deployed"),
+	)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	v := &CostOptimizationValidator{}
+	findings := v.checkHelmReleases(context.Background(), c)
+
+	f := validatortest.FindingByID(findings, "costoptimization-helm-stuck-releases")
+	if f == nil || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("expected stuck releases WARN, got %+v", findings)
+	}
+	if !strings.Contains(f.Description, "team-b/db (revision 2, pending-upgrade)") {
+		t.Errorf("unexpected description: %s", f.Description)
+	}
+
+	f = validatortest.FindingByID(findings, "costoptimization-helm-revisions")
+	if f == nil || f.Status != assessmentv1alpha1.FindingStatusInfo {
+		t.Fatalf("expected revisions INFO, got %+v", findings)
+	}
+	if !strings.Contains(f.Description, "1 Helm release(s) in 1 namespace(s)") || !strings.Contains(f.Description, "team-a/web (12 revisions)") {
+		t.Errorf("unexpected description: %s", f.Description)
+	}
+
+	// A higher limit leaves only the stuck release.
+	ctx := validator.WithConfig(context.Background(), map[string]string{configHelmMaxRevisions: "20"})
+	findings = v.checkHelmReleases(ctx, c)
+	if validatortest.FindingByID(findings, "costoptimization-helm-revisions") != nil {
+		t.Errorf("expected no revisions finding with a limit of 20, got %+v", findings)
+	}
+}
+
+func TestCheckOrphanPVCs_LabelSelector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	payments := map[string]string{"app.kubernetes.io/part-of": "payments"}
+	pvc := func(name string, labels map[string]string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: name, Labels: labels},
+			Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		pvc("ledger", payments),
+		pvc("invoices", payments),
+		pvc("catalog", nil),
+		// The pod using the ledger claim is not labeled, but still counts as a user.
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "ledger-0"},
+			Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+				Name:         "data",
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "ledger"}},
+			}}},
+		},
+	).Build()
+
+	ctx := validator.WithResourceLabelSelector(context.Background(), payments)
+	findings := (&CostOptimizationValidator{}).checkOrphanPVCs(ctx, c)
+	f := validatortest.FindingByID(findings, "costoptimization-orphan-pvcs")
+	if f == nil {
+		t.Fatalf("expected orphan PVC finding, got %+v", findings)
+	}
+	if !strings.Contains(f.Description, "Found 1 ") || !strings.Contains(f.Description, "shop/invoices") {
+		t.Errorf("expected only the selected, unused claim, got: %s", f.Description)
+	}
+}
+
+func TestCheckQoSClasses(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	resources := func(request, limit string) corev1.ResourceRequirements {
+		var r corev1.ResourceRequirements
+		if request != "" {
+			r.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(request), corev1.ResourceMemory: resource.MustParse("64Mi")}
+		}
+		if limit != "" {
+			r.Limits = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(limit), corev1.ResourceMemory: resource.MustParse("64Mi")}
+		}
+		return r
+	}
+	pod := func(ns, name string, r corev1.ResourceRequirements) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Resources: r}}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	completed := pod("shop", "migration", corev1.ResourceRequirements{})
+	completed.Status.Phase = corev1.PodSucceeded
+	reported := pod("shop", "reported", corev1.ResourceRequirements{})
+	reported.Status.QOSClass = corev1.PodQOSBurstable
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		pod("shop", "cart", corev1.ResourceRequirements{}),
+		pod("shop", "catalog", resources("100m", "")),
+		pod("shop", "payments", resources("", "200m")),
+		pod("shop", "ledger", resources("200m", "200m")),
+		reported,
+		completed,
+		pod("openshift-dns", "dns", corev1.ResourceRequirements{}),
+	).Build()
+	v := &CostOptimizationValidator{}
+
+	findings := v.checkQoSClasses(context.Background(), c, profiles.GetProfile(string(profiles.ProfileProduction)))
+	f := validatortest.FindingByID(findings, "costoptimization-besteffort-pods")
+	if f == nil || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("expected BestEffort WARN, got %+v", findings)
+	}
+	if !strings.Contains(f.Description, "1 of 5 active pod(s)") ||
+		!strings.Contains(f.Description, "Guaranteed 2, Burstable 2, BestEffort 1") ||
+		!strings.Contains(f.Description, "shop/cart") {
+		t.Errorf("unexpected description: %s", f.Description)
+	}
+
+	findings = v.checkQoSClasses(context.Background(), c, profiles.GetProfile(string(profiles.ProfileDevelopment)))
+	if f := validatortest.FindingByID(findings, "costoptimization-besteffort-pods"); f == nil || f.Status != assessmentv1alpha1.FindingStatusInfo {
+		t.Errorf("expected BestEffort INFO with the development profile, got %+v", findings)
+	}
+}
+
+func TestCheckAbandonedNamespaces(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	daysAgo := func(days int) metav1.Time {
+		return metav1.NewTime(time.Now().Add(-time.Duration(days) * 24 * time.Hour))
+	}
+	namespace := func(name string, age int) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: daysAgo(age)}}
+	}
+	pod := func(ns, name string, phase corev1.PodPhase, age int) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name, CreationTimestamp: daysAgo(age)},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		namespace("legacy-app", 400),
+		namespace("old-demo", 200),
+		pod("old-demo", "job-1", corev1.PodSucceeded, 150),
+		namespace("shop", 300),
+		pod("shop", "cart", corev1.PodRunning, 300),
+		namespace("reports", 300),
+		pod("reports", "nightly", corev1.PodSucceeded, 1),
+		namespace("new-team", 10),
+		namespace("openshift-old", 900),
+		namespace("default", 900),
+	).Build()
+	v := &CostOptimizationValidator{}
+
+	findings := v.checkAbandonedNamespaces(context.Background(), c)
+	f := validatortest.FindingByID(findings, "costoptimization-abandoned-namespaces")
+	if f == nil || f.Status != assessmentv1alpha1.FindingStatusInfo {
+		t.Fatalf("expected abandoned namespaces INFO, got %+v", findings)
+	}
+	if !strings.Contains(f.Description, "Found 2 namespace(s) older than 90 days") ||
+		!strings.Contains(f.Description, "legacy-app (400 days old), old-demo (200 days old)") {
+		t.Errorf("unexpected description: %s", f.Description)
+	}
+	for _, unwanted := range []string{"shop", "reports", "new-team", "openshift-old", "default"} {
+		if strings.Contains(f.Description, unwanted) {
+			t.Errorf("did not expect %q in description: %s", unwanted, f.Description)
+		}
+	}
+
+	ctx := validator.WithConfig(context.Background(), map[string]string{configAbandonedNamespaceDays: "500"})
+	findings = v.checkAbandonedNamespaces(ctx, c)
+	if validatortest.FindingByID(findings, "costoptimization-no-abandoned-namespaces") == nil {
+		t.Errorf("expected PASS with a 500 day threshold, got %+v", findings)
+	}
+}
+
+// fakeQuerier answers usage queries from fixed per-container values keyed by
+// namespace/pod/container.
+type fakeQuerier struct {
+	cpu, memory map[string]float64
+	err         error
+}
+
+func (q *fakeQuerier) Query(_ context.Context, query string) ([]metrics.TimeSeries, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	values := q.memory
+	if strings.Contains(query, "container_cpu_usage_seconds_total") {
+		values = q.cpu
+	}
+	var series []metrics.TimeSeries
+	for key, value := range values {
+		parts := strings.Split(key, "/")
+		series = append(series, metrics.TimeSeries{
+			Labels: map[string]string{"namespace": parts[0], "pod": parts[1], "container": parts[2]},
+			Value:  value,
+		})
+	}
+	return series, nil
+}
+
+func TestCheckResourceUsage(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	pod := func(ns, name, cpuRequest, cpuLimit, memoryRequest, memoryLimit string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpuRequest), corev1.ResourceMemory: resource.MustParse(memoryRequest)},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpuLimit), corev1.ResourceMemory: resource.MustParse(memoryLimit)},
+				},
+			}}},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		pod("shop", "catalog", "2", "4", "4Gi", "8Gi"),
+		pod("shop", "cart", "500m", "500m", "512Mi", "512Mi"),
+		pod("shop", "tiny", "50m", "1", "64Mi", "1Gi"),
+		pod("openshift-dns", "dns", "2", "4", "4Gi", "8Gi"),
+	).Build()
+	querier := &fakeQuerier{
+		cpu: map[string]float64{
+			"shop/catalog/app": 0.1,
+			"shop/cart/app":    0.48,
+			"shop/tiny/app":    0.001,
+		},
+		memory: map[string]float64{
+			"shop/catalog/app": 512 << 20,
+			"shop/cart/app":    256 << 20,
+			"shop/tiny/app":    1 << 20,
+		},
+	}
+	v := &CostOptimizationValidator{}
+
+	if findings := v.checkResourceUsage(context.Background(), c); findings != nil {
+		t.Fatalf("expected no findings without metrics analysis, got %+v", findings)
+	}
+
+	ctx := validator.WithMetricsQuerier(context.Background(), querier)
+	findings := v.checkResourceUsage(ctx, c)
+
+	f := validatortest.FindingByID(findings, "costoptimization-overprovisioned")
+	if f == nil || f.Status != assessmentv1alpha1.FindingStatusInfo {
+		t.Fatalf("expected over-provisioned INFO, got %+v", findings)
+	}
+	if !strings.Contains(f.Description, "1 of 3 analyzed container(s)") ||
+		!strings.Contains(f.Description, "reserving 1900m CPU and 3584Mi of memory") ||
+		!strings.Contains(f.Description, "shop/catalog/app (CPU request 2000m, peak 100m, memory request 4096Mi, peak 512Mi)") {
+		t.Errorf("unexpected description: %s", f.Description)
+	}
+	if strings.Contains(f.Description, "tiny") || strings.Contains(f.Description, "openshift-dns") {
+		t.Errorf("did not expect small requests or system pods in description: %s", f.Description)
+	}
+
+	f = validatortest.FindingByID(findings, "costoptimization-near-limits")
+	if f == nil || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("expected near-limits WARN, got %+v", findings)
+	}
+	if !strings.Contains(f.Description, "shop/cart/app (CPU limit 500m, peak 480m)") || strings.Contains(f.Description, "catalog") {
+		t.Errorf("unexpected description: %s", f.Description)
+	}
+
+	ctx = validator.WithConfig(ctx, map[string]string{configOverProvisionedRatio: "50", configLimitUsagePercent: "100"})
+	findings = v.checkResourceUsage(ctx, c)
+	if validatortest.FindingByID(findings, "costoptimization-usage-ok") == nil {
+		t.Errorf("expected PASS with relaxed thresholds, got %+v", findings)
+	}
+
+	ctx = validator.WithMetricsQuerier(context.Background(), &fakeQuerier{err: fmt.Errorf("403 Forbidden")})
+	findings = v.checkResourceUsage(ctx, c)
+	if f := validatortest.FindingByID(findings, "costoptimization-usage-error"); f == nil || !strings.Contains(f.Description, "403 Forbidden") {
+		t.Errorf("expected usage error INFO, got %+v", findings)
+	}
+}
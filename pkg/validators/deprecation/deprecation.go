@@ -25,6 +25,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
@@ -78,6 +79,9 @@ func (v *DeprecationValidator) Validate(ctx context.Context, c client.Client, pr
 	// Check 2: Resources without recommended fields
 	findings = append(findings, v.checkMissingRecommendedFields(ctx, c)...)
 
+	// Check 3: Image pull policy and tag combinations
+	findings = append(findings, v.checkImagePullPolicies(ctx, c)...)
+
 	return findings, nil
 }
 
@@ -90,6 +94,9 @@ func (v *DeprecationValidator) checkDeprecatedPatterns(ctx context.Context, c cl
 	if err := c.List(ctx, ingresses); err == nil {
 		var noClassName []string
 		for _, ing := range ingresses.Items {
+			if !validator.Selected(ctx, &ing) {
+				continue
+			}
 			if ing.Spec.IngressClassName == nil && ing.Annotations["kubernetes.io/ingress.class"] == "" {
 				noClassName = append(noClassName, fmt.Sprintf("%s/%s", ing.Namespace, ing.Name))
 			}
@@ -116,20 +123,27 @@ func (v *DeprecationValidator) checkDeprecatedPatterns(ctx context.Context, c cl
 	}
 
 	// Check for Deployments with deprecated fields
-	deployments := &appsv1.DeploymentList{}
-	if err := c.List(ctx, deployments); err == nil {
+	if deployments, err := validator.ListDeployments(ctx, c); err == nil {
 		var noProbes []string
+		var identicalProbes []string
+		var livenessOnly []string
 		var noResources []string
 
-		for _, deploy := range deployments.Items {
+		for _, deploy := range deployments {
 			// Skip system namespaces
-			if strings.HasPrefix(deploy.Namespace, "openshift-") || strings.HasPrefix(deploy.Namespace, "kube-") {
+			if strings.HasPrefix(deploy.Namespace, "openshift-") || strings.HasPrefix(deploy.Namespace, "kube-") || !validator.Selected(ctx, &deploy) {
 				continue
 			}
 
 			for _, container := range deploy.Spec.Template.Spec.Containers {
-				if container.LivenessProbe == nil && container.ReadinessProbe == nil {
-					noProbes = append(noProbes, fmt.Sprintf("%s/%s:%s", deploy.Namespace, deploy.Name, container.Name))
+				ref := fmt.Sprintf("%s/%s:%s", deploy.Namespace, deploy.Name, container.Name)
+				switch {
+				case container.LivenessProbe == nil && container.ReadinessProbe == nil:
+					noProbes = append(noProbes, ref)
+				case container.ReadinessProbe == nil:
+					livenessOnly = append(livenessOnly, ref)
+				case container.LivenessProbe != nil && equality.Semantic.DeepEqual(container.LivenessProbe, container.ReadinessProbe):
+					identicalProbes = append(identicalProbes, ref)
 				}
 				if container.Resources.Requests == nil && container.Resources.Limits == nil {
 					noResources = append(noResources, fmt.Sprintf("%s/%s:%s", deploy.Namespace, deploy.Name, container.Name))
@@ -154,6 +168,46 @@ func (v *DeprecationValidator) checkDeprecatedPatterns(ctx context.Context, c cl
 			})
 		}
 
+		if len(identicalProbes) > 0 {
+			sample := identicalProbes
+			if len(sample) > 5 {
+				sample = sample[:5]
+			}
+			findings = append(findings, assessmentv1alpha1.Finding{
+				ID:             "deprecation-identical-probes",
+				Validator:      validatorName,
+				Category:       validatorCategory,
+				Status:         assessmentv1alpha1.FindingStatusWarn,
+				Title:          "Liveness Probe Identical to Readiness Probe",
+				Description:    fmt.Sprintf("Found %d container(s) whose livenessProbe is identical to the readinessProbe: %s", len(identicalProbes), strings.Join(sample, ", ")),
+				Impact:         "When a shared dependency slows down, both probes fail together and the kubelet restarts containers that only needed to stop receiving traffic, which can cause restart storms.",
+				Recommendation: "Make the livenessProbe check only that the process is alive, and give it a higher failureThreshold or longer period than the readinessProbe.",
+				References: []string{
+					"https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/",
+				},
+			})
+		}
+
+		if len(livenessOnly) > 0 {
+			sample := livenessOnly
+			if len(sample) > 5 {
+				sample = sample[:5]
+			}
+			findings = append(findings, assessmentv1alpha1.Finding{
+				ID:             "deprecation-liveness-without-readiness",
+				Validator:      validatorName,
+				Category:       validatorCategory,
+				Status:         assessmentv1alpha1.FindingStatusWarn,
+				Title:          "Liveness Probe Without Readiness Probe",
+				Description:    fmt.Sprintf("Found %d container(s) with a livenessProbe but no readinessProbe: %s", len(livenessOnly), strings.Join(sample, ", ")),
+				Impact:         "Without a readinessProbe, Services send traffic to a pod as soon as its containers start, before the application is ready to serve it.",
+				Recommendation: "Add a readinessProbe that reports when the container can accept traffic.",
+				References: []string{
+					"https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/",
+				},
+			})
+		}
+
 		if len(noResources) > 0 {
 			sample := noResources
 			if len(sample) > 5 {
@@ -180,12 +234,11 @@ func (v *DeprecationValidator) checkMissingRecommendedFields(ctx context.Context
 	var findings []assessmentv1alpha1.Finding
 
 	// Check for pods without proper labels
-	pods := &corev1.PodList{}
-	if err := c.List(ctx, pods); err == nil {
+	if pods, err := validator.ListPods(ctx, c); err == nil {
 		var noAppLabel []string
-		for _, pod := range pods.Items {
+		for _, pod := range pods {
 			// Skip system namespaces
-			if strings.HasPrefix(pod.Namespace, "openshift-") || strings.HasPrefix(pod.Namespace, "kube-") {
+			if strings.HasPrefix(pod.Namespace, "openshift-") || strings.HasPrefix(pod.Namespace, "kube-") || !validator.Selected(ctx, &pod) {
 				continue
 			}
 			// Skip completed pods
@@ -215,6 +268,7 @@ func (v *DeprecationValidator) checkMissingRecommendedFields(ctx context.Context
 				Validator:      validatorName,
 				Category:       validatorCategory,
 				Status:         assessmentv1alpha1.FindingStatusInfo,
+				Confidence:     assessmentv1alpha1.FindingConfidenceLow,
 				Title:          "Pods Without App Labels",
 				Description:    fmt.Sprintf("Found %d pod(s) without app-related labels: %s...", len(noAppLabel), strings.Join(sample, ", ")),
 				Recommendation: "Use consistent labeling (app.kubernetes.io/name, app.kubernetes.io/component) for better observability.",
@@ -229,7 +283,7 @@ func (v *DeprecationValidator) checkMissingRecommendedFields(ctx context.Context
 		var noFailedLimit []string
 
 		for _, cj := range cronJobs.Items {
-			if strings.HasPrefix(cj.Namespace, "openshift-") || strings.HasPrefix(cj.Namespace, "kube-") {
+			if strings.HasPrefix(cj.Namespace, "openshift-") || strings.HasPrefix(cj.Namespace, "kube-") || !validator.Selected(ctx, &cj) {
 				continue
 			}
 
@@ -258,3 +312,162 @@ func (v *DeprecationValidator) checkMissingRecommendedFields(ctx context.Context
 
 	return findings
 }
+
+// podTemplate is a workload pod template to inspect for image settings.
+type podTemplate struct {
+	kind      string
+	namespace string
+	name      string
+	spec      corev1.PodSpec
+}
+
+// checkImagePullPolicies flags containers that pull mutable :latest images on
+// every start and digest-pinned images that are re-pulled needlessly.
+func (v *DeprecationValidator) checkImagePullPolicies(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	var findings []assessmentv1alpha1.Finding
+
+	listError := func(kind string, err error) []assessmentv1alpha1.Finding {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "deprecation-image-pinning-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Image Pull Policies",
+			Description: fmt.Sprintf("Failed to list %s: %v", kind, err),
+		}}
+	}
+
+	var templates []podTemplate
+	deployments, err := validator.ListDeployments(ctx, c)
+	if err != nil {
+		return listError("Deployments", err)
+	}
+	for _, d := range deployments {
+		if !validator.Selected(ctx, &d) {
+			continue
+		}
+		templates = append(templates, podTemplate{"deployment", d.Namespace, d.Name, d.Spec.Template.Spec})
+	}
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := c.List(ctx, statefulSets); err != nil {
+		return listError("StatefulSets", err)
+	}
+	for _, s := range statefulSets.Items {
+		if !validator.Selected(ctx, &s) {
+			continue
+		}
+		templates = append(templates, podTemplate{"statefulset", s.Namespace, s.Name, s.Spec.Template.Spec})
+	}
+	daemonSets := &appsv1.DaemonSetList{}
+	if err := c.List(ctx, daemonSets); err != nil {
+		return listError("DaemonSets", err)
+	}
+	for _, ds := range daemonSets.Items {
+		if !validator.Selected(ctx, &ds) {
+			continue
+		}
+		templates = append(templates, podTemplate{"daemonset", ds.Namespace, ds.Name, ds.Spec.Template.Spec})
+	}
+
+	var latestAlways, digestAlways []string
+	var total, pinned int
+	for _, t := range templates {
+		if strings.HasPrefix(t.namespace, "openshift-") || strings.HasPrefix(t.namespace, "kube-") {
+			continue
+		}
+
+		containers := append(append([]corev1.Container{}, t.spec.InitContainers...), t.spec.Containers...)
+		for _, container := range containers {
+			total++
+			digest := isDigestPinned(container.Image)
+			policy := effectivePullPolicy(container)
+			ref := fmt.Sprintf("%s %s/%s:%s (%s)", t.kind, t.namespace, t.name, container.Name, container.Image)
+
+			switch {
+			case digest && policy == corev1.PullIfNotPresent:
+				pinned++
+			case digest && policy == corev1.PullAlways:
+				digestAlways = append(digestAlways, ref)
+			case !digest && usesLatestTag(container.Image) && policy == corev1.PullAlways:
+				latestAlways = append(latestAlways, ref)
+			}
+		}
+	}
+
+	if total == 0 {
+		return findings
+	}
+
+	if len(latestAlways) > 0 {
+		sample := latestAlways
+		if len(sample) > 5 {
+			sample = sample[:5]
+		}
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "deprecation-latest-always-pull",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Mutable :latest Images Pulled on Every Start",
+			Description:    fmt.Sprintf("Found %d container(s) using a :latest or untagged image with imagePullPolicy Always: %s", len(latestAlways), strings.Join(sample, ", ")),
+			Impact:         "Replicas started at different times can run different image contents, and every pod start depends on the registry.",
+			Recommendation: "Pin images to a version tag or digest and use imagePullPolicy IfNotPresent.",
+			References: []string{
+				"https://kubernetes.io/docs/concepts/containers/images/#image-pull-policy",
+			},
+		})
+	}
+
+	if len(digestAlways) > 0 {
+		sample := digestAlways
+		if len(sample) > 5 {
+			sample = sample[:5]
+		}
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "deprecation-digest-always-pull",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusInfo,
+			Title:          "Digest-Pinned Images Pulled on Every Start",
+			Description:    fmt.Sprintf("Found %d container(s) using a digest-pinned image with imagePullPolicy Always: %s", len(digestAlways), strings.Join(sample, ", ")),
+			Impact:         "A digest never changes, so pulling on every start only adds registry load and startup latency.",
+			Recommendation: "Use imagePullPolicy IfNotPresent for digest-pinned images.",
+		})
+	}
+
+	findings = append(findings, assessmentv1alpha1.Finding{
+		ID:          "deprecation-image-pinning",
+		Validator:   validatorName,
+		Category:    validatorCategory,
+		Status:      assessmentv1alpha1.FindingStatusInfo,
+		Title:       "Image Pinning Summary",
+		Description: fmt.Sprintf("%d of %d workload container(s) use digest-pinned images with imagePullPolicy IfNotPresent.", pinned, total),
+	})
+
+	return findings
+}
+
+// isDigestPinned reports whether the image reference includes a digest.
+func isDigestPinned(image string) bool {
+	return strings.Contains(image, "@")
+}
+
+// usesLatestTag reports whether the image reference uses the :latest tag,
+// explicitly or by omitting the tag.
+func usesLatestTag(image string) bool {
+	name := image[strings.LastIndex(image, "/")+1:]
+	i := strings.LastIndex(name, ":")
+	return i < 0 || name[i+1:] == "latest"
+}
+
+// effectivePullPolicy returns the container pull policy, applying the API
+// server default when it is unset.
+func effectivePullPolicy(container corev1.Container) corev1.PullPolicy {
+	if container.ImagePullPolicy != "" {
+		return container.ImagePullPolicy
+	}
+	if !isDigestPinned(container.Image) && usesLatestTag(container.Image) {
+		return corev1.PullAlways
+	}
+	return corev1.PullIfNotPresent
+}
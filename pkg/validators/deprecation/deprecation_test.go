@@ -0,0 +1,189 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deprecation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator/validatortest"
+)
+
+func deploymentWith(namespace, name string, containers ...corev1.Container) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: containers}},
+		},
+	}
+}
+
+func TestCheckImagePullPolicies(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	digest := "quay.io/example/app@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		deploymentWith("team-a", "latest",
+			corev1.Container{Name: "app", Image: "quay.io/example/app:latest", ImagePullPolicy: corev1.PullAlways},
+			// An untagged image defaults to Always.
+			corev1.Container{Name: "sidecar", Image: "registry:5000/example/sidecar"},
+		),
+		deploymentWith("team-a", "digest-always",
+			corev1.Container{Name: "app", Image: digest, ImagePullPolicy: corev1.PullAlways},
+		),
+		deploymentWith("team-b", "pinned",
+			corev1.Container{Name: "app", Image: digest, ImagePullPolicy: corev1.PullIfNotPresent},
+			corev1.Container{Name: "tagged", Image: "quay.io/example/app:1.2.3"},
+		),
+		deploymentWith("openshift-system", "ignored",
+			corev1.Container{Name: "app", Image: "quay.io/example/app:latest", ImagePullPolicy: corev1.PullAlways},
+		),
+	).Build()
+
+	v := &DeprecationValidator{}
+	findings := v.checkImagePullPolicies(context.Background(), c)
+
+	latest := validatortest.FindingByID(findings, "deprecation-latest-always-pull")
+	if latest == nil || latest.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("expected deprecation-latest-always-pull WARN, got %+v", findings)
+	}
+	if !strings.Contains(latest.Description, "Found 2 container(s)") || strings.Contains(latest.Description, "openshift-system") {
+		t.Errorf("unexpected description: %s", latest.Description)
+	}
+
+	digestAlways := validatortest.FindingByID(findings, "deprecation-digest-always-pull")
+	if digestAlways == nil || !strings.Contains(digestAlways.Description, "team-a/digest-always:app") {
+		t.Errorf("expected deprecation-digest-always-pull for team-a/digest-always, got %+v", digestAlways)
+	}
+
+	summary := validatortest.FindingByID(findings, "deprecation-image-pinning")
+	if summary == nil || !strings.HasPrefix(summary.Description, "1 of 5 ") {
+		t.Errorf("unexpected pinning summary: %+v", summary)
+	}
+}
+
+func TestCheckImagePullPolicies_ListError(t *testing.T) {
+	// Without apps/v1 in the scheme the Deployment list fails
+	c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+
+	v := &DeprecationValidator{}
+	findings := v.checkImagePullPolicies(context.Background(), c)
+
+	if len(findings) != 1 || findings[0].ID != "deprecation-image-pinning-error" || findings[0].Status != assessmentv1alpha1.FindingStatusInfo {
+		t.Errorf("expected a single deprecation-image-pinning-error INFO finding, got %+v", findings)
+	}
+}
+
+func TestCheckImagePullPolicies_ResourceLabelSelector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	payments := map[string]string{"app.kubernetes.io/part-of": "payments"}
+	selected := deploymentWith("team-a", "ledger",
+		corev1.Container{Name: "app", Image: "quay.io/example/ledger:latest", ImagePullPolicy: corev1.PullAlways},
+	)
+	selected.Labels = payments
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		selected,
+		deploymentWith("team-a", "catalog",
+			corev1.Container{Name: "app", Image: "quay.io/example/catalog:latest", ImagePullPolicy: corev1.PullAlways},
+		),
+	).Build()
+
+	v := &DeprecationValidator{}
+	ctx := validator.WithResourceLabelSelector(context.Background(), payments)
+	findings := v.checkImagePullPolicies(ctx, c)
+
+	latest := validatortest.FindingByID(findings, "deprecation-latest-always-pull")
+	if latest == nil || !strings.Contains(latest.Description, "team-a/ledger:app") || strings.Contains(latest.Description, "catalog") {
+		t.Errorf("expected only the selected deployment to be assessed, got %+v", latest)
+	}
+}
+
+func TestUsesLatestTag(t *testing.T) {
+	tests := map[string]bool{
+		"nginx":                      true,
+		"nginx:latest":               true,
+		"registry:5000/nginx":        true,
+		"registry:5000/nginx:1.25":   false,
+		"quay.io/example/app:v1.0.0": false,
+	}
+	for image, want := range tests {
+		if got := usesLatestTag(image); got != want {
+			t.Errorf("usesLatestTag(%q) = %v, want %v", image, got, want)
+		}
+	}
+}
+
+func TestCheckProbeSemantics(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = networkingv1.AddToScheme(scheme)
+
+	httpProbe := func(path string) *corev1.Probe {
+		return &corev1.Probe{ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: path}}}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		deploymentWith("team-a", "identical",
+			corev1.Container{Name: "app", LivenessProbe: httpProbe("/healthz"), ReadinessProbe: httpProbe("/healthz")},
+		),
+		deploymentWith("team-a", "liveness-only",
+			corev1.Container{Name: "app", LivenessProbe: httpProbe("/healthz")},
+		),
+		deploymentWith("team-b", "good",
+			corev1.Container{Name: "app", LivenessProbe: httpProbe("/livez"), ReadinessProbe: httpProbe("/readyz")},
+			corev1.Container{Name: "readiness-only", ReadinessProbe: httpProbe("/readyz")},
+		),
+		deploymentWith("openshift-system", "ignored",
+			corev1.Container{Name: "app", LivenessProbe: httpProbe("/healthz")},
+		),
+	).Build()
+
+	v := &DeprecationValidator{}
+	findings := v.checkDeprecatedPatterns(context.Background(), c)
+
+	identical := validatortest.FindingByID(findings, "deprecation-identical-probes")
+	if identical == nil || !strings.Contains(identical.Description, "Found 1 container(s)") ||
+		!strings.Contains(identical.Description, "team-a/identical:app") {
+		t.Errorf("expected deprecation-identical-probes for team-a/identical:app, got %+v", identical)
+	}
+
+	livenessOnly := validatortest.FindingByID(findings, "deprecation-liveness-without-readiness")
+	if livenessOnly == nil || !strings.Contains(livenessOnly.Description, "Found 1 container(s)") ||
+		!strings.Contains(livenessOnly.Description, "team-a/liveness-only:app") {
+		t.Errorf("expected deprecation-liveness-without-readiness for team-a/liveness-only:app, got %+v", livenessOnly)
+	}
+
+	if f := validatortest.FindingByID(findings, "deprecation-no-probes"); f != nil {
+		t.Errorf("expected no deprecation-no-probes finding, got %+v", f)
+	}
+}
@@ -22,7 +22,9 @@ import (
 	"strings"
 	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -81,6 +83,7 @@ func (v *EtcdBackupValidator) Validate(ctx context.Context, c client.Client, pro
 			Validator:      validatorName,
 			Category:       validatorCategory,
 			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
 			Title:          "No Backup Solution Detected",
 			Description:    "No etcd backup configuration was detected. Consider implementing a backup strategy.",
 			Impact:         "Without backups, cluster recovery after data loss may not be possible.",
@@ -91,6 +94,9 @@ func (v *EtcdBackupValidator) Validate(ctx context.Context, c client.Client, pro
 		})
 	}
 
+	// Check where backups are written and whether they are protected
+	findings = append(findings, v.checkBackupDestinations(ctx, c, profile)...)
+
 	return findings, nil
 }
 
@@ -124,6 +130,8 @@ func (v *EtcdBackupValidator) checkOADP(ctx context.Context, c client.Client) []
 					Status:      assessmentv1alpha1.FindingStatusPass,
 					Title:       "OADP Configured",
 					Description: fmt.Sprintf("OpenShift API for Data Protection is configured: %s/%s", namespace, name),
+					Resource:    name,
+					Namespace:   namespace,
 				})
 			} else {
 				findings = append(findings, assessmentv1alpha1.Finding{
@@ -133,6 +141,8 @@ func (v *EtcdBackupValidator) checkOADP(ctx context.Context, c client.Client) []
 					Status:         assessmentv1alpha1.FindingStatusWarn,
 					Title:          "OADP Configuration Issue",
 					Description:    fmt.Sprintf("OADP %s/%s is in phase: %s", namespace, name, phase),
+					Resource:       name,
+					Namespace:      namespace,
 					Recommendation: "Check the OADP operator logs and DataProtectionApplication status.",
 				})
 			}
@@ -210,8 +220,11 @@ func (v *EtcdBackupValidator) checkBackupCronJobs(ctx context.Context, c client.
 						Validator:   validatorName,
 						Category:    validatorCategory,
 						Status:      status,
+						Confidence:  assessmentv1alpha1.FindingConfidenceLow,
 						Title:       "Backup CronJob Detected",
 						Description: desc,
+						Resource:    name,
+						Namespace:   namespace,
 					})
 				}
 			}
@@ -234,6 +247,7 @@ func (v *EtcdBackupValidator) checkVelero(ctx context.Context, c client.Client)
 			Validator:   validatorName,
 			Category:    validatorCategory,
 			Status:      assessmentv1alpha1.FindingStatusPass,
+			Confidence:  assessmentv1alpha1.FindingConfidenceMedium,
 			Title:       "Velero Namespace Found",
 			Description: "Velero backup solution appears to be installed.",
 		})
@@ -255,6 +269,210 @@ func (v *EtcdBackupValidator) checkVelero(ctx context.Context, c client.Client)
 	return findings
 }
 
+// encryptionParameters are StorageClass parameters that enable encryption at
+// rest on common provisioners (AWS EBS, GCE PD, Azure Disk, Ceph RBD).
+var encryptionParameters = []string{"encrypted", "kmsKeyId", "disk-encryption-kms-key", "diskEncryptionSetID", "encryptionKMSID"}
+
+// serverSideEncryptionKeys are Velero backup location config keys that
+// enable server-side encryption of objects in the bucket.
+var serverSideEncryptionKeys = []string{"serverSideEncryption", "kmsKeyId", "customerKeyEncryptionFile", "encryptionKeyId"}
+
+// checkBackupDestinations reports where backup CronJobs and OADP write their
+// backups, and flags destinations that are readable from outside the backup
+// job or not encrypted at rest.
+func (v *EtcdBackupValidator) checkBackupDestinations(ctx context.Context, c client.Client, profile profiles.Profile) []assessmentv1alpha1.Finding {
+	var findings []assessmentv1alpha1.Finding
+
+	unprotected := assessmentv1alpha1.FindingStatusInfo
+	if profile.Name == profiles.ProfileProduction {
+		unprotected = assessmentv1alpha1.FindingStatusWarn
+	}
+
+	// List metadata only and fetch just the backup CronJobs, as in checkBackupCronJobs
+	cronJobList := &metav1.PartialObjectMetadataList{}
+	cronJobList.SetGroupVersionKind(batchv1.SchemeGroupVersion.WithKind("CronJobList"))
+	if err := c.List(ctx, cronJobList); err == nil {
+		for _, item := range cronJobList.Items {
+			if !containsBackupKeyword(item.Name) {
+				continue
+			}
+			cj := &batchv1.CronJob{}
+			if err := c.Get(ctx, client.ObjectKey{Namespace: item.Namespace, Name: item.Name}, cj); err != nil {
+				continue
+			}
+			findings = append(findings, v.cronJobDestination(ctx, c, *cj, unprotected))
+		}
+	}
+
+	dpaList := &unstructured.UnstructuredList{}
+	dpaList.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "oadp.openshift.io",
+		Version: "v1alpha1",
+		Kind:    "DataProtectionApplicationList",
+	})
+	if err := c.List(ctx, dpaList); err == nil {
+		for _, dpa := range dpaList.Items {
+			evidence := validator.Evidence(ctx, &dpa)
+			for _, f := range objectStoreDestinations(dpa, unprotected) {
+				f.Evidence = evidence
+				findings = append(findings, f)
+			}
+		}
+	}
+
+	return findings
+}
+
+// cronJobDestination describes the volume a backup CronJob writes to.
+func (v *EtcdBackupValidator) cronJobDestination(ctx context.Context, c client.Client, cj batchv1.CronJob, unprotected assessmentv1alpha1.FindingStatus) assessmentv1alpha1.Finding {
+	finding := assessmentv1alpha1.Finding{
+		ID:        fmt.Sprintf("etcdbackup-destination-%s-%s", cj.Namespace, cj.Name),
+		Validator: validatorName,
+		Category:  validatorCategory,
+		Status:    assessmentv1alpha1.FindingStatusInfo,
+		Title:     "Backup Destination",
+		Resource:  cj.Name,
+		Namespace: cj.Namespace,
+		Evidence:  validator.Evidence(ctx, &cj),
+		References: []string{
+			"https://docs.openshift.com/container-platform/latest/backup_and_restore/control_plane_backup_and_restore/backing-up-etcd.html",
+		},
+	}
+
+	for _, vol := range cj.Spec.JobTemplate.Spec.Template.Spec.Volumes {
+		switch {
+		case vol.HostPath != nil:
+			finding.Status = assessmentv1alpha1.FindingStatusWarn
+			finding.Title = "Backups Stored on a Node Host Path"
+			finding.Description = fmt.Sprintf("Backup CronJob %s/%s writes to host path %s.", cj.Namespace, cj.Name, vol.HostPath.Path)
+			finding.Impact = "Backups on a node file system are unencrypted, readable by anything with access to the node, and lost with the node."
+			finding.Recommendation = "Write backups to an encrypted PVC or copy them to an encrypted object store."
+			return finding
+
+		case vol.PersistentVolumeClaim != nil:
+			claim := vol.PersistentVolumeClaim.ClaimName
+			pvc := &corev1.PersistentVolumeClaim{}
+			if err := c.Get(ctx, client.ObjectKey{Namespace: cj.Namespace, Name: claim}, pvc); err != nil {
+				finding.Description = fmt.Sprintf("Backup CronJob %s/%s writes to PVC %s, which could not be inspected: %v", cj.Namespace, cj.Name, claim, err)
+				return finding
+			}
+
+			if sharedAccess(pvc) {
+				finding.Status = assessmentv1alpha1.FindingStatusWarn
+				finding.Title = "Backups Stored on a Shared PVC"
+				finding.Description = fmt.Sprintf("Backup CronJob %s/%s writes to PVC %s, which can be mounted by many pods at once.", cj.Namespace, cj.Name, claim)
+				finding.Impact = "Any pod in the namespace can mount the claim and read the etcd snapshots, which contain every Secret in the cluster."
+				finding.Recommendation = "Use a ReadWriteOnce PVC dedicated to backups, in a namespace restricted to cluster administrators."
+				return finding
+			}
+
+			if encrypted, known := storageClassEncrypted(ctx, c, pvc); !encrypted {
+				finding.Status = unprotected
+				finding.Title = "Backups Stored on an Unencrypted PVC"
+				finding.Description = fmt.Sprintf("Backup CronJob %s/%s writes plaintext backups to PVC %s.", cj.Namespace, cj.Name, claim)
+				if !known {
+					finding.Description = fmt.Sprintf("Backup CronJob %s/%s writes to PVC %s, whose StorageClass does not show encryption at rest.", cj.Namespace, cj.Name, claim)
+				}
+				finding.Impact = "etcd snapshots contain every Secret in the cluster; without encryption at rest they can be read from the underlying volume."
+				finding.Recommendation = "Use an encrypted StorageClass for the backup PVC, or encrypt the snapshots before writing them."
+				return finding
+			}
+
+			finding.Description = fmt.Sprintf("Backup CronJob %s/%s writes to PVC %s on an encrypted StorageClass.", cj.Namespace, cj.Name, claim)
+			return finding
+		}
+	}
+
+	finding.Description = fmt.Sprintf("Backup CronJob %s/%s does not write to a PVC or host path; its destination (e.g. an object store) could not be inspected.", cj.Namespace, cj.Name)
+	return finding
+}
+
+// objectStoreDestinations describes the backup locations of an OADP
+// DataProtectionApplication and whether server-side encryption is configured.
+func objectStoreDestinations(dpa unstructured.Unstructured, unprotected assessmentv1alpha1.FindingStatus) []assessmentv1alpha1.Finding {
+	var findings []assessmentv1alpha1.Finding
+
+	locations, _, _ := unstructured.NestedSlice(dpa.Object, "spec", "backupLocations")
+	for i, loc := range locations {
+		location, ok := loc.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		provider, _, _ := unstructured.NestedString(location, "velero", "provider")
+		bucket, _, _ := unstructured.NestedString(location, "velero", "objectStorage", "bucket")
+		config, _, _ := unstructured.NestedStringMap(location, "velero", "config")
+
+		finding := assessmentv1alpha1.Finding{
+			ID:        fmt.Sprintf("etcdbackup-destination-oadp-%s-%d", dpa.GetName(), i),
+			Validator: validatorName,
+			Category:  validatorCategory,
+			Status:    assessmentv1alpha1.FindingStatusInfo,
+			Title:     "Backup Destination",
+			Resource:  dpa.GetName(),
+			Namespace: dpa.GetNamespace(),
+		}
+		destination := fmt.Sprintf("%s bucket %q", provider, bucket)
+
+		switch {
+		case strings.HasPrefix(config["s3Url"], "http://"):
+			finding.Status = assessmentv1alpha1.FindingStatusWarn
+			finding.Title = "Backups Sent Over Plaintext HTTP"
+			finding.Description = fmt.Sprintf("OADP %s/%s writes backups to %s at %s without TLS.", dpa.GetNamespace(), dpa.GetName(), destination, config["s3Url"])
+			finding.Impact = "Backups, including etcd contents, can be read in transit."
+			finding.Recommendation = "Use an https:// endpoint for the object store."
+		case !hasAnyKey(config, serverSideEncryptionKeys):
+			finding.Status = unprotected
+			finding.Title = "Backup Object Store Without Server-Side Encryption"
+			finding.Description = fmt.Sprintf("OADP %s/%s writes backups to %s without a server-side encryption setting.", dpa.GetNamespace(), dpa.GetName(), destination)
+			finding.Impact = "Unless the bucket enforces default encryption, backups are stored in plaintext."
+			finding.Recommendation = "Set serverSideEncryption or kmsKeyId in the backup location config, or confirm the bucket enforces default encryption."
+		default:
+			finding.Description = fmt.Sprintf("OADP %s/%s writes backups to %s with server-side encryption.", dpa.GetNamespace(), dpa.GetName(), destination)
+		}
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// sharedAccess reports whether a PVC can be mounted by several nodes at once.
+func sharedAccess(pvc *corev1.PersistentVolumeClaim) bool {
+	for _, mode := range pvc.Spec.AccessModes {
+		if mode == corev1.ReadWriteMany || mode == corev1.ReadOnlyMany {
+			return true
+		}
+	}
+	return false
+}
+
+// storageClassEncrypted reports whether the PVC's StorageClass enables
+// encryption at rest, and whether the StorageClass could be inspected.
+func storageClassEncrypted(ctx context.Context, c client.Client, pvc *corev1.PersistentVolumeClaim) (encrypted, known bool) {
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return false, false
+	}
+	sc := &storagev1.StorageClass{}
+	if err := c.Get(ctx, client.ObjectKey{Name: *pvc.Spec.StorageClassName}, sc); err != nil {
+		return false, false
+	}
+	for _, key := range encryptionParameters {
+		if value, ok := sc.Parameters[key]; ok && value != "" && value != "false" {
+			return true, true
+		}
+	}
+	return false, true
+}
+
+// hasAnyKey reports whether m has a non-empty value for any of keys.
+func hasAnyKey(m map[string]string, keys []string) bool {
+	for _, key := range keys {
+		if m[key] != "" {
+			return true
+		}
+	}
+	return false
+}
+
 func containsBackupKeyword(name string) bool {
 	keywords := []string{"backup", "etcd-backup", "cluster-backup", "velero", "oadp"}
 	for _, kw := range keywords {
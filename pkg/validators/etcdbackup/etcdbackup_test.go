@@ -7,11 +7,16 @@ import (
 	"time"
 
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 type mockClient struct {
@@ -147,3 +152,114 @@ func TestCheckBackupCronJobs_Logic(t *testing.T) {
 		t.Errorf("Expected 1 Get call, got %d", c.getCalls)
 	}
 }
+
+func backupCronJob(name string, volume corev1.Volume) *batchv1.CronJob {
+	cj := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "backups"}}
+	cj.Spec.JobTemplate.Spec.Template.Spec.Volumes = []corev1.Volume{volume}
+	return cj
+}
+
+func pvcVolume(claim string) corev1.Volume {
+	return corev1.Volume{Name: "data", VolumeSource: corev1.VolumeSource{
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim},
+	}}
+}
+
+func backupPVC(name, storageClass string, mode corev1.PersistentVolumeAccessMode) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "backups"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{mode},
+			StorageClassName: &storageClass,
+		},
+	}
+}
+
+func TestCheckBackupDestinations(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+	_ = storagev1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "gp3"}},
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "gp3-encrypted"}, Parameters: map[string]string{"encrypted": "true"}},
+		backupPVC("plain", "gp3", corev1.ReadWriteOnce),
+		backupPVC("secure", "gp3-encrypted", corev1.ReadWriteOnce),
+		backupPVC("shared", "gp3-encrypted", corev1.ReadWriteMany),
+		backupCronJob("etcd-backup-plain", pvcVolume("plain")),
+		backupCronJob("etcd-backup-secure", pvcVolume("secure")),
+		backupCronJob("etcd-backup-shared", pvcVolume("shared")),
+		backupCronJob("etcd-backup-host", corev1.Volume{Name: "data", VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{Path: "/var/backups"},
+		}}),
+		backupCronJob("report-generator", pvcVolume("plain")),
+	).Build()
+
+	v := &EtcdBackupValidator{}
+	want := map[string]assessmentv1alpha1.FindingStatus{
+		"etcdbackup-destination-backups-etcd-backup-plain":  assessmentv1alpha1.FindingStatusWarn,
+		"etcdbackup-destination-backups-etcd-backup-secure": assessmentv1alpha1.FindingStatusInfo,
+		"etcdbackup-destination-backups-etcd-backup-shared": assessmentv1alpha1.FindingStatusWarn,
+		"etcdbackup-destination-backups-etcd-backup-host":   assessmentv1alpha1.FindingStatusWarn,
+	}
+
+	findings := v.checkBackupDestinations(context.Background(), c, profiles.GetProfile("production"))
+	if len(findings) != len(want) {
+		t.Fatalf("expected %d findings, got %+v", len(want), findings)
+	}
+	for _, f := range findings {
+		if status, ok := want[f.ID]; !ok || f.Status != status {
+			t.Errorf("unexpected finding %s with status %s", f.ID, f.Status)
+		}
+	}
+
+	// Plaintext local PVC backups are only informational outside production.
+	for _, f := range v.checkBackupDestinations(context.Background(), c, profiles.GetProfile("development")) {
+		if f.ID == "etcdbackup-destination-backups-etcd-backup-plain" && f.Status != assessmentv1alpha1.FindingStatusInfo {
+			t.Errorf("expected INFO for plaintext PVC in development, got %s", f.Status)
+		}
+	}
+}
+
+func TestObjectStoreDestinations(t *testing.T) {
+	dpa := unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "dpa", "namespace": "openshift-adp"},
+		"spec": map[string]interface{}{
+			"backupLocations": []interface{}{
+				map[string]interface{}{"velero": map[string]interface{}{
+					"provider":      "aws",
+					"objectStorage": map[string]interface{}{"bucket": "encrypted"},
+					"config":        map[string]interface{}{"serverSideEncryption": "aws:kms"},
+				}},
+				map[string]interface{}{"velero": map[string]interface{}{
+					"provider":      "aws",
+					"objectStorage": map[string]interface{}{"bucket": "plain"},
+				}},
+				map[string]interface{}{"velero": map[string]interface{}{
+					"provider":      "aws",
+					"objectStorage": map[string]interface{}{"bucket": "minio"},
+					"config":        map[string]interface{}{"s3Url": "http://minio:9000", "kmsKeyId": "key"},
+				}},
+			},
+		},
+	}}
+
+	findings := objectStoreDestinations(dpa, assessmentv1alpha1.FindingStatusWarn)
+	want := []assessmentv1alpha1.FindingStatus{
+		assessmentv1alpha1.FindingStatusInfo,
+		assessmentv1alpha1.FindingStatusWarn,
+		assessmentv1alpha1.FindingStatusWarn,
+	}
+	if len(findings) != len(want) {
+		t.Fatalf("expected %d findings, got %+v", len(want), findings)
+	}
+	for i, f := range findings {
+		if f.Status != want[i] {
+			t.Errorf("location %d: expected %s, got %s (%s)", i, want[i], f.Status, f.Title)
+		}
+	}
+	if findings[2].Title != "Backups Sent Over Plaintext HTTP" {
+		t.Errorf("expected plaintext HTTP finding, got %q", findings[2].Title)
+	}
+}
@@ -19,6 +19,8 @@ package imageregistry
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -35,6 +37,16 @@ const (
 	validatorCategory    = "Platform"
 )
 
+const (
+	// configExternalRouteApproved, when "true", records that exposing the
+	// registry outside the cluster is intended, which lowers the exposure
+	// finding from WARN to INFO.
+	configExternalRouteApproved = "externalRouteApproved"
+
+	// registryNamespace holds the registry deployment and its routes.
+	registryNamespace = "openshift-image-registry"
+)
+
 func init() {
 	_ = validator.Register(&ImageRegistryValidator{})
 }
@@ -57,6 +69,16 @@ func (v *ImageRegistryValidator) Category() string {
 	return validatorCategory
 }
 
+// RequiresOpenShift reports that this validator needs OpenShift APIs.
+func (v *ImageRegistryValidator) RequiresOpenShift() bool {
+	return true
+}
+
+// ConfigKeys returns the override keys understood by this validator.
+func (v *ImageRegistryValidator) ConfigKeys() []string {
+	return []string{configExternalRouteApproved}
+}
+
 // Validate performs image registry checks.
 func (v *ImageRegistryValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
 	var findings []assessmentv1alpha1.Finding
@@ -67,6 +89,9 @@ func (v *ImageRegistryValidator) Validate(ctx context.Context, c client.Client,
 	// Check 2: Image pruner configuration
 	findings = append(findings, v.checkImagePruner(ctx, c)...)
 
+	// Check 3: External exposure through routes
+	findings = append(findings, v.checkRegistryExposure(ctx, c)...)
+
 	return findings, nil
 }
 
@@ -102,14 +127,15 @@ func (v *ImageRegistryValidator) checkRegistryConfig(ctx context.Context, c clie
 	switch managementState {
 	case "Removed":
 		findings = append(findings, assessmentv1alpha1.Finding{
-			ID:             "imageregistry-removed",
-			Validator:      validatorName,
-			Category:       validatorCategory,
-			Status:         assessmentv1alpha1.FindingStatusWarn,
-			Title:          "Image Registry Removed",
-			Description:    "The internal image registry is set to Removed state.",
-			Impact:         "Internal image builds and image streams will not function.",
-			Recommendation: "If internal registry is needed, set managementState to Managed.",
+			ID:                 "imageregistry-removed",
+			Validator:          validatorName,
+			Category:           validatorCategory,
+			Status:             assessmentv1alpha1.FindingStatusWarn,
+			Title:              "Image Registry Removed",
+			Description:        "The internal image registry is set to Removed state.",
+			Impact:             "Internal image builds and image streams will not function.",
+			Recommendation:     "If internal registry is needed, set managementState to Managed.",
+			RemediationCommand: "oc patch configs.imageregistry.operator.openshift.io/cluster --type merge -p '{\"spec\":{\"managementState\":\"Managed\"}}'",
 			References: []string{
 				"https://docs.openshift.com/container-platform/latest/registry/configuring-registry-operator.html",
 			},
@@ -283,3 +309,142 @@ func (v *ImageRegistryValidator) checkImagePruner(ctx context.Context, c client.
 
 	return findings
 }
+
+// checkRegistryExposure reports whether the internal registry is reachable
+// from outside the cluster through its default route or spec.routes, the
+// TLS settings of those routes, and whether pulls are redirected to the
+// storage backend.
+func (v *ImageRegistryValidator) checkRegistryExposure(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	registryConfig := &unstructured.Unstructured{}
+	registryConfig.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "imageregistry.operator.openshift.io",
+		Version: "v1",
+		Kind:    "Config",
+	})
+	if err := c.Get(ctx, client.ObjectKey{Name: "cluster"}, registryConfig); err != nil {
+		// Reported by checkRegistryConfig
+		return nil
+	}
+	if state, _, _ := unstructured.NestedString(registryConfig.Object, "spec", "managementState"); state == "Removed" {
+		return nil
+	}
+
+	defaultRoute, _, _ := unstructured.NestedBool(registryConfig.Object, "spec", "defaultRoute")
+	customRoutes, _, _ := unstructured.NestedSlice(registryConfig.Object, "spec", "routes")
+	if !defaultRoute && len(customRoutes) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "imageregistry-not-exposed",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Image Registry Not Exposed",
+			Description: "The internal image registry has no default route or additional routes and is only reachable from inside the cluster.",
+		}}
+	}
+
+	routes := &unstructured.UnstructuredList{}
+	routes.SetGroupVersionKind(schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "RouteList"})
+	if err := c.List(ctx, routes, client.InNamespace(registryNamespace)); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "imageregistry-route-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Image Registry Routes",
+			Description: fmt.Sprintf("Failed to list routes in %s: %v", registryNamespace, err),
+		}}
+	}
+
+	var hosts, insecure []string
+	var insecureEvidence string
+	for i := range routes.Items {
+		route := &routes.Items[i]
+		service, _, _ := unstructured.NestedString(route.Object, "spec", "to", "name")
+		if service != "image-registry" {
+			continue
+		}
+		host, _, _ := unstructured.NestedString(route.Object, "spec", "host")
+		hosts = append(hosts, host)
+
+		termination, _, _ := unstructured.NestedString(route.Object, "spec", "tls", "termination")
+		insecurePolicy, _, _ := unstructured.NestedString(route.Object, "spec", "tls", "insecureEdgeTerminationPolicy")
+		switch {
+		case termination == "":
+			insecure = append(insecure, fmt.Sprintf("%s (no TLS)", host))
+		case insecurePolicy == "Allow":
+			insecure = append(insecure, fmt.Sprintf("%s (%s, plain HTTP allowed)", host, termination))
+		default:
+			continue
+		}
+		if insecureEvidence == "" {
+			insecureEvidence = validator.Evidence(ctx, route)
+		}
+	}
+	sort.Strings(hosts)
+	sort.Strings(insecure)
+
+	exposedBy := "spec.routes"
+	if defaultRoute {
+		exposedBy = "spec.defaultRoute"
+		if len(customRoutes) > 0 {
+			exposedBy = "spec.defaultRoute and spec.routes"
+		}
+	}
+	hostList := "no route has been admitted yet"
+	if len(hosts) > 0 {
+		hostList = strings.Join(hosts, ", ")
+	}
+
+	var findings []assessmentv1alpha1.Finding
+	status := assessmentv1alpha1.FindingStatusWarn
+	if validator.ConfigString(ctx, configExternalRouteApproved, "") == "true" {
+		status = assessmentv1alpha1.FindingStatusInfo
+	}
+	findings = append(findings, assessmentv1alpha1.Finding{
+		ID:             "imageregistry-exposed",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         status,
+		Title:          "Image Registry Exposed Outside the Cluster",
+		Description:    fmt.Sprintf("The internal image registry is exposed through %s: %s", exposedBy, hostList),
+		Impact:         "Anyone who can reach the route and holds a token with image pull rights can pull, and with push rights overwrite, images used by cluster workloads.",
+		Recommendation: "Remove the route if external access is not needed. If it is, restrict the router shard or network that serves it and set the externalRouteApproved override to record the decision.",
+		References: []string{
+			"https://docs.openshift.com/container-platform/latest/registry/securing-exposing-registry.html",
+		},
+	})
+
+	if len(insecure) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "imageregistry-route-insecure",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Image Registry Route Without Enforced TLS",
+			Description:    fmt.Sprintf("Found %d registry route(s) that accept unencrypted traffic: %s", len(insecure), strings.Join(insecure, ", ")),
+			Impact:         "Registry credentials and image layers can be intercepted in transit.",
+			Recommendation: "Use reencrypt or passthrough termination and set insecureEdgeTerminationPolicy to Redirect or None.",
+			Evidence:       insecureEvidence,
+		})
+	}
+
+	disableRedirect, _, _ := unstructured.NestedBool(registryConfig.Object, "spec", "disableRedirect")
+	storage, _, _ := unstructured.NestedMap(registryConfig.Object, "spec", "storage")
+	_, pvc := storage["pvc"]
+	_, emptyDir := storage["emptyDir"]
+	if !disableRedirect && len(storage) > 0 && !pvc && !emptyDir {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "imageregistry-route-redirect",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusInfo,
+			Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
+			Title:          "External Pulls Redirected to Registry Storage",
+			Description:    "spec.disableRedirect is false, so clients pulling through the route are redirected to the object storage backend for image layers.",
+			Impact:         "External clients must be able to reach the storage endpoint, and receive short-lived signed URLs to it.",
+			Recommendation: "Set spec.disableRedirect to true if external clients cannot or should not reach the storage backend directly.",
+		})
+	}
+
+	return findings
+}
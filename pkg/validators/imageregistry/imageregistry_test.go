@@ -0,0 +1,174 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imageregistry
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator/validatortest"
+)
+
+func newRegistryConfig(spec map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	u.SetGroupVersionKind(schema.GroupVersionKind{Group: "imageregistry.operator.openshift.io", Version: "v1", Kind: "Config"})
+	u.SetName("cluster")
+	return u
+}
+
+func newRegistryRoute(name, host string, tls map[string]interface{}) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"host": host,
+		"to":   map[string]interface{}{"kind": "Service", "name": "image-registry"},
+	}
+	if tls != nil {
+		spec["tls"] = tls
+	}
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	u.SetGroupVersionKind(schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "Route"})
+	u.SetNamespace(registryNamespace)
+	u.SetName(name)
+	return u
+}
+
+func TestCheckRegistryExposure(t *testing.T) {
+	pvcStorage := map[string]interface{}{"pvc": map[string]interface{}{"claim": "registry"}}
+	s3Storage := map[string]interface{}{"s3": map[string]interface{}{"bucket": "registry"}}
+	reencrypt := map[string]interface{}{"termination": "reencrypt"}
+
+	tests := []struct {
+		name       string
+		objs       []client.Object
+		config     map[string]string
+		wantStatus map[string]assessmentv1alpha1.FindingStatus
+		wantDesc   []string
+	}{
+		{
+			name: "not exposed",
+			objs: []client.Object{
+				newRegistryConfig(map[string]interface{}{"managementState": "Managed", "storage": pvcStorage}),
+			},
+			wantStatus: map[string]assessmentv1alpha1.FindingStatus{
+				"imageregistry-not-exposed": assessmentv1alpha1.FindingStatusPass,
+			},
+		},
+		{
+			name: "removed",
+			objs: []client.Object{
+				newRegistryConfig(map[string]interface{}{"managementState": "Removed", "defaultRoute": true}),
+			},
+			wantStatus: map[string]assessmentv1alpha1.FindingStatus{},
+		},
+		{
+			name: "default route with TLS",
+			objs: []client.Object{
+				newRegistryConfig(map[string]interface{}{"defaultRoute": true, "storage": pvcStorage}),
+				newRegistryRoute("default-route", "registry.apps.example.com", reencrypt),
+			},
+			wantStatus: map[string]assessmentv1alpha1.FindingStatus{
+				"imageregistry-exposed": assessmentv1alpha1.FindingStatusWarn,
+			},
+			wantDesc: []string{"spec.defaultRoute: registry.apps.example.com"},
+		},
+		{
+			name: "approved route",
+			objs: []client.Object{
+				newRegistryConfig(map[string]interface{}{"defaultRoute": true, "storage": pvcStorage}),
+				newRegistryRoute("default-route", "registry.apps.example.com", reencrypt),
+			},
+			config: map[string]string{configExternalRouteApproved: "true"},
+			wantStatus: map[string]assessmentv1alpha1.FindingStatus{
+				"imageregistry-exposed": assessmentv1alpha1.FindingStatusInfo,
+			},
+		},
+		{
+			name: "routes accepting plain HTTP",
+			objs: []client.Object{
+				newRegistryConfig(map[string]interface{}{
+					"defaultRoute": true,
+					"routes":       []interface{}{map[string]interface{}{"name": "public", "hostname": "public.example.com"}},
+					"storage":      pvcStorage,
+				}),
+				newRegistryRoute("default-route", "registry.apps.example.com", nil),
+				newRegistryRoute("public", "public.example.com", map[string]interface{}{
+					"termination":                   "edge",
+					"insecureEdgeTerminationPolicy": "Allow",
+				}),
+			},
+			wantStatus: map[string]assessmentv1alpha1.FindingStatus{
+				"imageregistry-exposed":        assessmentv1alpha1.FindingStatusWarn,
+				"imageregistry-route-insecure": assessmentv1alpha1.FindingStatusWarn,
+			},
+			wantDesc: []string{
+				"spec.defaultRoute and spec.routes",
+				"public.example.com (edge, plain HTTP allowed)",
+				"registry.apps.example.com (no TLS)",
+			},
+		},
+		{
+			name: "route not admitted with object storage",
+			objs: []client.Object{
+				newRegistryConfig(map[string]interface{}{
+					"routes":  []interface{}{map[string]interface{}{"name": "public"}},
+					"storage": s3Storage,
+				}),
+			},
+			wantStatus: map[string]assessmentv1alpha1.FindingStatus{
+				"imageregistry-exposed":        assessmentv1alpha1.FindingStatusWarn,
+				"imageregistry-route-redirect": assessmentv1alpha1.FindingStatusInfo,
+			},
+			wantDesc: []string{"spec.routes: no route has been admitted yet"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).WithObjects(tt.objs...).Build()
+			ctx := validator.WithConfig(context.Background(), tt.config)
+
+			findings := (&ImageRegistryValidator{}).checkRegistryExposure(ctx, c)
+			if len(findings) != len(tt.wantStatus) {
+				t.Fatalf("expected %d finding(s), got %+v", len(tt.wantStatus), findings)
+			}
+			var descriptions []string
+			for id, status := range tt.wantStatus {
+				f := validatortest.FindingByID(findings, id)
+				if f == nil {
+					t.Fatalf("expected %s, got %+v", id, findings)
+				}
+				if f.Status != status {
+					t.Errorf("expected %s to be %s, got %s", id, status, f.Status)
+				}
+				descriptions = append(descriptions, f.Description)
+			}
+			for _, want := range tt.wantDesc {
+				if !strings.Contains(strings.Join(descriptions, "\n"), want) {
+					t.Errorf("expected %q in descriptions: %v", want, descriptions)
+				}
+			}
+		})
+	}
+}
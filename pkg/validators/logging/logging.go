@@ -59,6 +59,11 @@ func (v *LoggingValidator) Category() string {
 	return validatorCategory
 }
 
+// RequiresOpenShift reports that this validator needs OpenShift APIs.
+func (v *LoggingValidator) RequiresOpenShift() bool {
+	return true
+}
+
 // Validate performs logging checks.
 func (v *LoggingValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
 	var findings []assessmentv1alpha1.Finding
@@ -97,6 +102,7 @@ func (v *LoggingValidator) checkLoggingOperator(ctx context.Context, c client.Cl
 			Validator:      validatorName,
 			Category:       validatorCategory,
 			Status:         assessmentv1alpha1.FindingStatusInfo,
+			Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
 			Title:          "Cluster Logging Operator Not Installed",
 			Description:    "The cluster-logging operator is not installed or the openshift-logging namespace does not exist.",
 			Impact:         "Cluster logging is not configured. Application and infrastructure logs are not being collected centrally.",
@@ -135,6 +141,7 @@ func (v *LoggingValidator) checkLoggingOperator(ctx context.Context, c client.Cl
 			Validator:      validatorName,
 			Category:       validatorCategory,
 			Status:         assessmentv1alpha1.FindingStatusInfo,
+			Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
 			Title:          "Cluster Logging Operator Not Found",
 			Description:    "No cluster-logging or loki-operator CSV found in openshift-logging namespace.",
 			Impact:         "Centralized logging may not be configured.",
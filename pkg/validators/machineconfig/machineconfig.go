@@ -17,10 +17,19 @@ limitations under the License.
 package machineconfig
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"net"
+	"net/url"
 	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
@@ -31,7 +40,7 @@ import (
 
 const (
 	validatorName        = "machineconfig"
-	validatorDescription = "Validates MachineConfig and MachineConfigPool health and configuration"
+	validatorDescription = "Validates MachineConfig and MachineConfigPool health and configuration, including time synchronization"
 	validatorCategory    = "Platform"
 )
 
@@ -57,6 +66,11 @@ func (v *MachineConfigValidator) Category() string {
 	return validatorCategory
 }
 
+// RequiresOpenShift reports that this validator needs OpenShift APIs.
+func (v *MachineConfigValidator) RequiresOpenShift() bool {
+	return true
+}
+
 // Validate performs MachineConfig checks.
 func (v *MachineConfigValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
 	var findings []assessmentv1alpha1.Finding
@@ -67,6 +81,12 @@ func (v *MachineConfigValidator) Validate(ctx context.Context, c client.Client,
 	// Check 2: Custom MachineConfigs
 	findings = append(findings, v.checkCustomMachineConfigs(ctx, c)...)
 
+	// Check 3: Paused pools and rollout concurrency
+	findings = append(findings, v.checkPoolRolloutSettings(ctx, c)...)
+
+	// Check 4: Time synchronization configuration and clock skew indicators
+	findings = append(findings, v.checkTimeSync(ctx, c, time.Now())...)
+
 	return findings, nil
 }
 
@@ -217,3 +237,366 @@ func (v *MachineConfigValidator) checkCustomMachineConfigs(ctx context.Context,
 
 	return findings
 }
+
+// checkPoolRolloutSettings reports paused MachineConfigPools and the configured
+// rollout concurrency (maxUnavailable) of each pool.
+func (v *MachineConfigValidator) checkPoolRolloutSettings(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	var findings []assessmentv1alpha1.Finding
+
+	mcps := &mcv1.MachineConfigPoolList{}
+	if err := c.List(ctx, mcps); err != nil {
+		// Already reported by checkMachineConfigPools.
+		return findings
+	}
+
+	var pausedPools, unpause []string
+	var rollout []string
+	for _, mcp := range mcps.Items {
+		if mcp.Spec.Paused {
+			detail := mcp.Name
+			if since, ok := pendingSince(mcp); ok {
+				detail = fmt.Sprintf("%s (updates pending for %s)", mcp.Name, time.Since(since).Round(time.Minute))
+			}
+			if pending := mcp.Status.MachineCount - mcp.Status.UpdatedMachineCount; pending > 0 {
+				detail = fmt.Sprintf("%s, %d machine(s) not updated", detail, pending)
+			}
+			pausedPools = append(pausedPools, detail)
+			unpause = append(unpause, fmt.Sprintf("oc patch mcp %s --type merge -p '{\"spec\":{\"paused\":false}}'", mcp.Name))
+		}
+
+		maxUnavailable := "1 (default)"
+		if mcp.Spec.MaxUnavailable != nil {
+			maxUnavailable = mcp.Spec.MaxUnavailable.String()
+		}
+		rollout = append(rollout, fmt.Sprintf("%s: %s", mcp.Name, maxUnavailable))
+	}
+
+	if len(pausedPools) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:                 "machineconfig-mcp-paused",
+			Validator:          validatorName,
+			Category:           validatorCategory,
+			Status:             assessmentv1alpha1.FindingStatusWarn,
+			Title:              "Paused MachineConfigPools",
+			Description:        fmt.Sprintf("%d MachineConfigPool(s) are paused: %s", len(pausedPools), strings.Join(pausedPools, "; ")),
+			Impact:             "While a pool is paused, new MachineConfigs (including certificate rotations delivered via MachineConfig) are not applied to its nodes.",
+			Recommendation:     "Unpause the pool once maintenance is complete: oc patch mcp <name> --type merge -p '{\"spec\":{\"paused\":false}}'",
+			RemediationCommand: strings.Join(unpause, "\n"),
+			References: []string{
+				"https://docs.openshift.com/container-platform/latest/post_installation_configuration/machine-configuration-tasks.html",
+			},
+		})
+	}
+
+	if len(rollout) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "machineconfig-mcp-max-unavailable",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "MachineConfigPool Rollout Concurrency",
+			Description: fmt.Sprintf("maxUnavailable per pool: %s", strings.Join(rollout, ", ")),
+		})
+	}
+
+	return findings
+}
+
+// pendingSince returns when the pool's Updated condition last turned False,
+// which is the best available approximation of how long a paused pool has
+// been holding back updates. The MachineConfigPool API does not record when
+// spec.paused was set.
+func pendingSince(mcp mcv1.MachineConfigPool) (time.Time, bool) {
+	for _, condition := range mcp.Status.Conditions {
+		if condition.Type == mcv1.MachineConfigPoolUpdated && condition.Status == "False" && !condition.LastTransitionTime.IsZero() {
+			return condition.LastTransitionTime.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// chronyConfigPath is the chrony configuration file written by MachineConfigs
+// that set the cluster's time sources.
+const chronyConfigPath = "/etc/chrony.conf"
+
+// maxClockSkew is how far ahead of the operator a node's kubelet heartbeat may
+// be before the node's clock is reported as ahead.
+const maxClockSkew = time.Minute
+
+// checkTimeSync reports, per MachineConfigPool, whether a MachineConfig sets
+// valid chrony time sources, and flags nodes whose kubelet heartbeats are
+// timestamped in the future. Clock drift breaks certificate validation, etcd
+// leader election and token expiry, but NTP status is not exposed through the
+// API, so configuration and heartbeats are the available indicators.
+// Heartbeats are compared with now, the operator's clock.
+func (v *MachineConfigValidator) checkTimeSync(ctx context.Context, c client.Client, now time.Time) []assessmentv1alpha1.Finding {
+	var findings []assessmentv1alpha1.Finding
+
+	mcps := &mcv1.MachineConfigPoolList{}
+	mcs := &mcv1.MachineConfigList{}
+	if err := c.List(ctx, mcps); err == nil {
+		if err := c.List(ctx, mcs); err != nil {
+			return []assessmentv1alpha1.Finding{{
+				ID:          "machineconfig-timesync-error",
+				Validator:   validatorName,
+				Category:    validatorCategory,
+				Status:      assessmentv1alpha1.FindingStatusInfo,
+				Title:       "Unable to Check Time Synchronization",
+				Description: fmt.Sprintf("Failed to list MachineConfigs: %v", err),
+			}}
+		}
+		findings = append(findings, chronyFindings(mcps.Items, mcs.Items)...)
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil {
+		return findings
+	}
+	var ahead []string
+	for _, node := range nodes.Items {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type != corev1.NodeReady {
+				continue
+			}
+			if skew := condition.LastHeartbeatTime.Sub(now); skew > maxClockSkew {
+				ahead = append(ahead, fmt.Sprintf("%s (%s ahead)", node.Name, skew.Round(time.Second)))
+			}
+		}
+	}
+	if len(ahead) > 0 {
+		sample := ahead
+		if len(sample) > 5 {
+			sample = sample[:5]
+		}
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "machineconfig-node-clock-ahead",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
+			Title:          "Node Clocks Ahead of the Cluster",
+			Description:    fmt.Sprintf("%d node(s) report kubelet heartbeats more than %s in the future, compared with the clock of the node running this operator: %s", len(ahead), maxClockSkew, strings.Join(sample, ", ")),
+			Impact:         "Clock drift between nodes breaks certificate validation, etcd leader election and lease renewal, and token expiry checks.",
+			Recommendation: "Check time synchronization on the affected nodes and on the node running the operator with 'oc debug node/<node> -- chroot /host chronyc tracking'.",
+			References: []string{
+				"https://docs.openshift.com/container-platform/latest/installing/install_config/installing-customizing.html#installation-special-config-chrony_installing-customizing",
+			},
+		})
+	}
+
+	return findings
+}
+
+// chronyFindings reports for each pool whether its rendered MachineConfig
+// writes the chrony configuration, and whether that configuration names valid
+// time sources. A pool left on the default time sources while others are
+// customized is a warning, as its nodes may sync to different, possibly
+// unreachable, servers. When the rendered MachineConfig is not listed, the
+// pool's source MachineConfigs are checked instead.
+func chronyFindings(pools []mcv1.MachineConfigPool, mcs []mcv1.MachineConfig) []assessmentv1alpha1.Finding {
+	byName := make(map[string]*mcv1.MachineConfig, len(mcs))
+	for i := range mcs {
+		byName[mcs[i].Name] = &mcs[i]
+	}
+
+	// The chrony.conf entry of each pool, and the source MachineConfig that
+	// writes it. Sources are merged in order, so the last one wins.
+	poolFile := make(map[string]map[string]interface{})
+	poolChrony := make(map[string]string)
+	var customized []string
+	for _, pool := range pools {
+		var file map[string]interface{}
+		for _, source := range pool.Status.Configuration.Source {
+			if mc, ok := byName[source.Name]; ok {
+				if f, ok := ignitionFile(mc.Spec.Config, chronyConfigPath); ok {
+					file = f
+					poolChrony[pool.Name] = source.Name
+				}
+			}
+		}
+		if rendered, ok := byName[pool.Status.Configuration.Name]; ok {
+			file, _ = ignitionFile(rendered.Spec.Config, chronyConfigPath)
+		}
+		if file != nil {
+			poolFile[pool.Name] = file
+			customized = append(customized, pool.Name)
+		}
+	}
+
+	var findings []assessmentv1alpha1.Finding
+	for _, pool := range pools {
+		if len(pool.Status.Configuration.Source) == 0 {
+			continue
+		}
+		finding := assessmentv1alpha1.Finding{
+			ID:        fmt.Sprintf("machineconfig-timesync-%s", pool.Name),
+			Validator: validatorName,
+			Category:  validatorCategory,
+			Resource:  pool.Name,
+			References: []string{
+				"https://docs.openshift.com/container-platform/latest/installing/install_config/installing-customizing.html#installation-special-config-chrony_installing-customizing",
+			},
+		}
+		origin := fmt.Sprintf("The rendered configuration of pool %s", pool.Name)
+		if mc := poolChrony[pool.Name]; mc != "" {
+			origin = fmt.Sprintf("MachineConfig %s", mc)
+		}
+
+		file, configured := poolFile[pool.Name]
+		if !configured {
+			finding.Title = fmt.Sprintf("Default Time Sources for %s", pool.Name)
+			if len(customized) > 0 {
+				finding.Status = assessmentv1alpha1.FindingStatusWarn
+				finding.Description = fmt.Sprintf("Pool %s uses the default RHCOS time sources, while pool(s) %s set their own in %s.", pool.Name, strings.Join(customized, ", "), chronyConfigPath)
+				finding.Impact = "Nodes syncing to different time sources can drift apart, breaking certificate validation, etcd and token expiry."
+				finding.Recommendation = fmt.Sprintf("Apply the chrony MachineConfig used by the other pools to the %s pool as well.", pool.Name)
+			} else {
+				finding.Status = assessmentv1alpha1.FindingStatusInfo
+				finding.Description = fmt.Sprintf("No MachineConfig in pool %s writes %s, so its nodes use the default RHCOS time sources: the platform's time service or public NTP servers.", pool.Name, chronyConfigPath)
+				finding.Recommendation = "In disconnected or restricted networks, public NTP servers are unreachable. Configure reachable time sources with a chrony MachineConfig for every pool."
+			}
+			findings = append(findings, finding)
+			continue
+		}
+
+		conf, err := ignitionFileContents(file)
+		if err != nil {
+			finding.Status = assessmentv1alpha1.FindingStatusInfo
+			finding.Title = fmt.Sprintf("Time Sources Not Verified for %s", pool.Name)
+			finding.Description = fmt.Sprintf("%s writes %s, but its contents could not be read: %v", origin, chronyConfigPath, err)
+			findings = append(findings, finding)
+			continue
+		}
+
+		sources, invalid := chronySources(conf)
+		switch {
+		case len(invalid) > 0:
+			finding.Status = assessmentv1alpha1.FindingStatusWarn
+			finding.Title = fmt.Sprintf("Invalid Time Sources for %s", pool.Name)
+			finding.Description = fmt.Sprintf("%s writes %s with directives that do not name a valid hostname or IP address: %s", origin, chronyConfigPath, strings.Join(invalid, "; "))
+		case len(sources) == 0:
+			finding.Status = assessmentv1alpha1.FindingStatusWarn
+			finding.Title = fmt.Sprintf("No Time Sources for %s", pool.Name)
+			finding.Description = fmt.Sprintf("%s writes %s without any server, pool, peer or refclock directive, so nodes in pool %s do not synchronize their clocks.", origin, chronyConfigPath, pool.Name)
+		default:
+			finding.Status = assessmentv1alpha1.FindingStatusPass
+			finding.Title = fmt.Sprintf("Time Sources Configured for %s", pool.Name)
+			finding.Description = fmt.Sprintf("%s sets the chrony time sources for pool %s: %s.", origin, pool.Name, strings.Join(sources, ", "))
+		}
+		if finding.Status == assessmentv1alpha1.FindingStatusWarn {
+			finding.Impact = "Nodes without working time sources drift apart, breaking certificate validation, etcd and token expiry."
+			finding.Recommendation = fmt.Sprintf("Fix the chrony MachineConfig of the %s pool so that every server or pool directive names a reachable NTP server by hostname or IP address.", pool.Name)
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// maxIgnitionFileSize caps how much of a decoded Ignition file is read.
+const maxIgnitionFileSize = 1 << 20
+
+// ignitionFileContents decodes the contents of an Ignition file entry. Only
+// inline data URLs are supported, optionally base64-encoded and gzipped; files
+// fetched from remote sources cannot be read. A file without contents is
+// empty.
+func ignitionFileContents(file map[string]interface{}) (string, error) {
+	contents, _ := file["contents"].(map[string]interface{})
+	source, _ := contents["source"].(string)
+	if source == "" {
+		return "", nil
+	}
+	header, payload, ok := strings.Cut(source, ",")
+	if !ok || !strings.HasPrefix(header, "data:") {
+		scheme, _, _ := strings.Cut(source, ":")
+		return "", fmt.Errorf("contents are fetched from a %s source", scheme)
+	}
+
+	var data []byte
+	if strings.HasSuffix(header, ";base64") {
+		decoded, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			return "", fmt.Errorf("invalid base64 contents: %w", err)
+		}
+		data = decoded
+	} else {
+		decoded, err := url.PathUnescape(payload)
+		if err != nil {
+			return "", fmt.Errorf("invalid URL-encoded contents: %w", err)
+		}
+		data = []byte(decoded)
+	}
+
+	switch compression, _ := contents["compression"].(string); compression {
+	case "":
+	case "gzip":
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return "", fmt.Errorf("invalid gzip contents: %w", err)
+		}
+		defer func() { _ = reader.Close() }()
+		data, err = io.ReadAll(io.LimitReader(reader, maxIgnitionFileSize))
+		if err != nil {
+			return "", fmt.Errorf("invalid gzip contents: %w", err)
+		}
+	default:
+		return "", fmt.Errorf("unsupported compression %q", compression)
+	}
+	return string(data), nil
+}
+
+// chronySources returns the time sources named by the server, pool, peer and
+// refclock directives of a chrony configuration, and the server, pool and peer
+// directives whose address is not a valid hostname or IP address.
+func chronySources(conf string) (sources, invalid []string) {
+	for _, line := range strings.Split(conf, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.ContainsAny(fields[0][:1], "#!;%") {
+			continue
+		}
+		switch fields[0] {
+		case "server", "pool", "peer":
+			if len(fields) < 2 || !validTimeSource(fields[1]) {
+				invalid = append(invalid, strings.Join(fields, " "))
+				continue
+			}
+			sources = append(sources, fields[1])
+		case "refclock":
+			if len(fields) >= 2 {
+				sources = append(sources, "refclock "+fields[1])
+			}
+		}
+	}
+	return sources, invalid
+}
+
+// validTimeSource reports whether address is an IP address or a valid DNS
+// hostname.
+func validTimeSource(address string) bool {
+	if net.ParseIP(address) != nil {
+		return true
+	}
+	return len(validation.IsDNS1123Subdomain(strings.ToLower(address))) == 0
+}
+
+// ignitionFile returns the entry of an Ignition config that writes a file at
+// path.
+func ignitionFile(config interface{}, path string) (map[string]interface{}, bool) {
+	ignition, ok := config.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	storage, ok := ignition["storage"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	files, ok := storage["files"].([]interface{})
+	if !ok {
+		return nil, false
+	}
+	for _, file := range files {
+		if f, ok := file.(map[string]interface{}); ok && f["path"] == path {
+			return f, true
+		}
+	}
+	return nil, false
+}
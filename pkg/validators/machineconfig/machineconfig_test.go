@@ -0,0 +1,239 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machineconfig
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	mcv1 "github.com/openshift-assessment/cluster-assessment-operator/pkg/machineconfig"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator/validatortest"
+)
+
+func TestCheckPoolRolloutSettings(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = mcv1.AddToScheme(scheme)
+
+	maxUnavailable := intstr.FromString("20%")
+	worker := &mcv1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec:       mcv1.MachineConfigPoolSpec{Paused: true, MaxUnavailable: &maxUnavailable},
+		Status: mcv1.MachineConfigPoolStatus{
+			MachineCount:        3,
+			UpdatedMachineCount: 1,
+			Conditions: []mcv1.MachineConfigPoolCondition{{
+				Type:               mcv1.MachineConfigPoolUpdated,
+				Status:             "False",
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+			}},
+		},
+	}
+	master := &mcv1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "master"}}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(worker, master).Build()
+	v := &MachineConfigValidator{}
+	findings := v.checkPoolRolloutSettings(context.Background(), c)
+
+	paused := validatortest.FindingByID(findings, "machineconfig-mcp-paused")
+	if paused == nil {
+		t.Fatalf("expected paused finding, got %+v", findings)
+	}
+	if paused.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Errorf("expected WARN, got %s", paused.Status)
+	}
+	if !strings.Contains(paused.Description, "worker (updates pending for 2h0m0s), 2 machine(s) not updated") {
+		t.Errorf("unexpected description: %s", paused.Description)
+	}
+	if strings.Contains(paused.Description, "master") {
+		t.Errorf("master pool is not paused: %s", paused.Description)
+	}
+
+	rollout := validatortest.FindingByID(findings, "machineconfig-mcp-max-unavailable")
+	if rollout == nil {
+		t.Fatalf("expected maxUnavailable finding, got %+v", findings)
+	}
+	if !strings.Contains(rollout.Description, "worker: 20%") || !strings.Contains(rollout.Description, "master: 1 (default)") {
+		t.Errorf("unexpected description: %s", rollout.Description)
+	}
+}
+
+func TestCheckPoolRolloutSettingsNoPausedPools(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = mcv1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&mcv1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}},
+	).Build()
+	findings := (&MachineConfigValidator{}).checkPoolRolloutSettings(context.Background(), c)
+
+	if validatortest.FindingByID(findings, "machineconfig-mcp-paused") != nil {
+		t.Errorf("did not expect paused finding: %+v", findings)
+	}
+}
+
+func newPool(name string, sources ...string) *mcv1.MachineConfigPool {
+	pool := &mcv1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	for _, source := range sources {
+		pool.Status.Configuration.Source = append(pool.Status.Configuration.Source, mcv1.MachineConfigPoolConfigSource{Kind: "MachineConfig", Name: source})
+	}
+	return pool
+}
+
+func TestCheckTimeSync(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = mcv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	chrony := &mcv1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "99-master-chrony"},
+		Spec: mcv1.MachineConfigSpec{Config: map[string]interface{}{
+			"ignition": map[string]interface{}{"version": "3.2.0"},
+			"storage": map[string]interface{}{"files": []interface{}{
+				map[string]interface{}{"path": "/etc/chrony.conf", "mode": 420, "contents": map[string]interface{}{
+					"source": "data:,%23%20NTP%0Aserver%20ntp1.example.com%20iburst%0Apool%2010.0.0.1%0A",
+				}},
+			}},
+		}},
+	}
+	base := &mcv1.MachineConfig{ObjectMeta: metav1.ObjectMeta{Name: "00-worker"}}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ahead := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{{
+			Type:              corev1.NodeReady,
+			Status:            corev1.ConditionTrue,
+			LastHeartbeatTime: metav1.NewTime(now.Add(5 * time.Minute)),
+		}}},
+	}
+	inSync := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-2"},
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{{
+			Type:              corev1.NodeReady,
+			Status:            corev1.ConditionTrue,
+			LastHeartbeatTime: metav1.NewTime(now.Add(-20 * time.Second)),
+		}}},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		chrony, base,
+		newPool("master", "00-master", "99-master-chrony"),
+		newPool("worker", "00-worker"),
+		newPool("infra"),
+		ahead, inSync,
+	).Build()
+	v := &MachineConfigValidator{}
+	findings := v.checkTimeSync(context.Background(), c, now)
+
+	if f := validatortest.FindingByID(findings, "machineconfig-timesync-master"); f == nil || f.Status != assessmentv1alpha1.FindingStatusPass ||
+		!strings.Contains(f.Description, "99-master-chrony") || !strings.Contains(f.Description, "ntp1.example.com, 10.0.0.1") {
+		t.Errorf("expected PASS for master, got %+v", f)
+	}
+	if f := validatortest.FindingByID(findings, "machineconfig-timesync-worker"); f == nil || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Errorf("expected WARN for worker, got %+v", f)
+	}
+	if f := validatortest.FindingByID(findings, "machineconfig-timesync-infra"); f != nil {
+		t.Errorf("expected no finding for a pool without rendered config, got %+v", f)
+	}
+	f := validatortest.FindingByID(findings, "machineconfig-node-clock-ahead")
+	if f == nil {
+		t.Fatalf("expected clock-ahead finding, got %+v", findings)
+	}
+	if !strings.Contains(f.Description, "worker-1 (5m0s ahead)") {
+		t.Errorf("unexpected description: %s", f.Description)
+	}
+	if strings.Contains(f.Description, "worker-2") {
+		t.Errorf("worker-2 is in sync: %s", f.Description)
+	}
+
+	// Without any chrony MachineConfig every pool is informational.
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(base, newPool("worker", "00-worker")).Build()
+	findings = v.checkTimeSync(context.Background(), c, now)
+	if len(findings) != 1 || findings[0].Status != assessmentv1alpha1.FindingStatusInfo {
+		t.Errorf("expected a single INFO finding, got %+v", findings)
+	}
+}
+
+func TestChronyFindings_RenderedConfig(t *testing.T) {
+	gzipped := func(text string) string {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		_, _ = w.Write([]byte(text))
+		_ = w.Close()
+		return base64.StdEncoding.EncodeToString(buf.Bytes())
+	}
+	rendered := func(name string, contents map[string]interface{}) mcv1.MachineConfig {
+		return mcv1.MachineConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: mcv1.MachineConfigSpec{Config: map[string]interface{}{
+				"storage": map[string]interface{}{"files": []interface{}{
+					map[string]interface{}{"path": "/etc/chrony.conf", "contents": contents},
+				}},
+			}},
+		}
+	}
+	pool := func(name string) mcv1.MachineConfigPool {
+		p := newPool(name, "00-"+name)
+		p.Status.Configuration.Name = "rendered-" + name
+		return *p
+	}
+
+	mcs := []mcv1.MachineConfig{
+		rendered("rendered-master", map[string]interface{}{
+			"compression": "gzip",
+			"source":      "data:;base64," + gzipped("refclock PHC /dev/ptp0\nserver clock.example.com iburst\n"),
+		}),
+		rendered("rendered-worker", map[string]interface{}{
+			"source": "data:;base64," + base64.StdEncoding.EncodeToString([]byte("server ntp_server..bad\nserver 192.0.2.1\n")),
+		}),
+		rendered("rendered-infra", map[string]interface{}{"source": "data:,driftfile%20%2Fvar%2Flib%2Fchrony%2Fdrift%0A"}),
+		rendered("rendered-edge", map[string]interface{}{"source": "https://config.example.com/chrony.conf"}),
+	}
+	findings := chronyFindings([]mcv1.MachineConfigPool{pool("master"), pool("worker"), pool("infra"), pool("edge")}, mcs)
+
+	want := map[string]assessmentv1alpha1.FindingStatus{
+		"machineconfig-timesync-master": assessmentv1alpha1.FindingStatusPass,
+		"machineconfig-timesync-worker": assessmentv1alpha1.FindingStatusWarn,
+		"machineconfig-timesync-infra":  assessmentv1alpha1.FindingStatusWarn,
+		"machineconfig-timesync-edge":   assessmentv1alpha1.FindingStatusInfo,
+	}
+	for id, status := range want {
+		if f := validatortest.FindingByID(findings, id); f == nil || f.Status != status {
+			t.Errorf("expected %s for %s, got %+v", status, id, f)
+		}
+	}
+	if f := validatortest.FindingByID(findings, "machineconfig-timesync-master"); f != nil && !strings.Contains(f.Description, "refclock PHC, clock.example.com") {
+		t.Errorf("expected the decoded time sources, got %q", f.Description)
+	}
+	if f := validatortest.FindingByID(findings, "machineconfig-timesync-worker"); f != nil && !strings.Contains(f.Description, "server ntp_server..bad") {
+		t.Errorf("expected the invalid directive, got %q", f.Description)
+	}
+	if f := validatortest.FindingByID(findings, "machineconfig-timesync-edge"); f != nil && !strings.Contains(f.Description, "https source") {
+		t.Errorf("expected the remote source to be named, got %q", f.Description)
+	}
+}
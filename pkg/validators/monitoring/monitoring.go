@@ -19,8 +19,12 @@ package monitoring
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strconv"
+	"time"
 
 	configv1 "github.com/openshift/api/config/v1"
+	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -57,12 +61,17 @@ func (v *MonitoringValidator) Category() string {
 	return validatorCategory
 }
 
+// RequiresOpenShift reports that this validator needs OpenShift APIs.
+func (v *MonitoringValidator) RequiresOpenShift() bool {
+	return true
+}
+
 // Validate performs monitoring checks.
 func (v *MonitoringValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
 	var findings []assessmentv1alpha1.Finding
 
 	// Check 1: Cluster monitoring config
-	findings = append(findings, v.checkClusterMonitoringConfig(ctx, c)...)
+	findings = append(findings, v.checkClusterMonitoringConfig(ctx, c, profile)...)
 
 	// Check 2: User workload monitoring
 	findings = append(findings, v.checkUserWorkloadMonitoring(ctx, c)...)
@@ -74,7 +83,7 @@ func (v *MonitoringValidator) Validate(ctx context.Context, c client.Client, pro
 }
 
 // checkClusterMonitoringConfig checks cluster monitoring configuration.
-func (v *MonitoringValidator) checkClusterMonitoringConfig(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+func (v *MonitoringValidator) checkClusterMonitoringConfig(ctx context.Context, c client.Client, profile profiles.Profile) []assessmentv1alpha1.Finding {
 	var findings []assessmentv1alpha1.Finding
 
 	// Check for cluster-monitoring-config ConfigMap
@@ -104,32 +113,8 @@ func (v *MonitoringValidator) checkClusterMonitoringConfig(ctx context.Context,
 			Description: "Cluster monitoring has custom configuration in cluster-monitoring-config ConfigMap.",
 		})
 
-		// Check if persistent storage is configured
-		if configYAML, ok := cm.Data["config.yaml"]; ok {
-			if len(configYAML) > 0 {
-				// Simple check for persistent storage keywords
-				if containsAny(configYAML, []string{"volumeClaimTemplate", "storage", "pvc"}) {
-					findings = append(findings, assessmentv1alpha1.Finding{
-						ID:          "monitoring-persistent-storage",
-						Validator:   validatorName,
-						Category:    validatorCategory,
-						Status:      assessmentv1alpha1.FindingStatusPass,
-						Title:       "Monitoring Persistent Storage Configured",
-						Description: "Monitoring configuration includes persistent storage settings.",
-					})
-				} else {
-					findings = append(findings, assessmentv1alpha1.Finding{
-						ID:             "monitoring-no-persistent-storage",
-						Validator:      validatorName,
-						Category:       validatorCategory,
-						Status:         assessmentv1alpha1.FindingStatusWarn,
-						Title:          "No Persistent Storage for Monitoring",
-						Description:    "Monitoring configuration does not appear to include persistent storage.",
-						Impact:         "Metrics data will be lost when Prometheus pods restart.",
-						Recommendation: "Configure persistent storage for Prometheus to retain metrics across restarts.",
-					})
-				}
-			}
+		if configYAML := cm.Data["config.yaml"]; configYAML != "" {
+			findings = append(findings, v.checkPrometheusStorage(configYAML, profile)...)
 		}
 	}
 
@@ -150,6 +135,7 @@ func (v *MonitoringValidator) checkUserWorkloadMonitoring(ctx context.Context, c
 			Validator:      validatorName,
 			Category:       validatorCategory,
 			Status:         assessmentv1alpha1.FindingStatusInfo,
+			Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
 			Title:          "User Workload Monitoring Not Configured",
 			Description:    "User workload monitoring is not configured (no user-workload-monitoring-config ConfigMap).",
 			Impact:         "User workload monitoring allows monitoring of custom application metrics.",
@@ -246,16 +232,169 @@ func (v *MonitoringValidator) checkMonitoringOperator(ctx context.Context, c cli
 	return findings
 }
 
-// containsAny checks if s contains any of the substrings.
-func containsAny(s string, substrs []string) bool {
-	for _, substr := range substrs {
-		if len(substr) > 0 && len(s) >= len(substr) {
-			for i := 0; i <= len(s)-len(substr); i++ {
-				if s[i:i+len(substr)] == substr {
-					return true
-				}
-			}
+// defaultPrometheusRetention is the retention used by the cluster monitoring
+// operator when prometheusK8s.retention is not set.
+const defaultPrometheusRetention = "15d"
+
+// clusterMonitoringConfig is the subset of the cluster-monitoring-config
+// config.yaml that the validator inspects.
+type clusterMonitoringConfig struct {
+	PrometheusK8s *prometheusK8sConfig `yaml:"prometheusK8s"`
+}
+
+// prometheusK8sConfig holds the prometheusK8s settings of cluster-monitoring-config.
+type prometheusK8sConfig struct {
+	Retention           string               `yaml:"retention"`
+	RetentionSize       string               `yaml:"retentionSize"`
+	VolumeClaimTemplate *volumeClaimTemplate `yaml:"volumeClaimTemplate"`
+}
+
+// volumeClaimTemplate is the PVC template used for Prometheus storage.
+type volumeClaimTemplate struct {
+	Spec struct {
+		StorageClassName string `yaml:"storageClassName"`
+		Resources        struct {
+			Requests map[string]string `yaml:"requests"`
+		} `yaml:"resources"`
+	} `yaml:"spec"`
+}
+
+// checkPrometheusStorage parses the cluster-monitoring-config and validates the
+// Prometheus retention period and persistent volume claim template.
+func (v *MonitoringValidator) checkPrometheusStorage(configYAML string, profile profiles.Profile) []assessmentv1alpha1.Finding {
+	var findings []assessmentv1alpha1.Finding
+
+	var cfg clusterMonitoringConfig
+	if err := yaml.Unmarshal([]byte(configYAML), &cfg); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:             "monitoring-config-invalid",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Invalid Monitoring Configuration",
+			Description:    fmt.Sprintf("The cluster-monitoring-config config.yaml could not be parsed: %v", err),
+			Impact:         "The cluster monitoring operator cannot apply an invalid configuration and reports itself as degraded.",
+			Recommendation: "Fix the YAML in the cluster-monitoring-config ConfigMap in openshift-monitoring.",
+		}}
+	}
+
+	prometheus := cfg.PrometheusK8s
+	if prometheus == nil {
+		prometheus = &prometheusK8sConfig{}
+	}
+
+	// Persistent storage
+	if prometheus.VolumeClaimTemplate == nil {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "monitoring-no-persistent-storage",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "No Persistent Storage for Monitoring",
+			Description:    "prometheusK8s has no volumeClaimTemplate configured in cluster-monitoring-config.",
+			Impact:         "Metrics data will be lost when Prometheus pods restart.",
+			Recommendation: "Configure prometheusK8s.volumeClaimTemplate to retain metrics across restarts.",
+			References: []string{
+				"https://docs.openshift.com/container-platform/latest/monitoring/configuring-the-monitoring-stack.html#configuring-persistent-storage",
+			},
+		})
+	} else {
+		size := prometheus.VolumeClaimTemplate.Spec.Resources.Requests["storage"]
+		if size == "" {
+			size = "unspecified size"
+		}
+		storageClass := prometheus.VolumeClaimTemplate.Spec.StorageClassName
+		if storageClass == "" {
+			storageClass = "default StorageClass"
+		}
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "monitoring-persistent-storage",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Monitoring Persistent Storage Configured",
+			Description: fmt.Sprintf("Prometheus uses a persistent volume claim template (%s, %s).", size, storageClass),
+		})
+	}
+
+	// Retention period
+	retention := prometheus.Retention
+	if retention == "" {
+		retention = defaultPrometheusRetention
+	}
+	retentionDuration, err := parsePrometheusDuration(retention)
+	if err != nil {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "monitoring-retention-invalid",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Invalid Prometheus Retention",
+			Description:    fmt.Sprintf("prometheusK8s.retention %q is not a valid duration.", retention),
+			Recommendation: "Use a Prometheus duration such as 15d or 24h.",
+		})
+		return findings
+	}
+
+	minRetention := time.Duration(profile.Thresholds.MinMonitoringRetentionDays) * 24 * time.Hour
+	if retentionDuration < minRetention {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "monitoring-retention-short",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Short Metrics Retention",
+			Description:    fmt.Sprintf("Prometheus retention is %s, below the %s profile minimum of %d days.", retention, profile.Name, profile.Thresholds.MinMonitoringRetentionDays),
+			Impact:         "Short retention limits capacity planning and the investigation of incidents after the fact.",
+			Recommendation: "Increase prometheusK8s.retention and size the volumeClaimTemplate accordingly.",
+		})
+	} else {
+		description := fmt.Sprintf("Prometheus retention is %s.", retention)
+		if prometheus.RetentionSize != "" {
+			description = fmt.Sprintf("Prometheus retention is %s (size limit %s).", retention, prometheus.RetentionSize)
+		}
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "monitoring-retention",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Metrics Retention Meets Profile",
+			Description: description,
+		})
+	}
+
+	return findings
+}
+
+// prometheusDurationPattern matches Prometheus durations such as "15d" or "1w2d".
+var prometheusDurationPattern = regexp.MustCompile(`^(?:(\d+)y)?(?:(\d+)w)?(?:(\d+)d)?(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s)?(?:(\d+)ms)?$`)
+
+// parsePrometheusDuration parses a Prometheus duration string.
+func parsePrometheusDuration(s string) (time.Duration, error) {
+	matches := prometheusDurationPattern.FindStringSubmatch(s)
+	if s == "" || matches == nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	units := []time.Duration{
+		365 * 24 * time.Hour,
+		7 * 24 * time.Hour,
+		24 * time.Hour,
+		time.Hour,
+		time.Minute,
+		time.Second,
+		time.Millisecond,
+	}
+	var d time.Duration
+	for i, unit := range units {
+		if matches[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(matches[i+1])
+		if err != nil {
+			return 0, err
 		}
+		d += time.Duration(n) * unit
 	}
-	return false
+	return d, nil
 }
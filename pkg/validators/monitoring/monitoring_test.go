@@ -0,0 +1,132 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator/validatortest"
+)
+
+func TestParsePrometheusDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "15d", want: 15 * 24 * time.Hour},
+		{in: "24h", want: 24 * time.Hour},
+		{in: "1w2d", want: 9 * 24 * time.Hour},
+		{in: "1y", want: 365 * 24 * time.Hour},
+		{in: "1h30m", want: 90 * time.Minute},
+		{in: "", wantErr: true},
+		{in: "15 days", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parsePrometheusDuration(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parsePrometheusDuration(%q) expected error", tt.in)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parsePrometheusDuration(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestCheckPrometheusStorage(t *testing.T) {
+	v := &MonitoringValidator{}
+	production := profiles.GetProfile(string(profiles.ProfileProduction))
+
+	tests := []struct {
+		name    string
+		config  string
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "retention and storage configured",
+			config: `
+prometheusK8s:
+  retention: 30d
+  volumeClaimTemplate:
+    spec:
+      storageClassName: gp3-csi
+      resources:
+        requests:
+          storage: 100Gi
+`,
+			want:    []string{"monitoring-persistent-storage", "monitoring-retention"},
+			notWant: []string{"monitoring-no-persistent-storage", "monitoring-retention-short"},
+		},
+		{
+			name: "short retention without storage",
+			config: `
+prometheusK8s:
+  retention: 24h
+`,
+			want: []string{"monitoring-no-persistent-storage", "monitoring-retention-short"},
+		},
+		{
+			// Mentioning volumeClaimTemplate outside prometheusK8s must not count.
+			name: "storage configured for alertmanager only",
+			config: `
+alertmanagerMain:
+  volumeClaimTemplate:
+    spec:
+      resources:
+        requests:
+          storage: 10Gi
+`,
+			want: []string{"monitoring-no-persistent-storage", "monitoring-retention"},
+		},
+		{
+			name:   "invalid yaml",
+			config: "prometheusK8s: [",
+			want:   []string{"monitoring-config-invalid"},
+		},
+		{
+			name: "invalid retention",
+			config: `
+prometheusK8s:
+  retention: two weeks
+`,
+			want: []string{"monitoring-retention-invalid"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := v.checkPrometheusStorage(tt.config, production)
+			for _, id := range tt.want {
+				if validatortest.FindingByID(findings, id) == nil {
+					t.Errorf("expected finding %s, got %+v", id, findings)
+				}
+			}
+			for _, id := range tt.notWant {
+				if validatortest.FindingByID(findings, id) != nil {
+					t.Errorf("unexpected finding %s", id)
+				}
+			}
+		})
+	}
+}
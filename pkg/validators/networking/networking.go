@@ -19,10 +19,18 @@ package networking
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
@@ -32,7 +40,7 @@ import (
 
 const (
 	validatorName        = "networking"
-	validatorDescription = "Validates networking configuration including CNI and network policies"
+	validatorDescription = "Validates networking configuration including CNI, network policies and Service endpoints"
 	validatorCategory    = "Networking"
 )
 
@@ -58,6 +66,11 @@ func (v *NetworkingValidator) Category() string {
 	return validatorCategory
 }
 
+// RequiresOpenShift reports that this validator needs OpenShift APIs.
+func (v *NetworkingValidator) RequiresOpenShift() bool {
+	return true
+}
+
 // Validate performs networking checks.
 func (v *NetworkingValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
 	var findings []assessmentv1alpha1.Finding
@@ -71,6 +84,18 @@ func (v *NetworkingValidator) Validate(ctx context.Context, c client.Client, pro
 	// Check 3: Ingress configuration
 	findings = append(findings, v.checkIngressConfig(ctx, c)...)
 
+	// Check 4: Route/Ingress host collisions
+	findings = append(findings, v.checkHostCollisions(ctx, c)...)
+
+	// Check 5: LoadBalancer Services exposure
+	findings = append(findings, v.checkLoadBalancerServices(ctx, c)...)
+
+	// Check 6: Overlapping IngressControllers
+	findings = append(findings, v.checkIngressControllerOverlap(ctx, c)...)
+
+	// Check 7: Services that select no ready pods
+	findings = append(findings, v.checkServiceEndpoints(ctx, c)...)
+
 	return findings, nil
 }
 
@@ -168,8 +193,8 @@ func (v *NetworkingValidator) checkNetworkConfig(ctx context.Context, c client.C
 func (v *NetworkingValidator) checkNetworkPolicies(ctx context.Context, c client.Client, profile profiles.Profile) []assessmentv1alpha1.Finding {
 	var findings []assessmentv1alpha1.Finding
 
-	policies := &networkingv1.NetworkPolicyList{}
-	if err := c.List(ctx, policies); err != nil {
+	policies, err := validator.ListNetworkPolicies(ctx, c)
+	if err != nil {
 		return []assessmentv1alpha1.Finding{{
 			ID:          "networking-policies-error",
 			Validator:   validatorName,
@@ -180,7 +205,7 @@ func (v *NetworkingValidator) checkNetworkPolicies(ctx context.Context, c client
 		}}
 	}
 
-	if len(policies.Items) == 0 {
+	if len(policies) == 0 {
 		status := assessmentv1alpha1.FindingStatusInfo
 		if profile.Thresholds.RequireNetworkPolicy {
 			status = assessmentv1alpha1.FindingStatusWarn
@@ -201,7 +226,7 @@ func (v *NetworkingValidator) checkNetworkPolicies(ctx context.Context, c client
 	} else {
 		// Count policies per namespace
 		policyCount := make(map[string]int)
-		for _, policy := range policies.Items {
+		for _, policy := range policies {
 			policyCount[policy.Namespace]++
 		}
 
@@ -211,7 +236,7 @@ func (v *NetworkingValidator) checkNetworkPolicies(ctx context.Context, c client
 			Category:    validatorCategory,
 			Status:      assessmentv1alpha1.FindingStatusPass,
 			Title:       "NetworkPolicies Configured",
-			Description: fmt.Sprintf("Found %d NetworkPolicy(ies) across %d namespace(s).", len(policies.Items), len(policyCount)),
+			Description: fmt.Sprintf("Found %d NetworkPolicy(ies) across %d namespace(s).", len(policies), len(policyCount)),
 		})
 	}
 
@@ -246,3 +271,636 @@ func (v *NetworkingValidator) checkIngressConfig(ctx context.Context, c client.C
 
 	return findings
 }
+
+// checkHostCollisions detects Routes and Ingresses in different objects that
+// claim the same host and path.
+func (v *NetworkingValidator) checkHostCollisions(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	claims := make(map[string][]string)
+
+	routes := &unstructured.UnstructuredList{}
+	routes.SetGroupVersionKind(schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "RouteList"})
+	if err := c.List(ctx, routes); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "networking-route-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Routes",
+			Description: fmt.Sprintf("Failed to list Routes: %v", err),
+		}}
+	}
+	for _, route := range routes.Items {
+		// Routes generated from an Ingress are reported through the Ingress itself.
+		if ownedByIngress(route) {
+			continue
+		}
+		host, _, _ := unstructured.NestedString(route.Object, "spec", "host")
+		if host == "" {
+			continue
+		}
+		path, _, _ := unstructured.NestedString(route.Object, "spec", "path")
+		key := hostPathKey(host, path)
+		claims[key] = append(claims[key], fmt.Sprintf("route %s/%s", route.GetNamespace(), route.GetName()))
+	}
+
+	ingresses := &networkingv1.IngressList{}
+	if err := c.List(ctx, ingresses); err == nil {
+		for _, ing := range ingresses.Items {
+			seen := make(map[string]bool)
+			for _, rule := range ing.Spec.Rules {
+				if rule.Host == "" {
+					continue
+				}
+				paths := []string{""}
+				if rule.HTTP != nil && len(rule.HTTP.Paths) > 0 {
+					paths = paths[:0]
+					for _, p := range rule.HTTP.Paths {
+						paths = append(paths, p.Path)
+					}
+				}
+				for _, path := range paths {
+					key := hostPathKey(rule.Host, path)
+					if seen[key] {
+						continue
+					}
+					seen[key] = true
+					claims[key] = append(claims[key], fmt.Sprintf("ingress %s/%s", ing.Namespace, ing.Name))
+				}
+			}
+		}
+	}
+
+	var collisions []string
+	for key, owners := range claims {
+		if len(owners) > 1 {
+			collisions = append(collisions, fmt.Sprintf("%s (%s)", key, strings.Join(owners, ", ")))
+		}
+	}
+	sort.Strings(collisions)
+
+	if len(collisions) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "networking-no-host-collisions",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "No Route Host Collisions",
+			Description: "No two Routes or Ingresses claim the same host and path.",
+		}}
+	}
+
+	sample := collisions
+	if len(sample) > 5 {
+		sample = sample[:5]
+	}
+	return []assessmentv1alpha1.Finding{{
+		ID:             "networking-host-collisions",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Title:          "Route Host Collisions",
+		Description:    fmt.Sprintf("%d host/path combination(s) are claimed by more than one Route or Ingress: %s", len(collisions), strings.Join(sample, "; ")),
+		Impact:         "Only the oldest claim is admitted by the router; traffic for the host may reach a different application than intended, and the other Routes are rejected.",
+		Recommendation: "Give each application a unique hostname or path, and remove stale Routes or Ingresses.",
+		References: []string{
+			"https://docs.openshift.com/container-platform/latest/networking/routes/route-configuration.html",
+		},
+	}}
+}
+
+// hostPathKey formats a host and optional path as a single claim key.
+func hostPathKey(host, path string) string {
+	if path == "" || path == "/" {
+		return host
+	}
+	return host + path
+}
+
+// ownedByIngress reports whether the object was generated from an Ingress.
+func ownedByIngress(obj unstructured.Unstructured) bool {
+	for _, owner := range obj.GetOwnerReferences() {
+		if owner.Kind == "Ingress" {
+			return true
+		}
+	}
+	return false
+}
+
+// sensitivePorts maps ports that should not be reachable from the internet to
+// the service usually listening on them.
+var sensitivePorts = map[int32]string{
+	22:    "ssh",
+	1433:  "mssql",
+	1521:  "oracle",
+	2379:  "etcd",
+	3306:  "mysql",
+	5432:  "postgresql",
+	5672:  "amqp",
+	6379:  "redis",
+	9042:  "cassandra",
+	9200:  "elasticsearch",
+	11211: "memcached",
+	27017: "mongodb",
+}
+
+// internalLoadBalancerAnnotations are the cloud provider annotations that
+// request an internal (non internet-facing) load balancer.
+var internalLoadBalancerAnnotations = map[string]string{
+	"service.beta.kubernetes.io/aws-load-balancer-internal":          "true",
+	"service.beta.kubernetes.io/aws-load-balancer-scheme":            "internal",
+	"service.beta.kubernetes.io/azure-load-balancer-internal":        "true",
+	"networking.gke.io/load-balancer-type":                           "Internal",
+	"cloud.google.com/load-balancer-type":                            "Internal",
+	"service.kubernetes.io/ibm-load-balancer-cloud-provider-ip-type": "private",
+}
+
+// cloudPlatforms are the platforms whose LoadBalancer Services get a public
+// cloud load balancer by default.
+var cloudPlatforms = map[configv1.PlatformType]bool{
+	configv1.AWSPlatformType:          true,
+	configv1.AzurePlatformType:        true,
+	configv1.GCPPlatformType:          true,
+	configv1.IBMCloudPlatformType:     true,
+	configv1.AlibabaCloudPlatformType: true,
+	configv1.PowerVSPlatformType:      true,
+}
+
+// checkLoadBalancerServices lists LoadBalancer Services in user namespaces and
+// flags internet-facing ones that expose sensitive ports or accept traffic
+// from any source.
+func (v *NetworkingValidator) checkLoadBalancerServices(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	var findings []assessmentv1alpha1.Finding
+
+	services := &corev1.ServiceList{}
+	if err := c.List(ctx, services); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "networking-service-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Services",
+			Description: fmt.Sprintf("Failed to list Services: %v", err),
+		}}
+	}
+
+	cloud := false
+	infra := &configv1.Infrastructure{}
+	if err := c.Get(ctx, client.ObjectKey{Name: "cluster"}, infra); err == nil && infra.Status.PlatformStatus != nil {
+		cloud = cloudPlatforms[infra.Status.PlatformStatus.Type]
+	}
+
+	var exposed, sensitive, unrestricted []string
+	for _, svc := range services.Items {
+		if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+		if strings.HasPrefix(svc.Namespace, "openshift-") || strings.HasPrefix(svc.Namespace, "kube-") {
+			continue
+		}
+
+		name := fmt.Sprintf("%s/%s", svc.Namespace, svc.Name)
+		exposed = append(exposed, fmt.Sprintf("%s (%s)", name, loadBalancerAddresses(svc)))
+
+		if isInternalLoadBalancer(svc) {
+			continue
+		}
+		restricted := hasSourceRanges(svc)
+
+		var ports []string
+		for _, port := range svc.Spec.Ports {
+			if service, ok := sensitivePorts[port.Port]; ok {
+				ports = append(ports, fmt.Sprintf("%d/%s", port.Port, service))
+			} else if service, ok := sensitivePorts[port.TargetPort.IntVal]; ok {
+				ports = append(ports, fmt.Sprintf("%d->%d/%s", port.Port, port.TargetPort.IntVal, service))
+			}
+		}
+		if len(ports) > 0 && !restricted {
+			sensitive = append(sensitive, fmt.Sprintf("%s (%s)", name, strings.Join(ports, ", ")))
+		}
+		if cloud && !restricted {
+			unrestricted = append(unrestricted, name)
+		}
+	}
+
+	if len(exposed) == 0 {
+		return findings
+	}
+
+	sample := exposed
+	if len(sample) > 5 {
+		sample = sample[:5]
+	}
+	findings = append(findings, assessmentv1alpha1.Finding{
+		ID:          "networking-loadbalancer-services",
+		Validator:   validatorName,
+		Category:    validatorCategory,
+		Status:      assessmentv1alpha1.FindingStatusInfo,
+		Title:       "LoadBalancer Services",
+		Description: fmt.Sprintf("Found %d LoadBalancer Service(s) in user namespaces: %s", len(exposed), strings.Join(sample, ", ")),
+	})
+
+	if len(sensitive) > 0 {
+		sample := sensitive
+		if len(sample) > 5 {
+			sample = sample[:5]
+		}
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "networking-loadbalancer-sensitive-ports",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
+			Title:          "Sensitive Ports Exposed by LoadBalancer",
+			Description:    fmt.Sprintf("Found %d internet-facing LoadBalancer Service(s) exposing database or administrative ports: %s", len(sensitive), strings.Join(sample, "; ")),
+			Impact:         "Databases and administrative services reachable from the internet are a common target for brute-force and data exfiltration attacks.",
+			Recommendation: "Use a ClusterIP Service, an internal load balancer annotation, or restrict spec.loadBalancerSourceRanges to trusted networks.",
+			References: []string{
+				"https://kubernetes.io/docs/tasks/access-application-cluster/configure-cloud-provider-firewall/",
+			},
+		})
+	}
+
+	if len(unrestricted) > 0 {
+		sample := unrestricted
+		if len(sample) > 5 {
+			sample = sample[:5]
+		}
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "networking-loadbalancer-no-source-ranges",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
+			Title:          "LoadBalancers Without Source Range Restrictions",
+			Description:    fmt.Sprintf("Found %d internet-facing LoadBalancer Service(s) without loadBalancerSourceRanges: %s", len(unrestricted), strings.Join(sample, ", ")),
+			Impact:         "The cloud load balancer accepts traffic from any address on the internet.",
+			Recommendation: "Set spec.loadBalancerSourceRanges to the client networks that need access, or use an internal load balancer.",
+			References: []string{
+				"https://kubernetes.io/docs/tasks/access-application-cluster/configure-cloud-provider-firewall/",
+			},
+		})
+	}
+
+	return findings
+}
+
+// checkServiceEndpoints flags Services in user namespaces whose selector
+// matches no ready pods, so that connections to them fail. Headless,
+// ExternalName and selector-less Services are skipped, as their endpoints
+// are not derived from pods or are managed by hand.
+func (v *NetworkingValidator) checkServiceEndpoints(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	services := &corev1.ServiceList{}
+	if err := c.List(ctx, services); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "networking-service-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Services",
+			Description: fmt.Sprintf("Failed to list Services: %v", err),
+		}}
+	}
+	pods, err := validator.ListPods(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "networking-pod-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Pods",
+			Description: fmt.Sprintf("Failed to list pods: %v", err),
+		}}
+	}
+	podsByNamespace := make(map[string][]corev1.Pod)
+	for _, pod := range pods {
+		podsByNamespace[pod.Namespace] = append(podsByNamespace[pod.Namespace], pod)
+	}
+
+	var checked int
+	var broken []string
+	var evidence string
+	for _, svc := range services.Items {
+		if strings.HasPrefix(svc.Namespace, "openshift-") || strings.HasPrefix(svc.Namespace, "kube-") {
+			continue
+		}
+		if svc.Spec.Type == corev1.ServiceTypeExternalName || svc.Spec.ClusterIP == corev1.ClusterIPNone || len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		checked++
+
+		selector := labels.SelectorFromSet(svc.Spec.Selector)
+		matching, ready := 0, 0
+		for _, pod := range podsByNamespace[svc.Namespace] {
+			if !selector.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+			matching++
+			if isPodReady(pod) {
+				ready++
+			}
+		}
+		if ready > 0 {
+			continue
+		}
+
+		detail := fmt.Sprintf("%s/%s (selects no pods)", svc.Namespace, svc.Name)
+		if matching > 0 {
+			detail = fmt.Sprintf("%s/%s (%d matching pod(s), none ready)", svc.Namespace, svc.Name, matching)
+		}
+		broken = append(broken, detail)
+		if len(broken) == 1 {
+			evidence = validator.Evidence(ctx, &svc)
+		}
+	}
+
+	if checked == 0 {
+		return nil
+	}
+
+	if len(broken) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "networking-service-endpoints-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Services Have Ready Endpoints",
+			Description: fmt.Sprintf("All %d Service(s) with a pod selector in user namespaces select at least one ready pod.", checked),
+		}}
+	}
+
+	sample := broken
+	if len(sample) > 5 {
+		sample = sample[:5]
+	}
+	return []assessmentv1alpha1.Finding{{
+		ID:             "networking-service-no-endpoints",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Title:          "Services Without Ready Endpoints",
+		Description:    fmt.Sprintf("Found %d of %d Service(s) in user namespaces whose selector matches no ready pods: %s", len(broken), checked, strings.Join(sample, ", ")),
+		Impact:         "Connections to these Services, and to Routes and Ingresses pointing at them, are refused or time out, because there is no pod to forward them to.",
+		Recommendation: "Compare the Service selector with the labels of the intended pods (oc get pods -l <selector>), and check why matching pods are not ready. Delete Services left behind by removed or scaled-down applications.",
+		References: []string{
+			"https://kubernetes.io/docs/concepts/services-networking/service/#services-without-selectors",
+		},
+		Evidence: evidence,
+	}}
+}
+
+// isPodReady reports whether the pod is not being deleted and has its Ready
+// condition set.
+func isPodReady(pod corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// loadBalancerAddresses returns the external IPs and hostnames assigned to a
+// LoadBalancer Service.
+func loadBalancerAddresses(svc corev1.Service) string {
+	var addresses []string
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.Hostname != "" {
+			addresses = append(addresses, ingress.Hostname)
+		}
+		if ingress.IP != "" {
+			addresses = append(addresses, ingress.IP)
+		}
+	}
+	if len(addresses) == 0 {
+		return "pending"
+	}
+	return strings.Join(addresses, ", ")
+}
+
+// isInternalLoadBalancer reports whether the Service requests an internal load balancer.
+func isInternalLoadBalancer(svc corev1.Service) bool {
+	for key, want := range internalLoadBalancerAnnotations {
+		if strings.EqualFold(svc.Annotations[key], want) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSourceRanges reports whether the Service restricts which client addresses
+// may reach its load balancer. A range that allows every address does not count.
+func hasSourceRanges(svc corev1.Service) bool {
+	ranges := svc.Spec.LoadBalancerSourceRanges
+	if len(ranges) == 0 && svc.Annotations[corev1.AnnotationLoadBalancerSourceRangesKey] != "" {
+		ranges = strings.Split(svc.Annotations[corev1.AnnotationLoadBalancerSourceRangesKey], ",")
+	}
+	for _, r := range ranges {
+		if r = strings.TrimSpace(r); r == "0.0.0.0/0" || r == "::/0" {
+			return false
+		}
+	}
+	return len(ranges) > 0
+}
+
+// ingressController is the subset of an IngressController used to detect
+// overlapping shards.
+type ingressController struct {
+	name              string
+	domain            string
+	routeSelector     *metav1.LabelSelector
+	namespaceSelector *metav1.LabelSelector
+}
+
+// checkIngressControllerOverlap detects IngressControllers that share a
+// domain or whose route and namespace selectors can match the same Route.
+func (v *NetworkingValidator) checkIngressControllerOverlap(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{Group: "operator.openshift.io", Version: "v1", Kind: "IngressControllerList"})
+	if err := c.List(ctx, list, client.InNamespace("openshift-ingress-operator")); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return []assessmentv1alpha1.Finding{{
+			ID:          "networking-ingresscontroller-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check IngressControllers",
+			Description: fmt.Sprintf("Failed to list IngressControllers: %v", err),
+		}}
+	}
+
+	var controllers []ingressController
+	for _, item := range list.Items {
+		ic := ingressController{name: item.GetName()}
+		ic.domain, _, _ = unstructured.NestedString(item.Object, "status", "domain")
+		if ic.domain == "" {
+			ic.domain, _, _ = unstructured.NestedString(item.Object, "spec", "domain")
+		}
+		ic.routeSelector = nestedLabelSelector(item, "spec", "routeSelector")
+		ic.namespaceSelector = nestedLabelSelector(item, "spec", "namespaceSelector")
+		controllers = append(controllers, ic)
+	}
+	sort.Slice(controllers, func(i, j int) bool { return controllers[i].name < controllers[j].name })
+
+	if len(controllers) < 2 {
+		return nil
+	}
+
+	var overlaps []string
+	for i := range controllers {
+		for j := i + 1; j < len(controllers); j++ {
+			a, b := controllers[i], controllers[j]
+			var reasons []string
+			if sameDomain(a.domain, b.domain) {
+				reasons = append(reasons, "same domain")
+			}
+			if selectorsMayOverlap(a.routeSelector, b.routeSelector) && selectorsMayOverlap(a.namespaceSelector, b.namespaceSelector) {
+				reasons = append(reasons, "selectors can match the same routes")
+			}
+			if len(reasons) > 0 {
+				overlaps = append(overlaps, fmt.Sprintf("%s and %s: %s", describeIngressController(a), describeIngressController(b), strings.Join(reasons, ", ")))
+			}
+		}
+	}
+
+	if len(overlaps) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "networking-ingresscontrollers-distinct",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "IngressController Shards Are Distinct",
+			Description: fmt.Sprintf("The %d IngressControllers have distinct domains and non-overlapping selectors.", len(controllers)),
+		}}
+	}
+
+	sample := overlaps
+	if len(sample) > 5 {
+		sample = sample[:5]
+	}
+	return []assessmentv1alpha1.Finding{{
+		ID:             "networking-ingresscontroller-overlap",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
+		Title:          "Overlapping IngressControllers",
+		Description:    fmt.Sprintf("Found %d IngressController pair(s) that can admit the same routes: %s", len(overlaps), strings.Join(sample, "; ")),
+		Impact:         "Routes may be admitted by more than one router or by the wrong one, exposing applications on unintended domains or load balancers.",
+		Recommendation: "Give each IngressController its own domain and mutually exclusive routeSelector or namespaceSelector, including an exclusion on the default controller.",
+		References: []string{
+			"https://docs.openshift.com/container-platform/latest/networking/ingress-sharding.html",
+		},
+	}}
+}
+
+// nestedLabelSelector returns the label selector at the given path, or nil if
+// it is unset or cannot be decoded.
+func nestedLabelSelector(obj unstructured.Unstructured, fields ...string) *metav1.LabelSelector {
+	raw, found, err := unstructured.NestedMap(obj.Object, fields...)
+	if err != nil || !found {
+		return nil
+	}
+	selector := &metav1.LabelSelector{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, selector); err != nil {
+		return nil
+	}
+	return selector
+}
+
+// describeIngressController formats an IngressController with its domain and selectors.
+func describeIngressController(ic ingressController) string {
+	return fmt.Sprintf("%s (domain %s, routes %s, namespaces %s)",
+		ic.name, ic.domain, describeSelector(ic.routeSelector), describeSelector(ic.namespaceSelector))
+}
+
+// describeSelector formats a label selector, using "all" for an empty one.
+func describeSelector(selector *metav1.LabelSelector) string {
+	if selector == nil || (len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0) {
+		return "all"
+	}
+	return metav1.FormatLabelSelector(selector)
+}
+
+// sameDomain reports whether two ingress domains are the same, in which case
+// both controllers publish the same wildcard DNS record.
+func sameDomain(a, b string) bool {
+	a, b = strings.ToLower(strings.TrimSuffix(a, ".")), strings.ToLower(strings.TrimSuffix(b, "."))
+	return a != "" && a == b
+}
+
+// selectorsMayOverlap reports whether some label set could satisfy both
+// selectors. It only rules out overlap for direct contradictions, so it errs on
+// the side of reporting an overlap.
+func selectorsMayOverlap(a, b *metav1.LabelSelector) bool {
+	requirements := append(selectorRequirements(a), selectorRequirements(b)...)
+	byKey := make(map[string][]metav1.LabelSelectorRequirement)
+	for _, r := range requirements {
+		byKey[r.Key] = append(byKey[r.Key], r)
+	}
+
+	for _, reqs := range byKey {
+		// allowed is the set of values permitted by In requirements; nil means unconstrained.
+		var allowed map[string]bool
+		mustExist, mustNotExist := false, false
+		excluded := make(map[string]bool)
+
+		for _, r := range reqs {
+			switch r.Operator {
+			case metav1.LabelSelectorOpIn:
+				mustExist = true
+				next := make(map[string]bool)
+				for _, value := range r.Values {
+					if allowed == nil || allowed[value] {
+						next[value] = true
+					}
+				}
+				allowed = next
+			case metav1.LabelSelectorOpNotIn:
+				for _, value := range r.Values {
+					excluded[value] = true
+				}
+			case metav1.LabelSelectorOpExists:
+				mustExist = true
+			case metav1.LabelSelectorOpDoesNotExist:
+				mustNotExist = true
+			}
+		}
+
+		if mustExist && mustNotExist {
+			return false
+		}
+		if allowed != nil {
+			remaining := 0
+			for value := range allowed {
+				if !excluded[value] {
+					remaining++
+				}
+			}
+			if remaining == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// selectorRequirements expresses a label selector as a list of requirements.
+func selectorRequirements(selector *metav1.LabelSelector) []metav1.LabelSelectorRequirement {
+	if selector == nil {
+		return nil
+	}
+	requirements := append([]metav1.LabelSelectorRequirement{}, selector.MatchExpressions...)
+	for key, value := range selector.MatchLabels {
+		requirements = append(requirements, metav1.LabelSelectorRequirement{
+			Key:      key,
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   []string{value},
+		})
+	}
+	return requirements
+}
@@ -0,0 +1,341 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networking
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator/validatortest"
+)
+
+func newRoute(ns, name, host, path string, owners ...metav1.OwnerReference) *unstructured.Unstructured {
+	spec := map[string]interface{}{"host": host}
+	if path != "" {
+		spec["path"] = path
+	}
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	u.SetGroupVersionKind(schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "Route"})
+	u.SetNamespace(ns)
+	u.SetName(name)
+	u.SetOwnerReferences(owners)
+	return u
+}
+
+func TestCheckHostCollisions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = networkingv1.AddToScheme(scheme)
+
+	objs := []client.Object{
+		newRoute("team-a", "shop", "shop.apps.example.com", ""),
+		newRoute("team-b", "shop", "shop.apps.example.com", "/"),
+		newRoute("team-a", "api", "shop.apps.example.com", "/api"),
+		newRoute("team-c", "blog", "blog.apps.example.com", ""),
+		newRoute("team-c", "blog-ingress-x7k2", "blog.apps.example.com", "", metav1.OwnerReference{Kind: "Ingress", Name: "blog"}),
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "blog", Namespace: "team-c"},
+			Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{
+				Host: "blog.apps.example.com",
+				IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{Path: "/"}},
+				}},
+			}}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	findings := (&NetworkingValidator{}).checkHostCollisions(context.Background(), c)
+	if len(findings) != 1 || findings[0].ID != "networking-host-collisions" {
+		t.Fatalf("expected networking-host-collisions, got %+v", findings)
+	}
+	f := findings[0]
+	if f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Errorf("expected WARN, got %s", f.Status)
+	}
+	for _, want := range []string{
+		"blog.apps.example.com (route team-c/blog, ingress team-c/blog)",
+		"shop.apps.example.com (route team-a/shop, route team-b/shop)",
+	} {
+		if !strings.Contains(f.Description, want) {
+			t.Errorf("expected %q in description: %s", want, f.Description)
+		}
+	}
+	if strings.Contains(f.Description, "/api") || strings.Contains(f.Description, "x7k2") {
+		t.Errorf("unexpected collision reported: %s", f.Description)
+	}
+}
+
+func TestCheckHostCollisions_None(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = networkingv1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newRoute("team-a", "shop", "shop.apps.example.com", ""),
+	).Build()
+
+	findings := (&NetworkingValidator{}).checkHostCollisions(context.Background(), c)
+	if len(findings) != 1 || findings[0].ID != "networking-no-host-collisions" {
+		t.Fatalf("expected networking-no-host-collisions, got %+v", findings)
+	}
+}
+
+func newLoadBalancer(ns, name string, port int32, annotations map[string]string, sourceRanges ...string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name, Annotations: annotations},
+		Spec: corev1.ServiceSpec{
+			Type:                     corev1.ServiceTypeLoadBalancer,
+			Ports:                    []corev1.ServicePort{{Port: port, TargetPort: intstr.FromInt32(port)}},
+			LoadBalancerSourceRanges: sourceRanges,
+		},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+			Ingress: []corev1.LoadBalancerIngress{{Hostname: name + ".elb.example.com"}},
+		}},
+	}
+}
+
+func TestCheckLoadBalancerServices(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = configv1.AddToScheme(scheme)
+
+	objs := []client.Object{
+		&configv1.Infrastructure{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+			Status:     configv1.InfrastructureStatus{PlatformStatus: &configv1.PlatformStatus{Type: configv1.AWSPlatformType}},
+		},
+		newLoadBalancer("team-a", "postgres", 5432, nil),
+		newLoadBalancer("team-a", "web", 443, nil),
+		newLoadBalancer("team-b", "web", 443, nil, "203.0.113.0/24"),
+		newLoadBalancer("team-b", "redis", 6379, map[string]string{"service.beta.kubernetes.io/aws-load-balancer-internal": "true"}),
+		newLoadBalancer("team-c", "open", 8080, nil, "0.0.0.0/0"),
+		newLoadBalancer("openshift-ingress", "router", 443, nil),
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "db"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, Ports: []corev1.ServicePort{{Port: 5432}}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	findings := (&NetworkingValidator{}).checkLoadBalancerServices(context.Background(), c)
+
+	all := validatortest.FindingByID(findings, "networking-loadbalancer-services")
+	if all == nil || !strings.Contains(all.Description, "Found 5 LoadBalancer") ||
+		!strings.Contains(all.Description, "team-a/postgres (postgres.elb.example.com)") {
+		t.Errorf("unexpected LoadBalancer summary: %+v", all)
+	}
+
+	sensitive := validatortest.FindingByID(findings, "networking-loadbalancer-sensitive-ports")
+	if sensitive == nil || sensitive.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("expected sensitive ports WARN, got %+v", findings)
+	}
+	if !strings.Contains(sensitive.Description, "team-a/postgres (5432/postgresql)") || strings.Contains(sensitive.Description, "redis") {
+		t.Errorf("unexpected sensitive ports description: %s", sensitive.Description)
+	}
+
+	unrestricted := validatortest.FindingByID(findings, "networking-loadbalancer-no-source-ranges")
+	if unrestricted == nil {
+		t.Fatalf("expected no-source-ranges WARN, got %+v", findings)
+	}
+	if !strings.Contains(unrestricted.Description, "Found 3 ") || strings.Contains(unrestricted.Description, "team-b/web") {
+		t.Errorf("unexpected no-source-ranges description: %s", unrestricted.Description)
+	}
+}
+
+func TestCheckLoadBalancerServices_OnPrem(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = configv1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newLoadBalancer("team-a", "web", 443, nil),
+	).Build()
+
+	findings := (&NetworkingValidator{}).checkLoadBalancerServices(context.Background(), c)
+	if validatortest.FindingByID(findings, "networking-loadbalancer-no-source-ranges") != nil {
+		t.Errorf("source ranges should only be required on cloud platforms, got %+v", findings)
+	}
+}
+
+func newIngressController(name, domain string, routeSelector, namespaceSelector map[string]interface{}) *unstructured.Unstructured {
+	spec := map[string]interface{}{"domain": domain}
+	if routeSelector != nil {
+		spec["routeSelector"] = routeSelector
+	}
+	if namespaceSelector != nil {
+		spec["namespaceSelector"] = namespaceSelector
+	}
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	u.SetGroupVersionKind(schema.GroupVersionKind{Group: "operator.openshift.io", Version: "v1", Kind: "IngressController"})
+	u.SetNamespace("openshift-ingress-operator")
+	u.SetName(name)
+	return u
+}
+
+func TestCheckIngressControllerOverlap(t *testing.T) {
+	sharded := map[string]interface{}{"matchLabels": map[string]interface{}{"type": "sharded"}}
+	notSharded := map[string]interface{}{"matchExpressions": []interface{}{
+		map[string]interface{}{"key": "type", "operator": "NotIn", "values": []interface{}{"sharded"}},
+	}}
+
+	tests := []struct {
+		name     string
+		objs     []client.Object
+		wantID   string
+		wantDesc []string
+	}{
+		{
+			name: "default without exclusion",
+			objs: []client.Object{
+				newIngressController("default", "apps.example.com", nil, nil),
+				newIngressController("sharded", "shard.example.com", sharded, nil),
+			},
+			wantID:   "networking-ingresscontroller-overlap",
+			wantDesc: []string{"default (domain apps.example.com, routes all, namespaces all)", "selectors can match the same routes"},
+		},
+		{
+			name: "same domain",
+			objs: []client.Object{
+				newIngressController("default", "apps.example.com", notSharded, nil),
+				newIngressController("sharded", "apps.example.com", sharded, nil),
+			},
+			wantID:   "networking-ingresscontroller-overlap",
+			wantDesc: []string{"same domain"},
+		},
+		{
+			name: "properly sharded",
+			objs: []client.Object{
+				newIngressController("default", "apps.example.com", notSharded, nil),
+				newIngressController("sharded", "shard.example.com", sharded, nil),
+			},
+			wantID: "networking-ingresscontrollers-distinct",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).WithObjects(tt.objs...).Build()
+			findings := (&NetworkingValidator{}).checkIngressControllerOverlap(context.Background(), c)
+			f := validatortest.FindingByID(findings, tt.wantID)
+			if f == nil {
+				t.Fatalf("expected %s, got %+v", tt.wantID, findings)
+			}
+			for _, want := range tt.wantDesc {
+				if !strings.Contains(f.Description, want) {
+					t.Errorf("expected %q in description: %s", want, f.Description)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectorsMayOverlap(t *testing.T) {
+	in := func(key string, values ...string) *metav1.LabelSelector {
+		return &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: key, Operator: metav1.LabelSelectorOpIn, Values: values}}}
+	}
+	labels := func(key, value string) *metav1.LabelSelector {
+		return &metav1.LabelSelector{MatchLabels: map[string]string{key: value}}
+	}
+	doesNotExist := &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "shard", Operator: metav1.LabelSelectorOpDoesNotExist}}}
+
+	tests := []struct {
+		name string
+		a, b *metav1.LabelSelector
+		want bool
+	}{
+		{"both empty", nil, nil, true},
+		{"empty and labelled", nil, labels("shard", "a"), true},
+		{"different values", labels("shard", "a"), labels("shard", "b"), false},
+		{"different keys", labels("shard", "a"), labels("team", "b"), true},
+		{"intersecting In", in("shard", "a", "b"), in("shard", "b", "c"), true},
+		{"disjoint In", in("shard", "a"), in("shard", "c"), false},
+		{"exists and does not exist", labels("shard", "a"), doesNotExist, false},
+	}
+	for _, tt := range tests {
+		if got := selectorsMayOverlap(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s: selectorsMayOverlap = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCheckServiceEndpoints(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	service := func(ns, name string, selector map[string]string, mutate ...func(*corev1.Service)) *corev1.Service {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+			Spec:       corev1.ServiceSpec{Selector: selector, ClusterIP: "172.30.0.10"},
+		}
+		for _, m := range mutate {
+			m(svc)
+		}
+		return svc
+	}
+	pod := func(ns, name, app string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name, Labels: map[string]string{"app": app}},
+			Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		service("shop", "web", map[string]string{"app": "web"}),
+		service("shop", "api", map[string]string{"app": "api"}),
+		service("shop", "typo", map[string]string{"app": "wbe"}),
+		service("shop", "headless", map[string]string{"app": "db"}, func(s *corev1.Service) { s.Spec.ClusterIP = corev1.ClusterIPNone }),
+		service("shop", "external", nil, func(s *corev1.Service) { s.Spec.Type = corev1.ServiceTypeExternalName }),
+		service("shop", "manual", nil),
+		service("other", "web", map[string]string{"app": "web"}),
+		service("openshift-monitoring", "grafana", map[string]string{"app": "grafana"}),
+		pod("shop", "web-1", "web", corev1.ConditionTrue),
+		pod("shop", "api-1", "api", corev1.ConditionFalse),
+	).Build()
+
+	findings := (&NetworkingValidator{}).checkServiceEndpoints(context.Background(), c)
+	if len(findings) != 1 || findings[0].ID != "networking-service-no-endpoints" || findings[0].Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("expected a single no-endpoints WARN, got %+v", findings)
+	}
+	for _, want := range []string{
+		"Found 3 of 4 Service(s)",
+		"shop/api (1 matching pod(s), none ready)",
+		"shop/typo (selects no pods)",
+		"other/web (selects no pods)",
+	} {
+		if !strings.Contains(findings[0].Description, want) {
+			t.Errorf("expected %q in description: %s", want, findings[0].Description)
+		}
+	}
+
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		service("shop", "web", map[string]string{"app": "web"}),
+		pod("shop", "web-1", "web", corev1.ConditionTrue),
+	).Build()
+	findings = (&NetworkingValidator{}).checkServiceEndpoints(context.Background(), c)
+	if len(findings) != 1 || findings[0].ID != "networking-service-endpoints-ok" {
+		t.Errorf("expected a pass finding, got %+v", findings)
+	}
+}
@@ -19,10 +19,14 @@ package networkpolicyaudit
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
@@ -58,6 +62,16 @@ func (v *NetworkPolicyAuditValidator) Category() string {
 	return validatorCategory
 }
 
+// WatchedResources returns the kinds read by this validator.
+func (v *NetworkPolicyAuditValidator) WatchedResources() []schema.GroupVersionKind {
+	return []schema.GroupVersionKind{
+		corev1.SchemeGroupVersion.WithKind("Namespace"),
+		networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy"),
+		adminNetworkPolicyGroupVersion.WithKind("AdminNetworkPolicy"),
+		adminNetworkPolicyGroupVersion.WithKind("BaselineAdminNetworkPolicy"),
+	}
+}
+
 // Validate performs NetworkPolicy audit checks.
 func (v *NetworkPolicyAuditValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
 	var findings []assessmentv1alpha1.Finding
@@ -69,7 +83,10 @@ func (v *NetworkPolicyAuditValidator) Validate(ctx context.Context, c client.Cli
 	findings = append(findings, v.checkAllowAllPolicies(ctx, c)...)
 
 	// Check 3: Default deny policies
-	findings = append(findings, v.checkDefaultDenyPolicies(ctx, c)...)
+	findings = append(findings, v.checkDefaultDenyPolicies(ctx, c, profile)...)
+
+	// Check 4: Cluster-scoped AdminNetworkPolicies
+	findings = append(findings, v.checkAdminNetworkPolicies(ctx, c)...)
 
 	return findings, nil
 }
@@ -79,8 +96,8 @@ func (v *NetworkPolicyAuditValidator) checkNetworkPolicyCoverage(ctx context.Con
 	var findings []assessmentv1alpha1.Finding
 
 	// Get all namespaces
-	namespaces := &corev1.NamespaceList{}
-	if err := c.List(ctx, namespaces); err != nil {
+	namespaces, err := validator.ListNamespaces(ctx, c)
+	if err != nil {
 		return []assessmentv1alpha1.Finding{{
 			ID:          "networkpolicyaudit-ns-error",
 			Validator:   validatorName,
@@ -92,8 +109,8 @@ func (v *NetworkPolicyAuditValidator) checkNetworkPolicyCoverage(ctx context.Con
 	}
 
 	// Get all NetworkPolicies
-	networkPolicies := &networkingv1.NetworkPolicyList{}
-	if err := c.List(ctx, networkPolicies); err != nil {
+	networkPolicies, err := validator.ListNetworkPolicies(ctx, c)
+	if err != nil {
 		return []assessmentv1alpha1.Finding{{
 			ID:          "networkpolicyaudit-list-error",
 			Validator:   validatorName,
@@ -106,14 +123,14 @@ func (v *NetworkPolicyAuditValidator) checkNetworkPolicyCoverage(ctx context.Con
 
 	// Build map of namespaces with policies
 	nsWithPolicy := make(map[string]int)
-	for _, np := range networkPolicies.Items {
+	for _, np := range networkPolicies {
 		nsWithPolicy[np.Namespace]++
 	}
 
 	var userNamespacesWithoutPolicy []string
 	var userNamespacesWithPolicy []string
 
-	for _, ns := range namespaces.Items {
+	for _, ns := range namespaces {
 		// Skip system namespaces
 		if strings.HasPrefix(ns.Name, "openshift-") || strings.HasPrefix(ns.Name, "kube-") || ns.Name == "default" {
 			continue
@@ -176,15 +193,15 @@ func (v *NetworkPolicyAuditValidator) checkNetworkPolicyCoverage(ctx context.Con
 func (v *NetworkPolicyAuditValidator) checkAllowAllPolicies(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
 	var findings []assessmentv1alpha1.Finding
 
-	networkPolicies := &networkingv1.NetworkPolicyList{}
-	if err := c.List(ctx, networkPolicies); err != nil {
+	networkPolicies, err := validator.ListNetworkPolicies(ctx, c)
+	if err != nil {
 		return findings
 	}
 
 	var allowAllIngress []string
 	var allowAllEgress []string
 
-	for _, np := range networkPolicies.Items {
+	for _, np := range networkPolicies {
 		// Skip system namespaces
 		if strings.HasPrefix(np.Namespace, "openshift-") || strings.HasPrefix(np.Namespace, "kube-") {
 			continue
@@ -259,48 +276,46 @@ func (v *NetworkPolicyAuditValidator) checkAllowAllPolicies(ctx context.Context,
 	return findings
 }
 
-// checkDefaultDenyPolicies checks for default deny policies.
-func (v *NetworkPolicyAuditValidator) checkDefaultDenyPolicies(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+// checkDefaultDenyPolicies checks for default deny policies. With
+// RequireBidirectionalDeny, namespaces that deny only one direction by
+// default are flagged as well.
+func (v *NetworkPolicyAuditValidator) checkDefaultDenyPolicies(ctx context.Context, c client.Client, profile profiles.Profile) []assessmentv1alpha1.Finding {
 	var findings []assessmentv1alpha1.Finding
 
-	networkPolicies := &networkingv1.NetworkPolicyList{}
-	if err := c.List(ctx, networkPolicies); err != nil {
+	networkPolicies, err := validator.ListNetworkPolicies(ctx, c)
+	if err != nil {
 		return findings
 	}
 
 	var namespacesWithDenyAll []string
-	seenNamespaces := make(map[string]bool)
+	denyIngress := make(map[string]bool)
+	denyEgress := make(map[string]bool)
 
-	for _, np := range networkPolicies.Items {
+	for _, np := range networkPolicies {
 		// Skip system namespaces
 		if strings.HasPrefix(np.Namespace, "openshift-") || strings.HasPrefix(np.Namespace, "kube-") {
 			continue
 		}
 
-		if seenNamespaces[np.Namespace] {
-			continue
-		}
-
 		// Check for default deny policy:
 		// - Empty podSelector (applies to all pods)
-		// - PolicyTypes includes Ingress
-		// - No Ingress rules (denies all ingress)
+		// - PolicyTypes includes Ingress or Egress
+		// - No rules for that direction (denies all traffic in it)
+		// A namespace may deny each direction in a separate policy.
 		if len(np.Spec.PodSelector.MatchLabels) == 0 && len(np.Spec.PodSelector.MatchExpressions) == 0 {
-			hasDenyIngress := false
-			hasDenyEgress := false
+			seen := denyIngress[np.Namespace] || denyEgress[np.Namespace]
 
 			for _, policyType := range np.Spec.PolicyTypes {
 				if policyType == networkingv1.PolicyTypeIngress && len(np.Spec.Ingress) == 0 {
-					hasDenyIngress = true
+					denyIngress[np.Namespace] = true
 				}
 				if policyType == networkingv1.PolicyTypeEgress && len(np.Spec.Egress) == 0 {
-					hasDenyEgress = true
+					denyEgress[np.Namespace] = true
 				}
 			}
 
-			if hasDenyIngress || hasDenyEgress {
+			if !seen && (denyIngress[np.Namespace] || denyEgress[np.Namespace]) {
 				namespacesWithDenyAll = append(namespacesWithDenyAll, np.Namespace)
-				seenNamespaces[np.Namespace] = true
 			}
 		}
 	}
@@ -330,5 +345,166 @@ func (v *NetworkPolicyAuditValidator) checkDefaultDenyPolicies(ctx context.Conte
 		})
 	}
 
+	if profile.Thresholds.RequireBidirectionalDeny && len(namespacesWithDenyAll) > 0 {
+		findings = append(findings, checkBidirectionalDeny(namespacesWithDenyAll, denyIngress, denyEgress))
+	}
+
 	return findings
 }
+
+// checkBidirectionalDeny flags namespaces with a default-deny policy for only
+// one traffic direction.
+func checkBidirectionalDeny(namespaces []string, denyIngress, denyEgress map[string]bool) assessmentv1alpha1.Finding {
+	var oneWay []string
+	for _, ns := range namespaces {
+		switch {
+		case !denyEgress[ns]:
+			oneWay = append(oneWay, fmt.Sprintf("%s (egress missing)", ns))
+		case !denyIngress[ns]:
+			oneWay = append(oneWay, fmt.Sprintf("%s (ingress missing)", ns))
+		}
+	}
+
+	if len(oneWay) == 0 {
+		return assessmentv1alpha1.Finding{
+			ID:          "networkpolicyaudit-deny-bidirectional",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Default Deny in Both Directions",
+			Description: fmt.Sprintf("All %d namespace(s) with default-deny NetworkPolicies deny both ingress and egress by default.", len(namespaces)),
+		}
+	}
+
+	sample := oneWay
+	if len(sample) > 5 {
+		sample = sample[:5]
+	}
+
+	return assessmentv1alpha1.Finding{
+		ID:             "networkpolicyaudit-deny-one-direction",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Title:          "Default Deny in Only One Direction",
+		Description:    fmt.Sprintf("%d of %d namespace(s) with default-deny NetworkPolicies only deny one direction: %s", len(oneWay), len(namespaces), strings.Join(sample, ", ")),
+		Impact:         "Without default-deny egress, a compromised pod can reach any internal service or exfiltrate data to external endpoints.",
+		Recommendation: "Add a default-deny policy for the missing direction (an empty podSelector with that policyType and no rules), then allow the required traffic, including DNS for egress.",
+		References: []string{
+			"https://kubernetes.io/docs/concepts/services-networking/network-policies/#default-deny-all-egress-traffic",
+		},
+	}
+}
+
+// adminNetworkPolicyGroupVersion is the API group serving AdminNetworkPolicy and
+// BaselineAdminNetworkPolicy.
+var adminNetworkPolicyGroupVersion = schema.GroupVersion{Group: "policy.networking.k8s.io", Version: "v1alpha1"}
+
+// checkAdminNetworkPolicies reports cluster-scoped AdminNetworkPolicies and the
+// BaselineAdminNetworkPolicy, summarizing their tiers and rule actions.
+func (v *NetworkPolicyAuditValidator) checkAdminNetworkPolicies(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	var findings []assessmentv1alpha1.Finding
+
+	anps := &unstructured.UnstructuredList{}
+	anps.SetGroupVersionKind(adminNetworkPolicyGroupVersion.WithKind("AdminNetworkPolicyList"))
+	if err := c.List(ctx, anps); err != nil {
+		if meta.IsNoMatchError(err) {
+			return []assessmentv1alpha1.Finding{{
+				ID:          "networkpolicyaudit-anp-unavailable",
+				Validator:   validatorName,
+				Category:    validatorCategory,
+				Status:      assessmentv1alpha1.FindingStatusInfo,
+				Title:       "AdminNetworkPolicy API Not Available",
+				Description: "The policy.networking.k8s.io AdminNetworkPolicy API is not served by this cluster; cluster-wide segmentation relies on namespaced NetworkPolicies only.",
+			}}
+		}
+		return []assessmentv1alpha1.Finding{{
+			ID:          "networkpolicyaudit-anp-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check AdminNetworkPolicies",
+			Description: fmt.Sprintf("Failed to list AdminNetworkPolicies: %v", err),
+		}}
+	}
+
+	// BaselineAdminNetworkPolicy is a singleton; treat list errors as "not present".
+	banps := &unstructured.UnstructuredList{}
+	banps.SetGroupVersionKind(adminNetworkPolicyGroupVersion.WithKind("BaselineAdminNetworkPolicyList"))
+	if err := c.List(ctx, banps); err != nil {
+		banps.Items = nil
+	}
+
+	if len(anps.Items) == 0 && len(banps.Items) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:             "networkpolicyaudit-anp-none",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusInfo,
+			Title:          "No Admin Network Policies",
+			Description:    "No AdminNetworkPolicies or BaselineAdminNetworkPolicy are defined; cluster-wide segmentation relies on namespaced NetworkPolicies only.",
+			Recommendation: "Consider AdminNetworkPolicies for guardrails that namespace owners cannot override, and a BaselineAdminNetworkPolicy for cluster-wide defaults.",
+			References: []string{
+				"https://network-policy-api.sigs.k8s.io/",
+			},
+		}}
+	}
+
+	sort.Slice(anps.Items, func(i, j int) bool {
+		pi, _, _ := unstructured.NestedInt64(anps.Items[i].Object, "spec", "priority")
+		pj, _, _ := unstructured.NestedInt64(anps.Items[j].Object, "spec", "priority")
+		return pi < pj
+	})
+
+	var tiers []string
+	for i, anp := range anps.Items {
+		if i == 5 {
+			tiers = append(tiers, fmt.Sprintf("... and %d more", len(anps.Items)-5))
+			break
+		}
+		priority, _, _ := unstructured.NestedInt64(anp.Object, "spec", "priority")
+		tiers = append(tiers, fmt.Sprintf("%s (priority %d: %s)", anp.GetName(), priority, summarizeRuleActions(anp)))
+	}
+	for _, banp := range banps.Items {
+		tiers = append(tiers, fmt.Sprintf("%s (baseline: %s)", banp.GetName(), summarizeRuleActions(banp)))
+	}
+
+	findings = append(findings, assessmentv1alpha1.Finding{
+		ID:          "networkpolicyaudit-anp",
+		Validator:   validatorName,
+		Category:    validatorCategory,
+		Status:      assessmentv1alpha1.FindingStatusInfo,
+		Title:       "Admin Network Policies Configured",
+		Description: fmt.Sprintf("%d AdminNetworkPolicy(s) and %d BaselineAdminNetworkPolicy(s) defined: %s", len(anps.Items), len(banps.Items), strings.Join(tiers, "; ")),
+		Impact:      "AdminNetworkPolicies are evaluated before namespaced NetworkPolicies and cannot be overridden by namespace owners; the baseline tier applies only when no other policy matches.",
+	})
+
+	return findings
+}
+
+// summarizeRuleActions counts ingress and egress rules by action, e.g. "Allow 2, Deny 1".
+func summarizeRuleActions(policy unstructured.Unstructured) string {
+	counts := make(map[string]int)
+	for _, direction := range []string{"ingress", "egress"} {
+		rules, _, _ := unstructured.NestedSlice(policy.Object, "spec", direction)
+		for _, rule := range rules {
+			ruleMap, ok := rule.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			action, _, _ := unstructured.NestedString(ruleMap, "action")
+			counts[action]++
+		}
+	}
+	if len(counts) == 0 {
+		return "no rules"
+	}
+
+	var parts []string
+	for _, action := range []string{"Allow", "Deny", "Pass"} {
+		if counts[action] > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", action, counts[action]))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkpolicyaudit
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+)
+
+func newAdminPolicy(kind, name string, priority int64, ingressActions, egressActions []string) *unstructured.Unstructured {
+	rules := func(actions []string) []interface{} {
+		var out []interface{}
+		for _, a := range actions {
+			out = append(out, map[string]interface{}{"action": a})
+		}
+		return out
+	}
+	spec := map[string]interface{}{}
+	if kind == "AdminNetworkPolicy" {
+		spec["priority"] = priority
+	}
+	if len(ingressActions) > 0 {
+		spec["ingress"] = rules(ingressActions)
+	}
+	if len(egressActions) > 0 {
+		spec["egress"] = rules(egressActions)
+	}
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	u.SetGroupVersionKind(adminNetworkPolicyGroupVersion.WithKind(kind))
+	u.SetName(name)
+	return u
+}
+
+func TestCheckAdminNetworkPolicies(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).WithObjects(
+		newAdminPolicy("AdminNetworkPolicy", "tenant-isolation", 20, []string{"Deny", "Pass"}, nil),
+		newAdminPolicy("AdminNetworkPolicy", "allow-monitoring", 5, []string{"Allow", "Allow"}, []string{"Allow"}),
+		newAdminPolicy("BaselineAdminNetworkPolicy", "default", 0, []string{"Deny"}, nil),
+	).Build()
+
+	findings := (&NetworkPolicyAuditValidator{}).checkAdminNetworkPolicies(context.Background(), c)
+	if len(findings) != 1 || findings[0].ID != "networkpolicyaudit-anp" {
+		t.Fatalf("expected single networkpolicyaudit-anp finding, got %+v", findings)
+	}
+	want := "2 AdminNetworkPolicy(s) and 1 BaselineAdminNetworkPolicy(s) defined: " +
+		"allow-monitoring (priority 5: Allow 3); tenant-isolation (priority 20: Deny 1, Pass 1); default (baseline: Deny 1)"
+	if findings[0].Description != want {
+		t.Errorf("unexpected description:\n got: %s\nwant: %s", findings[0].Description, want)
+	}
+}
+
+func TestCheckAdminNetworkPolicies_None(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+
+	findings := (&NetworkPolicyAuditValidator{}).checkAdminNetworkPolicies(context.Background(), c)
+	if len(findings) != 1 || findings[0].ID != "networkpolicyaudit-anp-none" {
+		t.Fatalf("expected networkpolicyaudit-anp-none, got %+v", findings)
+	}
+}
+
+func TestCheckAdminNetworkPolicies_APIUnavailable(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, cl client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			return &meta.NoKindMatchError{GroupKind: adminNetworkPolicyGroupVersion.WithKind("AdminNetworkPolicy").GroupKind()}
+		},
+	}).Build()
+
+	findings := (&NetworkPolicyAuditValidator{}).checkAdminNetworkPolicies(context.Background(), c)
+	if len(findings) != 1 || findings[0].ID != "networkpolicyaudit-anp-unavailable" {
+		t.Fatalf("expected networkpolicyaudit-anp-unavailable, got %+v", findings)
+	}
+	if findings[0].Status != assessmentv1alpha1.FindingStatusInfo {
+		t.Errorf("expected INFO, got %s", findings[0].Status)
+	}
+	if !strings.Contains(findings[0].Description, "not served") {
+		t.Errorf("unexpected description: %s", findings[0].Description)
+	}
+}
+
+func newDenyPolicy(namespace, name string, policyTypes ...networkingv1.PolicyType) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       networkingv1.NetworkPolicySpec{PolicyTypes: policyTypes},
+	}
+}
+
+func TestCheckDefaultDenyPolicies_Bidirectional(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = networkingv1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newDenyPolicy("both", "deny-all", networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress),
+		newDenyPolicy("split", "deny-ingress", networkingv1.PolicyTypeIngress),
+		newDenyPolicy("split", "deny-egress", networkingv1.PolicyTypeEgress),
+		newDenyPolicy("ingress-only", "deny-ingress", networkingv1.PolicyTypeIngress),
+		newDenyPolicy("egress-only", "deny-egress", networkingv1.PolicyTypeEgress),
+		newDenyPolicy("openshift-monitoring", "deny-ingress", networkingv1.PolicyTypeIngress),
+	).Build()
+	v := &NetworkPolicyAuditValidator{}
+
+	findings := v.checkDefaultDenyPolicies(context.Background(), c, profiles.GetProfile("production"))
+	if len(findings) != 2 || findings[0].ID != "networkpolicyaudit-deny-default" {
+		t.Fatalf("expected deny-default and bidirectional findings, got %+v", findings)
+	}
+	f := findings[1]
+	if f.ID != "networkpolicyaudit-deny-one-direction" || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("unexpected finding %s (%s)", f.ID, f.Status)
+	}
+	for _, want := range []string{"2 of 4 namespace(s)", "ingress-only (egress missing)", "egress-only (ingress missing)"} {
+		if !strings.Contains(f.Description, want) {
+			t.Errorf("expected description to contain %q, got %q", want, f.Description)
+		}
+	}
+	for _, unwanted := range []string{"both", "split", "openshift-monitoring"} {
+		if strings.Contains(f.Description, unwanted) {
+			t.Errorf("description should not mention %q: %q", unwanted, f.Description)
+		}
+	}
+
+	findings = v.checkDefaultDenyPolicies(context.Background(), c, profiles.GetProfile("development"))
+	if len(findings) != 1 {
+		t.Errorf("expected no bidirectional check with the development profile, got %+v", findings)
+	}
+}
@@ -19,9 +19,16 @@ package nodes
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
@@ -57,6 +64,11 @@ func (v *NodesValidator) Category() string {
 	return validatorCategory
 }
 
+// ConfigKeys returns the override keys understood by this validator.
+func (v *NodesValidator) ConfigKeys() []string {
+	return []string{"podDensityThresholdPercent", "heartbeatStaleMinutes"}
+}
+
 // Validate performs node checks.
 func (v *NodesValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
 	var findings []assessmentv1alpha1.Finding
@@ -82,6 +94,24 @@ func (v *NodesValidator) Validate(ctx context.Context, c client.Client, profile
 	// Check 5: Resource pressure
 	findings = append(findings, v.checkResourcePressure(nodes)...)
 
+	// Check 6: Pod density against maxPods
+	findings = append(findings, v.checkPodDensity(ctx, c, nodes)...)
+
+	// Check 7: CPU architecture consistency
+	findings = append(findings, v.checkNodeArchitecture(ctx, c, nodes)...)
+
+	// Check 8: Ready nodes with stale status heartbeats
+	findings = append(findings, v.checkStaleHeartbeats(ctx, nodes)...)
+
+	// Check 9: Cluster autoscaler and MachineAutoscalers
+	findings = append(findings, v.checkClusterAutoscaling(ctx, c)...)
+
+	// Check 10: Node counts against the expected state
+	findings = append(findings, v.checkExpectedNodeCounts(ctx, nodes)...)
+
+	// Check 11: Container runtime and kubelet version consistency
+	findings = append(findings, v.checkRuntimeVersions(nodes)...)
+
 	return findings, nil
 }
 
@@ -152,6 +182,59 @@ func (v *NodesValidator) checkNodeCount(nodes *corev1.NodeList, profile profiles
 	return findings
 }
 
+// checkExpectedNodeCounts compares the control plane and worker node counts
+// with those of the expected state, if it sets any.
+func (v *NodesValidator) checkExpectedNodeCounts(ctx context.Context, nodes *corev1.NodeList) []assessmentv1alpha1.Finding {
+	expected := validator.ExpectedStateFromContext(ctx).Nodes
+	if expected == nil || expected.ControlPlane == nil && expected.Workers == nil {
+		return nil
+	}
+
+	var controlPlaneCount, workerCount int
+	for _, node := range nodes.Items {
+		if v.hasRole(node, "master") || v.hasRole(node, "control-plane") {
+			controlPlaneCount++
+		}
+		if v.hasRole(node, "worker") {
+			workerCount++
+		}
+	}
+
+	var mismatches, matches []string
+	compare := func(role string, want *int, got int) {
+		switch {
+		case want == nil:
+		case *want != got:
+			mismatches = append(mismatches, fmt.Sprintf("%d %s node(s), expected %d", got, role, *want))
+		default:
+			matches = append(matches, fmt.Sprintf("%d %s node(s)", got, role))
+		}
+	}
+	compare("control plane", expected.ControlPlane, controlPlaneCount)
+	compare("worker", expected.Workers, workerCount)
+
+	if len(mismatches) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "nodes-count-expected",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Node Counts Match Expected State",
+			Description: fmt.Sprintf("The cluster has the expected %s.", strings.Join(matches, " and ")),
+		}}
+	}
+	return []assessmentv1alpha1.Finding{{
+		ID:             "nodes-count-unexpected",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Title:          "Node Counts Differ From Expected State",
+		Description:    fmt.Sprintf("The cluster has %s.", strings.Join(mismatches, " and ")),
+		Impact:         "Missing nodes reduce capacity and availability; extra nodes may be unaccounted for or left over from scaling.",
+		Recommendation: "Scale the MachineSets back to the expected size, or update the expected state if the change is intended.",
+	}}
+}
+
 // checkNodeConditions validates node conditions.
 func (v *NodesValidator) checkNodeConditions(nodes *corev1.NodeList) []assessmentv1alpha1.Finding {
 	var findings []assessmentv1alpha1.Finding
@@ -211,6 +294,72 @@ func (v *NodesValidator) checkNodeConditions(nodes *corev1.NodeList) []assessmen
 	return findings
 }
 
+// checkStaleHeartbeats flags nodes that are still reported Ready although the
+// kubelet has not refreshed the Ready condition's heartbeat for longer than
+// the threshold. The kubelet reports its status at least every 5 minutes, so
+// a stale heartbeat means status updates are failing while the node lease is
+// still renewed, which often precedes the node going NotReady.
+func (v *NodesValidator) checkStaleHeartbeats(ctx context.Context, nodes *corev1.NodeList) []assessmentv1alpha1.Finding {
+	threshold := time.Duration(validator.ConfigInt(ctx, "heartbeatStaleMinutes", 10)) * time.Minute
+
+	type staleNode struct {
+		name string
+		age  time.Duration
+	}
+	var stale []staleNode
+	var ready int
+	now := time.Now()
+	for _, node := range nodes.Items {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type != corev1.NodeReady || condition.Status != corev1.ConditionTrue || condition.LastHeartbeatTime.IsZero() {
+				continue
+			}
+			ready++
+			if age := now.Sub(condition.LastHeartbeatTime.Time); age > threshold {
+				stale = append(stale, staleNode{name: node.Name, age: age})
+			}
+		}
+	}
+
+	if ready == 0 {
+		return nil
+	}
+
+	if len(stale) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "nodes-heartbeat-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Node Heartbeats Current",
+			Description: fmt.Sprintf("All %d Ready node(s) reported their status within the last %s.", ready, threshold),
+		}}
+	}
+
+	sort.Slice(stale, func(i, j int) bool { return stale[i].age > stale[j].age })
+	var sample []string
+	for i, node := range stale {
+		if i == 5 {
+			break
+		}
+		sample = append(sample, fmt.Sprintf("%s (last heartbeat %s ago)", node.name, node.age.Round(time.Second)))
+	}
+
+	return []assessmentv1alpha1.Finding{{
+		ID:             "nodes-stale-heartbeat",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Title:          "Ready Nodes With Stale Heartbeats",
+		Description:    fmt.Sprintf("%d node(s) are reported Ready but have not updated their status for more than %s: %s", len(stale), threshold, strings.Join(sample, ", ")),
+		Impact:         "The Ready status of these nodes is out of date. The kubelet may be overloaded or unable to reach the API server, and the node may be about to go NotReady, at which point its pods are evicted.",
+		Recommendation: "Check the kubelet logs and the node's CPU, memory and network with 'oc adm node-logs <node> -u kubelet', and compare with the node's Lease in the kube-node-lease namespace.",
+		References: []string{
+			"https://kubernetes.io/docs/concepts/architecture/nodes/#heartbeats",
+		},
+	}}
+}
+
 // checkNodeRoles validates node role configuration.
 func (v *NodesValidator) checkNodeRoles(nodes *corev1.NodeList) []assessmentv1alpha1.Finding {
 	var findings []assessmentv1alpha1.Finding
@@ -317,6 +466,107 @@ func (v *NodesValidator) checkNodeOS(nodes *corev1.NodeList) []assessmentv1alpha
 	return findings
 }
 
+// checkRuntimeVersions flags nodes running different container runtimes,
+// container runtime versions or kubelet versions. Outside of an upgrade in
+// progress, this points to a stalled or partial upgrade or to nodes that
+// drifted from their MachineConfigPool.
+func (v *NodesValidator) checkRuntimeVersions(nodes *corev1.NodeList) []assessmentv1alpha1.Finding {
+	runtimes := make(map[string][]string)
+	runtimeVersions := make(map[string][]string)
+	kubeletVersions := make(map[string][]string)
+	for _, node := range nodes.Items {
+		info := node.Status.NodeInfo
+		if version := info.ContainerRuntimeVersion; version != "" {
+			runtimeVersions[version] = append(runtimeVersions[version], node.Name)
+			runtime, _, _ := strings.Cut(version, "://")
+			runtimes[runtime] = append(runtimes[runtime], node.Name)
+		}
+		if info.KubeletVersion != "" {
+			kubeletVersions[info.KubeletVersion] = append(kubeletVersions[info.KubeletVersion], node.Name)
+		}
+	}
+	if len(runtimeVersions) == 0 && len(kubeletVersions) == 0 {
+		return nil
+	}
+
+	references := []string{
+		"https://docs.openshift.com/container-platform/latest/updating/understanding_updates/how-updates-work.html",
+	}
+	var findings []assessmentv1alpha1.Finding
+	if len(runtimeVersions) > 1 {
+		finding := assessmentv1alpha1.Finding{
+			ID:             "nodes-runtime-mixed",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Mixed Container Runtime Versions",
+			Description:    fmt.Sprintf("Nodes run %d different container runtime versions: %s", len(runtimeVersions), describeVersionGroups(runtimeVersions)),
+			Impact:         "Nodes on an older runtime did not complete the last update, so they may miss fixes and behave differently from the rest of the cluster.",
+			Recommendation: "If no update is in progress, check the MachineConfigPools of the affected nodes for paused or degraded pools and stuck node updates.",
+			References:     references,
+		}
+		if len(runtimes) > 1 {
+			finding.Title = "Multiple Container Runtimes"
+			finding.Impact = "OpenShift supports CRI-O as the container runtime. Nodes on another runtime behave differently, are outside the supported configuration and are not managed by the Machine Config Operator."
+			finding.Recommendation = "Replace or reconfigure the nodes that do not run CRI-O, and check how they were provisioned."
+		}
+		findings = append(findings, finding)
+	}
+	if len(kubeletVersions) > 1 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "nodes-kubelet-mixed",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Mixed Kubelet Versions",
+			Description:    fmt.Sprintf("Nodes run %d different kubelet versions: %s", len(kubeletVersions), describeVersionGroups(kubeletVersions)),
+			Impact:         "Kubelet version skew outside of an update points to a partial upgrade. Nodes left behind keep known bugs and limit the next update, as the control plane may only be a limited number of versions ahead of the kubelet.",
+			Recommendation: "If no update is in progress, check the MachineConfigPools of the affected nodes for paused or degraded pools and stuck node updates.",
+			References:     references,
+		})
+	}
+	if len(findings) > 0 {
+		return findings
+	}
+
+	var versions []string
+	for version := range runtimeVersions {
+		versions = append(versions, "runtime "+version)
+	}
+	for version := range kubeletVersions {
+		versions = append(versions, "kubelet "+version)
+	}
+	return []assessmentv1alpha1.Finding{{
+		ID:          "nodes-runtime-consistent",
+		Validator:   validatorName,
+		Category:    validatorCategory,
+		Status:      assessmentv1alpha1.FindingStatusPass,
+		Title:       "Consistent Container Runtime and Kubelet",
+		Description: fmt.Sprintf("All nodes run the same versions: %s", strings.Join(versions, ", ")),
+	}}
+}
+
+// describeVersionGroups lists each version with the nodes running it, naming
+// up to five nodes per version.
+func describeVersionGroups(groups map[string][]string) string {
+	versions := make([]string, 0, len(groups))
+	for version := range groups {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	parts := make([]string, 0, len(versions))
+	for _, version := range versions {
+		nodeNames := groups[version]
+		sample := nodeNames
+		if len(sample) > 5 {
+			sample = append(sample[:5:5], "...")
+		}
+		parts = append(parts, fmt.Sprintf("%s on %d node(s) (%s)", version, len(nodeNames), strings.Join(sample, ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
 // checkResourcePressure checks for resource constraints.
 func (v *NodesValidator) checkResourcePressure(nodes *corev1.NodeList) []assessmentv1alpha1.Finding {
 	var findings []assessmentv1alpha1.Finding
@@ -373,6 +623,469 @@ func (v *NodesValidator) checkResourcePressure(nodes *corev1.NodeList) []assessm
 	return findings
 }
 
+// nodePodDensity is the number of active pods on a node relative to its pod capacity.
+type nodePodDensity struct {
+	name     string
+	pods     int64
+	capacity int64
+}
+
+func (d nodePodDensity) percent() int64 {
+	return d.pods * 100 / d.capacity
+}
+
+// checkPodDensity compares the number of active pods on each node with the
+// node's pod capacity (maxPods).
+func (v *NodesValidator) checkPodDensity(ctx context.Context, c client.Client, nodes *corev1.NodeList) []assessmentv1alpha1.Finding {
+	threshold := int64(validator.ConfigInt(ctx, "podDensityThresholdPercent", 90))
+
+	podsPerNode := make(map[string]int64)
+	err := validator.ForEachPod(ctx, c, func(pod *corev1.Pod) {
+		// Completed pods no longer count towards the kubelet's maxPods.
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			return
+		}
+		podsPerNode[pod.Spec.NodeName]++
+	})
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "nodes-pod-density-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Pod Density",
+			Description: fmt.Sprintf("Failed to list pods: %v", err),
+		}}
+	}
+
+	var dense []nodePodDensity
+	for _, node := range nodes.Items {
+		capacity := node.Status.Capacity.Pods().Value()
+		if capacity == 0 {
+			continue
+		}
+		d := nodePodDensity{name: node.Name, pods: podsPerNode[node.Name], capacity: capacity}
+		if d.percent() >= threshold {
+			dense = append(dense, d)
+		}
+	}
+
+	if len(dense) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "nodes-pod-density-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Pod Density Within Limits",
+			Description: fmt.Sprintf("All nodes run fewer than %d%% of their maximum pod count.", threshold),
+		}}
+	}
+
+	sort.Slice(dense, func(i, j int) bool {
+		return dense[i].percent() > dense[j].percent()
+	})
+	var sample []string
+	for i, d := range dense {
+		if i == 5 {
+			break
+		}
+		sample = append(sample, fmt.Sprintf("%s (%d/%d pods)", d.name, d.pods, d.capacity))
+	}
+
+	return []assessmentv1alpha1.Finding{{
+		ID:             "nodes-pod-density-high",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Title:          "Nodes Approaching maxPods",
+		Description:    fmt.Sprintf("%d node(s) run at least %d%% of their maximum pod count: %s", len(dense), threshold, strings.Join(sample, ", ")),
+		Impact:         "Once a node reaches maxPods, new pods cannot be scheduled on it even if CPU and memory are available.",
+		Recommendation: "Add nodes, rebalance workloads, or raise maxPods through a KubeletConfig if the nodes have headroom.",
+		References: []string{
+			"https://docs.openshift.com/container-platform/latest/nodes/nodes/nodes-nodes-managing-max-pods.html",
+		},
+	}}
+}
+
+// archLabels are the node labels workloads can select a CPU architecture with.
+var archLabels = []string{corev1.LabelArchStable, "beta.kubernetes.io/arch"}
+
+// checkNodeArchitecture reports clusters whose nodes mix CPU architectures,
+// warning when user workloads do not pin an architecture and may be
+// scheduled onto nodes their images were not built for.
+func (v *NodesValidator) checkNodeArchitecture(ctx context.Context, c client.Client, nodes *corev1.NodeList) []assessmentv1alpha1.Finding {
+	archCounts := make(map[string]int)
+	poolArchs := make(map[string]map[string]int)
+	for _, node := range nodes.Items {
+		arch := nodeArchitecture(node)
+		if arch == "" {
+			continue
+		}
+		archCounts[arch]++
+		pool := v.nodePool(node)
+		if poolArchs[pool] == nil {
+			poolArchs[pool] = make(map[string]int)
+		}
+		poolArchs[pool][arch]++
+	}
+
+	if len(archCounts) == 0 {
+		return nil
+	}
+	if len(archCounts) == 1 {
+		for arch, count := range archCounts {
+			return []assessmentv1alpha1.Finding{{
+				ID:          "nodes-architecture-consistent",
+				Validator:   validatorName,
+				Category:    validatorCategory,
+				Status:      assessmentv1alpha1.FindingStatusPass,
+				Title:       "Consistent Node Architecture",
+				Description: fmt.Sprintf("All %d node(s) use the %s architecture.", count, arch),
+			}}
+		}
+	}
+
+	description := fmt.Sprintf("Nodes use %d CPU architectures: %s.", len(archCounts), describeArchCounts(archCounts))
+	var mixedPools []string
+	for pool, counts := range poolArchs {
+		if len(counts) > 1 {
+			mixedPools = append(mixedPools, fmt.Sprintf("%s (%s)", pool, describeArchCounts(counts)))
+		}
+	}
+	if len(mixedPools) > 0 {
+		sort.Strings(mixedPools)
+		description += fmt.Sprintf(" Pools mixing architectures: %s.", strings.Join(mixedPools, ", "))
+	}
+
+	unpinned, err := unpinnedWorkloads(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "nodes-architecture-mixed",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Mixed Node Architectures",
+			Description: fmt.Sprintf("%s Unable to check workload architecture constraints: %v", description, err),
+		}}
+	}
+
+	if len(unpinned) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "nodes-architecture-mixed",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Mixed Node Architectures",
+			Description: description + " All user workloads select an architecture.",
+		}}
+	}
+
+	sort.Strings(unpinned)
+	sample := unpinned
+	if len(sample) > 5 {
+		sample = sample[:5]
+	}
+
+	return []assessmentv1alpha1.Finding{{
+		ID:         "nodes-architecture-mixed",
+		Validator:  validatorName,
+		Category:   validatorCategory,
+		Status:     assessmentv1alpha1.FindingStatusWarn,
+		Confidence: assessmentv1alpha1.FindingConfidenceMedium,
+		Title:      "Mixed Node Architectures Without Workload Affinity",
+		Description: fmt.Sprintf("%s %d user workload(s) do not select an architecture: %s",
+			description, len(unpinned), strings.Join(sample, ", ")),
+		Impact:         "Pods scheduled onto a node whose architecture their image does not support fail with exec format errors.",
+		Recommendation: "Use multi-arch images, or add a kubernetes.io/arch nodeSelector or required nodeAffinity to workloads built for a single architecture.",
+		References: []string{
+			"https://docs.openshift.com/container-platform/latest/post_installation_configuration/configuring-multi-arch-compute-machines/multi-architecture-configuration.html",
+		},
+	}}
+}
+
+// nodeArchitecture returns the CPU architecture reported by the kubelet,
+// falling back to the node's architecture label.
+func nodeArchitecture(node corev1.Node) string {
+	if arch := node.Status.NodeInfo.Architecture; arch != "" {
+		return arch
+	}
+	return node.Labels[corev1.LabelArchStable]
+}
+
+// nodePool returns the default MachineConfigPool a node belongs to.
+func (v *NodesValidator) nodePool(node corev1.Node) string {
+	switch {
+	case v.hasRole(node, "master") || v.hasRole(node, "control-plane"):
+		return "master"
+	case v.hasRole(node, "infra"):
+		return "infra"
+	default:
+		return "worker"
+	}
+}
+
+// describeArchCounts renders node counts per architecture in a stable order.
+func describeArchCounts(counts map[string]int) string {
+	archs := make([]string, 0, len(counts))
+	for arch := range counts {
+		archs = append(archs, arch)
+	}
+	sort.Strings(archs)
+
+	parts := make([]string, 0, len(archs))
+	for _, arch := range archs {
+		parts = append(parts, fmt.Sprintf("%s: %d", arch, counts[arch]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// unpinnedWorkloads returns the Deployments and StatefulSets in user
+// namespaces whose pods may be scheduled onto any architecture.
+func unpinnedWorkloads(ctx context.Context, c client.Client) ([]string, error) {
+	var unpinned []string
+
+	deployments, err := validator.ListDeployments(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("listing Deployments: %w", err)
+	}
+	for _, d := range deployments {
+		if !validator.IsPlatformNamespace(d.Namespace) && !selectsArchitecture(d.Spec.Template.Spec) {
+			unpinned = append(unpinned, fmt.Sprintf("Deployment %s/%s", d.Namespace, d.Name))
+		}
+	}
+
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := c.List(ctx, statefulSets); err != nil {
+		return nil, fmt.Errorf("listing StatefulSets: %w", err)
+	}
+	for _, sts := range statefulSets.Items {
+		if !validator.IsPlatformNamespace(sts.Namespace) && !selectsArchitecture(sts.Spec.Template.Spec) {
+			unpinned = append(unpinned, fmt.Sprintf("StatefulSet %s/%s", sts.Namespace, sts.Name))
+		}
+	}
+
+	return unpinned, nil
+}
+
+// selectsArchitecture reports whether a pod template constrains the node
+// architecture through a nodeSelector or required node affinity.
+func selectsArchitecture(spec corev1.PodSpec) bool {
+	for _, label := range archLabels {
+		if _, ok := spec.NodeSelector[label]; ok {
+			return true
+		}
+	}
+
+	if spec.Affinity == nil || spec.Affinity.NodeAffinity == nil ||
+		spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return false
+	}
+	terms := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) == 0 {
+		return false
+	}
+	// Terms are ORed, so every term must constrain the architecture.
+	for _, term := range terms {
+		constrained := false
+		for _, expr := range term.MatchExpressions {
+			for _, label := range archLabels {
+				if expr.Key == label {
+					constrained = true
+				}
+			}
+		}
+		if !constrained {
+			return false
+		}
+	}
+	return true
+}
+
+// machineAPINamespace holds MachineSets, MachineAutoscalers and the
+// cluster-autoscaler deployment.
+const machineAPINamespace = "openshift-machine-api"
+
+// checkClusterAutoscaling reports whether node autoscaling is configured
+// through a ClusterAutoscaler, whether the autoscaler is running, and
+// MachineAutoscalers that cannot scale anything.
+func (v *NodesValidator) checkClusterAutoscaling(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	autoscalers := &unstructured.UnstructuredList{}
+	autoscalers.SetGroupVersionKind(schema.GroupVersionKind{Group: "autoscaling.openshift.io", Version: "v1", Kind: "ClusterAutoscalerList"})
+	if err := c.List(ctx, autoscalers); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return autoscalingError("ClusterAutoscalers", err)
+	}
+
+	machineAutoscalers := &unstructured.UnstructuredList{}
+	machineAutoscalers.SetGroupVersionKind(schema.GroupVersionKind{Group: "autoscaling.openshift.io", Version: "v1beta1", Kind: "MachineAutoscalerList"})
+	if err := c.List(ctx, machineAutoscalers, client.InNamespace(machineAPINamespace)); err != nil && !meta.IsNoMatchError(err) {
+		return autoscalingError("MachineAutoscalers", err)
+	}
+
+	if len(autoscalers.Items) == 0 && len(machineAutoscalers.Items) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:             "nodes-autoscaling-not-configured",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusInfo,
+			Title:          "Cluster Autoscaling Not Configured",
+			Description:    "No ClusterAutoscaler or MachineAutoscalers exist; worker capacity only changes when MachineSets are scaled by hand.",
+			Recommendation: "Create a ClusterAutoscaler and MachineAutoscalers for the MachineSets that should grow and shrink with demand.",
+			References: []string{
+				"https://docs.openshift.com/container-platform/latest/machine_management/applying-autoscaling.html",
+			},
+		}}
+	}
+
+	var findings []assessmentv1alpha1.Finding
+
+	if len(autoscalers.Items) == 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "nodes-autoscaler-missing",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "MachineAutoscalers Without a ClusterAutoscaler",
+			Description:    fmt.Sprintf("Found %d MachineAutoscaler(s) but no ClusterAutoscaler, so none of them take effect.", len(machineAutoscalers.Items)),
+			Impact:         "MachineSets are never scaled because the cluster-autoscaler is not deployed.",
+			Recommendation: "Create the default ClusterAutoscaler, or remove the unused MachineAutoscalers.",
+		})
+	}
+
+	for _, ca := range autoscalers.Items {
+		deployment := &appsv1.Deployment{}
+		key := client.ObjectKey{Namespace: machineAPINamespace, Name: "cluster-autoscaler-" + ca.GetName()}
+		err := c.Get(ctx, key, deployment)
+		switch {
+		case err != nil && !errors.IsNotFound(err):
+			findings = append(findings, autoscalingError("the cluster-autoscaler deployment", err)...)
+		case err != nil || deployment.Status.AvailableReplicas == 0:
+			findings = append(findings, assessmentv1alpha1.Finding{
+				ID:             "nodes-autoscaler-unavailable",
+				Validator:      validatorName,
+				Category:       validatorCategory,
+				Status:         assessmentv1alpha1.FindingStatusWarn,
+				Title:          "Cluster Autoscaler Not Running",
+				Description:    fmt.Sprintf("ClusterAutoscaler %s has no available %s/%s deployment replicas.", ca.GetName(), key.Namespace, key.Name),
+				Impact:         "Pending pods do not trigger new nodes and idle nodes are not removed.",
+				Recommendation: "Check the cluster-autoscaler-operator and the cluster-autoscaler pods in openshift-machine-api.",
+				Evidence:       validator.Evidence(ctx, &ca),
+			})
+		}
+	}
+
+	if len(autoscalers.Items) > 0 && len(machineAutoscalers.Items) == 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "nodes-machineautoscalers-missing",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusInfo,
+			Title:          "ClusterAutoscaler Without MachineAutoscalers",
+			Description:    "A ClusterAutoscaler exists but no MachineAutoscalers select MachineSets to scale.",
+			Recommendation: "Create a MachineAutoscaler for each MachineSet that should scale with demand.",
+		})
+	}
+
+	if len(machineAutoscalers.Items) > 0 {
+		findings = append(findings, v.checkMachineAutoscalers(ctx, c, machineAutoscalers.Items)...)
+	}
+
+	if len(findings) == 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "nodes-autoscaling-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Cluster Autoscaling Healthy",
+			Description: fmt.Sprintf("The cluster-autoscaler is running and %d MachineAutoscaler(s) can scale their MachineSets.", len(machineAutoscalers.Items)),
+		})
+	}
+
+	return findings
+}
+
+// checkMachineAutoscalers flags MachineAutoscalers whose replica bounds are
+// equal or whose target MachineSet does not exist.
+func (v *NodesValidator) checkMachineAutoscalers(ctx context.Context, c client.Client, machineAutoscalers []unstructured.Unstructured) []assessmentv1alpha1.Finding {
+	machineSets := &unstructured.UnstructuredList{}
+	machineSets.SetGroupVersionKind(schema.GroupVersionKind{Group: "machine.openshift.io", Version: "v1beta1", Kind: "MachineSetList"})
+	if err := c.List(ctx, machineSets, client.InNamespace(machineAPINamespace)); err != nil && !meta.IsNoMatchError(err) {
+		return autoscalingError("MachineSets", err)
+	}
+	existing := make(map[string]bool, len(machineSets.Items))
+	for _, ms := range machineSets.Items {
+		existing[ms.GetName()] = true
+	}
+
+	var fixed, missing []string
+	var fixedEvidence, missingEvidence string
+	for i := range machineAutoscalers {
+		ma := &machineAutoscalers[i]
+		minReplicas, _, _ := unstructured.NestedInt64(ma.Object, "spec", "minReplicas")
+		maxReplicas, _, _ := unstructured.NestedInt64(ma.Object, "spec", "maxReplicas")
+		if minReplicas == maxReplicas {
+			fixed = append(fixed, fmt.Sprintf("%s (%d)", ma.GetName(), minReplicas))
+			if fixedEvidence == "" {
+				fixedEvidence = validator.Evidence(ctx, ma)
+			}
+		}
+
+		kind, _, _ := unstructured.NestedString(ma.Object, "spec", "scaleTargetRef", "kind")
+		target, _, _ := unstructured.NestedString(ma.Object, "spec", "scaleTargetRef", "name")
+		if kind == "MachineSet" && !existing[target] {
+			missing = append(missing, fmt.Sprintf("%s -> %s", ma.GetName(), target))
+			if missingEvidence == "" {
+				missingEvidence = validator.Evidence(ctx, ma)
+			}
+		}
+	}
+
+	var findings []assessmentv1alpha1.Finding
+	if len(fixed) > 0 {
+		sort.Strings(fixed)
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "nodes-machineautoscaler-fixed-size",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "MachineAutoscalers That Cannot Scale",
+			Description:    fmt.Sprintf("Found %d MachineAutoscaler(s) with minReplicas equal to maxReplicas: %s", len(fixed), strings.Join(validator.SampleOf(fixed), ", ")),
+			Impact:         "The MachineSet is pinned to a fixed size, so the autoscaler never adds or removes its nodes.",
+			Recommendation: "Raise maxReplicas above minReplicas, or delete the MachineAutoscaler if the pool should stay fixed.",
+			Evidence:       fixedEvidence,
+		})
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "nodes-machineautoscaler-missing-target",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "MachineAutoscalers Targeting Missing MachineSets",
+			Description:    fmt.Sprintf("Found %d MachineAutoscaler(s) whose MachineSet does not exist: %s", len(missing), strings.Join(validator.SampleOf(missing), ", ")),
+			Impact:         "These MachineAutoscalers have nothing to scale, so the capacity they were meant to provide is never added.",
+			Recommendation: "Point scaleTargetRef at an existing MachineSet or delete the stale MachineAutoscaler.",
+			Evidence:       missingEvidence,
+		})
+	}
+	return findings
+}
+
+// autoscalingError reports a failure to read autoscaling resources.
+func autoscalingError(what string, err error) []assessmentv1alpha1.Finding {
+	return []assessmentv1alpha1.Finding{{
+		ID:          "nodes-autoscaling-error",
+		Validator:   validatorName,
+		Category:    validatorCategory,
+		Status:      assessmentv1alpha1.FindingStatusInfo,
+		Title:       "Unable to Check Cluster Autoscaling",
+		Description: fmt.Sprintf("Failed to read %s: %v", what, err),
+	}}
+}
+
 // hasRole checks if a node has a specific role.
 func (v *NodesValidator) hasRole(node corev1.Node, role string) bool {
 	_, ok := node.Labels[fmt.Sprintf("node-role.kubernetes.io/%s", role)]
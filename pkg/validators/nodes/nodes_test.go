@@ -18,16 +18,24 @@ package nodes
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
 	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator"
 )
 
 func TestNodesValidator_Name(t *testing.T) {
@@ -226,3 +234,323 @@ func createNodeWithCondition(name string, isMaster, isWorker bool, readyStatus c
 		},
 	}
 }
+
+func TestNodesValidator_CheckPodDensity(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	nodeWithCapacity := func(name string, maxPods int64) *corev1.Node {
+		node := createNode(name, false, true, "Red Hat Enterprise Linux CoreOS")
+		node.Status.Capacity = corev1.ResourceList{corev1.ResourcePods: *resource.NewQuantity(maxPods, resource.DecimalSI)}
+		return node
+	}
+	nodes := []*corev1.Node{nodeWithCapacity("worker-0", 10), nodeWithCapacity("worker-1", 10)}
+
+	var objs []client.Object
+	addPods := func(node string, count int, phase corev1.PodPhase) {
+		for i := 0; i < count; i++ {
+			objs = append(objs, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%s-%d", node, phase, i), Namespace: "default"},
+				Spec:       corev1.PodSpec{NodeName: node},
+				Status:     corev1.PodStatus{Phase: phase},
+			})
+		}
+	}
+	addPods("worker-0", 9, corev1.PodRunning)
+	addPods("worker-1", 5, corev1.PodRunning)
+	addPods("worker-1", 5, corev1.PodSucceeded)
+
+	nodeList := &corev1.NodeList{}
+	for _, n := range nodes {
+		nodeList.Items = append(nodeList.Items, *n)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	v := &NodesValidator{}
+
+	findings := v.checkPodDensity(context.Background(), c, nodeList)
+	if len(findings) != 1 || findings[0].ID != "nodes-pod-density-high" {
+		t.Fatalf("expected nodes-pod-density-high, got %+v", findings)
+	}
+	if !strings.Contains(findings[0].Description, "worker-0 (9/10 pods)") || strings.Contains(findings[0].Description, "worker-1") {
+		t.Errorf("unexpected description: %s", findings[0].Description)
+	}
+
+	ctx := validator.WithConfig(context.Background(), map[string]string{"podDensityThresholdPercent": "95"})
+	findings = v.checkPodDensity(ctx, c, nodeList)
+	if len(findings) != 1 || findings[0].ID != "nodes-pod-density-ok" {
+		t.Errorf("expected nodes-pod-density-ok with a 95%% threshold, got %+v", findings)
+	}
+}
+
+func TestNodesValidator_CheckNodeArchitecture(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	archNode := func(name string, isMaster bool, arch string) corev1.Node {
+		node := createNode(name, isMaster, !isMaster, "Red Hat Enterprise Linux CoreOS")
+		node.Status.NodeInfo.Architecture = arch
+		return *node
+	}
+	deployment := func(ns, name string, spec corev1.PodSpec) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+			Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: spec}},
+		}
+	}
+
+	v := &NodesValidator{}
+	ctx := context.Background()
+
+	// Single architecture passes without listing workloads.
+	uniform := &corev1.NodeList{Items: []corev1.Node{archNode("master-0", true, "amd64"), archNode("worker-0", false, "amd64")}}
+	findings := v.checkNodeArchitecture(ctx, fake.NewClientBuilder().WithScheme(scheme).Build(), uniform)
+	if len(findings) != 1 || findings[0].ID != "nodes-architecture-consistent" {
+		t.Fatalf("Expected nodes-architecture-consistent, got %v", findings)
+	}
+
+	mixed := &corev1.NodeList{Items: []corev1.Node{
+		archNode("master-0", true, "amd64"),
+		archNode("worker-0", false, "amd64"),
+		archNode("worker-1", false, "arm64"),
+		archNode("worker-2", false, "arm64"),
+	}}
+	pinned := corev1.PodSpec{NodeSelector: map[string]string{corev1.LabelArchStable: "amd64"}}
+	affinity := corev1.PodSpec{Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+			MatchExpressions: []corev1.NodeSelectorRequirement{{
+				Key: corev1.LabelArchStable, Operator: corev1.NodeSelectorOpIn, Values: []string{"arm64"},
+			}},
+		}}},
+	}}}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		deployment("app", "pinned", pinned),
+		deployment("app", "affinity", affinity),
+		deployment("openshift-console", "console", corev1.PodSpec{}),
+	).Build()
+	findings = v.checkNodeArchitecture(ctx, c, mixed)
+	if len(findings) != 1 || findings[0].Status != assessmentv1alpha1.FindingStatusInfo {
+		t.Fatalf("Expected one INFO finding when all workloads are pinned, got %v", findings)
+	}
+	for _, want := range []string{"amd64: 2, arm64: 2", "worker (amd64: 1, arm64: 2)"} {
+		if !strings.Contains(findings[0].Description, want) {
+			t.Errorf("Expected description to contain %q, got %q", want, findings[0].Description)
+		}
+	}
+
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		deployment("app", "pinned", pinned),
+		deployment("app", "any-arch", corev1.PodSpec{}),
+	).Build()
+	findings = v.checkNodeArchitecture(ctx, c, mixed)
+	if len(findings) != 1 || findings[0].Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("Expected one WARN finding, got %v", findings)
+	}
+	if !strings.Contains(findings[0].Description, "Deployment app/any-arch") || strings.Contains(findings[0].Description, "app/pinned") {
+		t.Errorf("Unexpected workloads in description: %q", findings[0].Description)
+	}
+}
+
+func TestNodesValidator_CheckStaleHeartbeats(t *testing.T) {
+	node := func(name string, status corev1.ConditionStatus, heartbeatAge time.Duration) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{{
+				Type:              corev1.NodeReady,
+				Status:            status,
+				LastHeartbeatTime: metav1.NewTime(time.Now().Add(-heartbeatAge)),
+			}}},
+		}
+	}
+	nodes := &corev1.NodeList{Items: []corev1.Node{
+		node("worker-0", corev1.ConditionTrue, 2*time.Minute),
+		node("worker-1", corev1.ConditionTrue, 20*time.Minute),
+		node("worker-2", corev1.ConditionTrue, 45*time.Minute),
+		node("worker-3", corev1.ConditionFalse, time.Hour),
+	}}
+	v := &NodesValidator{}
+
+	findings := v.checkStaleHeartbeats(context.Background(), nodes)
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 finding, got %d: %+v", len(findings), findings)
+	}
+	f := findings[0]
+	if f.ID != "nodes-stale-heartbeat" || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Errorf("Unexpected finding %s (%s)", f.ID, f.Status)
+	}
+	if !strings.Contains(f.Description, "2 node(s)") || !strings.Contains(f.Description, "worker-2 (last heartbeat 45m0s ago), worker-1 (last heartbeat 20m0s ago)") {
+		t.Errorf("Unexpected description: %s", f.Description)
+	}
+	if strings.Contains(f.Description, "worker-3") {
+		t.Errorf("NotReady nodes are reported by the Ready check: %s", f.Description)
+	}
+
+	ctx := validator.WithConfig(context.Background(), map[string]string{"heartbeatStaleMinutes": "60"})
+	findings = v.checkStaleHeartbeats(ctx, nodes)
+	if len(findings) != 1 || findings[0].ID != "nodes-heartbeat-ok" || findings[0].Status != assessmentv1alpha1.FindingStatusPass {
+		t.Errorf("Expected a single pass finding with a 60 minute threshold, got %+v", findings)
+	}
+}
+
+func TestNodesValidator_CheckClusterAutoscaling(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	clusterAutoscaler := func() *unstructured.Unstructured {
+		ca := &unstructured.Unstructured{}
+		ca.SetGroupVersionKind(schema.GroupVersionKind{Group: "autoscaling.openshift.io", Version: "v1", Kind: "ClusterAutoscaler"})
+		ca.SetName("default")
+		return ca
+	}
+	machineAutoscaler := func(name, target string, minReplicas, maxReplicas int64) *unstructured.Unstructured {
+		ma := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"minReplicas":    minReplicas,
+				"maxReplicas":    maxReplicas,
+				"scaleTargetRef": map[string]interface{}{"apiVersion": "machine.openshift.io/v1beta1", "kind": "MachineSet", "name": target},
+			},
+		}}
+		ma.SetGroupVersionKind(schema.GroupVersionKind{Group: "autoscaling.openshift.io", Version: "v1beta1", Kind: "MachineAutoscaler"})
+		ma.SetNamespace(machineAPINamespace)
+		ma.SetName(name)
+		return ma
+	}
+	machineSet := func(name string) *unstructured.Unstructured {
+		ms := &unstructured.Unstructured{}
+		ms.SetGroupVersionKind(schema.GroupVersionKind{Group: "machine.openshift.io", Version: "v1beta1", Kind: "MachineSet"})
+		ms.SetNamespace(machineAPINamespace)
+		ms.SetName(name)
+		return ms
+	}
+	autoscalerDeployment := func(available int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-autoscaler-default", Namespace: machineAPINamespace},
+			Status:     appsv1.DeploymentStatus{AvailableReplicas: available},
+		}
+	}
+	ids := func(findings []assessmentv1alpha1.Finding) map[string]assessmentv1alpha1.Finding {
+		byID := make(map[string]assessmentv1alpha1.Finding)
+		for _, f := range findings {
+			byID[f.ID] = f
+		}
+		return byID
+	}
+	v := &NodesValidator{}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	got := ids(v.checkClusterAutoscaling(context.Background(), c))
+	if f, ok := got["nodes-autoscaling-not-configured"]; !ok || f.Status != assessmentv1alpha1.FindingStatusInfo || len(got) != 1 {
+		t.Errorf("Expected only an INFO not-configured finding, got %+v", got)
+	}
+
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		clusterAutoscaler(),
+		autoscalerDeployment(1),
+		machineSet("worker-a"),
+		machineAutoscaler("worker-a", "worker-a", 1, 6),
+	).Build()
+	got = ids(v.checkClusterAutoscaling(context.Background(), c))
+	if f, ok := got["nodes-autoscaling-ok"]; !ok || f.Status != assessmentv1alpha1.FindingStatusPass || len(got) != 1 {
+		t.Errorf("Expected only a PASS finding, got %+v", got)
+	}
+
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		clusterAutoscaler(),
+		autoscalerDeployment(0),
+		machineSet("worker-a"),
+		machineAutoscaler("worker-a", "worker-a", 3, 3),
+		machineAutoscaler("worker-b", "worker-b", 1, 4),
+	).Build()
+	got = ids(v.checkClusterAutoscaling(context.Background(), c))
+	if f, ok := got["nodes-autoscaler-unavailable"]; !ok || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Errorf("Expected an unavailable autoscaler warning, got %+v", got)
+	}
+	if f, ok := got["nodes-machineautoscaler-fixed-size"]; !ok || !strings.Contains(f.Description, "worker-a (3)") {
+		t.Errorf("Expected worker-a to be reported as fixed size, got %+v", got)
+	}
+	if f, ok := got["nodes-machineautoscaler-missing-target"]; !ok || !strings.Contains(f.Description, "worker-b -> worker-b") || strings.Contains(f.Description, "worker-a") {
+		t.Errorf("Expected only worker-b to be reported with a missing target, got %+v", got)
+	}
+	if _, ok := got["nodes-autoscaling-ok"]; ok {
+		t.Errorf("Did not expect a PASS finding, got %+v", got)
+	}
+
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		machineAutoscaler("worker-a", "worker-a", 1, 3),
+	).Build()
+	got = ids(v.checkClusterAutoscaling(context.Background(), c))
+	if _, ok := got["nodes-autoscaler-missing"]; !ok {
+		t.Errorf("Expected a missing ClusterAutoscaler warning, got %+v", got)
+	}
+}
+
+func TestNodesValidator_CheckExpectedNodeCounts(t *testing.T) {
+	nodes := &corev1.NodeList{Items: []corev1.Node{
+		*createNode("master-0", true, false, "Red Hat Enterprise Linux CoreOS"),
+		*createNode("master-1", true, false, "Red Hat Enterprise Linux CoreOS"),
+		*createNode("master-2", true, false, "Red Hat Enterprise Linux CoreOS"),
+		*createNode("worker-0", false, true, "Red Hat Enterprise Linux CoreOS"),
+		*createNode("worker-1", false, true, "Red Hat Enterprise Linux CoreOS"),
+	}}
+	v := &NodesValidator{}
+	count := func(n int) *int { return &n }
+	withNodes := func(expected *validator.ExpectedNodes) context.Context {
+		return validator.WithExpectedState(context.Background(), &validator.ExpectedState{Nodes: expected})
+	}
+
+	if findings := v.checkExpectedNodeCounts(context.Background(), nodes); findings != nil {
+		t.Errorf("Expected no findings without an expected state, got %+v", findings)
+	}
+
+	findings := v.checkExpectedNodeCounts(withNodes(&validator.ExpectedNodes{ControlPlane: count(3), Workers: count(2)}), nodes)
+	if len(findings) != 1 || findings[0].ID != "nodes-count-expected" || findings[0].Status != assessmentv1alpha1.FindingStatusPass {
+		t.Errorf("Expected a PASS finding, got %+v", findings)
+	}
+
+	findings = v.checkExpectedNodeCounts(withNodes(&validator.ExpectedNodes{ControlPlane: count(3), Workers: count(4)}), nodes)
+	if len(findings) != 1 || findings[0].ID != "nodes-count-unexpected" || findings[0].Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("Expected a WARN finding, got %+v", findings)
+	}
+	if findings[0].Description != "The cluster has 2 worker node(s), expected 4." {
+		t.Errorf("Unexpected description: %s", findings[0].Description)
+	}
+}
+
+func TestNodesValidator_CheckRuntimeVersions(t *testing.T) {
+	withVersions := func(name, runtime, kubelet string) corev1.Node {
+		node := createNode(name, false, true, "Red Hat Enterprise Linux CoreOS")
+		node.Status.NodeInfo.ContainerRuntimeVersion = runtime
+		node.Status.NodeInfo.KubeletVersion = kubelet
+		return *node
+	}
+	v := &NodesValidator{}
+
+	nodes := &corev1.NodeList{Items: []corev1.Node{
+		withVersions("worker-0", "cri-o://1.29.1", "v1.29.1"),
+		withVersions("worker-1", "cri-o://1.29.1", "v1.29.1"),
+	}}
+	findings := v.checkRuntimeVersions(nodes)
+	if len(findings) != 1 || findings[0].ID != "nodes-runtime-consistent" || findings[0].Status != assessmentv1alpha1.FindingStatusPass {
+		t.Fatalf("Expected a PASS finding, got %+v", findings)
+	}
+
+	nodes.Items = append(nodes.Items, withVersions("worker-2", "cri-o://1.28.4", "v1.28.4"))
+	findings = v.checkRuntimeVersions(nodes)
+	if len(findings) != 2 || findings[0].ID != "nodes-runtime-mixed" || findings[1].ID != "nodes-kubelet-mixed" {
+		t.Fatalf("Expected runtime and kubelet WARN findings, got %+v", findings)
+	}
+	if findings[0].Title != "Mixed Container Runtime Versions" {
+		t.Errorf("Unexpected title: %s", findings[0].Title)
+	}
+	want := "cri-o://1.28.4 on 1 node(s) (worker-2); cri-o://1.29.1 on 2 node(s) (worker-0, worker-1)"
+	if !strings.Contains(findings[0].Description, want) {
+		t.Errorf("Expected description to contain %q, got %q", want, findings[0].Description)
+	}
+
+	nodes.Items = append(nodes.Items, withVersions("worker-3", "containerd://1.7.0", "v1.29.1"))
+	findings = v.checkRuntimeVersions(nodes)
+	if len(findings) != 2 || findings[0].Title != "Multiple Container Runtimes" {
+		t.Errorf("Expected a multiple runtimes finding, got %+v", findings)
+	}
+}
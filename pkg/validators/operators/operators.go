@@ -19,7 +19,11 @@ package operators
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +39,10 @@ const (
 	validatorCategory    = "Platform"
 )
 
+// terminatingNamespaceGracePeriod is how long a namespace may be Terminating
+// before it is considered stuck.
+const terminatingNamespaceGracePeriod = 5 * time.Minute
+
 func init() {
 	_ = validator.Register(&OperatorsValidator{})
 }
@@ -57,10 +65,18 @@ func (v *OperatorsValidator) Category() string {
 	return validatorCategory
 }
 
+// RequiresOpenShift reports that this validator needs OpenShift APIs.
+func (v *OperatorsValidator) RequiresOpenShift() bool {
+	return true
+}
+
 // Validate performs operator health checks.
 func (v *OperatorsValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
 	var findings []assessmentv1alpha1.Finding
 
+	// Check namespaces stuck in Terminating, which does not depend on OLM
+	findings = append(findings, v.checkTerminatingNamespaces(ctx, c)...)
+
 	// Check ClusterServiceVersions
 	csvGVK := schema.GroupVersionKind{
 		Group:   "operators.coreos.com",
@@ -149,9 +165,18 @@ func (v *OperatorsValidator) Validate(ctx context.Context, c client.Client, prof
 		})
 	}
 
+	// Check required operators, preferring those of the expected state
+	if required := validator.ExpectedStateFromContext(ctx).RequiredOperators; len(required) > 0 {
+		profile.RequiredOperators = required
+	}
+	findings = append(findings, v.checkRequiredOperators(profile, csvList.Items)...)
+
 	// Check ClusterOperators
 	findings = append(findings, v.checkClusterOperators(ctx, c)...)
 
+	// Check OperatorGroups
+	findings = append(findings, v.checkOperatorGroups(ctx, c)...)
+
 	return findings, nil
 }
 
@@ -260,6 +285,284 @@ func (v *OperatorsValidator) checkClusterOperators(ctx context.Context, c client
 	return findings
 }
 
+// checkTerminatingNamespaces flags namespaces that have been Terminating for
+// longer than terminatingNamespaceGracePeriod, which usually means a finalizer
+// or an unavailable API service is blocking deletion.
+func (v *OperatorsValidator) checkTerminatingNamespaces(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	namespaces, err := validator.ListNamespaces(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "operators-namespace-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Namespaces",
+			Description: fmt.Sprintf("Failed to list namespaces: %v", err),
+		}}
+	}
+
+	type stuckNamespace struct {
+		detail string
+		age    time.Duration
+	}
+	var stuck []stuckNamespace
+	for _, ns := range namespaces {
+		if ns.Status.Phase != corev1.NamespaceTerminating || ns.DeletionTimestamp == nil {
+			continue
+		}
+		age := time.Since(ns.DeletionTimestamp.Time)
+		if age < terminatingNamespaceGracePeriod {
+			continue
+		}
+
+		detail := fmt.Sprintf("%s (terminating for %s", ns.Name, age.Round(time.Minute))
+		if reason := deletionBlocker(ns); reason != "" {
+			detail += ", " + reason
+		}
+		stuck = append(stuck, stuckNamespace{detail: detail + ")", age: age})
+	}
+
+	if len(stuck) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "operators-no-stuck-namespaces",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "No Stuck Namespaces",
+			Description: "No namespaces have been in Terminating state for longer than a few minutes.",
+		}}
+	}
+
+	// Longest-stuck first
+	sort.Slice(stuck, func(i, j int) bool { return stuck[i].age > stuck[j].age })
+	details := make([]string, 0, len(stuck))
+	for _, ns := range stuck {
+		details = append(details, ns.detail)
+	}
+
+	return []assessmentv1alpha1.Finding{{
+		ID:             "operators-namespace-stuck-terminating",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Title:          "Namespaces Stuck in Terminating",
+		Description:    fmt.Sprintf("Found %d namespace(s) in Terminating state for more than %s: %s", len(stuck), terminatingNamespaceGracePeriod, strings.Join(truncateList(details, 5), ", ")),
+		Impact:         "Stuck namespaces cannot be recreated and usually point to finalizers whose controller is gone or to an unavailable aggregated API service.",
+		Recommendation: "Inspect the namespace status conditions and remaining resources, then fix or remove the blocking finalizers or API services.",
+		References: []string{
+			"https://kubernetes.io/docs/concepts/overview/working-with-objects/finalizers/",
+		},
+	}}
+}
+
+// deletionBlocker returns the reason of the first namespace deletion condition
+// that is reporting a problem.
+func deletionBlocker(ns corev1.Namespace) string {
+	for _, cond := range ns.Status.Conditions {
+		switch cond.Type {
+		case corev1.NamespaceDeletionDiscoveryFailure, corev1.NamespaceDeletionContentFailure,
+			corev1.NamespaceDeletionGVParsingFailure, corev1.NamespaceFinalizersRemaining,
+			corev1.NamespaceContentRemaining:
+			if cond.Status == corev1.ConditionTrue && cond.Reason != "" {
+				return cond.Reason
+			}
+		}
+	}
+	return ""
+}
+
+// checkOperatorGroups fails namespaces with more than one OperatorGroup, which
+// OLM refuses to install operators into, and reports the target namespace
+// scopes of the OperatorGroups in the cluster.
+func (v *OperatorsValidator) checkOperatorGroups(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	ogList := &unstructured.UnstructuredList{}
+	ogList.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "operators.coreos.com",
+		Version: "v1",
+		Kind:    "OperatorGroupList",
+	})
+	if err := c.List(ctx, ogList); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "operators-operatorgroup-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to List OperatorGroups",
+			Description: fmt.Sprintf("Failed to list OperatorGroups: %v", err),
+		}}
+	}
+	if len(ogList.Items) == 0 {
+		return nil
+	}
+
+	byNamespace := make(map[string][]string)
+	scopes := make(map[string]int)
+	for _, og := range ogList.Items {
+		byNamespace[og.GetNamespace()] = append(byNamespace[og.GetNamespace()], og.GetName())
+		scopes[operatorGroupScope(og)]++
+	}
+
+	var duplicates []string
+	for ns, names := range byNamespace {
+		if len(names) > 1 {
+			sort.Strings(names)
+			duplicates = append(duplicates, fmt.Sprintf("%s (%s)", ns, strings.Join(names, ", ")))
+		}
+	}
+	sort.Strings(duplicates)
+
+	var findings []assessmentv1alpha1.Finding
+	if len(duplicates) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "operators-operatorgroup-multiple",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusFail,
+			Title:          "Multiple OperatorGroups in a Namespace",
+			Description:    fmt.Sprintf("Found %d namespace(s) with more than one OperatorGroup: %s", len(duplicates), strings.Join(truncateList(duplicates, 5), "; ")),
+			Impact:         "OLM does not install or upgrade operators in a namespace with more than one OperatorGroup. Their CSVs fail with reason TooManyOperatorGroups and the operators stay stuck.",
+			Recommendation: "Keep a single OperatorGroup per namespace and delete the others. Its targetNamespaces must suit every operator subscribed in the namespace.",
+			References: []string{
+				"https://docs.openshift.com/container-platform/latest/operators/understanding/olm/olm-understanding-operatorgroups.html",
+			},
+		})
+	} else {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "operators-operatorgroups-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "One OperatorGroup per Namespace",
+			Description: fmt.Sprintf("All %d namespace(s) with OperatorGroups have exactly one.", len(byNamespace)),
+		})
+	}
+
+	var parts []string
+	for _, scope := range []string{"AllNamespaces", "OwnNamespace", "SingleNamespace", "MultiNamespace", "Selector"} {
+		if n := scopes[scope]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", scope, n))
+		}
+	}
+	findings = append(findings, assessmentv1alpha1.Finding{
+		ID:          "operators-operatorgroup-scopes",
+		Validator:   validatorName,
+		Category:    validatorCategory,
+		Status:      assessmentv1alpha1.FindingStatusInfo,
+		Title:       "OperatorGroup Target Namespace Scopes",
+		Description: fmt.Sprintf("Found %d OperatorGroup(s) by install scope: %s.", len(ogList.Items), strings.Join(parts, ", ")),
+	})
+
+	return findings
+}
+
+// operatorGroupScope returns the install mode an OperatorGroup's target
+// namespaces correspond to, or "Selector" when it selects namespaces by label.
+func operatorGroupScope(og unstructured.Unstructured) string {
+	if selector, _, _ := unstructured.NestedMap(og.Object, "spec", "selector"); len(selector) > 0 {
+		return "Selector"
+	}
+	targets, _, _ := unstructured.NestedStringSlice(og.Object, "spec", "targetNamespaces")
+	switch {
+	case len(targets) == 0:
+		return "AllNamespaces"
+	case len(targets) == 1 && targets[0] == og.GetNamespace():
+		return "OwnNamespace"
+	case len(targets) == 1:
+		return "SingleNamespace"
+	default:
+		return "MultiNamespace"
+	}
+}
+
+// checkRequiredOperators reports the operators required by the profile that
+// have no ClusterServiceVersion (FAIL) or whose CSVs are not Succeeded (WARN).
+func (v *OperatorsValidator) checkRequiredOperators(profile profiles.Profile, csvs []unstructured.Unstructured) []assessmentv1alpha1.Finding {
+	if len(profile.RequiredOperators) == 0 {
+		return nil
+	}
+
+	var missing, unhealthy []string
+	for _, required := range profile.RequiredOperators {
+		var matched []string
+		succeeded := false
+		for _, csv := range csvs {
+			if !matchesOperator(required, csv.GetName()) {
+				continue
+			}
+			phase, _, _ := unstructured.NestedString(csv.Object, "status", "phase")
+			if phase == "Succeeded" {
+				succeeded = true
+				break
+			}
+			if phase == "" {
+				phase = "Unknown"
+			}
+			matched = append(matched, fmt.Sprintf("%s/%s: %s", csv.GetNamespace(), csv.GetName(), phase))
+		}
+		switch {
+		case succeeded:
+		case len(matched) == 0:
+			missing = append(missing, required)
+		default:
+			unhealthy = append(unhealthy, fmt.Sprintf("%s (%s)", required, strings.Join(truncateList(matched, 3), ", ")))
+		}
+	}
+
+	var findings []assessmentv1alpha1.Finding
+	if len(missing) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "operators-required-missing",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusFail,
+			Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
+			Title:          "Required Operators Not Installed",
+			Description:    fmt.Sprintf("%d of %d required operator(s) have no ClusterServiceVersion: %s", len(missing), len(profile.RequiredOperators), strings.Join(missing, ", ")),
+			Impact:         "The cluster does not meet the platform standard: the capabilities these operators provide, such as logging, compliance scanning or backups, are not available.",
+			Recommendation: "Install the missing operators from OperatorHub with a Subscription, or remove them from requiredOperators if they are no longer part of the standard.",
+			References: []string{
+				"https://docs.openshift.com/container-platform/latest/operators/admin/olm-adding-operators-to-cluster.html",
+			},
+		})
+	}
+	if len(unhealthy) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "operators-required-unhealthy",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Required Operators Not Succeeded",
+			Description:    fmt.Sprintf("%d required operator(s) are installed but none of their ClusterServiceVersions has succeeded: %s", len(unhealthy), strings.Join(unhealthy, "; ")),
+			Impact:         "A required operator whose CSV is not Succeeded may not be running or reconciling, so the capability it provides cannot be relied on.",
+			Recommendation: "Check the CSV's status conditions and the InstallPlan and Subscription in the operator's namespace to see why the installation or upgrade has not completed.",
+		})
+	}
+
+	if len(findings) == 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "operators-required-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Required Operators Installed",
+			Description: fmt.Sprintf("All %d required operator(s) are installed and Succeeded: %s", len(profile.RequiredOperators), strings.Join(profile.RequiredOperators, ", ")),
+		})
+	}
+
+	return findings
+}
+
+// matchesOperator reports whether a CSV name satisfies a requiredOperators
+// entry: the exact name, the package name followed by a version
+// ("cluster-logging" matches "cluster-logging.v5.9.1"), or any name with the
+// prefix before a trailing "*".
+func matchesOperator(required, csvName string) bool {
+	if prefix, ok := strings.CutSuffix(required, "*"); ok {
+		return strings.HasPrefix(csvName, prefix)
+	}
+	return csvName == required || strings.HasPrefix(csvName, required+".")
+}
+
 func truncateList(items []string, max int) []string {
 	if len(items) <= max {
 		return items
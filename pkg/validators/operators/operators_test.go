@@ -0,0 +1,236 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operators
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator/validatortest"
+)
+
+func newCSV(namespace, name, phase string) unstructured.Unstructured {
+	csv := unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{"phase": phase},
+	}}
+	csv.SetNamespace(namespace)
+	csv.SetName(name)
+	return csv
+}
+
+func terminatingNamespace(name string, since time.Duration, conditions ...corev1.NamespaceCondition) *corev1.Namespace {
+	deleted := metav1.NewTime(time.Now().Add(-since))
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			DeletionTimestamp: &deleted,
+			Finalizers:        []string{"example.com/cleanup"},
+		},
+		Status: corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating, Conditions: conditions},
+	}
+}
+
+func TestCheckTerminatingNamespaces(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		terminatingNamespace("old-team", 3*time.Hour, corev1.NamespaceCondition{
+			Type:   corev1.NamespaceFinalizersRemaining,
+			Status: corev1.ConditionTrue,
+			Reason: "SomeFinalizersRemain",
+		}),
+		terminatingNamespace("older-team", 48*time.Hour),
+		terminatingNamespace("just-deleted", time.Minute),
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "active"}},
+	).Build()
+
+	findings := (&OperatorsValidator{}).checkTerminatingNamespaces(context.Background(), c)
+	f := validatortest.FindingByID(findings, "operators-namespace-stuck-terminating")
+	if f == nil || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("expected stuck namespace WARN, got %+v", findings)
+	}
+	if !strings.Contains(f.Description, "Found 2 namespace(s)") {
+		t.Errorf("expected 2 stuck namespaces: %s", f.Description)
+	}
+	if !strings.Contains(f.Description, ": older-team (terminating for 48h0m0s), old-team (terminating for 3h0m0s, SomeFinalizersRemain)") {
+		t.Errorf("unexpected description: %s", f.Description)
+	}
+	if strings.Contains(f.Description, "just-deleted") {
+		t.Errorf("recently deleted namespace should not be reported: %s", f.Description)
+	}
+}
+
+func TestCheckTerminatingNamespaces_None(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "active"}},
+	).Build()
+
+	findings := (&OperatorsValidator{}).checkTerminatingNamespaces(context.Background(), c)
+	if validatortest.FindingByID(findings, "operators-no-stuck-namespaces") == nil {
+		t.Errorf("expected PASS finding, got %+v", findings)
+	}
+}
+
+func TestMatchesOperator(t *testing.T) {
+	tests := []struct {
+		required, csv string
+		want          bool
+	}{
+		{"cluster-logging", "cluster-logging.v5.9.1", true},
+		{"cluster-logging", "cluster-logging", true},
+		{"cluster-logging", "cluster-logging-extras.v1.0.0", false},
+		{"oadp-operator.v1.4.0", "oadp-operator.v1.4.0", true},
+		{"oadp-operator.v1.4.0", "oadp-operator.v1.4.1", false},
+		{"compliance-*", "compliance-operator.v1.6.0", true},
+		{"compliance-*", "file-integrity-operator.v1.3.0", false},
+	}
+	for _, tt := range tests {
+		if got := matchesOperator(tt.required, tt.csv); got != tt.want {
+			t.Errorf("matchesOperator(%q, %q) = %v, want %v", tt.required, tt.csv, got, tt.want)
+		}
+	}
+}
+
+func TestCheckRequiredOperators(t *testing.T) {
+	csvs := []unstructured.Unstructured{
+		newCSV("openshift-logging", "cluster-logging.v5.9.1", "Succeeded"),
+		newCSV("openshift-adp", "oadp-operator.v1.4.0", "Installing"),
+	}
+	profile := profiles.GetProfile(string(profiles.ProfileProduction))
+	v := &OperatorsValidator{}
+
+	if findings := v.checkRequiredOperators(profile, csvs); findings != nil {
+		t.Errorf("expected no findings without required operators, got %+v", findings)
+	}
+
+	profile.RequiredOperators = []string{"cluster-logging", "oadp-operator", "compliance-*"}
+	findings := v.checkRequiredOperators(profile, csvs)
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %+v", len(findings), findings)
+	}
+	missing, unhealthy := findings[0], findings[1]
+	if missing.ID != "operators-required-missing" || missing.Status != assessmentv1alpha1.FindingStatusFail ||
+		!strings.Contains(missing.Description, "1 of 3 required operator(s) have no ClusterServiceVersion: compliance-*") {
+		t.Errorf("unexpected missing finding: %+v", missing)
+	}
+	if unhealthy.ID != "operators-required-unhealthy" || unhealthy.Status != assessmentv1alpha1.FindingStatusWarn ||
+		!strings.Contains(unhealthy.Description, "oadp-operator (openshift-adp/oadp-operator.v1.4.0: Installing)") {
+		t.Errorf("unexpected unhealthy finding: %+v", unhealthy)
+	}
+
+	profile.RequiredOperators = []string{"cluster-logging"}
+	findings = v.checkRequiredOperators(profile, csvs)
+	if len(findings) != 1 || findings[0].ID != "operators-required-ok" || findings[0].Status != assessmentv1alpha1.FindingStatusPass {
+		t.Errorf("expected a single pass finding, got %+v", findings)
+	}
+}
+
+func newOperatorGroup(namespace, name string, targets ...string) *unstructured.Unstructured {
+	og := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{}}}
+	og.SetAPIVersion("operators.coreos.com/v1")
+	og.SetKind("OperatorGroup")
+	og.SetNamespace(namespace)
+	og.SetName(name)
+	if len(targets) > 0 {
+		_ = unstructured.SetNestedStringSlice(og.Object, targets, "spec", "targetNamespaces")
+	}
+	return og
+}
+
+func TestCheckOperatorGroups(t *testing.T) {
+	c := fake.NewClientBuilder().WithObjects(
+		newOperatorGroup("openshift-operators", "global-operators"),
+		newOperatorGroup("openshift-logging", "cluster-logging", "openshift-logging"),
+		newOperatorGroup("team-a", "team-a-og", "team-a"),
+		newOperatorGroup("team-a", "team-a-extra", "team-a", "team-b"),
+	).Build()
+	v := &OperatorsValidator{}
+
+	findings := v.checkOperatorGroups(context.Background(), c)
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %+v", len(findings), findings)
+	}
+	multiple, scopes := findings[0], findings[1]
+	if multiple.ID != "operators-operatorgroup-multiple" || multiple.Status != assessmentv1alpha1.FindingStatusFail ||
+		!strings.Contains(multiple.Description, "team-a (team-a-extra, team-a-og)") {
+		t.Errorf("unexpected multiple finding: %+v", multiple)
+	}
+	if scopes.ID != "operators-operatorgroup-scopes" ||
+		!strings.Contains(scopes.Description, "AllNamespaces: 1, OwnNamespace: 2, MultiNamespace: 1") {
+		t.Errorf("unexpected scopes finding: %+v", scopes)
+	}
+}
+
+func TestOperatorGroupScope(t *testing.T) {
+	selector := newOperatorGroup("ns", "og")
+	_ = unstructured.SetNestedStringMap(selector.Object, map[string]string{"team": "a"}, "spec", "selector", "matchLabels")
+	tests := []struct {
+		og   *unstructured.Unstructured
+		want string
+	}{
+		{newOperatorGroup("ns", "og"), "AllNamespaces"},
+		{newOperatorGroup("ns", "og", "ns"), "OwnNamespace"},
+		{newOperatorGroup("ns", "og", "other"), "SingleNamespace"},
+		{newOperatorGroup("ns", "og", "ns", "other"), "MultiNamespace"},
+		{selector, "Selector"},
+	}
+	for _, tt := range tests {
+		if got := operatorGroupScope(*tt.og); got != tt.want {
+			t.Errorf("operatorGroupScope(%v) = %q, want %q", tt.og.Object["spec"], got, tt.want)
+		}
+	}
+}
+
+func TestValidate_ChecksNamespacesWhenCSVListFails(t *testing.T) {
+	c := fake.NewClientBuilder().WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "active"}},
+	).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if u, ok := list.(*unstructured.UnstructuredList); ok && u.GetKind() == "ClusterServiceVersionList" {
+				return errors.New("no matches for kind ClusterServiceVersion")
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}).Build()
+
+	findings, err := (&OperatorsValidator{}).Validate(context.Background(), c, profiles.GetProfile(string(profiles.ProfileProduction)))
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if validatortest.FindingByID(findings, "operators-csv-error") == nil {
+		t.Errorf("expected operators-csv-error, got %+v", findings)
+	}
+	if validatortest.FindingByID(findings, "operators-no-stuck-namespaces") == nil {
+		t.Errorf("expected the terminating namespace check to run, got %+v", findings)
+	}
+}
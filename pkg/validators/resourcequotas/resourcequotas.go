@@ -19,6 +19,7 @@ package resourcequotas
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -60,6 +61,15 @@ func (v *ResourceQuotasValidator) Category() string {
 	return validatorCategory
 }
 
+// WatchedResources returns the kinds read by this validator.
+func (v *ResourceQuotasValidator) WatchedResources() []schema.GroupVersionKind {
+	return []schema.GroupVersionKind{
+		corev1.SchemeGroupVersion.WithKind("Namespace"),
+		corev1.SchemeGroupVersion.WithKind("ResourceQuota"),
+		corev1.SchemeGroupVersion.WithKind("LimitRange"),
+	}
+}
+
 // Validate performs resource governance checks.
 func (v *ResourceQuotasValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
 	var findings []assessmentv1alpha1.Finding
@@ -89,6 +99,11 @@ func (v *ResourceQuotasValidator) Validate(ctx context.Context, c client.Client,
 		if strings.HasPrefix(ns.Name, "openshift-") || strings.HasPrefix(ns.Name, "kube-") || ns.Name == "default" {
 			continue
 		}
+		// Quotas and LimitRanges are assessed per namespace, so the resource
+		// label selector applies to the namespace's own labels
+		if !validator.Selected(ctx, &ns) {
+			continue
+		}
 		userNamespaces = append(userNamespaces, ns.Name)
 	}
 
@@ -209,9 +224,186 @@ func (v *ResourceQuotasValidator) checkResourceQuotas(ctx context.Context, c cli
 		})
 	}
 
+	// Report quotas that constrain the same resource for the same pods
+	findings = append(findings, v.checkOverlappingQuotas(nsWithQuota, userNamespaces)...)
+
 	return findings
 }
 
+// checkOverlappingQuotas reports namespaces where more than one ResourceQuota
+// limits the same resource for pods matched by both quotas' scopes. Every
+// matching quota is enforced, so the tightest one silently wins.
+func (v *ResourceQuotasValidator) checkOverlappingQuotas(nsWithQuota map[string][]corev1.ResourceQuota, userNamespaces []string) []assessmentv1alpha1.Finding {
+	var overlaps []string
+	identicalScopes := false
+
+	for _, nsName := range userNamespaces {
+		quotas := nsWithQuota[nsName]
+		for i := 0; i < len(quotas); i++ {
+			for j := i + 1; j < len(quotas); j++ {
+				a, b := quotas[i], quotas[j]
+				shared := sharedResources(a, b)
+				if len(shared) == 0 {
+					continue
+				}
+				scopesA, scopesB := quotaScopes(a), quotaScopes(b)
+				if !scopesMayOverlap(scopesA, scopesB) {
+					continue
+				}
+				if describeScopes(scopesA) == describeScopes(scopesB) {
+					identicalScopes = true
+				}
+				overlaps = append(overlaps, fmt.Sprintf("%s: %s and %s (%s)", nsName, a.Name, b.Name, strings.Join(shared, ", ")))
+			}
+		}
+	}
+
+	if len(overlaps) == 0 {
+		return nil
+	}
+	sort.Strings(overlaps)
+
+	// Quotas with the same scopes always count the same pods; partially
+	// overlapping scopes only double-count some of them.
+	status := assessmentv1alpha1.FindingStatusInfo
+	if identicalScopes {
+		status = assessmentv1alpha1.FindingStatusWarn
+	}
+
+	sample := overlaps
+	if len(sample) > 5 {
+		sample = sample[:5]
+	}
+
+	return []assessmentv1alpha1.Finding{{
+		ID:             "resourcequotas-overlapping-quotas",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         status,
+		Title:          "Overlapping ResourceQuotas",
+		Description:    fmt.Sprintf("%d pair(s) of ResourceQuotas limit the same resources for overlapping pod scopes: %s", len(overlaps), strings.Join(sample, "; ")),
+		Impact:         "Pods are counted against every matching quota and the tightest limit applies, so raising one quota may have no effect.",
+		Recommendation: "Consolidate the limits into a single quota per scope, or use disjoint scopes such as Terminating/NotTerminating or distinct PriorityClasses.",
+		References: []string{
+			"https://kubernetes.io/docs/concepts/policy/resource-quotas/#quota-scopes",
+		},
+	}}
+}
+
+// quotaResourceAliases maps shorthand quota resource names to their canonical form.
+var quotaResourceAliases = map[corev1.ResourceName]corev1.ResourceName{
+	corev1.ResourceCPU:              corev1.ResourceRequestsCPU,
+	corev1.ResourceMemory:           corev1.ResourceRequestsMemory,
+	corev1.ResourceEphemeralStorage: corev1.ResourceRequestsEphemeralStorage,
+}
+
+// sharedResources returns the canonical resource names limited by both quotas.
+func sharedResources(a, b corev1.ResourceQuota) []string {
+	limited := make(map[corev1.ResourceName]bool)
+	for name := range a.Spec.Hard {
+		limited[canonicalResource(name)] = true
+	}
+
+	seen := make(map[corev1.ResourceName]bool)
+	var shared []string
+	for name := range b.Spec.Hard {
+		name = canonicalResource(name)
+		if limited[name] && !seen[name] {
+			seen[name] = true
+			shared = append(shared, string(name))
+		}
+	}
+	sort.Strings(shared)
+	return shared
+}
+
+// canonicalResource resolves shorthand quota resource names.
+func canonicalResource(name corev1.ResourceName) corev1.ResourceName {
+	if alias, ok := quotaResourceAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// exclusiveScopes pairs quota scopes that never match the same pod.
+var exclusiveScopes = map[corev1.ResourceQuotaScope]corev1.ResourceQuotaScope{
+	corev1.ResourceQuotaScopeTerminating:    corev1.ResourceQuotaScopeNotTerminating,
+	corev1.ResourceQuotaScopeNotTerminating: corev1.ResourceQuotaScopeTerminating,
+	corev1.ResourceQuotaScopeBestEffort:     corev1.ResourceQuotaScopeNotBestEffort,
+	corev1.ResourceQuotaScopeNotBestEffort:  corev1.ResourceQuotaScopeBestEffort,
+}
+
+// quotaScopes returns the scope requirements of a quota from both spec.scopes
+// and spec.scopeSelector. DoesNotExist on a scope with an exclusive
+// counterpart is rewritten as Exists on the counterpart.
+func quotaScopes(quota corev1.ResourceQuota) []corev1.ScopedResourceSelectorRequirement {
+	var scopes []corev1.ScopedResourceSelectorRequirement
+	for _, scope := range quota.Spec.Scopes {
+		scopes = append(scopes, corev1.ScopedResourceSelectorRequirement{
+			ScopeName: scope,
+			Operator:  corev1.ScopeSelectorOpExists,
+		})
+	}
+	if quota.Spec.ScopeSelector != nil {
+		for _, req := range quota.Spec.ScopeSelector.MatchExpressions {
+			if counterpart, ok := exclusiveScopes[req.ScopeName]; ok && req.Operator == corev1.ScopeSelectorOpDoesNotExist {
+				req = corev1.ScopedResourceSelectorRequirement{ScopeName: counterpart, Operator: corev1.ScopeSelectorOpExists}
+			}
+			scopes = append(scopes, req)
+		}
+	}
+	return scopes
+}
+
+// scopesMayOverlap reports whether a pod could match both sets of scope
+// requirements. It only rules out overlap for exclusive scope pairs and for
+// In requirements on the same scope with no common value.
+func scopesMayOverlap(a, b []corev1.ScopedResourceSelectorRequirement) bool {
+	for _, ra := range a {
+		for _, rb := range b {
+			if ra.Operator == corev1.ScopeSelectorOpExists && rb.Operator == corev1.ScopeSelectorOpExists &&
+				exclusiveScopes[ra.ScopeName] == rb.ScopeName {
+				return false
+			}
+			if ra.ScopeName != rb.ScopeName {
+				continue
+			}
+			if (ra.Operator == corev1.ScopeSelectorOpExists && rb.Operator == corev1.ScopeSelectorOpDoesNotExist) ||
+				(ra.Operator == corev1.ScopeSelectorOpDoesNotExist && rb.Operator == corev1.ScopeSelectorOpExists) {
+				return false
+			}
+			if ra.Operator == corev1.ScopeSelectorOpIn && rb.Operator == corev1.ScopeSelectorOpIn && !sharesValue(ra.Values, rb.Values) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sharesValue reports whether the two lists have a value in common.
+func sharesValue(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// describeScopes renders scope requirements in a canonical order.
+func describeScopes(scopes []corev1.ScopedResourceSelectorRequirement) string {
+	parts := make([]string, 0, len(scopes))
+	for _, req := range scopes {
+		values := append([]string(nil), req.Values...)
+		sort.Strings(values)
+		parts = append(parts, fmt.Sprintf("%s %s %s", req.ScopeName, req.Operator, strings.Join(values, ",")))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "; ")
+}
+
 // checkLimitRanges checks LimitRange configuration across namespaces.
 func (v *ResourceQuotasValidator) checkLimitRanges(ctx context.Context, c client.Client, profile profiles.Profile, userNamespaces []string) []assessmentv1alpha1.Finding {
 	var findings []assessmentv1alpha1.Finding
@@ -297,6 +489,7 @@ func (v *ResourceQuotasValidator) checkLimitRanges(ctx context.Context, c client
 			Validator:      validatorName,
 			Category:       validatorCategory,
 			Status:         assessmentv1alpha1.FindingStatusInfo,
+			Confidence:     assessmentv1alpha1.FindingConfidenceLow,
 			Title:          "LimitRanges with Very High Defaults",
 			Description:    fmt.Sprintf("%d LimitRange(s) have default memory > 8Gi: %s", len(veryHighDefaultLimits), strings.Join(veryHighDefaultLimits, ", ")),
 			Impact:         "High default limits may lead to inefficient resource allocation.",
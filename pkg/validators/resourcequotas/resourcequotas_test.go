@@ -2,10 +2,13 @@ package resourcequotas
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
 	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -81,3 +84,96 @@ func TestValidate_Optimization(t *testing.T) {
 		t.Errorf("Expected 0 NamespaceList calls, got %d", c.listNamespaceCalls)
 	}
 }
+
+func quota(name string, hard []corev1.ResourceName, scopes ...corev1.ResourceQuotaScope) corev1.ResourceQuota {
+	q := corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-app"},
+		Spec:       corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{}, Scopes: scopes},
+	}
+	for _, r := range hard {
+		q.Spec.Hard[r] = resource.MustParse("1")
+	}
+	return q
+}
+
+func TestCheckOverlappingQuotas(t *testing.T) {
+	v := &ResourceQuotasValidator{}
+
+	tests := []struct {
+		name       string
+		quotas     []corev1.ResourceQuota
+		wantStatus assessmentv1alpha1.FindingStatus
+		wantText   string
+	}{
+		{
+			name: "unscoped quotas on the same resource",
+			quotas: []corev1.ResourceQuota{
+				quota("compute", []corev1.ResourceName{corev1.ResourceCPU}),
+				quota("team", []corev1.ResourceName{corev1.ResourceRequestsCPU, corev1.ResourcePods}),
+			},
+			wantStatus: assessmentv1alpha1.FindingStatusWarn,
+			wantText:   "my-app: compute and team (requests.cpu)",
+		},
+		{
+			name: "scoped quota overlapping an unscoped one",
+			quotas: []corev1.ResourceQuota{
+				quota("compute", []corev1.ResourceName{corev1.ResourcePods}),
+				quota("batch", []corev1.ResourceName{corev1.ResourcePods}, corev1.ResourceQuotaScopeTerminating),
+			},
+			wantStatus: assessmentv1alpha1.FindingStatusInfo,
+			wantText:   "compute and batch (pods)",
+		},
+		{
+			name: "exclusive scopes",
+			quotas: []corev1.ResourceQuota{
+				quota("long-running", []corev1.ResourceName{corev1.ResourcePods}, corev1.ResourceQuotaScopeNotTerminating),
+				quota("batch", []corev1.ResourceName{corev1.ResourcePods}, corev1.ResourceQuotaScopeTerminating),
+			},
+		},
+		{
+			name: "different resources",
+			quotas: []corev1.ResourceQuota{
+				quota("compute", []corev1.ResourceName{corev1.ResourceLimitsCPU}),
+				quota("objects", []corev1.ResourceName{corev1.ResourceConfigMaps}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := v.checkOverlappingQuotas(map[string][]corev1.ResourceQuota{"my-app": tt.quotas}, []string{"my-app"})
+			if tt.wantStatus == "" {
+				if len(findings) != 0 {
+					t.Fatalf("Expected no findings, got %v", findings)
+				}
+				return
+			}
+			if len(findings) != 1 {
+				t.Fatalf("Expected 1 finding, got %d", len(findings))
+			}
+			if findings[0].Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", findings[0].Status, tt.wantStatus)
+			}
+			if !strings.Contains(findings[0].Description, tt.wantText) {
+				t.Errorf("Description %q does not contain %q", findings[0].Description, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestScopesMayOverlap_PriorityClass(t *testing.T) {
+	selector := func(values ...string) []corev1.ScopedResourceSelectorRequirement {
+		return []corev1.ScopedResourceSelectorRequirement{{
+			ScopeName: corev1.ResourceQuotaScopePriorityClass,
+			Operator:  corev1.ScopeSelectorOpIn,
+			Values:    values,
+		}}
+	}
+
+	if scopesMayOverlap(selector("high"), selector("low")) {
+		t.Error("Expected disjoint PriorityClass values not to overlap")
+	}
+	if !scopesMayOverlap(selector("high", "medium"), selector("medium")) {
+		t.Error("Expected shared PriorityClass values to overlap")
+	}
+}
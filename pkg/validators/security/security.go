@@ -18,11 +18,18 @@ package security
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"path"
+	"slices"
+	"sort"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
@@ -36,6 +43,17 @@ const (
 	validatorCategory    = "Security"
 )
 
+const (
+	// configPrivilegedPodSampleSize overrides how many offending pods are listed per finding.
+	configPrivilegedPodSampleSize = "privilegedPodSampleSize"
+
+	// configHostPathExemptDaemonSets is a comma-separated list of namespace/name
+	// DaemonSets whose pods may mount host paths, added to knownAgentDaemonSets.
+	configHostPathExemptDaemonSets = "hostPathExemptDaemonSets"
+
+	defaultPrivilegedPodSampleSize = 5
+)
+
 // Namespaces that are expected to have cluster-admin or privileged access
 var systemNamespaces = map[string]bool{
 	"openshift-apiserver":                    true,
@@ -86,6 +104,11 @@ func (v *SecurityValidator) Category() string {
 	return validatorCategory
 }
 
+// ConfigKeys returns the override keys understood by this validator.
+func (v *SecurityValidator) ConfigKeys() []string {
+	return []string{configPrivilegedPodSampleSize, configHostPathExemptDaemonSets}
+}
+
 // Validate performs security checks.
 func (v *SecurityValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
 	var findings []assessmentv1alpha1.Finding
@@ -102,6 +125,30 @@ func (v *SecurityValidator) Validate(ctx context.Context, c client.Client, profi
 	// Check 4: Risky RBAC patterns
 	findings = append(findings, v.checkRiskyRBACPatterns(ctx, c)...)
 
+	// Check 5: SecurityContextConstraints usage
+	findings = append(findings, v.checkSCCs(ctx, c)...)
+
+	// Check 6: Image pull secrets referenced by service accounts
+	findings = append(findings, v.checkImagePullSecrets(ctx, c)...)
+
+	// Check 7: hostPath volumes
+	findings = append(findings, v.checkHostPathVolumes(ctx, c)...)
+
+	// Check 8: admin/edit granted to broad groups
+	findings = append(findings, v.checkNamespaceAdminBindings(ctx, c)...)
+
+	// Check 9: Pods running as root under anyuid/privileged
+	findings = append(findings, v.checkRootUnderPermissiveSCC(ctx, c)...)
+
+	// Check 10: Risky RBAC patterns in namespaced Roles
+	findings = append(findings, v.checkRiskyRoles(ctx, c)...)
+
+	// Check 11: Workload images from registries outside the expected state
+	findings = append(findings, v.checkAllowedRegistries(ctx, c)...)
+
+	// Check 12: Seccomp profiles of user workloads
+	findings = append(findings, v.checkSeccompProfiles(ctx, c, profile)...)
+
 	return findings, nil
 }
 
@@ -124,6 +171,8 @@ func (v *SecurityValidator) checkClusterAdminBindings(ctx context.Context, c cli
 
 	var clusterAdminBindings []string
 	var nonSystemClusterAdminBindings []string
+	// subjectBindings maps each cluster-admin subject identifier to its bindings.
+	subjectBindings := make(map[string][]string)
 
 	for _, crb := range crbs.Items {
 		if crb.RoleRef.Name == "cluster-admin" {
@@ -131,6 +180,9 @@ func (v *SecurityValidator) checkClusterAdminBindings(ctx context.Context, c cli
 
 			// Check if it's binding to non-system subjects
 			for _, subject := range crb.Subjects {
+				id := subjectID(subject)
+				subjectBindings[id] = append(subjectBindings[id], crb.Name)
+
 				switch subject.Kind {
 				case "ServiceAccount":
 					if !systemNamespaces[subject.Namespace] {
@@ -158,6 +210,17 @@ func (v *SecurityValidator) checkClusterAdminBindings(ctx context.Context, c cli
 		Description: fmt.Sprintf("Found %d ClusterRoleBindings referencing cluster-admin.", len(clusterAdminBindings)),
 	})
 
+	// With an expected list, report drift from it instead of applying the
+	// threshold. spec.expectedClusterAdmins takes precedence over the expected
+	// state.
+	expected := profile.ExpectedClusterAdmins
+	if len(expected) == 0 {
+		expected = validator.ExpectedStateFromContext(ctx).ClusterAdmins
+	}
+	if len(expected) > 0 {
+		return append(findings, clusterAdminDrift(subjectBindings, expected)...)
+	}
+
 	// Check non-system cluster-admin bindings
 	if len(nonSystemClusterAdminBindings) > profile.Thresholds.MaxClusterAdminBindings {
 		findings = append(findings, assessmentv1alpha1.Finding{
@@ -196,12 +259,101 @@ func (v *SecurityValidator) checkClusterAdminBindings(ctx context.Context, c cli
 	return findings
 }
 
+// subjectID returns the identifier used for a subject in ExpectedClusterAdmins.
+func subjectID(subject rbacv1.Subject) string {
+	if subject.Kind == rbacv1.ServiceAccountKind {
+		return fmt.Sprintf("%s:%s/%s", subject.Kind, subject.Namespace, subject.Name)
+	}
+	return fmt.Sprintf("%s:%s", subject.Kind, subject.Name)
+}
+
+// clusterAdminDrift compares the non-system cluster-admin subjects against the
+// expected list and reports subjects that were added or removed.
+func clusterAdminDrift(subjectBindings map[string][]string, expected []string) []assessmentv1alpha1.Finding {
+	var findings []assessmentv1alpha1.Finding
+
+	expectedSet := make(map[string]bool, len(expected))
+	for _, id := range expected {
+		expectedSet[id] = true
+	}
+
+	var unexpected []string
+	for id, bindings := range subjectBindings {
+		if expectedSet[id] || isSystemSubject(id) {
+			continue
+		}
+		unexpected = append(unexpected, fmt.Sprintf("%s (%s)", id, strings.Join(bindings, ", ")))
+	}
+	sort.Strings(unexpected)
+
+	var missing []string
+	for id := range expectedSet {
+		if _, ok := subjectBindings[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	sort.Strings(missing)
+
+	if len(unexpected) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "security-cluster-admin-unexpected",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Unexpected Cluster-Admin Subjects",
+			Description:    fmt.Sprintf("Found %d cluster-admin subject(s) not in expectedClusterAdmins: %s", len(unexpected), strings.Join(unexpected, "; ")),
+			Impact:         "New cluster-admin grants may indicate privilege escalation or an untracked change.",
+			Recommendation: "Remove the binding, or add the subject to spec.expectedClusterAdmins if it is legitimate.",
+			References: []string{
+				"https://docs.openshift.com/container-platform/latest/authentication/using-rbac.html",
+			},
+		})
+	}
+
+	if len(missing) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "security-cluster-admin-missing",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Expected Cluster-Admin Subjects Missing",
+			Description:    fmt.Sprintf("Found %d expected cluster-admin subject(s) without a cluster-admin binding: %s", len(missing), strings.Join(missing, ", ")),
+			Impact:         "The administrators relied on for break-glass access or operations may no longer have it.",
+			Recommendation: "Restore the binding, or remove the subject from spec.expectedClusterAdmins.",
+		})
+	}
+
+	if len(unexpected) == 0 && len(missing) == 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "security-cluster-admin-expected",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Cluster-Admin Subjects Match Expectations",
+			Description: fmt.Sprintf("All non-system cluster-admin subjects match the %d expected subject(s).", len(expected)),
+		})
+	}
+
+	return findings
+}
+
+// isSystemSubject reports whether a subject identifier refers to a platform
+// subject that is excluded from cluster-admin reporting.
+func isSystemSubject(id string) bool {
+	kind, name, _ := strings.Cut(id, ":")
+	if kind == rbacv1.ServiceAccountKind {
+		namespace, _, _ := strings.Cut(name, "/")
+		return systemNamespaces[namespace]
+	}
+	return strings.HasPrefix(name, "system:")
+}
+
 // checkPrivilegedPods checks for privileged containers.
 func (v *SecurityValidator) checkPrivilegedPods(ctx context.Context, c client.Client, profile profiles.Profile) []assessmentv1alpha1.Finding {
 	var findings []assessmentv1alpha1.Finding
 
-	pods := &corev1.PodList{}
-	if err := c.List(ctx, pods); err != nil {
+	pods, err := validator.ListPods(ctx, c)
+	if err != nil {
 		return []assessmentv1alpha1.Finding{{
 			ID:          "security-pods-error",
 			Validator:   validatorName,
@@ -212,13 +364,19 @@ func (v *SecurityValidator) checkPrivilegedPods(ctx context.Context, c client.Cl
 		}}
 	}
 
+	sampleSize := validator.ConfigInt(ctx, configPrivilegedPodSampleSize, defaultPrivilegedPodSampleSize)
+	if sampleSize < 1 {
+		sampleSize = defaultPrivilegedPodSampleSize
+	}
+
 	var privilegedPods []string
+	var privilegedEvidence string
 	var hostNetworkPods []string
 	var hostPIDPods []string
 
-	for _, pod := range pods.Items {
+	for _, pod := range pods {
 		// Skip system namespaces
-		if systemNamespaces[pod.Namespace] || strings.HasPrefix(pod.Namespace, "openshift-") {
+		if systemNamespaces[pod.Namespace] || strings.HasPrefix(pod.Namespace, "openshift-") || !validator.Selected(ctx, &pod) {
 			continue
 		}
 
@@ -232,6 +390,9 @@ func (v *SecurityValidator) checkPrivilegedPods(ctx context.Context, c client.Cl
 		}
 		if isPrivileged {
 			privilegedPods = append(privilegedPods, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+			if len(privilegedPods) == 1 {
+				privilegedEvidence = validator.Evidence(ctx, &pod)
+			}
 		}
 
 		// Check for host network
@@ -253,8 +414,8 @@ func (v *SecurityValidator) checkPrivilegedPods(ctx context.Context, c client.Cl
 		}
 
 		sample := privilegedPods
-		if len(sample) > 5 {
-			sample = sample[:5]
+		if len(sample) > sampleSize {
+			sample = sample[:sampleSize]
 		}
 
 		findings = append(findings, assessmentv1alpha1.Finding{
@@ -266,6 +427,7 @@ func (v *SecurityValidator) checkPrivilegedPods(ctx context.Context, c client.Cl
 			Description:    fmt.Sprintf("Found %d pod(s) with privileged containers in user namespaces: %s...", len(privilegedPods), strings.Join(sample, ", ")),
 			Impact:         "Privileged containers have elevated access to the host and bypass many security controls.",
 			Recommendation: "Review if privileged access is necessary. Consider using specific capabilities instead of full privileged mode.",
+			Evidence:       privilegedEvidence,
 		})
 	} else {
 		findings = append(findings, assessmentv1alpha1.Finding{
@@ -281,8 +443,8 @@ func (v *SecurityValidator) checkPrivilegedPods(ctx context.Context, c client.Cl
 	// Report host network pods
 	if len(hostNetworkPods) > 0 {
 		sample := hostNetworkPods
-		if len(sample) > 5 {
-			sample = sample[:5]
+		if len(sample) > sampleSize {
+			sample = sample[:sampleSize]
 		}
 		findings = append(findings, assessmentv1alpha1.Finding{
 			ID:             "security-host-network",
@@ -299,8 +461,8 @@ func (v *SecurityValidator) checkPrivilegedPods(ctx context.Context, c client.Cl
 	// Report host PID pods
 	if len(hostPIDPods) > 0 {
 		sample := hostPIDPods
-		if len(sample) > 5 {
-			sample = sample[:5]
+		if len(sample) > sampleSize {
+			sample = sample[:sampleSize]
 		}
 		findings = append(findings, assessmentv1alpha1.Finding{
 			ID:             "security-host-pid",
@@ -322,14 +484,14 @@ func (v *SecurityValidator) checkServiceAccountTokenAutomation(ctx context.Conte
 	var findings []assessmentv1alpha1.Finding
 
 	// Check if default service accounts have automount disabled
-	namespaces := &corev1.NamespaceList{}
-	if err := c.List(ctx, namespaces); err != nil {
+	namespaces, err := validator.ListNamespaces(ctx, c)
+	if err != nil {
 		return findings
 	}
 
 	var automountEnabledNamespaces []string
 
-	for _, ns := range namespaces.Items {
+	for _, ns := range namespaces {
 		// Skip system namespaces
 		if systemNamespaces[ns.Name] || strings.HasPrefix(ns.Name, "openshift-") || strings.HasPrefix(ns.Name, "kube-") {
 			continue
@@ -386,28 +548,11 @@ func (v *SecurityValidator) checkRiskyRBACPatterns(ctx context.Context, c client
 		}
 
 		for _, rule := range cr.Rules {
-			// Check for wildcard permissions
-			for _, verb := range rule.Verbs {
-				if verb == "*" {
-					for _, resource := range rule.Resources {
-						if resource == "*" {
-							wildcardRoles = append(wildcardRoles, cr.Name)
-							break
-						}
-					}
-				}
+			if ruleHasWildcard(rule) {
+				wildcardRoles = append(wildcardRoles, cr.Name)
 			}
-
-			// Check for secrets access
-			for _, resource := range rule.Resources {
-				if resource == "secrets" || resource == "*" {
-					for _, verb := range rule.Verbs {
-						if verb == "get" || verb == "list" || verb == "watch" || verb == "*" {
-							secretsAccessRoles = append(secretsAccessRoles, cr.Name)
-							break
-						}
-					}
-				}
+			if ruleReadsSecrets(rule) {
+				secretsAccessRoles = append(secretsAccessRoles, cr.Name)
 			}
 		}
 	}
@@ -445,6 +590,1053 @@ func (v *SecurityValidator) checkRiskyRBACPatterns(ctx context.Context, c client
 	return findings
 }
 
+// checkRiskyRoles applies the ClusterRole wildcard and secrets checks to
+// namespaced Roles in user namespaces.
+func (v *SecurityValidator) checkRiskyRoles(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	roles := &rbacv1.RoleList{}
+	if err := c.List(ctx, roles); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "security-rbac-role-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Namespaced Roles",
+			Description: fmt.Sprintf("Failed to list Roles: %v", err),
+		}}
+	}
+
+	var wildcardRoles []string
+	var secretsAccessRoles []string
+	var wildcardEvidence, secretsEvidence string
+
+	for i := range roles.Items {
+		role := &roles.Items[i]
+		if systemNamespaces[role.Namespace] || strings.HasPrefix(role.Namespace, "openshift-") || strings.HasPrefix(role.Namespace, "kube-") {
+			continue
+		}
+		if strings.HasPrefix(role.Name, "system:") {
+			continue
+		}
+
+		name := role.Namespace + "/" + role.Name
+		for _, rule := range role.Rules {
+			if ruleHasWildcard(rule) {
+				wildcardRoles = append(wildcardRoles, name)
+				if wildcardEvidence == "" {
+					wildcardEvidence = validator.Evidence(ctx, role)
+				}
+			}
+			if ruleReadsSecrets(rule) {
+				secretsAccessRoles = append(secretsAccessRoles, name)
+				if secretsEvidence == "" {
+					secretsEvidence = validator.Evidence(ctx, role)
+				}
+			}
+		}
+	}
+
+	wildcardRoles = unique(wildcardRoles)
+	secretsAccessRoles = unique(secretsAccessRoles)
+
+	if len(wildcardRoles) == 0 && len(secretsAccessRoles) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "security-rbac-roles-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "No Risky Namespaced Roles",
+			Description: "No Roles in user namespaces grant wildcard permissions or read access to secrets.",
+		}}
+	}
+
+	var findings []assessmentv1alpha1.Finding
+	if len(wildcardRoles) > 0 {
+		sample := validator.SampleOf(wildcardRoles)
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "security-rbac-role-wildcard",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Roles with Wildcard Permissions",
+			Description:    fmt.Sprintf("Found %d Role(s) in user namespaces with wildcard (*) permissions: %s", len(wildcardRoles), strings.Join(sample, ", ")),
+			Impact:         "A wildcard Role gives its subjects full control of the namespace, including its RBAC, which makes it equivalent to namespace admin.",
+			Recommendation: "Refine Roles to specify only the necessary resources and verbs, or bind the built-in admin ClusterRole where full control is intended.",
+			Evidence:       wildcardEvidence,
+		})
+	}
+
+	if len(secretsAccessRoles) > 0 {
+		sample := validator.SampleOf(secretsAccessRoles)
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "security-rbac-role-secrets",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Roles with Secrets Access",
+			Description:    fmt.Sprintf("Found %d Role(s) in user namespaces with read access to secrets: %s", len(secretsAccessRoles), strings.Join(sample, ", ")),
+			Impact:         "Reading secrets exposes credentials and service account tokens stored in the namespace.",
+			Recommendation: "Limit secrets access with resourceNames to the specific secrets a workload needs.",
+			Evidence:       secretsEvidence,
+		})
+	}
+
+	return findings
+}
+
+// sensitiveHostPaths are host paths whose mount effectively grants control of the node.
+var sensitiveHostPaths = map[string]bool{
+	"/":                               true,
+	"/etc":                            true,
+	"/proc":                           true,
+	"/root":                           true,
+	"/var/lib/kubelet":                true,
+	"/var/run":                        true,
+	"/var/run/docker.sock":            true,
+	"/var/run/crio/crio.sock":         true,
+	"/run/containerd/containerd.sock": true,
+}
+
+// knownAgentDaemonSets are well-known node agents, as namespace/name, that
+// legitimately mount host paths even when deployed outside the platform
+// namespaces. Matching the namespace keeps user DaemonSets that merely reuse
+// a name from being exempted.
+var knownAgentDaemonSets = map[string]bool{
+	"monitoring/node-exporter":     true,
+	"logging/fluentd":              true,
+	"logging/fluent-bit":           true,
+	"fluent-bit/fluent-bit":        true,
+	"datadog/datadog-agent":        true,
+	"dynatrace/dynatrace-oneagent": true,
+}
+
+// checkHostPathVolumes flags user-namespace pods mounting hostPath volumes.
+// Sensitive host paths escalate the finding to FAIL.
+func (v *SecurityValidator) checkHostPathVolumes(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	var findings []assessmentv1alpha1.Finding
+
+	pods, err := validator.ListPods(ctx, c)
+	if err != nil {
+		return findings
+	}
+
+	exempt := make(map[string]bool, len(knownAgentDaemonSets))
+	for key := range knownAgentDaemonSets {
+		exempt[key] = true
+	}
+	for _, key := range validator.ConfigList(ctx, configHostPathExemptDaemonSets) {
+		exempt[key] = true
+	}
+
+	var hostPathMounts []string
+	var sensitiveMounts []string
+
+	for _, pod := range pods {
+		if systemNamespaces[pod.Namespace] || strings.HasPrefix(pod.Namespace, "openshift-") || strings.HasPrefix(pod.Namespace, "kube-") || !validator.Selected(ctx, &pod) {
+			continue
+		}
+		if isExemptDaemonSetPod(&pod, exempt) {
+			continue
+		}
+
+		for _, vol := range pod.Spec.Volumes {
+			if vol.HostPath == nil {
+				continue
+			}
+			hostPath := path.Clean(vol.HostPath.Path)
+			entry := fmt.Sprintf("%s/%s (%s)", pod.Namespace, pod.Name, hostPath)
+			if sensitiveHostPaths[hostPath] {
+				sensitiveMounts = append(sensitiveMounts, entry)
+			} else {
+				hostPathMounts = append(hostPathMounts, entry)
+			}
+		}
+	}
+
+	if len(sensitiveMounts) > 0 {
+		sample := validator.SampleOf(sensitiveMounts)
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "security-hostpath-sensitive",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusFail,
+			Title:          "Pods Mounting Sensitive Host Paths",
+			Description:    fmt.Sprintf("Found %d hostPath mount(s) of sensitive host paths in user namespaces: %s", len(sensitiveMounts), strings.Join(sample, ", ")),
+			Impact:         "Mounting the host root, /etc, or the container runtime socket allows trivial escape to the node.",
+			Recommendation: "Remove these hostPath volumes. Use ConfigMaps, Secrets, or CSI volumes instead, and restrict hostPath through SCCs.",
+			References: []string{
+				"https://kubernetes.io/docs/concepts/storage/volumes/#hostpath",
+			},
+		})
+	}
+
+	if len(hostPathMounts) > 0 {
+		sample := validator.SampleOf(hostPathMounts)
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "security-hostpath",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Pods Mounting hostPath Volumes",
+			Description:    fmt.Sprintf("Found %d hostPath mount(s) in user namespaces: %s", len(hostPathMounts), strings.Join(sample, ", ")),
+			Impact:         "hostPath volumes expose the node filesystem to the pod and are a common container escape vector.",
+			Recommendation: "Review if host filesystem access is necessary. Prefer persistent volumes or emptyDir.",
+			References: []string{
+				"https://kubernetes.io/docs/concepts/storage/volumes/#hostpath",
+			},
+		})
+	}
+
+	if len(sensitiveMounts) == 0 && len(hostPathMounts) == 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "security-no-hostpath",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "No hostPath Volumes in User Namespaces",
+			Description: "No pods in user namespaces mount hostPath volumes.",
+		})
+	}
+
+	return findings
+}
+
+// broadGroups are groups that include every (authenticated) user or service account.
+var broadGroups = map[string]bool{
+	"system:authenticated":       true,
+	"system:authenticated:oauth": true,
+	"system:unauthenticated":     true,
+	"system:serviceaccounts":     true,
+}
+
+// namespaceWriteRoles are the default ClusterRoles that grant write access within a namespace.
+var namespaceWriteRoles = map[string]bool{
+	"admin": true,
+	"edit":  true,
+}
+
+// checkNamespaceAdminBindings finds RoleBindings and ClusterRoleBindings that
+// grant the admin or edit ClusterRoles to broad groups such as system:authenticated.
+func (v *SecurityValidator) checkNamespaceAdminBindings(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	var broadBindings []string
+
+	crbs := &rbacv1.ClusterRoleBindingList{}
+	if err := c.List(ctx, crbs); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "security-broad-binding-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Broad Role Bindings",
+			Description: fmt.Sprintf("Failed to list ClusterRoleBindings: %v", err),
+		}}
+	}
+	for _, crb := range crbs.Items {
+		if crb.RoleRef.Kind != "ClusterRole" || !namespaceWriteRoles[crb.RoleRef.Name] {
+			continue
+		}
+		for _, group := range broadGroupSubjects(crb.Subjects) {
+			broadBindings = append(broadBindings, fmt.Sprintf("ClusterRoleBinding %s (%s to %s, all namespaces)", crb.Name, crb.RoleRef.Name, group))
+		}
+	}
+
+	rbs := &rbacv1.RoleBindingList{}
+	if err := c.List(ctx, rbs); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "security-broad-binding-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Broad Role Bindings",
+			Description: fmt.Sprintf("Failed to list RoleBindings: %v", err),
+		}}
+	}
+	for _, rb := range rbs.Items {
+		if strings.HasPrefix(rb.Namespace, "openshift-") || strings.HasPrefix(rb.Namespace, "kube-") {
+			continue
+		}
+		if rb.RoleRef.Kind != "ClusterRole" || !namespaceWriteRoles[rb.RoleRef.Name] {
+			continue
+		}
+		for _, group := range broadGroupSubjects(rb.Subjects) {
+			broadBindings = append(broadBindings, fmt.Sprintf("RoleBinding %s/%s (%s to %s)", rb.Namespace, rb.Name, rb.RoleRef.Name, group))
+		}
+	}
+
+	if len(broadBindings) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "security-no-broad-bindings",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "No Broad admin/edit Bindings",
+			Description: "No RoleBindings or ClusterRoleBindings grant admin or edit to all authenticated users or service accounts.",
+		}}
+	}
+
+	sample := validator.SampleOf(broadBindings)
+	return []assessmentv1alpha1.Finding{{
+		ID:             "security-broad-bindings",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Title:          "admin/edit Granted to Broad Groups",
+		Description:    fmt.Sprintf("Found %d binding(s) granting admin or edit to broad groups: %s", len(broadBindings), strings.Join(sample, "; ")),
+		Impact:         "Every user or service account in the group can modify workloads, and with admin also RBAC, in the affected namespaces.",
+		Recommendation: "Bind admin/edit to specific users or team groups instead of system:authenticated or system:serviceaccounts.",
+		References: []string{
+			"https://docs.openshift.com/container-platform/latest/authentication/using-rbac.html",
+		},
+	}}
+}
+
+// broadGroupSubjects returns the subjects that are broad groups.
+func broadGroupSubjects(subjects []rbacv1.Subject) []string {
+	var groups []string
+	for _, subject := range subjects {
+		if subject.Kind == rbacv1.GroupKind && broadGroups[subject.Name] {
+			groups = append(groups, subject.Name)
+		}
+	}
+	return groups
+}
+
+// isExemptDaemonSetPod reports whether the pod belongs to one of the exempt
+// DaemonSets, given as namespace/name.
+func isExemptDaemonSetPod(pod *corev1.Pod, exempt map[string]bool) bool {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "DaemonSet" && exempt[pod.Namespace+"/"+owner.Name] {
+			return true
+		}
+	}
+	return false
+}
+
+// builtinSCCs lists the SecurityContextConstraints shipped with OpenShift.
+var builtinSCCs = map[string]bool{
+	"anyuid":                          true,
+	"hostaccess":                      true,
+	"hostmount-anyuid":                true,
+	"hostnetwork":                     true,
+	"hostnetwork-v2":                  true,
+	"machine-api-termination-handler": true,
+	"node-exporter":                   true,
+	"nonroot":                         true,
+	"nonroot-v2":                      true,
+	"privileged":                      true,
+	"restricted":                      true,
+	"restricted-v2":                   true,
+}
+
+// defaultSCCSubjects lists the users and groups the anyuid and privileged
+// SCCs are granted to on a fresh cluster.
+var defaultSCCSubjects = map[string]map[string]bool{
+	"anyuid": {
+		"system:cluster-admins": true,
+	},
+	"privileged": {
+		"system:admin": true,
+		"system:serviceaccount:openshift-infra:build-controller": true,
+		"system:cluster-admins":                                  true,
+		"system:nodes":                                           true,
+		"system:masters":                                         true,
+	},
+}
+
+// broadlyGrantableSCCs are the SCCs meant to be available to every user.
+var broadlyGrantableSCCs = map[string]bool{
+	"restricted":    true,
+	"restricted-v2": true,
+}
+
+// checkSCCs inspects SecurityContextConstraints for permissive custom SCCs,
+// for grants of the built-in anyuid and privileged SCCs beyond the defaults,
+// and for SCCs granted to broad groups such as system:authenticated.
+func (v *SecurityValidator) checkSCCs(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	var findings []assessmentv1alpha1.Finding
+
+	sccList := &unstructured.UnstructuredList{}
+	sccList.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "security.openshift.io",
+		Version: "v1",
+		Kind:    "SecurityContextConstraintsList",
+	})
+	if err := c.List(ctx, sccList); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "security-scc-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check SecurityContextConstraints",
+			Description: fmt.Sprintf("Failed to list SecurityContextConstraints: %v", err),
+		}}
+	}
+
+	var customSCCs []string
+	var permissiveCustomSCCs []string
+	var extendedBuiltinGrants []string
+	var broadGrants []string
+	var broadGrantEvidence string
+
+	for _, scc := range sccList.Items {
+		name := scc.GetName()
+		users, _, _ := unstructured.NestedStringSlice(scc.Object, "users")
+		groups, _, _ := unstructured.NestedStringSlice(scc.Object, "groups")
+		subjects := append(users, groups...)
+
+		if !broadlyGrantableSCCs[name] {
+			for _, group := range groups {
+				if broadGroups[group] {
+					broadGrants = append(broadGrants, fmt.Sprintf("%s (%s)", name, group))
+					if len(broadGrants) == 1 {
+						broadGrantEvidence = validator.Evidence(ctx, &scc)
+					}
+				}
+			}
+		}
+
+		if defaults, ok := defaultSCCSubjects[name]; ok {
+			for _, subject := range subjects {
+				// Broad groups are reported separately as a failure
+				if !defaults[subject] && !broadGroups[subject] {
+					extendedBuiltinGrants = append(extendedBuiltinGrants, fmt.Sprintf("%s (%s)", name, subject))
+				}
+			}
+			continue
+		}
+
+		if builtinSCCs[name] {
+			continue
+		}
+		customSCCs = append(customSCCs, name)
+
+		privileged, _, _ := unstructured.NestedBool(scc.Object, "allowPrivilegedContainer")
+		runAsUser, _, _ := unstructured.NestedString(scc.Object, "runAsUser", "type")
+		if !privileged && runAsUser != "RunAsAny" {
+			continue
+		}
+
+		var nonSystem []string
+		for _, subject := range subjects {
+			if !isSystemSCCSubject(subject) {
+				nonSystem = append(nonSystem, subject)
+			}
+		}
+		if len(nonSystem) > 0 {
+			permissiveCustomSCCs = append(permissiveCustomSCCs,
+				fmt.Sprintf("%s (%s)", name, strings.Join(nonSystem, ", ")))
+		}
+	}
+
+	if len(customSCCs) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "security-scc-custom",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Custom SecurityContextConstraints",
+			Description: fmt.Sprintf("Found %d custom SCC(s): %s", len(customSCCs), strings.Join(customSCCs, ", ")),
+		})
+	}
+
+	if len(permissiveCustomSCCs) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "security-scc-custom-permissive",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Permissive Custom SCCs Granted to Non-System Subjects",
+			Description:    fmt.Sprintf("Found %d custom SCC(s) allowing privileged containers or RunAsAny granted to non-system users or service accounts: %s", len(permissiveCustomSCCs), strings.Join(permissiveCustomSCCs, "; ")),
+			Impact:         "Permissive SCCs let workloads run privileged or as any UID, including root, bypassing the restricted-v2 defaults.",
+			Recommendation: "Restrict custom SCCs to the minimum required capabilities and grant them to specific service accounts through RBAC rather than the SCC users/groups fields.",
+			References: []string{
+				"https://docs.openshift.com/container-platform/latest/authentication/managing-security-context-constraints.html",
+			},
+		})
+	}
+
+	if len(extendedBuiltinGrants) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "security-scc-builtin-extended",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Built-in Privileged SCCs Granted Beyond Defaults",
+			Description:    fmt.Sprintf("The anyuid/privileged SCCs are granted to %d additional subject(s): %s", len(extendedBuiltinGrants), strings.Join(extendedBuiltinGrants, ", ")),
+			Impact:         "Subjects granted anyuid or privileged can run containers as root or with full host access.",
+			Recommendation: "Review these grants and prefer a narrowly scoped custom SCC bound to specific service accounts.",
+			References: []string{
+				"https://docs.openshift.com/container-platform/latest/authentication/managing-security-context-constraints.html",
+			},
+		})
+	}
+
+	if len(broadGrants) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "security-scc-broad-grant",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusFail,
+			Title:          "SCCs Granted to Broad Groups",
+			Description:    fmt.Sprintf("Found %d SCC grant(s) to groups that include every user or service account: %s", len(broadGrants), strings.Join(broadGrants, ", ")),
+			Impact:         "Granting an SCC to system:authenticated or system:serviceaccounts makes it available cluster-wide. With anyuid, privileged or hostaccess, any user or workload can run as root or access the host.",
+			Recommendation: "Remove the broad groups from the SCC groups field and grant the SCC to specific service accounts with a Role that allows the 'use' verb on it.",
+			References: []string{
+				"https://docs.openshift.com/container-platform/latest/authentication/managing-security-context-constraints.html",
+			},
+			Evidence: broadGrantEvidence,
+		})
+	}
+
+	if len(permissiveCustomSCCs) == 0 && len(extendedBuiltinGrants) == 0 && len(broadGrants) == 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "security-scc-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "SecurityContextConstraints Follow Defaults",
+			Description: "No permissive custom SCCs, extended anyuid/privileged grants or SCCs granted to broad groups found.",
+		})
+	}
+
+	return findings
+}
+
+// sccAnnotation is set by SCC admission to the SCC a pod was admitted under.
+const sccAnnotation = "openshift.io/scc"
+
+// rootCapableSCCs are the built-in SCCs that let containers run as UID 0.
+var rootCapableSCCs = map[string]bool{
+	"anyuid":     true,
+	"privileged": true,
+}
+
+// checkRootUnderPermissiveSCC finds running pods in user namespaces that were
+// admitted under anyuid or privileged and run containers as root. Containers
+// without a runAsUser run as the image's USER, which cannot be read from the
+// pod, so they are reported separately.
+func (v *SecurityValidator) checkRootUnderPermissiveSCC(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	pods, err := validator.ListPods(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "security-scc-root-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Pods Running as Root",
+			Description: fmt.Sprintf("Failed to list pods: %v", err),
+		}}
+	}
+
+	var rootPods, imageUserPods []string
+	for _, pod := range pods {
+		if systemNamespaces[pod.Namespace] || strings.HasPrefix(pod.Namespace, "openshift-") || strings.HasPrefix(pod.Namespace, "kube-") || !validator.Selected(ctx, &pod) {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		scc := pod.Annotations[sccAnnotation]
+		if !rootCapableSCCs[scc] {
+			continue
+		}
+
+		var root, imageUser []string
+		for _, container := range podContainers(&pod.Spec) {
+			uid, nonRoot := effectiveRunAsUser(&pod, &container)
+			switch {
+			case uid != nil && *uid == 0:
+				root = append(root, container.Name)
+			case uid == nil && !nonRoot:
+				imageUser = append(imageUser, container.Name)
+			}
+		}
+		if len(root) > 0 {
+			rootPods = append(rootPods, fmt.Sprintf("%s/%s (%s, %s)", pod.Namespace, pod.Name, scc, strings.Join(root, ", ")))
+		}
+		if len(imageUser) > 0 {
+			imageUserPods = append(imageUserPods, fmt.Sprintf("%s/%s (%s, %s)", pod.Namespace, pod.Name, scc, strings.Join(imageUser, ", ")))
+		}
+	}
+
+	var findings []assessmentv1alpha1.Finding
+	if len(rootPods) > 0 {
+		sample := validator.SampleOf(rootPods)
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "security-scc-root-pods",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Pods Running as Root Under anyuid/privileged",
+			Description:    fmt.Sprintf("Found %d pod(s) in user namespaces admitted under anyuid or privileged with containers running as UID 0: %s", len(rootPods), strings.Join(sample, "; ")),
+			Impact:         "Containers running as root have full control of their filesystem and, combined with a container escape, of the node.",
+			Recommendation: "Rebuild the images to run as a non-root user and move the workloads to restricted-v2 or nonroot-v2.",
+			References: []string{
+				"https://docs.openshift.com/container-platform/latest/authentication/managing-security-context-constraints.html",
+			},
+		})
+	}
+
+	if len(imageUserPods) > 0 {
+		sample := validator.SampleOf(imageUserPods)
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "security-scc-image-user-pods",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusInfo,
+			Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
+			Title:          "Pods Under anyuid/privileged Using the Image User",
+			Description:    fmt.Sprintf("Found %d pod(s) in user namespaces admitted under anyuid or privileged with containers that set neither runAsUser nor runAsNonRoot, so they run as the image's USER, which may be root: %s", len(imageUserPods), strings.Join(sample, "; ")),
+			Recommendation: "Check the USER of these images. Set runAsNonRoot: true, or an explicit non-zero runAsUser, to make sure they do not run as root.",
+		})
+	}
+
+	if len(findings) == 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "security-scc-root-pods-none",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "No Pods Running as Root Under anyuid/privileged",
+			Description: "No running pods in user namespaces admitted under anyuid or privileged run containers as root.",
+		})
+	}
+
+	return findings
+}
+
+// podContainers returns the init and regular containers of a pod in a new
+// slice, leaving the spec's slices untouched.
+func podContainers(spec *corev1.PodSpec) []corev1.Container {
+	containers := make([]corev1.Container, len(spec.InitContainers)+len(spec.Containers))
+	n := copy(containers, spec.InitContainers)
+	copy(containers[n:], spec.Containers)
+	return containers
+}
+
+// effectiveRunAsUser returns the UID a container runs as, if set, and whether
+// it must run as non-root. Container settings override the pod's.
+func effectiveRunAsUser(pod *corev1.Pod, container *corev1.Container) (*int64, bool) {
+	var uid *int64
+	var nonRoot bool
+	if psc := pod.Spec.SecurityContext; psc != nil {
+		uid = psc.RunAsUser
+		nonRoot = psc.RunAsNonRoot != nil && *psc.RunAsNonRoot
+	}
+	if csc := container.SecurityContext; csc != nil {
+		if csc.RunAsUser != nil {
+			uid = csc.RunAsUser
+		}
+		if csc.RunAsNonRoot != nil {
+			nonRoot = *csc.RunAsNonRoot
+		}
+	}
+	return uid, nonRoot
+}
+
+// checkSeccompProfiles finds active pods in user namespaces with containers
+// that run without the RuntimeDefault or a Localhost seccomp profile, either
+// because they set Unconfined or because neither the container nor the pod
+// sets a profile, which the container runtime treats as Unconfined.
+// Privileged containers are skipped, as seccomp does not apply to them and
+// they are reported by the privileged pods check. Findings are warnings with
+// the production profile and informational otherwise.
+func (v *SecurityValidator) checkSeccompProfiles(ctx context.Context, c client.Client, profile profiles.Profile) []assessmentv1alpha1.Finding {
+	pods, err := validator.ListPods(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "security-seccomp-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Seccomp Profiles",
+			Description: fmt.Sprintf("Failed to list pods: %v", err),
+		}}
+	}
+
+	var unconfinedPods, unsetPods []string
+	total := 0
+	for _, pod := range pods {
+		if systemNamespaces[pod.Namespace] || strings.HasPrefix(pod.Namespace, "openshift-") || strings.HasPrefix(pod.Namespace, "kube-") || !validator.Selected(ctx, &pod) {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		total++
+
+		var unconfined, unset []string
+		for _, container := range podContainers(&pod.Spec) {
+			if csc := container.SecurityContext; csc != nil && csc.Privileged != nil && *csc.Privileged {
+				continue
+			}
+			switch seccomp := effectiveSeccompProfile(&pod, &container); {
+			case seccomp == nil:
+				unset = append(unset, container.Name)
+			case seccomp.Type == corev1.SeccompProfileTypeUnconfined:
+				unconfined = append(unconfined, container.Name)
+			}
+		}
+		if len(unconfined) > 0 {
+			unconfinedPods = append(unconfinedPods, fmt.Sprintf("%s/%s (%s)", pod.Namespace, pod.Name, strings.Join(unconfined, ", ")))
+		}
+		if len(unset) > 0 {
+			unsetPods = append(unsetPods, fmt.Sprintf("%s/%s (%s)", pod.Namespace, pod.Name, strings.Join(unset, ", ")))
+		}
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	status := assessmentv1alpha1.FindingStatusInfo
+	if profile.Name == profiles.ProfileProduction {
+		status = assessmentv1alpha1.FindingStatusWarn
+	}
+	references := []string{
+		"https://kubernetes.io/docs/tutorials/security/seccomp/",
+		"https://docs.openshift.com/container-platform/latest/security/seccomp-profiles.html",
+	}
+
+	var findings []assessmentv1alpha1.Finding
+	if len(unconfinedPods) > 0 {
+		sample := validator.SampleOf(unconfinedPods)
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "security-seccomp-unconfined",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         status,
+			Title:          "Pods With Unconfined Seccomp Profiles",
+			Description:    fmt.Sprintf("%d of %d active pod(s) in user namespaces run containers with seccompProfile type Unconfined: %s", len(unconfinedPods), total, strings.Join(sample, "; ")),
+			Impact:         "Unconfined containers can make any system call, including the rarely needed ones behind most kernel privilege escalations, so a compromised container has a much larger attack surface on the node.",
+			Recommendation: "Set seccompProfile type RuntimeDefault, or a Localhost profile allowing the system calls the workload needs, and remove Unconfined from the pod and container security contexts.",
+			References:     references,
+		})
+	}
+	if len(unsetPods) > 0 {
+		sample := validator.SampleOf(unsetPods)
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "security-seccomp-unset",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         status,
+			Title:          "Pods Without a Seccomp Profile",
+			Description:    fmt.Sprintf("%d of %d active pod(s) in user namespaces run containers without a seccomp profile, which the container runtime treats as Unconfined: %s", len(unsetPods), total, strings.Join(sample, "; ")),
+			Impact:         "Without a seccomp profile, containers can make any system call. The restricted-v2 SCC sets RuntimeDefault on admission, so these pods were usually admitted under a more permissive SCC.",
+			Recommendation: "Set seccompProfile type RuntimeDefault in the pod security context, and move the workloads to restricted-v2 or nonroot-v2 where possible.",
+			References:     references,
+		})
+	}
+
+	if len(findings) == 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "security-seccomp-runtime-default",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Pods Use Seccomp Profiles",
+			Description: fmt.Sprintf("All %d active pod(s) in user namespaces run their unprivileged containers with the RuntimeDefault or a Localhost seccomp profile.", total),
+		})
+	}
+
+	return findings
+}
+
+// effectiveSeccompProfile returns the seccomp profile a container runs with,
+// or nil if neither the container nor the pod sets one. Container settings
+// override the pod's.
+func effectiveSeccompProfile(pod *corev1.Pod, container *corev1.Container) *corev1.SeccompProfile {
+	if csc := container.SecurityContext; csc != nil && csc.SeccompProfile != nil {
+		return csc.SeccompProfile
+	}
+	if psc := pod.Spec.SecurityContext; psc != nil {
+		return psc.SeccompProfile
+	}
+	return nil
+}
+
+// isSystemSCCSubject reports whether an SCC user or group belongs to the platform.
+func isSystemSCCSubject(subject string) bool {
+	if strings.HasPrefix(subject, "system:serviceaccount:") {
+		parts := strings.Split(subject, ":")
+		if len(parts) != 4 {
+			return false
+		}
+		ns := parts[2]
+		return systemNamespaces[ns] || strings.HasPrefix(ns, "openshift-") || strings.HasPrefix(ns, "kube-")
+	}
+	return strings.HasPrefix(subject, "system:")
+}
+
+// dockerAuthEntry is a single registry entry of a docker config secret.
+// Only used to check for presence of credentials; values are never logged.
+type dockerAuthEntry struct {
+	Auth     string `json:"auth"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// parseDockerConfigSecret returns the registry entries of a dockercfg or
+// dockerconfigjson secret.
+func parseDockerConfigSecret(secret *corev1.Secret) (map[string]dockerAuthEntry, error) {
+	switch secret.Type {
+	case corev1.SecretTypeDockerConfigJson:
+		var config struct {
+			Auths map[string]dockerAuthEntry `json:"auths"`
+		}
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &config); err != nil {
+			return nil, err
+		}
+		return config.Auths, nil
+	case corev1.SecretTypeDockercfg:
+		var auths map[string]dockerAuthEntry
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigKey], &auths); err != nil {
+			return nil, err
+		}
+		return auths, nil
+	}
+	return nil, fmt.Errorf("unsupported secret type %q", secret.Type)
+}
+
+// internalRegistry is the in-cluster image registry service, which is always
+// allowed by checkAllowedRegistries.
+const internalRegistry = "image-registry.openshift-image-registry.svc:5000"
+
+// checkAllowedRegistries reports pods in user namespaces whose images do not
+// come from the allowed registries of the expected state.
+func (v *SecurityValidator) checkAllowedRegistries(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	allowed := validator.ExpectedStateFromContext(ctx).AllowedRegistries
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	pods, err := validator.ListPods(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "security-image-registry-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Image Registries",
+			Description: fmt.Sprintf("Failed to list pods: %v", err),
+		}}
+	}
+
+	var disallowed []string
+	var evidence string
+	for i := range pods {
+		pod := &pods[i]
+		if systemNamespaces[pod.Namespace] || strings.HasPrefix(pod.Namespace, "openshift-") || strings.HasPrefix(pod.Namespace, "kube-") || !validator.Selected(ctx, pod) {
+			continue
+		}
+		var images []string
+		for _, container := range podContainers(&pod.Spec) {
+			if !imageAllowed(container.Image, allowed) {
+				images = append(images, container.Image)
+			}
+		}
+		if len(images) == 0 {
+			continue
+		}
+		disallowed = append(disallowed, fmt.Sprintf("%s/%s (%s)", pod.Namespace, pod.Name, strings.Join(unique(images), ", ")))
+		if evidence == "" {
+			evidence = validator.Evidence(ctx, pod)
+		}
+	}
+
+	if len(disallowed) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "security-image-registries-allowed",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Images From Allowed Registries",
+			Description: fmt.Sprintf("All pods in user namespaces use images from the allowed registries: %s", strings.Join(allowed, ", ")),
+		}}
+	}
+
+	sort.Strings(disallowed)
+	sample := validator.SampleOf(disallowed)
+	return []assessmentv1alpha1.Finding{{
+		ID:             "security-image-registry-disallowed",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Title:          "Images From Registries Outside the Allowed List",
+		Description:    fmt.Sprintf("Found %d pod(s) in user namespaces running images from registries that are not allowed: %s", len(disallowed), strings.Join(sample, "; ")),
+		Impact:         "Images from unvetted registries bypass the scanning and signing controls applied to the approved ones.",
+		Recommendation: "Mirror the images into an allowed registry, or add the registry to allowedRegistries in the expected state if it is approved. Enforce the list with the cluster image configuration's allowedRegistries policy.",
+		References: []string{
+			"https://docs.openshift.com/container-platform/latest/openshift_images/image-configuration.html",
+		},
+		Evidence: evidence,
+	}}
+}
+
+// imageAllowed reports whether an image reference belongs to one of the
+// allowed registries or repository prefixes. References without a registry
+// host are resolved against docker.io, as the container runtime does.
+func imageAllowed(image string, allowed []string) bool {
+	first, _, found := strings.Cut(image, "/")
+	if !found || !strings.ContainsAny(first, ".:") && first != "localhost" {
+		image = "docker.io/" + image
+	}
+	if strings.HasPrefix(image, internalRegistry+"/") {
+		return true
+	}
+	for _, entry := range allowed {
+		entry = strings.TrimSuffix(entry, "/")
+		if image == entry || strings.HasPrefix(image, entry+"/") || strings.HasPrefix(image, entry+":") || strings.HasPrefix(image, entry+"@") {
+			return true
+		}
+	}
+	return false
+}
+
+// checkImagePullSecrets validates the structure of image pull secrets referenced
+// by service accounts in user namespaces. It never probes registries and never
+// reports credential values.
+func (v *SecurityValidator) checkImagePullSecrets(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	var findings []assessmentv1alpha1.Finding
+
+	serviceAccounts := &corev1.ServiceAccountList{}
+	if err := c.List(ctx, serviceAccounts); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "security-pull-secret-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Image Pull Secrets",
+			Description: fmt.Sprintf("Failed to list ServiceAccounts: %v", err),
+		}}
+	}
+
+	referenced := make(map[client.ObjectKey]bool)
+	for _, sa := range serviceAccounts.Items {
+		if systemNamespaces[sa.Namespace] || strings.HasPrefix(sa.Namespace, "openshift-") || strings.HasPrefix(sa.Namespace, "kube-") {
+			continue
+		}
+		for _, ref := range sa.ImagePullSecrets {
+			referenced[client.ObjectKey{Namespace: sa.Namespace, Name: ref.Name}] = true
+		}
+	}
+
+	if len(referenced) == 0 {
+		return findings
+	}
+
+	var malformed []string
+	var emptyAuth []string
+	var unreadable []string
+	registries := make(map[string]bool)
+
+	for key := range referenced {
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, key, secret); err != nil {
+			// References to missing secrets are common and harmless
+			if !errors.IsNotFound(err) {
+				unreadable = append(unreadable, key.String())
+			}
+			continue
+		}
+		if secret.Type != corev1.SecretTypeDockerConfigJson && secret.Type != corev1.SecretTypeDockercfg {
+			continue
+		}
+
+		auths, err := parseDockerConfigSecret(secret)
+		if err != nil {
+			malformed = append(malformed, key.String())
+			continue
+		}
+		if len(auths) == 0 {
+			emptyAuth = append(emptyAuth, key.String())
+			continue
+		}
+		for registry, entry := range auths {
+			registries[registry] = true
+			if entry.Auth == "" && (entry.Username == "" || entry.Password == "") {
+				emptyAuth = append(emptyAuth, fmt.Sprintf("%s (%s)", key.String(), registry))
+			}
+		}
+	}
+
+	sort.Strings(malformed)
+	sort.Strings(emptyAuth)
+	sort.Strings(unreadable)
+
+	if len(unreadable) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "security-pull-secret-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Image Pull Secrets",
+			Description: fmt.Sprintf("Failed to read %d image pull secret(s): %s", len(unreadable), strings.Join(unreadable, ", ")),
+		})
+	}
+
+	if len(malformed) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "security-pull-secret-malformed",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Malformed Image Pull Secrets",
+			Description:    fmt.Sprintf("Found %d image pull secret(s) referenced by service accounts that are not valid docker config JSON: %s", len(malformed), strings.Join(malformed, ", ")),
+			Impact:         "Pods using these service accounts will fail to pull images from private registries.",
+			Recommendation: "Recreate the secrets with 'oc create secret docker-registry' or fix the JSON payload.",
+		})
+	}
+
+	if len(emptyAuth) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "security-pull-secret-empty-auth",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Image Pull Secrets Without Credentials",
+			Description:    fmt.Sprintf("Found %d image pull secret entry(ies) without credentials: %s", len(emptyAuth), strings.Join(emptyAuth, ", ")),
+			Impact:         "Registry entries without credentials cannot authenticate and may cause image pull failures.",
+			Recommendation: "Populate the registry credentials or remove unused entries and references.",
+		})
+	}
+
+	if len(registries) > 0 {
+		hosts := make([]string, 0, len(registries))
+		for host := range registries {
+			hosts = append(hosts, host)
+		}
+		sort.Strings(hosts)
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "security-pull-secret-registries",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Registries Referenced by Image Pull Secrets",
+			Description: fmt.Sprintf("Service accounts in user namespaces reference credentials for %d registry(ies): %s", len(hosts), strings.Join(hosts, ", ")),
+		})
+	}
+
+	return findings
+}
+
+// ruleHasWildcard reports whether a policy rule grants every verb on every resource.
+func ruleHasWildcard(rule rbacv1.PolicyRule) bool {
+	return slices.Contains(rule.Verbs, "*") && slices.Contains(rule.Resources, "*")
+}
+
+// ruleReadsSecrets reports whether a policy rule allows reading secrets.
+func ruleReadsSecrets(rule rbacv1.PolicyRule) bool {
+	if !slices.Contains(rule.Resources, "secrets") && !slices.Contains(rule.Resources, "*") {
+		return false
+	}
+	for _, verb := range rule.Verbs {
+		if verb == "get" || verb == "list" || verb == "watch" || verb == "*" {
+			return true
+		}
+	}
+	return false
+}
+
 // unique removes duplicates from a string slice.
 func unique(slice []string) []string {
 	seen := make(map[string]bool)
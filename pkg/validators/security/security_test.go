@@ -0,0 +1,569 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package security
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator/validatortest"
+)
+
+func newSCC(name string, privileged bool, runAsUser string, users, groups []string) *unstructured.Unstructured {
+	scc := &unstructured.Unstructured{Object: map[string]interface{}{
+		"allowPrivilegedContainer": privileged,
+		"runAsUser":                map[string]interface{}{"type": runAsUser},
+	}}
+	scc.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "security.openshift.io",
+		Version: "v1",
+		Kind:    "SecurityContextConstraints",
+	})
+	scc.SetName(name)
+	_ = unstructured.SetNestedStringSlice(scc.Object, users, "users")
+	_ = unstructured.SetNestedStringSlice(scc.Object, groups, "groups")
+	return scc
+}
+
+func TestCheckSCCs(t *testing.T) {
+	objs := []client.Object{
+		newSCC("privileged", true, "RunAsAny", []string{"system:admin", "alice"}, []string{"system:cluster-admins"}),
+		newSCC("anyuid", false, "RunAsAny", nil, []string{"system:cluster-admins"}),
+		newSCC("restricted-v2", false, "MustRunAsRange", nil, nil),
+		newSCC("my-root-scc", false, "RunAsAny", []string{"system:serviceaccount:team-a:builder"}, nil),
+		newSCC("platform-scc", true, "RunAsAny", []string{"system:serviceaccount:openshift-monitoring:agent"}, nil),
+	}
+
+	c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).WithObjects(objs...).Build()
+	v := &SecurityValidator{}
+
+	findings := v.checkSCCs(context.Background(), c)
+
+	if f := validatortest.FindingByID(findings, "security-scc-custom"); f == nil {
+		t.Error("Expected security-scc-custom finding")
+	}
+	if f := validatortest.FindingByID(findings, "security-scc-custom-permissive"); f == nil {
+		t.Error("Expected security-scc-custom-permissive finding for my-root-scc")
+	} else if f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Errorf("Expected WARN, got %s", f.Status)
+	}
+	if f := validatortest.FindingByID(findings, "security-scc-builtin-extended"); f == nil {
+		t.Error("Expected security-scc-builtin-extended finding for alice on privileged")
+	}
+	if f := validatortest.FindingByID(findings, "security-scc-ok"); f != nil {
+		t.Error("Did not expect security-scc-ok finding")
+	}
+}
+
+func TestCheckSCCs_BroadGroups(t *testing.T) {
+	objs := []client.Object{
+		newSCC("anyuid", false, "RunAsAny", nil, []string{"system:cluster-admins", "system:authenticated"}),
+		newSCC("hostaccess", false, "MustRunAsRange", nil, []string{"system:serviceaccounts"}),
+		newSCC("restricted", false, "MustRunAsRange", nil, []string{"system:authenticated"}),
+	}
+
+	c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).WithObjects(objs...).Build()
+	v := &SecurityValidator{}
+
+	findings := v.checkSCCs(context.Background(), c)
+
+	f := validatortest.FindingByID(findings, "security-scc-broad-grant")
+	if f == nil {
+		t.Fatal("Expected security-scc-broad-grant finding")
+	}
+	if f.Status != assessmentv1alpha1.FindingStatusFail {
+		t.Errorf("Expected FAIL, got %s", f.Status)
+	}
+	for _, want := range []string{"anyuid (system:authenticated)", "hostaccess (system:serviceaccounts)"} {
+		if !strings.Contains(f.Description, want) {
+			t.Errorf("Expected description to contain %q, got %q", want, f.Description)
+		}
+	}
+	if strings.Contains(f.Description, "restricted") {
+		t.Errorf("Did not expect restricted in %q", f.Description)
+	}
+	if f := validatortest.FindingByID(findings, "security-scc-builtin-extended"); f != nil {
+		t.Errorf("Did not expect broad groups to be reported again: %q", f.Description)
+	}
+	if f := validatortest.FindingByID(findings, "security-scc-ok"); f != nil {
+		t.Error("Did not expect security-scc-ok finding")
+	}
+}
+
+func TestIsSystemSCCSubject(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    bool
+	}{
+		{"system:admin", true},
+		{"system:serviceaccount:openshift-infra:build-controller", true},
+		{"system:serviceaccount:kube-system:default", true},
+		{"system:serviceaccount:team-a:builder", false},
+		{"alice", false},
+		{"developers", false},
+	}
+
+	for _, tt := range tests {
+		if got := isSystemSCCSubject(tt.subject); got != tt.want {
+			t.Errorf("isSystemSCCSubject(%q) = %v, want %v", tt.subject, got, tt.want)
+		}
+	}
+}
+
+func TestCheckImagePullSecrets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	objs := []client.Object{
+		&corev1.ServiceAccount{
+			ObjectMeta:       metav1.ObjectMeta{Name: "builder", Namespace: "team-a"},
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "good"}, {Name: "broken"}, {Name: "empty"}},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "good", Namespace: "team-a"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{"quay.io":{"auth":"c2VjcmV0"}}}`)},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "broken", Namespace: "team-a"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{not-json`)},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "team-a"},
+			Type:       corev1.SecretTypeDockercfg,
+			Data:       map[string][]byte{corev1.DockerConfigKey: []byte(`{"registry.example.com":{"auth":""}}`)},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	v := &SecurityValidator{}
+
+	findings := v.checkImagePullSecrets(context.Background(), c)
+
+	if f := validatortest.FindingByID(findings, "security-pull-secret-malformed"); f == nil {
+		t.Error("Expected security-pull-secret-malformed finding")
+	} else if strings.Contains(f.Description, "not-json") {
+		t.Error("Finding description must not include secret contents")
+	}
+	if f := validatortest.FindingByID(findings, "security-pull-secret-empty-auth"); f == nil {
+		t.Error("Expected security-pull-secret-empty-auth finding")
+	}
+	if f := validatortest.FindingByID(findings, "security-pull-secret-registries"); f == nil {
+		t.Error("Expected security-pull-secret-registries finding")
+	} else if strings.Contains(f.Description, "c2VjcmV0") {
+		t.Error("Finding description must not include credentials")
+	}
+}
+
+func TestCheckImagePullSecrets_ListError(t *testing.T) {
+	// ServiceAccounts are not registered in the scheme, so listing fails
+	c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	v := &SecurityValidator{}
+
+	findings := v.checkImagePullSecrets(context.Background(), c)
+
+	f := validatortest.FindingByID(findings, "security-pull-secret-error")
+	if f == nil || f.Status != assessmentv1alpha1.FindingStatusInfo {
+		t.Errorf("Expected INFO security-pull-secret-error finding, got %+v", findings)
+	}
+}
+
+func TestCheckHostPathVolumes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	hostPathPod := func(ns, name, hostPath string, owners ...metav1.OwnerReference) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns, OwnerReferences: owners},
+			Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+				Name:         "host",
+				VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: hostPath}},
+			}}},
+		}
+	}
+
+	objs := []client.Object{
+		hostPathPod("team-a", "logs", "/var/log/app"),
+		hostPathPod("team-a", "docker", "/var/run/docker.sock"),
+		hostPathPod("monitoring", "exporter", "/", metav1.OwnerReference{Kind: "DaemonSet", Name: "node-exporter"}),
+		hostPathPod("team-a", "impostor", "/", metav1.OwnerReference{Kind: "DaemonSet", Name: "node-exporter"}),
+		hostPathPod("team-b", "agent", "/", metav1.OwnerReference{Kind: "DaemonSet", Name: "agent"}),
+		hostPathPod("openshift-sdn", "sdn", "/"),
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	v := &SecurityValidator{}
+
+	ctx := validator.WithConfig(context.Background(), map[string]string{configHostPathExemptDaemonSets: "team-b/agent"})
+	findings := v.checkHostPathVolumes(ctx, c)
+
+	if f := validatortest.FindingByID(findings, "security-hostpath-sensitive"); f == nil {
+		t.Error("Expected security-hostpath-sensitive finding")
+	} else if f.Status != assessmentv1alpha1.FindingStatusFail || !strings.Contains(f.Description, "team-a/docker") {
+		t.Errorf("Unexpected sensitive finding: %s %q", f.Status, f.Description)
+	} else if strings.Contains(f.Description, "exporter") || strings.Contains(f.Description, "agent") || strings.Contains(f.Description, "sdn") {
+		t.Errorf("Known agents, configured exemptions and system namespaces should be excluded: %q", f.Description)
+	} else if !strings.Contains(f.Description, "team-a/impostor") {
+		t.Errorf("A user DaemonSet reusing an agent name should not be exempted: %q", f.Description)
+	}
+	if f := validatortest.FindingByID(findings, "security-hostpath"); f == nil {
+		t.Error("Expected security-hostpath finding")
+	} else if f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Errorf("Expected WARN, got %s", f.Status)
+	}
+}
+
+func TestCheckNamespaceAdminBindings(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = rbacv1.AddToScheme(scheme)
+
+	group := func(name string) rbacv1.Subject {
+		return rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: name}
+	}
+	roleBinding := func(ns, name, role string, subjects ...rbacv1.Subject) *rbacv1.RoleBinding {
+		return &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: role},
+			Subjects:   subjects,
+		}
+	}
+
+	objs := []client.Object{
+		roleBinding("team-a", "everyone-edits", "edit", group("system:authenticated")),
+		roleBinding("team-a", "team-admins", "admin", group("team-a-admins")),
+		roleBinding("team-b", "everyone-views", "view", group("system:authenticated")),
+		roleBinding("openshift-config", "system-edit", "edit", group("system:serviceaccounts")),
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "sa-admin"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "admin"},
+			Subjects:   []rbacv1.Subject{group("system:serviceaccounts")},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	findings := (&SecurityValidator{}).checkNamespaceAdminBindings(context.Background(), c)
+	f := validatortest.FindingByID(findings, "security-broad-bindings")
+	if f == nil {
+		t.Fatalf("expected security-broad-bindings finding, got %+v", findings)
+	}
+	if f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Errorf("expected WARN, got %s", f.Status)
+	}
+	for _, want := range []string{
+		"RoleBinding team-a/everyone-edits (edit to system:authenticated)",
+		"ClusterRoleBinding sa-admin (admin to system:serviceaccounts, all namespaces)",
+	} {
+		if !strings.Contains(f.Description, want) {
+			t.Errorf("expected %q in description: %s", want, f.Description)
+		}
+	}
+	for _, unwanted := range []string{"team-admins", "everyone-views", "system-edit"} {
+		if strings.Contains(f.Description, unwanted) {
+			t.Errorf("did not expect %q in description: %s", unwanted, f.Description)
+		}
+	}
+}
+
+func TestCheckNamespaceAdminBindings_None(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = rbacv1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	findings := (&SecurityValidator{}).checkNamespaceAdminBindings(context.Background(), c)
+	if validatortest.FindingByID(findings, "security-no-broad-bindings") == nil {
+		t.Errorf("expected PASS finding, got %+v", findings)
+	}
+}
+
+func TestCheckClusterAdminBindings_ExpectedAdmins(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = rbacv1.AddToScheme(scheme)
+
+	clusterAdmin := func(name string, subjects ...rbacv1.Subject) *rbacv1.ClusterRoleBinding {
+		return &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin"},
+			Subjects:   subjects,
+		}
+	}
+	objs := []client.Object{
+		clusterAdmin("platform-admins", rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "platform-admins"}),
+		clusterAdmin("gitops", rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: "gitops", Name: "argocd"}),
+		clusterAdmin("bob-admin", rbacv1.Subject{Kind: rbacv1.UserKind, Name: "bob"}),
+		clusterAdmin("system-masters", rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "system:masters"}),
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	profile := profiles.GetProfile("production")
+	profile.ExpectedClusterAdmins = []string{"Group:platform-admins", "ServiceAccount:gitops/argocd", "User:alice"}
+	findings := (&SecurityValidator{}).checkClusterAdminBindings(context.Background(), c, profile)
+
+	unexpected := validatortest.FindingByID(findings, "security-cluster-admin-unexpected")
+	if unexpected == nil || unexpected.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("expected security-cluster-admin-unexpected WARN, got %+v", findings)
+	}
+	if !strings.Contains(unexpected.Description, "User:bob (bob-admin)") || strings.Contains(unexpected.Description, "platform-admins") {
+		t.Errorf("unexpected description: %s", unexpected.Description)
+	}
+
+	missing := validatortest.FindingByID(findings, "security-cluster-admin-missing")
+	if missing == nil || !strings.HasSuffix(missing.Description, ": User:alice") {
+		t.Errorf("expected User:alice to be reported missing, got %+v", missing)
+	}
+
+	if validatortest.FindingByID(findings, "security-cluster-admin-found") != nil {
+		t.Error("threshold findings should not be reported when ExpectedClusterAdmins is set")
+	}
+}
+
+func TestCheckClusterAdminBindings_ExpectedAdminsMatch(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = rbacv1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-admin"},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin"},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+	}).Build()
+
+	profile := profiles.GetProfile("production")
+	profile.ExpectedClusterAdmins = []string{"User:alice"}
+	findings := (&SecurityValidator{}).checkClusterAdminBindings(context.Background(), c, profile)
+	if validatortest.FindingByID(findings, "security-cluster-admin-expected") == nil {
+		t.Errorf("expected PASS finding, got %+v", findings)
+	}
+}
+
+func TestCheckRootUnderPermissiveSCC(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	uid := func(v int64) *int64 { return &v }
+	yes := true
+	sccPod := func(ns, name, scc string, podSC *corev1.PodSecurityContext, containerSC *corev1.SecurityContext) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns, Annotations: map[string]string{"openshift.io/scc": scc}},
+			Spec: corev1.PodSpec{
+				SecurityContext: podSC,
+				Containers:      []corev1.Container{{Name: "app", SecurityContext: containerSC}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+
+	objs := []client.Object{
+		sccPod("team-a", "root-pod", "anyuid", &corev1.PodSecurityContext{RunAsUser: uid(0)}, nil),
+		sccPod("team-a", "override", "anyuid", &corev1.PodSecurityContext{RunAsUser: uid(0)}, &corev1.SecurityContext{RunAsUser: uid(1000)}),
+		sccPod("team-a", "image-user", "privileged", nil, nil),
+		sccPod("team-a", "non-root", "anyuid", &corev1.PodSecurityContext{RunAsNonRoot: &yes}, nil),
+		sccPod("team-a", "restricted", "restricted-v2", nil, &corev1.SecurityContext{RunAsUser: uid(0)}),
+		sccPod("openshift-dns", "platform", "privileged", &corev1.PodSecurityContext{RunAsUser: uid(0)}, nil),
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	v := &SecurityValidator{}
+
+	findings := v.checkRootUnderPermissiveSCC(context.Background(), c)
+
+	root := validatortest.FindingByID(findings, "security-scc-root-pods")
+	if root == nil {
+		t.Fatal("Expected security-scc-root-pods finding")
+	}
+	if root.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Errorf("Expected WARN, got %s", root.Status)
+	}
+	if !strings.Contains(root.Description, "Found 1 pod(s)") || !strings.Contains(root.Description, "team-a/root-pod (anyuid, app)") {
+		t.Errorf("Unexpected description: %q", root.Description)
+	}
+
+	imageUser := validatortest.FindingByID(findings, "security-scc-image-user-pods")
+	if imageUser == nil {
+		t.Fatal("Expected security-scc-image-user-pods finding")
+	}
+	if !strings.Contains(imageUser.Description, "Found 1 pod(s)") || !strings.Contains(imageUser.Description, "team-a/image-user (privileged, app)") {
+		t.Errorf("Unexpected description: %q", imageUser.Description)
+	}
+}
+
+func TestCheckRiskyRoles(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = rbacv1.AddToScheme(scheme)
+
+	role := func(ns, name string, rules ...rbacv1.PolicyRule) *rbacv1.Role {
+		return &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}, Rules: rules}
+	}
+	wildcard := rbacv1.PolicyRule{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}
+	readSecrets := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get", "list"}}
+	readPods := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}
+
+	objs := []client.Object{
+		role("team-a", "do-anything", wildcard),
+		role("team-a", "secret-reader", readSecrets, readPods),
+		role("team-b", "pod-reader", readPods),
+		role("openshift-monitoring", "prometheus-k8s", wildcard),
+		role("kube-system", "extension-apiserver-authentication-reader", readSecrets),
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	findings := (&SecurityValidator{}).checkRiskyRoles(context.Background(), c)
+
+	f := validatortest.FindingByID(findings, "security-rbac-role-wildcard")
+	if f == nil {
+		t.Fatalf("expected security-rbac-role-wildcard finding, got %+v", findings)
+	}
+	if f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Errorf("expected WARN, got %s", f.Status)
+	}
+	if !strings.Contains(f.Description, "Found 1 Role(s)") || !strings.Contains(f.Description, "team-a/do-anything") {
+		t.Errorf("unexpected description: %s", f.Description)
+	}
+
+	f = validatortest.FindingByID(findings, "security-rbac-role-secrets")
+	if f == nil {
+		t.Fatalf("expected security-rbac-role-secrets finding, got %+v", findings)
+	}
+	if f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Errorf("expected WARN, got %s", f.Status)
+	}
+	for _, want := range []string{"Found 2 Role(s)", "team-a/do-anything", "team-a/secret-reader"} {
+		if !strings.Contains(f.Description, want) {
+			t.Errorf("expected %q in description: %s", want, f.Description)
+		}
+	}
+	for _, unwanted := range []string{"pod-reader", "prometheus-k8s", "extension-apiserver"} {
+		if strings.Contains(f.Description, unwanted) {
+			t.Errorf("did not expect %q in description: %s", unwanted, f.Description)
+		}
+	}
+}
+
+func TestCheckRiskyRoles_None(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = rbacv1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	findings := (&SecurityValidator{}).checkRiskyRoles(context.Background(), c)
+	if validatortest.FindingByID(findings, "security-rbac-roles-ok") == nil {
+		t.Errorf("expected PASS finding, got %+v", findings)
+	}
+}
+
+func TestCheckAllowedRegistries(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	pod := func(ns, name string, images ...string) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}}
+		for _, image := range images {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Name: name, Image: image})
+		}
+		return p
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		pod("shop", "cart", "quay.io/acme/cart:1.2"),
+		pod("shop", "cache", "redis:7", "registry.redhat.io/ubi9/ubi:latest"),
+		pod("shop", "build", "image-registry.openshift-image-registry.svc:5000/shop/build@sha256:abc"),
+		pod("shop", "other", "quay.io/other/tool:1"),
+		pod("openshift-monitoring", "prometheus", "example.com/prometheus:1"),
+	).Build()
+	v := &SecurityValidator{}
+
+	if findings := v.checkAllowedRegistries(context.Background(), c); findings != nil {
+		t.Fatalf("expected no findings without allowed registries, got %+v", findings)
+	}
+
+	ctx := validator.WithExpectedState(context.Background(), &validator.ExpectedState{
+		AllowedRegistries: []string{"registry.redhat.io", "quay.io/acme/"},
+	})
+	findings := v.checkAllowedRegistries(ctx, c)
+	f := validatortest.FindingByID(findings, "security-image-registry-disallowed")
+	if f == nil || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("expected a disallowed registry WARN, got %+v", findings)
+	}
+	for _, want := range []string{"Found 2 pod(s)", "shop/cache (redis:7)", "shop/other (quay.io/other/tool:1)"} {
+		if !strings.Contains(f.Description, want) {
+			t.Errorf("expected %q in description: %s", want, f.Description)
+		}
+	}
+	for _, unwanted := range []string{"cart", "build", "prometheus", "ubi"} {
+		if strings.Contains(f.Description, unwanted) {
+			t.Errorf("did not expect %q in description: %s", unwanted, f.Description)
+		}
+	}
+}
+
+func TestCheckSeccompProfiles(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	yes := true
+	seccomp := func(t corev1.SeccompProfileType) *corev1.SeccompProfile { return &corev1.SeccompProfile{Type: t} }
+	pod := func(ns, name string, podSC *corev1.PodSecurityContext, containerSC *corev1.SecurityContext) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+			Spec: corev1.PodSpec{
+				SecurityContext: podSC,
+				Containers:      []corev1.Container{{Name: "app", SecurityContext: containerSC}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		pod("team-a", "hardened", &corev1.PodSecurityContext{SeccompProfile: seccomp(corev1.SeccompProfileTypeRuntimeDefault)}, nil),
+		pod("team-a", "localhost", nil, &corev1.SecurityContext{SeccompProfile: seccomp(corev1.SeccompProfileTypeLocalhost)}),
+		pod("team-a", "unconfined", &corev1.PodSecurityContext{SeccompProfile: seccomp(corev1.SeccompProfileTypeRuntimeDefault)},
+			&corev1.SecurityContext{SeccompProfile: seccomp(corev1.SeccompProfileTypeUnconfined)}),
+		pod("team-a", "unset", nil, nil),
+		pod("team-a", "privileged", nil, &corev1.SecurityContext{Privileged: &yes}),
+		pod("openshift-dns", "platform", nil, nil),
+	).Build()
+	v := &SecurityValidator{}
+
+	findings := v.checkSeccompProfiles(context.Background(), c, profiles.GetProfile(string(profiles.ProfileProduction)))
+	unconfined := validatortest.FindingByID(findings, "security-seccomp-unconfined")
+	if unconfined == nil || unconfined.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("Expected security-seccomp-unconfined WARN, got %+v", findings)
+	}
+	if !strings.Contains(unconfined.Description, "1 of 5 active pod(s)") || !strings.Contains(unconfined.Description, "team-a/unconfined (app)") {
+		t.Errorf("Unexpected description: %q", unconfined.Description)
+	}
+	unset := validatortest.FindingByID(findings, "security-seccomp-unset")
+	if unset == nil || !strings.Contains(unset.Description, "team-a/unset (app)") {
+		t.Fatalf("Expected security-seccomp-unset finding for team-a/unset, got %+v", findings)
+	}
+	if strings.Contains(unset.Description, "privileged") || strings.Contains(unset.Description, "platform") {
+		t.Errorf("Did not expect privileged or platform pods: %q", unset.Description)
+	}
+
+	findings = v.checkSeccompProfiles(context.Background(), c, profiles.GetProfile(string(profiles.ProfileDevelopment)))
+	if f := validatortest.FindingByID(findings, "security-seccomp-unset"); f == nil || f.Status != assessmentv1alpha1.FindingStatusInfo {
+		t.Errorf("Expected INFO with the development profile, got %+v", findings)
+	}
+}
@@ -19,9 +19,14 @@ package storage
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
@@ -35,6 +40,14 @@ const (
 	validatorCategory    = "Storage"
 )
 
+const (
+	// configResizeStuckMinutes overrides how long a PVC expansion may stay
+	// in progress before it is reported as stuck.
+	configResizeStuckMinutes = "resizeStuckMinutes"
+
+	defaultResizeStuckMinutes = 60
+)
+
 // List of known supported CSI drivers
 var supportedCSIDrivers = map[string]bool{
 	"ebs.csi.aws.com":                       true,
@@ -55,6 +68,18 @@ var supportedCSIDrivers = map[string]bool{
 	"nfs.csi.k8s.io":                        true,
 }
 
+// Provisioners whose volumes are bound to a single zone, so binding them before
+// the consuming pod is scheduled can strand the pod in a zone without capacity.
+var zonalProvisioners = map[string]bool{
+	"ebs.csi.aws.com":          true,
+	"kubernetes.io/aws-ebs":    true,
+	"disk.csi.azure.com":       true,
+	"kubernetes.io/azure-disk": true,
+	"pd.csi.storage.gke.io":    true,
+	"kubernetes.io/gce-pd":     true,
+	"cinder.csi.openstack.org": true,
+}
+
 func init() {
 	_ = validator.Register(&StorageValidator{})
 }
@@ -77,6 +102,21 @@ func (v *StorageValidator) Category() string {
 	return validatorCategory
 }
 
+// ConfigKeys returns the override keys understood by this validator.
+func (v *StorageValidator) ConfigKeys() []string {
+	return []string{configResizeStuckMinutes}
+}
+
+// WatchedResources returns the kinds read by this validator.
+func (v *StorageValidator) WatchedResources() []schema.GroupVersionKind {
+	return []schema.GroupVersionKind{
+		storagev1.SchemeGroupVersion.WithKind("StorageClass"),
+		storagev1.SchemeGroupVersion.WithKind("CSIDriver"),
+		appsv1.SchemeGroupVersion.WithKind("StatefulSet"),
+		corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"),
+	}
+}
+
 // Validate performs storage checks.
 func (v *StorageValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
 	var findings []assessmentv1alpha1.Finding
@@ -87,6 +127,12 @@ func (v *StorageValidator) Validate(ctx context.Context, c client.Client, profil
 	// Check 2: CSI Drivers
 	findings = append(findings, v.checkCSIDrivers(ctx, c)...)
 
+	// Check 3: Volume binding modes and reclaim policies
+	findings = append(findings, v.checkBindingModes(ctx, c)...)
+
+	// Check 4: Failed and stuck PVC expansions
+	findings = append(findings, v.checkPVCResizes(ctx, c)...)
+
 	return findings, nil
 }
 
@@ -122,11 +168,13 @@ func (v *StorageValidator) checkStorageClasses(ctx context.Context, c client.Cli
 	// Check for default StorageClass
 	var defaultSC *storagev1.StorageClass
 	var defaultSCCount int
+	var defaultSCNames []string
 	for i := range scs.Items {
 		sc := &scs.Items[i]
 		if sc.Annotations["storageclass.kubernetes.io/is-default-class"] == "true" {
 			defaultSC = sc
 			defaultSCCount++
+			defaultSCNames = append(defaultSCNames, sc.Name)
 		}
 	}
 
@@ -148,7 +196,7 @@ func (v *StorageValidator) checkStorageClasses(ctx context.Context, c client.Cli
 			Category:       validatorCategory,
 			Status:         assessmentv1alpha1.FindingStatusWarn,
 			Title:          "Multiple Default StorageClasses",
-			Description:    fmt.Sprintf("%d StorageClasses are marked as default.", defaultSCCount),
+			Description:    fmt.Sprintf("%d StorageClasses are marked as default: %s", defaultSCCount, strings.Join(defaultSCNames, ", ")),
 			Impact:         "Having multiple default StorageClasses can cause unpredictable behavior.",
 			Recommendation: "Ensure only one StorageClass is marked as default.",
 		})
@@ -269,6 +317,7 @@ func (v *StorageValidator) checkCSIDrivers(ctx context.Context, c client.Client)
 			Validator:      validatorName,
 			Category:       validatorCategory,
 			Status:         assessmentv1alpha1.FindingStatusInfo,
+			Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
 			Title:          "Third-Party CSI Drivers",
 			Description:    fmt.Sprintf("Found %d third-party CSI driver(s): %s", len(unknownDrivers), strings.Join(unknownDrivers, ", ")),
 			Impact:         "Third-party CSI drivers may have different support levels and update schedules.",
@@ -278,3 +327,240 @@ func (v *StorageValidator) checkCSIDrivers(ctx context.Context, c client.Client)
 
 	return findings
 }
+
+// checkBindingModes reports each StorageClass's volume binding mode and reclaim
+// policy, flagging Immediate binding on topology-constrained backends and
+// StatefulSets whose volumes are deleted together with their claims.
+func (v *StorageValidator) checkBindingModes(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	var findings []assessmentv1alpha1.Finding
+
+	scs := &storagev1.StorageClassList{}
+	if err := c.List(ctx, scs); err != nil || len(scs.Items) == 0 {
+		// Already reported by checkStorageClasses.
+		return findings
+	}
+
+	classes := make(map[string]storagev1.StorageClass, len(scs.Items))
+	var defaultClass string
+	var modes []string
+	var immediateZonal []string
+	for _, sc := range scs.Items {
+		classes[sc.Name] = sc
+		if sc.Annotations["storageclass.kubernetes.io/is-default-class"] == "true" {
+			defaultClass = sc.Name
+		}
+
+		mode := storagev1.VolumeBindingImmediate
+		if sc.VolumeBindingMode != nil {
+			mode = *sc.VolumeBindingMode
+		}
+		modes = append(modes, fmt.Sprintf("%s (%s, %s)", sc.Name, mode, reclaimPolicy(sc)))
+
+		if mode == storagev1.VolumeBindingImmediate && (zonalProvisioners[sc.Provisioner] || len(sc.AllowedTopologies) > 0) {
+			immediateZonal = append(immediateZonal, fmt.Sprintf("%s (%s)", sc.Name, sc.Provisioner))
+		}
+	}
+
+	findings = append(findings, assessmentv1alpha1.Finding{
+		ID:          "storage-binding-modes",
+		Validator:   validatorName,
+		Category:    validatorCategory,
+		Status:      assessmentv1alpha1.FindingStatusInfo,
+		Title:       "StorageClass Binding Modes and Reclaim Policies",
+		Description: fmt.Sprintf("Binding mode and reclaim policy per StorageClass: %s", strings.Join(modes, ", ")),
+	})
+
+	if len(immediateZonal) > 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "storage-immediate-binding",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
+			Title:          "Immediate Binding on Topology-Constrained Storage",
+			Description:    fmt.Sprintf("%d StorageClass(es) provision zonal volumes with volumeBindingMode Immediate: %s", len(immediateZonal), strings.Join(immediateZonal, ", ")),
+			Impact:         "Volumes are provisioned before the pod is scheduled and may land in a zone where the pod cannot run, leaving it Pending.",
+			Recommendation: "Use volumeBindingMode: WaitForFirstConsumer for zonal storage so volumes are created in the zone of the scheduled pod.",
+			References: []string{
+				"https://kubernetes.io/docs/concepts/storage/storage-classes/#volume-binding-mode",
+			},
+		})
+	}
+
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := c.List(ctx, statefulSets); err != nil {
+		return findings
+	}
+
+	var deleteReclaim []string
+	for _, sts := range statefulSets.Items {
+		if strings.HasPrefix(sts.Namespace, "openshift-") || strings.HasPrefix(sts.Namespace, "kube-") {
+			continue
+		}
+		for _, tmpl := range sts.Spec.VolumeClaimTemplates {
+			className := defaultClass
+			if tmpl.Spec.StorageClassName != nil {
+				className = *tmpl.Spec.StorageClassName
+			}
+			sc, ok := classes[className]
+			if !ok || reclaimPolicy(sc) != corev1.PersistentVolumeReclaimDelete {
+				continue
+			}
+			deleteReclaim = append(deleteReclaim, fmt.Sprintf("%s/%s (%s)", sts.Namespace, sts.Name, className))
+			break
+		}
+	}
+
+	if len(deleteReclaim) > 0 {
+		sample := deleteReclaim
+		if len(sample) > 5 {
+			sample = sample[:5]
+		}
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "storage-stateful-delete-reclaim",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusInfo,
+			Title:          "StatefulSets Using Delete Reclaim Policy",
+			Description:    fmt.Sprintf("%d StatefulSet(s) store data on StorageClasses with reclaimPolicy Delete: %s", len(deleteReclaim), strings.Join(sample, ", ")),
+			Impact:         "Deleting a PersistentVolumeClaim (for example during cleanup or a namespace deletion) permanently deletes the underlying volume and its data.",
+			Recommendation: "Use a StorageClass with reclaimPolicy Retain for stateful production data, or ensure backups are in place.",
+		})
+	}
+
+	return findings
+}
+
+// reclaimPolicy returns the StorageClass reclaim policy, which defaults to Delete.
+func reclaimPolicy(sc storagev1.StorageClass) corev1.PersistentVolumeReclaimPolicy {
+	if sc.ReclaimPolicy != nil {
+		return *sc.ReclaimPolicy
+	}
+	return corev1.PersistentVolumeReclaimDelete
+}
+
+// checkPVCResizes reports PVC expansions that failed, and expansions that have
+// been in progress or waiting for a file system resize longer than the
+// configured threshold.
+func (v *StorageValidator) checkPVCResizes(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	threshold := time.Duration(validator.ConfigInt(ctx, configResizeStuckMinutes, defaultResizeStuckMinutes)) * time.Minute
+	now := time.Now()
+
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := c.List(ctx, pvcs); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "storage-pvc-resize-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check PVC Expansions",
+			Description: fmt.Sprintf("Failed to list PVCs: %v", err),
+		}}
+	}
+
+	var failed, stuck []string
+	var failedEvidence, stuckEvidence string
+	for i := range pvcs.Items {
+		pvc := &pvcs.Items[i]
+		name := pvc.Namespace + "/" + pvc.Name
+
+		if reason := resizeFailure(pvc); reason != "" {
+			failed = append(failed, fmt.Sprintf("%s (%s)", name, reason))
+			if failedEvidence == "" {
+				failedEvidence = validator.Evidence(ctx, pvc)
+			}
+			continue
+		}
+		if reason := resizeStuck(pvc, now, threshold); reason != "" {
+			stuck = append(stuck, fmt.Sprintf("%s (%s)", name, reason))
+			if stuckEvidence == "" {
+				stuckEvidence = validator.Evidence(ctx, pvc)
+			}
+		}
+	}
+
+	if len(failed) == 0 && len(stuck) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "storage-pvc-resize-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "No Stuck PVC Expansions",
+			Description: fmt.Sprintf("No PVC expansion has failed or been pending for more than %s.", threshold),
+		}}
+	}
+
+	var findings []assessmentv1alpha1.Finding
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "storage-pvc-resize-failed",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Failed PVC Expansions",
+			Description:    fmt.Sprintf("Found %d PVC(s) whose expansion failed: %s", len(failed), strings.Join(validator.SampleOf(failed), ", ")),
+			Impact:         "The volumes keep their old size, so workloads that needed the space can still run out of it.",
+			Recommendation: "Check the PVC events and the CSI driver logs, fix the cause (for example a storage quota or an unsupported size), and retry by editing the requested size.",
+			References: []string{
+				"https://kubernetes.io/docs/concepts/storage/persistent-volumes/#recovering-from-failure-when-expanding-volumes",
+			},
+			Evidence: failedEvidence,
+		})
+	}
+	if len(stuck) > 0 {
+		sort.Strings(stuck)
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "storage-pvc-resize-stuck",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Stuck PVC Expansions",
+			Description:    fmt.Sprintf("Found %d PVC(s) whose expansion has not completed after %s: %s", len(stuck), threshold, strings.Join(validator.SampleOf(stuck), ", ")),
+			Impact:         "The requested capacity is not available to the workload until the expansion completes.",
+			Recommendation: "For a pending file system resize, restart or reschedule the pod using the volume. Otherwise check the PVC events and the CSI driver's resizer sidecar.",
+			Evidence:       stuckEvidence,
+		})
+	}
+	return findings
+}
+
+// resizeFailure returns why the expansion of a PVC failed, or "" if it did not.
+func resizeFailure(pvc *corev1.PersistentVolumeClaim) string {
+	for _, cond := range pvc.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		if cond.Type == corev1.PersistentVolumeClaimControllerResizeError || cond.Type == corev1.PersistentVolumeClaimNodeResizeError {
+			if cond.Message != "" {
+				return fmt.Sprintf("%s: %s", cond.Type, cond.Message)
+			}
+			return string(cond.Type)
+		}
+	}
+	switch status := pvc.Status.AllocatedResourceStatuses[corev1.ResourceStorage]; status {
+	case corev1.PersistentVolumeClaimControllerResizeInfeasible, corev1.PersistentVolumeClaimNodeResizeInfeasible:
+		return string(status)
+	}
+	return ""
+}
+
+// resizeStuck returns why the expansion of a PVC counts as stuck, or "" if it
+// does not. An expansion is stuck when its Resizing or FileSystemResizePending
+// condition has not changed for longer than threshold. Expansions without
+// either condition have not started, or have no timestamp to measure by.
+func resizeStuck(pvc *corev1.PersistentVolumeClaim, now time.Time, threshold time.Duration) string {
+	for _, cond := range pvc.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue || cond.LastTransitionTime.IsZero() {
+			continue
+		}
+		if cond.Type == corev1.PersistentVolumeClaimResizing || cond.Type == corev1.PersistentVolumeClaimFileSystemResizePending {
+			age := now.Sub(cond.LastTransitionTime.Time)
+			if age < threshold {
+				return ""
+			}
+			return fmt.Sprintf("%s for %s", cond.Type, age.Round(time.Minute))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator/validatortest"
+)
+
+func newStorageClass(name, provisioner string, mode storagev1.VolumeBindingMode, reclaim corev1.PersistentVolumeReclaimPolicy, isDefault bool) *storagev1.StorageClass {
+	sc := &storagev1.StorageClass{
+		ObjectMeta:        metav1.ObjectMeta{Name: name},
+		Provisioner:       provisioner,
+		VolumeBindingMode: &mode,
+		ReclaimPolicy:     &reclaim,
+	}
+	if isDefault {
+		sc.Annotations = map[string]string{"storageclass.kubernetes.io/is-default-class": "true"}
+	}
+	return sc
+}
+
+func newStatefulSet(ns, name string, storageClass *string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		Spec: appsv1.StatefulSetSpec{
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
+				ObjectMeta: metav1.ObjectMeta{Name: "data"},
+				Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: storageClass},
+			}},
+		},
+	}
+}
+
+func TestCheckBindingModes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = storagev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	retain := "gp3-retain"
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newStorageClass("gp3-csi", "ebs.csi.aws.com", storagev1.VolumeBindingWaitForFirstConsumer, corev1.PersistentVolumeReclaimDelete, true),
+		newStorageClass("gp3-immediate", "ebs.csi.aws.com", storagev1.VolumeBindingImmediate, corev1.PersistentVolumeReclaimDelete, false),
+		newStorageClass("gp3-retain", "ebs.csi.aws.com", storagev1.VolumeBindingWaitForFirstConsumer, corev1.PersistentVolumeReclaimRetain, false),
+		newStorageClass("nfs", "nfs.csi.k8s.io", storagev1.VolumeBindingImmediate, corev1.PersistentVolumeReclaimDelete, false),
+		newStatefulSet("app", "postgres", nil),
+		newStatefulSet("app", "kafka", &retain),
+		newStatefulSet("openshift-monitoring", "prometheus-k8s", nil),
+	).Build()
+
+	findings := (&StorageValidator{}).checkBindingModes(context.Background(), c)
+
+	modes := validatortest.FindingByID(findings, "storage-binding-modes")
+	if modes == nil || !strings.Contains(modes.Description, "gp3-retain (WaitForFirstConsumer, Retain)") {
+		t.Errorf("unexpected binding modes finding: %+v", modes)
+	}
+
+	immediate := validatortest.FindingByID(findings, "storage-immediate-binding")
+	if immediate == nil {
+		t.Fatalf("expected immediate binding finding, got %+v", findings)
+	}
+	if immediate.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Errorf("expected WARN, got %s", immediate.Status)
+	}
+	if !strings.Contains(immediate.Description, "gp3-immediate") || strings.Contains(immediate.Description, "nfs") {
+		t.Errorf("only zonal classes should be flagged: %s", immediate.Description)
+	}
+
+	reclaim := validatortest.FindingByID(findings, "storage-stateful-delete-reclaim")
+	if reclaim == nil {
+		t.Fatalf("expected delete reclaim finding, got %+v", findings)
+	}
+	if !strings.Contains(reclaim.Description, "app/postgres (gp3-csi)") ||
+		strings.Contains(reclaim.Description, "kafka") ||
+		strings.Contains(reclaim.Description, "prometheus") {
+		t.Errorf("unexpected description: %s", reclaim.Description)
+	}
+}
+
+func TestCheckPVCResizes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	pvc := func(name, requested, capacity string, conditions ...corev1.PersistentVolumeClaimCondition) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				Resources: corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(requested)}},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Phase:      corev1.ClaimBound,
+				Capacity:   corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)},
+				Conditions: conditions,
+			},
+		}
+	}
+	condition := func(t corev1.PersistentVolumeClaimConditionType, age time.Duration, message string) corev1.PersistentVolumeClaimCondition {
+		return corev1.PersistentVolumeClaimCondition{
+			Type:               t,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-age)),
+			Message:            message,
+		}
+	}
+	infeasible := pvc("infeasible", "20Gi", "10Gi")
+	infeasible.Status.AllocatedResourceStatuses = map[corev1.ResourceName]corev1.ClaimResourceStatus{
+		corev1.ResourceStorage: corev1.PersistentVolumeClaimControllerResizeInfeasible,
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		pvc("healthy", "10Gi", "10Gi"),
+		pvc("resizing", "20Gi", "10Gi", condition(corev1.PersistentVolumeClaimResizing, 5*time.Minute, "")),
+		pvc("fs-pending", "20Gi", "10Gi", condition(corev1.PersistentVolumeClaimFileSystemResizePending, 3*time.Hour, "")),
+		pvc("quota", "20Gi", "10Gi", condition(corev1.PersistentVolumeClaimControllerResizeError, time.Minute, "quota exceeded")),
+		pvc("untracked", "20Gi", "10Gi"),
+		infeasible,
+	).Build()
+	v := &StorageValidator{}
+
+	findings := v.checkPVCResizes(context.Background(), c)
+	f := validatortest.FindingByID(findings, "storage-pvc-resize-failed")
+	if f == nil || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("expected a failed resize WARN, got %+v", findings)
+	}
+	for _, want := range []string{"Found 2 PVC(s)", "app/infeasible (ControllerResizeInfeasible)", "app/quota (ControllerResizeError: quota exceeded)"} {
+		if !strings.Contains(f.Description, want) {
+			t.Errorf("expected %q in description: %s", want, f.Description)
+		}
+	}
+
+	f = validatortest.FindingByID(findings, "storage-pvc-resize-stuck")
+	if f == nil || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("expected a stuck resize WARN, got %+v", findings)
+	}
+	for _, want := range []string{"Found 1 PVC(s)", "app/fs-pending (FileSystemResizePending for 3h0m0s)"} {
+		if !strings.Contains(f.Description, want) {
+			t.Errorf("expected %q in description: %s", want, f.Description)
+		}
+	}
+	// Without a resize condition there is no time to measure the expansion by
+	for _, unwanted := range []string{"healthy", "app/resizing", "app/untracked"} {
+		if strings.Contains(f.Description, unwanted) {
+			t.Errorf("did not expect %q in description: %s", unwanted, f.Description)
+		}
+	}
+}
@@ -19,10 +19,14 @@ package version
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
@@ -58,6 +62,11 @@ func (v *VersionValidator) Category() string {
 	return validatorCategory
 }
 
+// RequiresOpenShift reports that this validator needs OpenShift APIs.
+func (v *VersionValidator) RequiresOpenShift() bool {
+	return true
+}
+
 // Validate performs version checks.
 func (v *VersionValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
 	var findings []assessmentv1alpha1.Finding
@@ -83,6 +92,18 @@ func (v *VersionValidator) Validate(ctx context.Context, c client.Client, profil
 	// Check 5: Version age
 	findings = append(findings, v.checkVersionAge(cv, profile))
 
+	// Check 6: Component overrides
+	findings = append(findings, v.checkOverrides(cv))
+
+	// Check 7: Updates gated by known risks
+	findings = append(findings, v.checkConditionalUpdates(cv)...)
+
+	// Check 8: Feature set
+	findings = append(findings, v.checkFeatureGate(ctx, c)...)
+
+	// Check 9: ClusterOperators lagging the desired version
+	findings = append(findings, v.checkOperatorVersions(ctx, c, cv)...)
+
 	return findings, nil
 }
 
@@ -314,3 +335,308 @@ func (v *VersionValidator) checkVersionAge(cv *configv1.ClusterVersion, profile
 		Description: fmt.Sprintf("Cluster was last updated %d days ago.", daysSinceUpdate),
 	}
 }
+
+// checkOverrides reports component overrides in spec.overrides. Unmanaged
+// overrides stop the cluster version operator from reconciling a component,
+// which is unsupported and blocks minor version upgrades.
+func (v *VersionValidator) checkOverrides(cv *configv1.ClusterVersion) assessmentv1alpha1.Finding {
+	if len(cv.Spec.Overrides) == 0 {
+		return assessmentv1alpha1.Finding{
+			ID:          "version-overrides-none",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "No Component Overrides",
+			Description: "ClusterVersion has no component overrides; all components are managed by the cluster version operator.",
+		}
+	}
+
+	var unmanaged, managed []string
+	for _, o := range cv.Spec.Overrides {
+		component := fmt.Sprintf("%s/%s", o.Kind, o.Name)
+		if o.Group != "" {
+			component = fmt.Sprintf("%s.%s/%s", o.Kind, o.Group, o.Name)
+		}
+		if o.Namespace != "" {
+			component = fmt.Sprintf("%s (namespace %s)", component, o.Namespace)
+		}
+		if o.Unmanaged {
+			unmanaged = append(unmanaged, component)
+		} else {
+			managed = append(managed, component)
+		}
+	}
+
+	if len(unmanaged) == 0 {
+		return assessmentv1alpha1.Finding{
+			ID:             "version-overrides-set",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusInfo,
+			Title:          "Component Overrides Present",
+			Description:    fmt.Sprintf("ClusterVersion lists %d override(s), none of them unmanaged: %s", len(managed), strings.Join(managed, ", ")),
+			Recommendation: "Remove overrides that are no longer needed from spec.overrides.",
+		}
+	}
+
+	description := fmt.Sprintf("%d component(s) are marked unmanaged in ClusterVersion spec.overrides: %s", len(unmanaged), strings.Join(unmanaged, ", "))
+	if len(managed) > 0 {
+		description += fmt.Sprintf(". %d other override(s) are still managed: %s", len(managed), strings.Join(managed, ", "))
+	}
+
+	return assessmentv1alpha1.Finding{
+		ID:             "version-overrides-unmanaged",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Title:          "Unmanaged Component Overrides",
+		Description:    description,
+		Impact:         "The cluster version operator no longer reconciles these components. The cluster is in an unsupported state and minor version upgrades are blocked.",
+		Recommendation: "Remove the overrides from ClusterVersion spec.overrides once the components no longer need manual changes.",
+		References: []string{
+			"https://docs.openshift.com/container-platform/latest/updating/understanding_updates/how-updates-work.html",
+		},
+	}
+}
+
+// checkConditionalUpdates reports conditional updates that are not
+// recommended for this cluster, with the risks that keep them out of
+// availableUpdates.
+func (v *VersionValidator) checkConditionalUpdates(cv *configv1.ClusterVersion) []assessmentv1alpha1.Finding {
+	// Risks may be listed inline or, with accepted risks, by name in status.
+	knownRisks := make(map[string]configv1.ConditionalUpdateRisk, len(cv.Status.ConditionalUpdateRisks))
+	for _, risk := range cv.Status.ConditionalUpdateRisks {
+		knownRisks[risk.Name] = risk
+	}
+
+	var gated []string
+	var references []string
+	seenRefs := make(map[string]bool)
+
+	for _, update := range cv.Status.ConditionalUpdates {
+		recommended := meta.FindStatusCondition(update.Conditions, "Recommended")
+		if recommended != nil && recommended.Status == metav1.ConditionTrue {
+			continue
+		}
+
+		risks := update.Risks
+		for _, name := range update.RiskNames {
+			if risk, ok := knownRisks[name]; ok && !hasRisk(risks, name) {
+				risks = append(risks, risk)
+			}
+		}
+
+		var reasons []string
+		for _, risk := range risks {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", risk.Name, strings.Join(strings.Fields(risk.Message), " ")))
+			if risk.URL != "" && !seenRefs[risk.URL] && len(references) < 5 {
+				seenRefs[risk.URL] = true
+				references = append(references, risk.URL)
+			}
+		}
+		if len(reasons) == 0 && recommended != nil {
+			reasons = append(reasons, fmt.Sprintf("Recommended=%s: %s", recommended.Status, recommended.Message))
+		}
+
+		gated = append(gated, fmt.Sprintf("%s (%s)", update.Release.Version, strings.Join(reasons, "; ")))
+	}
+
+	if len(gated) == 0 {
+		return nil
+	}
+
+	sample := gated
+	if len(sample) > 5 {
+		sample = sample[:5]
+	}
+
+	return []assessmentv1alpha1.Finding{{
+		ID:             "version-conditional-updates",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusInfo,
+		Title:          "Updates Gated by Known Risks",
+		Description:    fmt.Sprintf("%d update(s) exist for this version but are not recommended for this cluster: %s", len(gated), strings.Join(sample, ", ")),
+		Impact:         "These updates are not offered in availableUpdates because known issues may apply to this cluster.",
+		Recommendation: "Review each risk before updating. Wait for a release without the risk, or explicitly accept the risk with `oc adm upgrade --allow-not-recommended` if it does not affect you.",
+		References:     append(references, "https://docs.openshift.com/container-platform/latest/updating/understanding_updates/understanding-update-channels-release.html"),
+	}}
+}
+
+// checkFeatureGate reports the active feature set. The TechPreviewNoUpgrade,
+// DevPreviewNoUpgrade and CustomNoUpgrade feature sets cannot be turned off
+// once enabled and permanently block upgrades.
+func (v *VersionValidator) checkFeatureGate(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	fg := &configv1.FeatureGate{}
+	if err := c.Get(ctx, client.ObjectKey{Name: "cluster"}, fg); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return []assessmentv1alpha1.Finding{{
+			ID:          "version-featuregate-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Read FeatureGate",
+			Description: fmt.Sprintf("Could not get FeatureGate 'cluster': %v", err),
+		}}
+	}
+
+	switch fg.Spec.FeatureSet {
+	case configv1.TechPreviewNoUpgrade, configv1.DevPreviewNoUpgrade, configv1.CustomNoUpgrade:
+	default:
+		featureSet := string(fg.Spec.FeatureSet)
+		if featureSet == "" {
+			featureSet = "Default"
+		}
+		return []assessmentv1alpha1.Finding{{
+			ID:          "version-featureset",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Cluster Feature Set",
+			Description: fmt.Sprintf("The cluster uses the %s feature set.", featureSet),
+		}}
+	}
+
+	description := fmt.Sprintf("FeatureGate 'cluster' enables the %s feature set.", fg.Spec.FeatureSet)
+	if custom := fg.Spec.CustomNoUpgrade; fg.Spec.FeatureSet == configv1.CustomNoUpgrade && custom != nil {
+		if len(custom.Enabled) > 0 {
+			description += fmt.Sprintf(" Enabled: %s.", joinFeatureGates(custom.Enabled))
+		}
+		if len(custom.Disabled) > 0 {
+			description += fmt.Sprintf(" Disabled: %s.", joinFeatureGates(custom.Disabled))
+		}
+	}
+
+	return []assessmentv1alpha1.Finding{{
+		ID:             "version-featureset-noupgrade",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Title:          "Feature Set Blocks Upgrades",
+		Description:    description,
+		Impact:         "This feature set cannot be disabled once enabled. The cluster can never be upgraded, including z-stream updates, and is not supported for production use.",
+		Recommendation: "Use this cluster for testing only. Plan to migrate workloads to a new cluster installed with the Default feature set.",
+		References: []string{
+			"https://docs.openshift.com/container-platform/latest/nodes/clusters/nodes-cluster-enabling-features.html",
+		},
+	}}
+}
+
+// updateStallThreshold is how long an update may run before ClusterOperators
+// that have not reached the desired version are reported as stalled.
+const updateStallThreshold = 2 * time.Hour
+
+// checkOperatorVersions compares the operator version reported by each
+// ClusterOperator with the desired cluster version. Operators lagging behind
+// are expected while an update rolls out, so they are informational for the
+// first updateStallThreshold of an update and a warning after that, or when
+// no update is in progress.
+func (v *VersionValidator) checkOperatorVersions(ctx context.Context, c client.Client, cv *configv1.ClusterVersion) []assessmentv1alpha1.Finding {
+	target := cv.Status.Desired.Version
+	if target == "" {
+		return nil
+	}
+
+	operators := &configv1.ClusterOperatorList{}
+	if err := c.List(ctx, operators); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "version-operators-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check ClusterOperator Versions",
+			Description: fmt.Sprintf("Failed to list ClusterOperators: %v", err),
+		}}
+	}
+
+	var lagging []string
+	reporting := 0
+	for _, co := range operators.Items {
+		for _, version := range co.Status.Versions {
+			if version.Name != "operator" {
+				continue
+			}
+			reporting++
+			if version.Version != target {
+				lagging = append(lagging, fmt.Sprintf("%s (%s)", co.Name, version.Version))
+			}
+			break
+		}
+	}
+	if reporting == 0 {
+		return nil
+	}
+
+	if len(lagging) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "version-operators-at-target",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "ClusterOperators at Desired Version",
+			Description: fmt.Sprintf("All %d ClusterOperator(s) report version %s.", reporting, target),
+		}}
+	}
+
+	sort.Strings(lagging)
+	sample := lagging
+	if len(sample) > 5 {
+		sample = sample[:5]
+	}
+	description := fmt.Sprintf("%d of %d ClusterOperator(s) have not reached the desired version %s: %s", len(lagging), reporting, target, strings.Join(sample, ", "))
+
+	status := assessmentv1alpha1.FindingStatusWarn
+	title := "ClusterOperators Lagging the Desired Version"
+	if len(cv.Status.History) > 0 && cv.Status.History[0].State == configv1.PartialUpdate {
+		elapsed := time.Since(cv.Status.History[0].StartedTime.Time)
+		description += fmt.Sprintf(". The update to %s started %s ago.", target, elapsed.Round(time.Minute))
+		if elapsed < updateStallThreshold {
+			status = assessmentv1alpha1.FindingStatusInfo
+			title = "ClusterOperators Updating"
+		} else {
+			title = "Cluster Update Stalled"
+		}
+	} else {
+		description += ", although no update is in progress."
+	}
+
+	return []assessmentv1alpha1.Finding{{
+		ID:             "version-operators-lagging",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         status,
+		Title:          title,
+		Description:    description,
+		Impact:         "The cluster runs a mix of component versions until every operator is updated, a combination that is only supported during an update. Operators blocked on a version often stop the rest of the update from progressing.",
+		Recommendation: "Inspect the lagging operators with 'oc get co' and 'oc describe co <name>', and check the cluster version operator's status message for the component it is waiting on.",
+		References: []string{
+			"https://docs.openshift.com/container-platform/latest/updating/updating-cluster-cli.html",
+		},
+	}}
+}
+
+// joinFeatureGates returns up to five feature gate names as a comma-separated
+// list.
+func joinFeatureGates(gates []configv1.FeatureGateName) string {
+	var names []string
+	for _, gate := range gates {
+		if len(names) == 5 {
+			names = append(names, fmt.Sprintf("and %d more", len(gates)-5))
+			break
+		}
+		names = append(names, string(gate))
+	}
+	return strings.Join(names, ", ")
+}
+
+// hasRisk reports whether risks contains a risk with the given name.
+func hasRisk(risks []configv1.ConditionalUpdateRisk, name string) bool {
+	for _, risk := range risks {
+		if risk.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -18,7 +18,9 @@ package version
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	configv1 "github.com/openshift/api/config/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -172,3 +174,268 @@ func TestVersionValidator_NoClusterVersion(t *testing.T) {
 		t.Error("Expected error when ClusterVersion is missing, got nil")
 	}
 }
+
+func TestVersionValidator_CheckOverrides(t *testing.T) {
+	v := &VersionValidator{}
+
+	tests := []struct {
+		name       string
+		overrides  []configv1.ComponentOverride
+		wantID     string
+		wantStatus assessmentv1alpha1.FindingStatus
+		wantText   string
+	}{
+		{
+			name:       "no overrides",
+			wantID:     "version-overrides-none",
+			wantStatus: assessmentv1alpha1.FindingStatusPass,
+		},
+		{
+			name: "managed override",
+			overrides: []configv1.ComponentOverride{
+				{Kind: "Deployment", Group: "apps", Namespace: "openshift-monitoring", Name: "cluster-monitoring-operator"},
+			},
+			wantID:     "version-overrides-set",
+			wantStatus: assessmentv1alpha1.FindingStatusInfo,
+			wantText:   "Deployment.apps/cluster-monitoring-operator (namespace openshift-monitoring)",
+		},
+		{
+			name: "unmanaged override",
+			overrides: []configv1.ComponentOverride{
+				{Kind: "Deployment", Group: "apps", Namespace: "openshift-monitoring", Name: "cluster-monitoring-operator", Unmanaged: true},
+				{Kind: "ClusterOperator", Group: "config.openshift.io", Name: "console"},
+			},
+			wantID:     "version-overrides-unmanaged",
+			wantStatus: assessmentv1alpha1.FindingStatusWarn,
+			wantText:   "1 other override(s) are still managed: ClusterOperator.config.openshift.io/console",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv := &configv1.ClusterVersion{Spec: configv1.ClusterVersionSpec{Overrides: tt.overrides}}
+			f := v.checkOverrides(cv)
+			if f.ID != tt.wantID || f.Status != tt.wantStatus {
+				t.Errorf("Got %s (%s), want %s (%s)", f.ID, f.Status, tt.wantID, tt.wantStatus)
+			}
+			if !strings.Contains(f.Description, tt.wantText) {
+				t.Errorf("Expected description to contain %q, got %q", tt.wantText, f.Description)
+			}
+		})
+	}
+}
+
+func TestCheckConditionalUpdates(t *testing.T) {
+	v := &VersionValidator{}
+
+	cv := &configv1.ClusterVersion{
+		Status: configv1.ClusterVersionStatus{
+			ConditionalUpdates: []configv1.ConditionalUpdate{
+				{
+					Release:    configv1.Release{Version: "4.16.5"},
+					Risks:      []configv1.ConditionalUpdateRisk{{Name: "BrokenDNS", Message: "DNS may fail\non upgrade.", URL: "https://issues.example.com/DNS-1"}},
+					Conditions: []metav1.Condition{{Type: "Recommended", Status: metav1.ConditionFalse}},
+				},
+				{
+					Release:    configv1.Release{Version: "4.16.6"},
+					RiskNames:  []string{"SlowEtcd"},
+					Conditions: []metav1.Condition{{Type: "Recommended", Status: metav1.ConditionFalse}},
+				},
+				{
+					Release:    configv1.Release{Version: "4.16.7"},
+					Risks:      []configv1.ConditionalUpdateRisk{{Name: "OnlyOnAzure", Message: "Azure only.", URL: "https://issues.example.com/AZ-1"}},
+					Conditions: []metav1.Condition{{Type: "Recommended", Status: metav1.ConditionTrue}},
+				},
+			},
+			ConditionalUpdateRisks: []configv1.ConditionalUpdateRisk{
+				{Name: "SlowEtcd", Message: "etcd may be slow.", URL: "https://issues.example.com/ETCD-1"},
+			},
+		},
+	}
+
+	findings := v.checkConditionalUpdates(cv)
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 finding, got %d", len(findings))
+	}
+	f := findings[0]
+	if f.ID != "version-conditional-updates" || f.Status != assessmentv1alpha1.FindingStatusInfo {
+		t.Errorf("Unexpected finding %s (%s)", f.ID, f.Status)
+	}
+	for _, want := range []string{"2 update(s)", "4.16.5 (BrokenDNS: DNS may fail on upgrade.)", "4.16.6 (SlowEtcd: etcd may be slow.)"} {
+		if !strings.Contains(f.Description, want) {
+			t.Errorf("Expected description to contain %q, got %q", want, f.Description)
+		}
+	}
+	if strings.Contains(f.Description, "4.16.7") {
+		t.Errorf("Recommended update should not be listed: %q", f.Description)
+	}
+	if len(f.References) != 3 || f.References[0] != "https://issues.example.com/DNS-1" {
+		t.Errorf("Expected risk URLs in references, got %v", f.References)
+	}
+
+	if findings := v.checkConditionalUpdates(&configv1.ClusterVersion{}); len(findings) != 0 {
+		t.Errorf("Expected no findings without conditional updates, got %+v", findings)
+	}
+}
+
+func TestCheckFeatureGate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = configv1.AddToScheme(scheme)
+
+	tests := []struct {
+		name       string
+		spec       *configv1.FeatureGateSpec
+		wantID     string
+		wantStatus assessmentv1alpha1.FindingStatus
+		wantText   string
+	}{
+		{
+			name: "no FeatureGate",
+		},
+		{
+			name:       "default feature set",
+			spec:       &configv1.FeatureGateSpec{},
+			wantID:     "version-featureset",
+			wantStatus: assessmentv1alpha1.FindingStatusInfo,
+			wantText:   "Default feature set",
+		},
+		{
+			name:       "tech preview",
+			spec:       &configv1.FeatureGateSpec{FeatureGateSelection: configv1.FeatureGateSelection{FeatureSet: configv1.TechPreviewNoUpgrade}},
+			wantID:     "version-featureset-noupgrade",
+			wantStatus: assessmentv1alpha1.FindingStatusWarn,
+			wantText:   "TechPreviewNoUpgrade",
+		},
+		{
+			name: "custom feature gates",
+			spec: &configv1.FeatureGateSpec{FeatureGateSelection: configv1.FeatureGateSelection{
+				FeatureSet:      configv1.CustomNoUpgrade,
+				CustomNoUpgrade: &configv1.CustomFeatureGates{Enabled: []configv1.FeatureGateName{"GatewayAPI"}},
+			}},
+			wantID:     "version-featureset-noupgrade",
+			wantStatus: assessmentv1alpha1.FindingStatusWarn,
+			wantText:   "Enabled: GatewayAPI.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tt.spec != nil {
+				builder = builder.WithObjects(&configv1.FeatureGate{
+					ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+					Spec:       *tt.spec,
+				})
+			}
+
+			v := &VersionValidator{}
+			findings := v.checkFeatureGate(context.Background(), builder.Build())
+			if tt.wantID == "" {
+				if len(findings) != 0 {
+					t.Fatalf("Expected no findings, got %+v", findings)
+				}
+				return
+			}
+			if len(findings) != 1 {
+				t.Fatalf("Expected 1 finding, got %d", len(findings))
+			}
+			f := findings[0]
+			if f.ID != tt.wantID || f.Status != tt.wantStatus {
+				t.Errorf("Got %s (%s), want %s (%s)", f.ID, f.Status, tt.wantID, tt.wantStatus)
+			}
+			if !strings.Contains(f.Description, tt.wantText) {
+				t.Errorf("Expected description to contain %q, got %q", tt.wantText, f.Description)
+			}
+		})
+	}
+}
+
+func TestCheckOperatorVersions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = configv1.AddToScheme(scheme)
+
+	operator := func(name, version string) *configv1.ClusterOperator {
+		return &configv1.ClusterOperator{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: configv1.ClusterOperatorStatus{Versions: []configv1.OperandVersion{
+				{Name: "raw-internal", Version: version},
+				{Name: "operator", Version: version},
+			}},
+		}
+	}
+	clusterVersion := func(state configv1.UpdateState, started time.Duration) *configv1.ClusterVersion {
+		return &configv1.ClusterVersion{Status: configv1.ClusterVersionStatus{
+			Desired: configv1.Release{Version: "4.16.3"},
+			History: []configv1.UpdateHistory{{
+				State:       state,
+				Version:     "4.16.3",
+				StartedTime: metav1.NewTime(time.Now().Add(-started)),
+			}},
+		}}
+	}
+
+	tests := []struct {
+		name       string
+		cv         *configv1.ClusterVersion
+		operators  []client.Object
+		wantID     string
+		wantStatus assessmentv1alpha1.FindingStatus
+		wantText   string
+	}{
+		{
+			name:       "all at target",
+			cv:         clusterVersion(configv1.CompletedUpdate, 48*time.Hour),
+			operators:  []client.Object{operator("dns", "4.16.3"), operator("ingress", "4.16.3")},
+			wantID:     "version-operators-at-target",
+			wantStatus: assessmentv1alpha1.FindingStatusPass,
+			wantText:   "All 2 ClusterOperator(s) report version 4.16.3.",
+		},
+		{
+			name:       "update in progress",
+			cv:         clusterVersion(configv1.PartialUpdate, 30*time.Minute),
+			operators:  []client.Object{operator("dns", "4.16.3"), operator("ingress", "4.15.9")},
+			wantID:     "version-operators-lagging",
+			wantStatus: assessmentv1alpha1.FindingStatusInfo,
+			wantText:   "1 of 2 ClusterOperator(s) have not reached the desired version 4.16.3: ingress (4.15.9)",
+		},
+		{
+			name:       "stalled update",
+			cv:         clusterVersion(configv1.PartialUpdate, 5*time.Hour),
+			operators:  []client.Object{operator("machine-config", "4.15.9"), operator("dns", "4.16.3"), operator("network", "4.15.9")},
+			wantID:     "version-operators-lagging",
+			wantStatus: assessmentv1alpha1.FindingStatusWarn,
+			wantText:   "machine-config (4.15.9), network (4.15.9). The update to 4.16.3 started 5h0m0s ago.",
+		},
+		{
+			name:       "lagging without an update",
+			cv:         clusterVersion(configv1.CompletedUpdate, 48*time.Hour),
+			operators:  []client.Object{operator("dns", "4.16.2")},
+			wantID:     "version-operators-lagging",
+			wantStatus: assessmentv1alpha1.FindingStatusWarn,
+			wantText:   "although no update is in progress",
+		},
+		{
+			name: "no operator reports a version",
+			cv:   clusterVersion(configv1.CompletedUpdate, 48*time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.operators...).Build()
+			findings := (&VersionValidator{}).checkOperatorVersions(context.Background(), c, tt.cv)
+			if tt.wantID == "" {
+				if len(findings) != 0 {
+					t.Errorf("Expected no findings, got %+v", findings)
+				}
+				return
+			}
+			if len(findings) != 1 || findings[0].ID != tt.wantID || findings[0].Status != tt.wantStatus {
+				t.Fatalf("Expected a single %s %s finding, got %+v", tt.wantStatus, tt.wantID, findings)
+			}
+			if !strings.Contains(findings[0].Description, tt.wantText) {
+				t.Errorf("Expected %q in description: %s", tt.wantText, findings[0].Description)
+			}
+		})
+	}
+}
@@ -0,0 +1,1260 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadhealth
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator"
+)
+
+const (
+	validatorName        = "workloadhealth"
+	validatorDescription = "Validates workload correctness including StatefulSet service wiring, replica spreading and placement, config references, DaemonSet coverage, priority classes, termination grace periods and spot node placement"
+	validatorCategory    = "Workloads"
+)
+
+const (
+	// configStatefulSetServiceStatus overrides the status reported for StatefulSets
+	// without a valid headless governing Service (WARN, FAIL or INFO).
+	configStatefulSetServiceStatus = "statefulSetServiceStatus"
+
+	// configCriticalNamespaces lists additional user namespaces, comma-separated,
+	// whose workloads are expected to set a priorityClassName.
+	configCriticalNamespaces = "criticalNamespaces"
+)
+
+// clusterMonitoringLabel marks namespaces whose workloads are scraped by the
+// platform monitoring stack, typically operators and other system-adjacent
+// components installed outside the openshift- namespaces.
+const clusterMonitoringLabel = "openshift.io/cluster-monitoring"
+
+// systemPriorityClasses are the built-in priority classes reserved for
+// components the cluster cannot run without.
+var systemPriorityClasses = map[string]bool{
+	"system-cluster-critical": true,
+	"system-node-critical":    true,
+}
+
+func init() {
+	_ = validator.Register(&WorkloadHealthValidator{})
+}
+
+// WorkloadHealthValidator checks workload configuration correctness.
+type WorkloadHealthValidator struct{}
+
+// Name returns the validator name.
+func (v *WorkloadHealthValidator) Name() string {
+	return validatorName
+}
+
+// Description returns the validator description.
+func (v *WorkloadHealthValidator) Description() string {
+	return validatorDescription
+}
+
+// Category returns the finding category.
+func (v *WorkloadHealthValidator) Category() string {
+	return validatorCategory
+}
+
+// ConfigKeys returns the override keys understood by this validator.
+func (v *WorkloadHealthValidator) ConfigKeys() []string {
+	return []string{configStatefulSetServiceStatus, configCriticalNamespaces}
+}
+
+// WatchedResources returns the kinds read by this validator.
+func (v *WorkloadHealthValidator) WatchedResources() []schema.GroupVersionKind {
+	return []schema.GroupVersionKind{
+		appsv1.SchemeGroupVersion.WithKind("StatefulSet"),
+		appsv1.SchemeGroupVersion.WithKind("Deployment"),
+		appsv1.SchemeGroupVersion.WithKind("DaemonSet"),
+		batchv1.SchemeGroupVersion.WithKind("Job"),
+		batchv1.SchemeGroupVersion.WithKind("CronJob"),
+		corev1.SchemeGroupVersion.WithKind("Pod"),
+		corev1.SchemeGroupVersion.WithKind("Namespace"),
+		corev1.SchemeGroupVersion.WithKind("Service"),
+		corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+		corev1.SchemeGroupVersion.WithKind("Secret"),
+		corev1.SchemeGroupVersion.WithKind("Node"),
+		policyv1.SchemeGroupVersion.WithKind("PodDisruptionBudget"),
+	}
+}
+
+// Validate performs workload health checks.
+func (v *WorkloadHealthValidator) Validate(ctx context.Context, c client.Client, profile profiles.Profile) ([]assessmentv1alpha1.Finding, error) {
+	var findings []assessmentv1alpha1.Finding
+
+	// Check 1: StatefulSets reference an existing headless Service
+	findings = append(findings, v.checkStatefulSetServices(ctx, c)...)
+
+	// Check 2: Multi-replica workloads spread their pods across nodes
+	findings = append(findings, v.checkReplicaSpread(ctx, c, profile)...)
+
+	// Check 3: Workloads only reference ConfigMaps and Secrets that exist
+	findings = append(findings, v.checkConfigReferences(ctx, c)...)
+
+	// Check 4: DaemonSets run on every eligible node, and only there
+	findings = append(findings, v.checkDaemonSetCoverage(ctx, c)...)
+
+	// Check 5: Multi-replica Deployments are not running on a single node
+	findings = append(findings, v.checkReplicaColocation(ctx, c)...)
+
+	// Check 6: Priority classes match how critical a workload is
+	findings = append(findings, v.checkPriorityClasses(ctx, c)...)
+
+	// Check 7: Pods get a chance to shut down gracefully
+	findings = append(findings, v.checkTerminationGracePeriods(ctx, c)...)
+
+	// Check 8: Disruption-sensitive workloads on spot nodes are protected
+	findings = append(findings, v.checkSpotPlacement(ctx, c)...)
+
+	return findings, nil
+}
+
+// checkStatefulSetServices verifies each StatefulSet's serviceName points to an
+// existing headless Service, which is required for stable network identities.
+func (v *WorkloadHealthValidator) checkStatefulSetServices(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	var findings []assessmentv1alpha1.Finding
+
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := c.List(ctx, statefulSets); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-statefulset-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check StatefulSets",
+			Description: fmt.Sprintf("Failed to list StatefulSets: %v", err),
+		}}
+	}
+
+	var checked int
+	var broken []string
+	var evidence string
+
+	for _, sts := range statefulSets.Items {
+		if validator.IsPlatformNamespace(sts.Namespace) || !validator.Selected(ctx, &sts) {
+			continue
+		}
+		checked++
+
+		name := fmt.Sprintf("%s/%s", sts.Namespace, sts.Name)
+		svc := &corev1.Service{}
+		var problem string
+		switch {
+		case sts.Spec.ServiceName == "":
+			problem = fmt.Sprintf("%s -> (no serviceName)", name)
+		case c.Get(ctx, client.ObjectKey{Namespace: sts.Namespace, Name: sts.Spec.ServiceName}, svc) != nil:
+			problem = fmt.Sprintf("%s -> %s (missing)", name, sts.Spec.ServiceName)
+		case svc.Spec.ClusterIP != corev1.ClusterIPNone:
+			problem = fmt.Sprintf("%s -> %s (not headless)", name, sts.Spec.ServiceName)
+		default:
+			continue
+		}
+		broken = append(broken, problem)
+		if len(broken) == 1 {
+			evidence = validator.Evidence(ctx, &sts)
+		}
+	}
+
+	if checked == 0 {
+		return findings
+	}
+
+	if len(broken) > 0 {
+		status := assessmentv1alpha1.FindingStatusWarn
+		switch override := assessmentv1alpha1.FindingStatus(strings.ToUpper(
+			validator.ConfigString(ctx, configStatefulSetServiceStatus, ""))); override {
+		case assessmentv1alpha1.FindingStatusFail, assessmentv1alpha1.FindingStatusInfo:
+			status = override
+		}
+
+		sample := broken
+		if len(sample) > 5 {
+			sample = sample[:5]
+		}
+
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "workloadhealth-statefulset-headless-service",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         status,
+			Title:          "StatefulSets Without a Headless Service",
+			Description:    fmt.Sprintf("Found %d StatefulSet(s) whose serviceName does not reference an existing headless Service: %s", len(broken), strings.Join(sample, ", ")),
+			Impact:         "Without a headless governing Service, StatefulSet pods do not get stable DNS names, breaking peer discovery for clustered applications.",
+			Recommendation: "Create a Service with clusterIP: None matching the StatefulSet's spec.serviceName and pod selector.",
+			References: []string{
+				"https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#stable-network-id",
+			},
+			Evidence: evidence,
+		})
+	} else {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "workloadhealth-statefulset-services-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "StatefulSets Have Headless Services",
+			Description: fmt.Sprintf("All %d StatefulSet(s) in user namespaces reference an existing headless Service.", checked),
+		})
+	}
+
+	return findings
+}
+
+// checkReplicaSpread flags multi-replica Deployments and StatefulSets whose pod
+// template has neither pod anti-affinity nor topology spread constraints, so
+// the scheduler may place every replica on the same node.
+func (v *WorkloadHealthValidator) checkReplicaSpread(ctx context.Context, c client.Client, profile profiles.Profile) []assessmentv1alpha1.Finding {
+	deployments, err := validator.ListDeployments(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-deployment-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Deployments",
+			Description: fmt.Sprintf("Failed to list Deployments: %v", err),
+		}}
+	}
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := c.List(ctx, statefulSets); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-statefulset-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check StatefulSets",
+			Description: fmt.Sprintf("Failed to list StatefulSets: %v", err),
+		}}
+	}
+
+	var checked int
+	var unspread []string
+
+	check := func(kind, ns, name string, replicas *int32, spec corev1.PodSpec) {
+		if validator.IsPlatformNamespace(ns) || replicas == nil || *replicas < 2 {
+			return
+		}
+		checked++
+		if !spreadsReplicas(spec) {
+			unspread = append(unspread, fmt.Sprintf("%s %s/%s (%d replicas)", kind, ns, name, *replicas))
+		}
+	}
+	for _, d := range deployments {
+		if !validator.Selected(ctx, &d) {
+			continue
+		}
+		check("Deployment", d.Namespace, d.Name, d.Spec.Replicas, d.Spec.Template.Spec)
+	}
+	for _, sts := range statefulSets.Items {
+		if !validator.Selected(ctx, &sts) {
+			continue
+		}
+		check("StatefulSet", sts.Namespace, sts.Name, sts.Spec.Replicas, sts.Spec.Template.Spec)
+	}
+
+	if checked == 0 {
+		return nil
+	}
+
+	if len(unspread) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-replica-spread-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Multi-Replica Workloads Spread Their Pods",
+			Description: fmt.Sprintf("All %d multi-replica workload(s) in user namespaces declare pod anti-affinity or topology spread constraints.", checked),
+		}}
+	}
+
+	status := assessmentv1alpha1.FindingStatusInfo
+	if profile.Thresholds.RequireReplicaSpread {
+		status = assessmentv1alpha1.FindingStatusWarn
+	}
+
+	sample := unspread
+	if len(sample) > 5 {
+		sample = sample[:5]
+	}
+
+	return []assessmentv1alpha1.Finding{{
+		ID:             "workloadhealth-replica-spread",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         status,
+		Title:          "Multi-Replica Workloads Without Pod Spreading",
+		Description:    fmt.Sprintf("Found %d of %d multi-replica workload(s) without pod anti-affinity or topology spread constraints: %s", len(unspread), checked, strings.Join(sample, ", ")),
+		Impact:         "All replicas may be scheduled onto one node, so a single node failure or drain takes the whole workload down.",
+		Recommendation: "Add topologySpreadConstraints on kubernetes.io/hostname (or topology.kubernetes.io/zone), or a podAntiAffinity rule, to the pod template.",
+		References: []string{
+			"https://kubernetes.io/docs/concepts/scheduling-eviction/topology-spread-constraints/",
+			"https://kubernetes.io/docs/concepts/scheduling-eviction/assign-pod-node/#inter-pod-affinity-and-anti-affinity",
+		},
+	}}
+}
+
+// checkDaemonSetCoverage flags DaemonSets in user namespaces that are not
+// scheduled on every node they should run on, or that run pods on nodes they
+// should not run on. DaemonSets whose controller has not yet observed the
+// latest spec are skipped, as their status is stale.
+func (v *WorkloadHealthValidator) checkDaemonSetCoverage(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	daemonSets := &appsv1.DaemonSetList{}
+	if err := c.List(ctx, daemonSets); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-daemonset-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check DaemonSets",
+			Description: fmt.Sprintf("Failed to list DaemonSets: %v", err),
+		}}
+	}
+
+	var checked int
+	var gaps, misscheduled []string
+	var gapEvidence, misscheduledEvidence string
+
+	for _, ds := range daemonSets.Items {
+		if validator.IsPlatformNamespace(ds.Namespace) || !validator.Selected(ctx, &ds) || ds.Status.ObservedGeneration < ds.Generation {
+			continue
+		}
+		checked++
+
+		name := fmt.Sprintf("%s/%s", ds.Namespace, ds.Name)
+		if ds.Status.CurrentNumberScheduled < ds.Status.DesiredNumberScheduled {
+			gaps = append(gaps, fmt.Sprintf("%s (%d/%d nodes)", name, ds.Status.CurrentNumberScheduled, ds.Status.DesiredNumberScheduled))
+			if len(gaps) == 1 {
+				gapEvidence = validator.Evidence(ctx, &ds)
+			}
+		}
+		if ds.Status.NumberMisscheduled > 0 {
+			misscheduled = append(misscheduled, fmt.Sprintf("%s (%d pods)", name, ds.Status.NumberMisscheduled))
+			if len(misscheduled) == 1 {
+				misscheduledEvidence = validator.Evidence(ctx, &ds)
+			}
+		}
+	}
+
+	if checked == 0 {
+		return nil
+	}
+
+	var findings []assessmentv1alpha1.Finding
+	if len(gaps) > 0 {
+		sample := gaps
+		if len(sample) > 5 {
+			sample = sample[:5]
+		}
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "workloadhealth-daemonset-coverage",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "DaemonSets Not Scheduled on All Eligible Nodes",
+			Description:    fmt.Sprintf("Found %d DaemonSet(s) with fewer scheduled pods than eligible nodes: %s", len(gaps), strings.Join(sample, ", ")),
+			Impact:         "Nodes without the DaemonSet pod miss its per-node function, such as log collection, monitoring or storage and network agents.",
+			Recommendation: "Check the DaemonSet events and the affected nodes for taints without matching tolerations, insufficient resources or host port conflicts.",
+			References: []string{
+				"https://kubernetes.io/docs/concepts/workloads/controllers/daemonset/",
+			},
+			Evidence: gapEvidence,
+		})
+	}
+
+	if len(misscheduled) > 0 {
+		sample := misscheduled
+		if len(sample) > 5 {
+			sample = sample[:5]
+		}
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "workloadhealth-daemonset-misscheduled",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "DaemonSets With Misscheduled Pods",
+			Description:    fmt.Sprintf("Found %d DaemonSet(s) running pods on nodes they should not run on: %s", len(misscheduled), strings.Join(sample, ", ")),
+			Impact:         "Misscheduled pods usually follow a node label or taint change and consume resources on nodes the DaemonSet no longer targets.",
+			Recommendation: "Review the DaemonSet node selector, affinity and tolerations against the current node labels and taints.",
+			References: []string{
+				"https://kubernetes.io/docs/concepts/workloads/controllers/daemonset/#how-daemon-pods-are-scheduled",
+			},
+			Evidence: misscheduledEvidence,
+		})
+	}
+
+	if len(findings) == 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "workloadhealth-daemonset-coverage-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "DaemonSets Cover Their Eligible Nodes",
+			Description: fmt.Sprintf("All %d DaemonSet(s) in user namespaces are scheduled on every eligible node and nowhere else.", checked),
+		})
+	}
+
+	return findings
+}
+
+// checkReplicaColocation flags multi-replica Deployments in user namespaces
+// whose running pods are all on the same node right now. Unlike
+// checkReplicaSpread it looks at actual placement, so it also catches
+// workloads that declare spreading but could not be spread, for example
+// because only one node matched or preferred rules were outweighed.
+func (v *WorkloadHealthValidator) checkReplicaColocation(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	deployments, err := validator.ListDeployments(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-deployment-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Deployments",
+			Description: fmt.Sprintf("Failed to list Deployments: %v", err),
+		}}
+	}
+	pods, err := validator.ListPods(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-pod-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Pod Placement",
+			Description: fmt.Sprintf("Failed to list pods: %v", err),
+		}}
+	}
+
+	runningPods := make(map[string][]corev1.Pod)
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning || pod.Spec.NodeName == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		runningPods[pod.Namespace] = append(runningPods[pod.Namespace], pod)
+	}
+
+	var checked int
+	var colocated []string
+	var evidence string
+	for _, d := range deployments {
+		if validator.IsPlatformNamespace(d.Namespace) || !validator.Selected(ctx, &d) || d.Spec.Replicas == nil || *d.Spec.Replicas < 2 || d.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+		if err != nil || selector.Empty() {
+			continue
+		}
+
+		nodes := make(map[string]bool)
+		var running int
+		for _, pod := range runningPods[d.Namespace] {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				running++
+				nodes[pod.Spec.NodeName] = true
+			}
+		}
+		if running < 2 {
+			continue
+		}
+		checked++
+		if len(nodes) > 1 {
+			continue
+		}
+
+		var node string
+		for n := range nodes {
+			node = n
+		}
+		entry := fmt.Sprintf("%s/%s (%d pods on %s)", d.Namespace, d.Name, running, node)
+		if spreadsReplicas(d.Spec.Template.Spec) {
+			entry = fmt.Sprintf("%s/%s (%d pods on %s despite declared spreading)", d.Namespace, d.Name, running, node)
+		}
+		colocated = append(colocated, entry)
+		if len(colocated) == 1 {
+			evidence = validator.Evidence(ctx, &d)
+		}
+	}
+
+	if checked == 0 {
+		return nil
+	}
+
+	if len(colocated) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-replica-colocation-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Multi-Replica Deployments Run on Several Nodes",
+			Description: fmt.Sprintf("All %d multi-replica Deployment(s) in user namespaces have running pods on more than one node.", checked),
+		}}
+	}
+
+	sample := colocated
+	if len(sample) > 5 {
+		sample = sample[:5]
+	}
+
+	return []assessmentv1alpha1.Finding{{
+		ID:             "workloadhealth-replicas-colocated",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Title:          "All Replicas Running on One Node",
+		Description:    fmt.Sprintf("Found %d of %d multi-replica Deployment(s) with every running pod on the same node: %s", len(colocated), checked, strings.Join(sample, ", ")),
+		Impact:         "The workload is not highly available right now: a failure, reboot or drain of that node takes every replica down at once.",
+		Recommendation: "Add a podAntiAffinity rule or topologySpreadConstraints on kubernetes.io/hostname. Where spreading is already declared, check that enough nodes match the pod's node selector, tolerations and resource requests, and use a required rule if a preferred one is being outweighed. Deleting a pod lets the scheduler place it again.",
+		References: []string{
+			"https://kubernetes.io/docs/concepts/scheduling-eviction/topology-spread-constraints/",
+			"https://kubernetes.io/docs/concepts/scheduling-eviction/assign-pod-node/#inter-pod-affinity-and-anti-affinity",
+		},
+		Evidence: evidence,
+	}}
+}
+
+// checkPriorityClasses flags workloads in critical user namespaces that do
+// not set a priorityClassName, and workloads in user namespaces that use the
+// system-cluster-critical or system-node-critical classes. Critical namespaces
+// are those labeled openshift.io/cluster-monitoring=true and those listed in
+// the criticalNamespaces override.
+func (v *WorkloadHealthValidator) checkPriorityClasses(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	namespaces, err := validator.ListNamespaces(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-namespace-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Namespaces",
+			Description: fmt.Sprintf("Failed to list namespaces: %v", err),
+		}}
+	}
+	critical := make(map[string]bool)
+	for _, ns := range namespaces {
+		if ns.Labels[clusterMonitoringLabel] == "true" {
+			critical[ns.Name] = true
+		}
+	}
+	for _, ns := range validator.ConfigList(ctx, configCriticalNamespaces) {
+		critical[ns] = true
+	}
+
+	deployments, err := validator.ListDeployments(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-deployment-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Deployments",
+			Description: fmt.Sprintf("Failed to list Deployments: %v", err),
+		}}
+	}
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := c.List(ctx, statefulSets); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-statefulset-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check StatefulSets",
+			Description: fmt.Sprintf("Failed to list StatefulSets: %v", err),
+		}}
+	}
+	daemonSets := &appsv1.DaemonSetList{}
+	if err := c.List(ctx, daemonSets); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-daemonset-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check DaemonSets",
+			Description: fmt.Sprintf("Failed to list DaemonSets: %v", err),
+		}}
+	}
+
+	var checked int
+	var unprioritized, misused []string
+
+	check := func(kind, ns, name string, spec corev1.PodSpec) {
+		if validator.IsPlatformNamespace(ns) {
+			return
+		}
+		checked++
+		switch {
+		case systemPriorityClasses[spec.PriorityClassName]:
+			misused = append(misused, fmt.Sprintf("%s %s/%s (%s)", kind, ns, name, spec.PriorityClassName))
+		case critical[ns] && spec.PriorityClassName == "":
+			unprioritized = append(unprioritized, fmt.Sprintf("%s %s/%s", kind, ns, name))
+		}
+	}
+	for _, d := range deployments {
+		if !validator.Selected(ctx, &d) {
+			continue
+		}
+		check("Deployment", d.Namespace, d.Name, d.Spec.Template.Spec)
+	}
+	for _, sts := range statefulSets.Items {
+		if !validator.Selected(ctx, &sts) {
+			continue
+		}
+		check("StatefulSet", sts.Namespace, sts.Name, sts.Spec.Template.Spec)
+	}
+	for _, ds := range daemonSets.Items {
+		if !validator.Selected(ctx, &ds) {
+			continue
+		}
+		check("DaemonSet", ds.Namespace, ds.Name, ds.Spec.Template.Spec)
+	}
+
+	if checked == 0 {
+		return nil
+	}
+
+	var findings []assessmentv1alpha1.Finding
+	if len(misused) > 0 {
+		sample := misused
+		if len(sample) > 5 {
+			sample = sample[:5]
+		}
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "workloadhealth-system-priority-misuse",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "User Workloads Using System Priority Classes",
+			Description:    fmt.Sprintf("Found %d workload(s) in user namespaces using a system priority class: %s", len(misused), strings.Join(sample, ", ")),
+			Impact:         "Pods with system-cluster-critical or system-node-critical priority are scheduled ahead of, and can preempt, platform components. Under resource pressure they can starve or evict the pods the cluster needs to stay healthy.",
+			Recommendation: "Create a dedicated PriorityClass with a value below the system classes for important application workloads, and reserve the system classes for platform components. A ResourceQuota with a priorityClass scope selector can prevent their use in user namespaces.",
+			References: []string{
+				"https://kubernetes.io/docs/concepts/scheduling-eviction/pod-priority-preemption/",
+				"https://kubernetes.io/docs/concepts/policy/resource-quotas/#limit-priority-class-consumption-by-default",
+			},
+		})
+	}
+	if len(unprioritized) > 0 {
+		sample := unprioritized
+		if len(sample) > 5 {
+			sample = sample[:5]
+		}
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:             "workloadhealth-critical-namespace-no-priority",
+			Validator:      validatorName,
+			Category:       validatorCategory,
+			Status:         assessmentv1alpha1.FindingStatusWarn,
+			Title:          "Critical Workloads Without a Priority Class",
+			Description:    fmt.Sprintf("Found %d workload(s) in critical namespaces running at the default priority: %s", len(unprioritized), strings.Join(sample, ", ")),
+			Impact:         "Without a priority class these workloads compete with every other pod for capacity. When the cluster is full they cannot preempt less important pods and are among the first to stay Pending or be evicted.",
+			Recommendation: "Set priorityClassName in the pod template to a PriorityClass that reflects how critical the workload is.",
+			References: []string{
+				"https://kubernetes.io/docs/concepts/scheduling-eviction/pod-priority-preemption/",
+			},
+		})
+	}
+
+	if len(findings) == 0 {
+		findings = append(findings, assessmentv1alpha1.Finding{
+			ID:          "workloadhealth-priority-classes-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Priority Classes Used Appropriately",
+			Description: fmt.Sprintf("None of the %d workload(s) in user namespaces uses a system priority class, and workloads in critical namespaces set a priority class.", checked),
+		})
+	}
+
+	return findings
+}
+
+// checkTerminationGracePeriods flags user workloads, Jobs, CronJobs and
+// standalone pods with terminationGracePeriodSeconds set to 0, which makes
+// the kubelet kill their containers immediately instead of letting them shut
+// down. Pods are checked through the template of their controller when it is
+// one of these, and on their own otherwise.
+func (v *WorkloadHealthValidator) checkTerminationGracePeriods(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	deployments, err := validator.ListDeployments(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-deployment-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Deployments",
+			Description: fmt.Sprintf("Failed to list Deployments: %v", err),
+		}}
+	}
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := c.List(ctx, statefulSets); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-statefulset-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check StatefulSets",
+			Description: fmt.Sprintf("Failed to list StatefulSets: %v", err),
+		}}
+	}
+	daemonSets := &appsv1.DaemonSetList{}
+	if err := c.List(ctx, daemonSets); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-daemonset-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check DaemonSets",
+			Description: fmt.Sprintf("Failed to list DaemonSets: %v", err),
+		}}
+	}
+	jobs := &batchv1.JobList{}
+	if err := c.List(ctx, jobs); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-job-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Jobs",
+			Description: fmt.Sprintf("Failed to list Jobs: %v", err),
+		}}
+	}
+	cronJobs := &batchv1.CronJobList{}
+	if err := c.List(ctx, cronJobs); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-cronjob-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check CronJobs",
+			Description: fmt.Sprintf("Failed to list CronJobs: %v", err),
+		}}
+	}
+	pods, err := validator.ListPods(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-pod-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Pods",
+			Description: fmt.Sprintf("Failed to list pods: %v", err),
+		}}
+	}
+
+	var checked int
+	var immediate []string
+	var evidence string
+
+	check := func(kind, ns, name string, spec corev1.PodSpec, obj client.Object) {
+		if validator.IsPlatformNamespace(ns) {
+			return
+		}
+		checked++
+		if spec.TerminationGracePeriodSeconds == nil || *spec.TerminationGracePeriodSeconds != 0 {
+			return
+		}
+		immediate = append(immediate, fmt.Sprintf("%s %s/%s", kind, ns, name))
+		if len(immediate) == 1 {
+			evidence = validator.Evidence(ctx, obj)
+		}
+	}
+	for _, sts := range statefulSets.Items {
+		if !validator.Selected(ctx, &sts) {
+			continue
+		}
+		check("StatefulSet", sts.Namespace, sts.Name, sts.Spec.Template.Spec, &sts)
+	}
+	for _, d := range deployments {
+		if !validator.Selected(ctx, &d) {
+			continue
+		}
+		check("Deployment", d.Namespace, d.Name, d.Spec.Template.Spec, &d)
+	}
+	for _, ds := range daemonSets.Items {
+		if !validator.Selected(ctx, &ds) {
+			continue
+		}
+		check("DaemonSet", ds.Namespace, ds.Name, ds.Spec.Template.Spec, &ds)
+	}
+	for _, cj := range cronJobs.Items {
+		if !validator.Selected(ctx, &cj) {
+			continue
+		}
+		check("CronJob", cj.Namespace, cj.Name, cj.Spec.JobTemplate.Spec.Template.Spec, &cj)
+	}
+	for _, job := range jobs.Items {
+		// Jobs created by a CronJob inherit its template, checked above
+		if ownedBy(&job, "CronJob") || !validator.Selected(ctx, &job) {
+			continue
+		}
+		check("Job", job.Namespace, job.Name, job.Spec.Template.Spec, &job)
+	}
+	// Pods created by one of the controllers above inherit its template.
+	// Pods of other controllers, such as operators, are checked on their own.
+	for _, pod := range pods {
+		if ownedBy(&pod, "ReplicaSet", "StatefulSet", "DaemonSet", "Job") || !validator.Selected(ctx, &pod) {
+			continue
+		}
+		check("Pod", pod.Namespace, pod.Name, pod.Spec, &pod)
+	}
+
+	if checked == 0 {
+		return nil
+	}
+
+	if len(immediate) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-grace-period-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Workloads Shut Down Gracefully",
+			Description: fmt.Sprintf("None of the %d workload(s) and standalone pod(s) in user namespaces sets terminationGracePeriodSeconds to 0.", checked),
+		}}
+	}
+
+	sample := immediate
+	if len(sample) > 5 {
+		sample = sample[:5]
+	}
+	return []assessmentv1alpha1.Finding{{
+		ID:             "workloadhealth-zero-grace-period",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Title:          "Workloads Killed Without a Grace Period",
+		Description:    fmt.Sprintf("Found %d workload(s) or standalone pod(s) with terminationGracePeriodSeconds set to 0: %s", len(immediate), strings.Join(sample, ", ")),
+		Impact:         "With a grace period of 0 the containers receive SIGKILL as soon as the pod is deleted, during every rollout, drain and eviction. In-flight requests are dropped, and stateful applications such as databases cannot flush their data and may corrupt it.",
+		Recommendation: "Remove terminationGracePeriodSeconds to use the default of 30 seconds, or set it to the time the application needs to shut down cleanly. Use a preStop hook if it needs a signal before SIGTERM.",
+		References: []string{
+			"https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle/#pod-termination",
+		},
+		Evidence: evidence,
+	}}
+}
+
+// ownedBy reports whether obj is controlled by an object of one of kinds.
+func ownedBy(obj metav1.Object, kinds ...string) bool {
+	owner := metav1.GetControllerOf(obj)
+	return owner != nil && slices.Contains(kinds, owner.Kind)
+}
+
+// spreadsReplicas reports whether a pod template asks the scheduler to keep
+// its replicas apart.
+func spreadsReplicas(spec corev1.PodSpec) bool {
+	if len(spec.TopologySpreadConstraints) > 0 {
+		return true
+	}
+	if spec.Affinity == nil || spec.Affinity.PodAntiAffinity == nil {
+		return false
+	}
+	anti := spec.Affinity.PodAntiAffinity
+	return len(anti.RequiredDuringSchedulingIgnoredDuringExecution) > 0 ||
+		len(anti.PreferredDuringSchedulingIgnoredDuringExecution) > 0
+}
+
+// configRef is a ConfigMap or Secret referenced by a pod template.
+type configRef struct {
+	kind string
+	name string
+}
+
+// checkConfigReferences flags Deployments and StatefulSets whose pod template
+// references a ConfigMap or Secret that does not exist in its namespace, which
+// keeps new pods from starting. Optional references are ignored.
+func (v *WorkloadHealthValidator) checkConfigReferences(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	deployments, err := validator.ListDeployments(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-deployment-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Deployments",
+			Description: fmt.Sprintf("Failed to list Deployments: %v", err),
+		}}
+	}
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := c.List(ctx, statefulSets); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-statefulset-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check StatefulSets",
+			Description: fmt.Sprintf("Failed to list StatefulSets: %v", err),
+		}}
+	}
+
+	// Only metadata is needed to know that an object exists, and avoids
+	// reading Secret data.
+	existing := make(map[string]bool)
+	for _, kind := range []string{"ConfigMap", "Secret"} {
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(kind + "List"))
+		if err := c.List(ctx, list); err != nil {
+			return []assessmentv1alpha1.Finding{{
+				ID:          "workloadhealth-config-refs-error",
+				Validator:   validatorName,
+				Category:    validatorCategory,
+				Status:      assessmentv1alpha1.FindingStatusInfo,
+				Title:       "Unable to Check ConfigMap and Secret References",
+				Description: fmt.Sprintf("Failed to list %ss: %v", kind, err),
+			}}
+		}
+		for _, item := range list.Items {
+			existing[fmt.Sprintf("%s/%s/%s", kind, item.Namespace, item.Name)] = true
+		}
+	}
+
+	var checked int
+	var dangling []string
+
+	check := func(kind, ns, name string, spec corev1.PodSpec) {
+		if validator.IsPlatformNamespace(ns) {
+			return
+		}
+		checked++
+		for _, ref := range configReferences(spec) {
+			if !existing[fmt.Sprintf("%s/%s/%s", ref.kind, ns, ref.name)] {
+				dangling = append(dangling, fmt.Sprintf("%s %s/%s -> %s %s", kind, ns, name, ref.kind, ref.name))
+			}
+		}
+	}
+	for _, d := range deployments {
+		if !validator.Selected(ctx, &d) {
+			continue
+		}
+		check("Deployment", d.Namespace, d.Name, d.Spec.Template.Spec)
+	}
+	for _, sts := range statefulSets.Items {
+		if !validator.Selected(ctx, &sts) {
+			continue
+		}
+		check("StatefulSet", sts.Namespace, sts.Name, sts.Spec.Template.Spec)
+	}
+
+	if checked == 0 {
+		return nil
+	}
+
+	if len(dangling) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-config-refs-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "ConfigMap and Secret References Resolve",
+			Description: fmt.Sprintf("All ConfigMaps and Secrets referenced by %d workload(s) in user namespaces exist.", checked),
+		}}
+	}
+
+	sample := dangling
+	if len(sample) > 5 {
+		sample = sample[:5]
+	}
+
+	return []assessmentv1alpha1.Finding{{
+		ID:             "workloadhealth-missing-config-refs",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Title:          "Workloads Referencing Missing ConfigMaps or Secrets",
+		Description:    fmt.Sprintf("Found %d reference(s) to ConfigMaps or Secrets that do not exist: %s", len(dangling), strings.Join(sample, ", ")),
+		Impact:         "Pods that reference a missing ConfigMap or Secret stay in ContainerCreating or CreateContainerConfigError, so rollouts and rescheduled replicas fail to start.",
+		Recommendation: "Create the missing objects, fix the names in the pod template, or mark the reference optional if the workload can run without it.",
+		References: []string{
+			"https://kubernetes.io/docs/concepts/configuration/configmap/#using-configmaps-as-files-from-a-pod",
+		},
+	}}
+}
+
+// configReferences returns the distinct ConfigMaps and Secrets a pod template
+// requires through volumes, envFrom and env valueFrom.
+func configReferences(spec corev1.PodSpec) []configRef {
+	var refs []configRef
+	seen := make(map[configRef]bool)
+	add := func(kind, name string, optional *bool) {
+		ref := configRef{kind: kind, name: name}
+		if name == "" || (optional != nil && *optional) || seen[ref] {
+			return
+		}
+		seen[ref] = true
+		refs = append(refs, ref)
+	}
+
+	for _, vol := range spec.Volumes {
+		if vol.ConfigMap != nil {
+			add("ConfigMap", vol.ConfigMap.Name, vol.ConfigMap.Optional)
+		}
+		if vol.Secret != nil {
+			add("Secret", vol.Secret.SecretName, vol.Secret.Optional)
+		}
+		if vol.Projected != nil {
+			for _, source := range vol.Projected.Sources {
+				if source.ConfigMap != nil {
+					add("ConfigMap", source.ConfigMap.Name, source.ConfigMap.Optional)
+				}
+				if source.Secret != nil {
+					add("Secret", source.Secret.Name, source.Secret.Optional)
+				}
+			}
+		}
+	}
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				add("ConfigMap", envFrom.ConfigMapRef.Name, envFrom.ConfigMapRef.Optional)
+			}
+			if envFrom.SecretRef != nil {
+				add("Secret", envFrom.SecretRef.Name, envFrom.SecretRef.Optional)
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+				add("ConfigMap", ref.Name, ref.Optional)
+			}
+			if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+				add("Secret", ref.Name, ref.Optional)
+			}
+		}
+	}
+
+	return refs
+}
+
+// spotNodeLabels maps node labels that mark spot or preemptible capacity to
+// the value they must have. An empty value matches any value.
+var spotNodeLabels = map[string]string{
+	"machine.openshift.io/interruptible-instance": "",
+	"node.kubernetes.io/lifecycle":                "spot",
+	"karpenter.sh/capacity-type":                  "spot",
+	"eks.amazonaws.com/capacityType":              "SPOT",
+	"cloud.google.com/gke-spot":                   "true",
+	"cloud.google.com/gke-preemptible":            "true",
+	"kubernetes.azure.com/scalesetpriority":       "spot",
+}
+
+// isSpotNode reports whether the node runs on spot or preemptible capacity.
+func isSpotNode(node *corev1.Node) bool {
+	for key, want := range spotNodeLabels {
+		if value, ok := node.Labels[key]; ok && (want == "" || value == want) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSpotPlacement flags StatefulSets and single-replica Deployments in user
+// namespaces with running pods on spot or preemptible nodes when they have
+// neither a PodDisruptionBudget nor pod anti-affinity or topology spreading.
+// Clusters without spot nodes are skipped.
+func (v *WorkloadHealthValidator) checkSpotPlacement(ctx context.Context, c client.Client) []assessmentv1alpha1.Finding {
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-node-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Spot Nodes",
+			Description: fmt.Sprintf("Failed to list nodes: %v", err),
+		}}
+	}
+	spotNodes := make(map[string]bool)
+	for i := range nodes.Items {
+		if isSpotNode(&nodes.Items[i]) {
+			spotNodes[nodes.Items[i].Name] = true
+		}
+	}
+	if len(spotNodes) == 0 {
+		return nil
+	}
+
+	deployments, err := validator.ListDeployments(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-deployment-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Deployments",
+			Description: fmt.Sprintf("Failed to list Deployments: %v", err),
+		}}
+	}
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := c.List(ctx, statefulSets); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-statefulset-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check StatefulSets",
+			Description: fmt.Sprintf("Failed to list StatefulSets: %v", err),
+		}}
+	}
+	pdbs := &policyv1.PodDisruptionBudgetList{}
+	if err := c.List(ctx, pdbs); err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-pdb-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check PodDisruptionBudgets",
+			Description: fmt.Sprintf("Failed to list PodDisruptionBudgets: %v", err),
+		}}
+	}
+	pods, err := validator.ListPods(ctx, c)
+	if err != nil {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-pod-error",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusInfo,
+			Title:       "Unable to Check Pod Placement",
+			Description: fmt.Sprintf("Failed to list pods: %v", err),
+		}}
+	}
+
+	spotPods := make(map[string][]corev1.Pod)
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning || !spotNodes[pod.Spec.NodeName] || pod.DeletionTimestamp != nil {
+			continue
+		}
+		spotPods[pod.Namespace] = append(spotPods[pod.Namespace], pod)
+	}
+	budgets := make(map[string][]labels.Selector)
+	for _, pdb := range pdbs.Items {
+		if pdb.Spec.Selector == nil {
+			continue
+		}
+		if selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector); err == nil && !selector.Empty() {
+			budgets[pdb.Namespace] = append(budgets[pdb.Namespace], selector)
+		}
+	}
+
+	var checked int
+	var unprotected []string
+	var evidence string
+
+	check := func(obj client.Object, kind string, podSelector *metav1.LabelSelector, template corev1.PodTemplateSpec) {
+		ns := obj.GetNamespace()
+		if validator.IsPlatformNamespace(ns) || podSelector == nil {
+			return
+		}
+		selector, err := metav1.LabelSelectorAsSelector(podSelector)
+		if err != nil || selector.Empty() {
+			return
+		}
+
+		var spotNode string
+		for _, pod := range spotPods[ns] {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				spotNode = pod.Spec.NodeName
+				break
+			}
+		}
+		if spotNode == "" {
+			return
+		}
+		checked++
+
+		if spreadsReplicas(template.Spec) {
+			return
+		}
+		for _, budget := range budgets[ns] {
+			if budget.Matches(labels.Set(template.Labels)) {
+				return
+			}
+		}
+		unprotected = append(unprotected, fmt.Sprintf("%s %s/%s on %s", kind, ns, obj.GetName(), spotNode))
+		if len(unprotected) == 1 {
+			evidence = validator.Evidence(ctx, obj)
+		}
+	}
+	for i := range deployments {
+		d := &deployments[i]
+		if !validator.Selected(ctx, d) || (d.Spec.Replicas != nil && *d.Spec.Replicas != 1) {
+			continue
+		}
+		check(d, "Deployment", d.Spec.Selector, d.Spec.Template)
+	}
+	for i := range statefulSets.Items {
+		sts := &statefulSets.Items[i]
+		if !validator.Selected(ctx, sts) {
+			continue
+		}
+		check(sts, "StatefulSet", sts.Spec.Selector, sts.Spec.Template)
+	}
+
+	if checked == 0 {
+		return nil
+	}
+
+	if len(unprotected) == 0 {
+		return []assessmentv1alpha1.Finding{{
+			ID:          "workloadhealth-spot-placement-ok",
+			Validator:   validatorName,
+			Category:    validatorCategory,
+			Status:      assessmentv1alpha1.FindingStatusPass,
+			Title:       "Workloads on Spot Nodes Tolerate Disruption",
+			Description: fmt.Sprintf("All %d stateful or single-replica workload(s) running on spot nodes have a PodDisruptionBudget or spread their pods.", checked),
+		}}
+	}
+
+	sample := unprotected
+	if len(sample) > 5 {
+		sample = sample[:5]
+	}
+
+	return []assessmentv1alpha1.Finding{{
+		ID:             "workloadhealth-spot-unprotected",
+		Validator:      validatorName,
+		Category:       validatorCategory,
+		Status:         assessmentv1alpha1.FindingStatusWarn,
+		Confidence:     assessmentv1alpha1.FindingConfidenceMedium,
+		Title:          "Disruption-Sensitive Workloads on Spot Nodes",
+		Description:    fmt.Sprintf("Found %d of %d stateful or single-replica workload(s) on the cluster's %d spot node(s) without a PodDisruptionBudget or pod spreading: %s", len(unprotected), checked, len(spotNodes), strings.Join(sample, ", ")),
+		Impact:         "The cloud provider can reclaim spot capacity at short notice. When it does, these workloads go down until their pods are rescheduled, and a StatefulSet pod cannot start elsewhere until its volume is detached.",
+		Recommendation: "Keep stateful and single-replica workloads off spot capacity with a node selector or node affinity for on-demand nodes. Otherwise run several replicas, spread them with topologySpreadConstraints or podAntiAffinity, and add a PodDisruptionBudget.",
+		References: []string{
+			"https://docs.openshift.com/container-platform/latest/machine_management/creating_machinesets/creating-machineset-aws.html#machineset-non-guaranteed-instance_creating-machineset-aws",
+			"https://kubernetes.io/docs/concepts/workloads/pods/disruptions/",
+		},
+		Evidence: evidence,
+	}}
+}
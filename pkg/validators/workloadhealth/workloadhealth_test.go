@@ -0,0 +1,545 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadhealth
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	assessmentv1alpha1 "github.com/openshift-assessment/cluster-assessment-operator/api/v1alpha1"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/profiles"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator"
+	"github.com/openshift-assessment/cluster-assessment-operator/pkg/validator/validatortest"
+)
+
+func newStatefulSet(ns, name, serviceName string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		Spec:       appsv1.StatefulSetSpec{ServiceName: serviceName},
+	}
+}
+
+func newService(ns, name, clusterIP string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		Spec:       corev1.ServiceSpec{ClusterIP: clusterIP},
+	}
+}
+
+func TestCheckStatefulSetServices(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	objs := []client.Object{
+		newStatefulSet("app", "good", "good-headless"),
+		newService("app", "good-headless", corev1.ClusterIPNone),
+		newStatefulSet("app", "missing", "does-not-exist"),
+		newStatefulSet("app", "clusterip", "regular"),
+		newService("app", "regular", "10.0.0.1"),
+		newStatefulSet("openshift-monitoring", "prometheus", "ignored"),
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	v := &WorkloadHealthValidator{}
+
+	findings := v.checkStatefulSetServices(context.Background(), c)
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 finding, got %d", len(findings))
+	}
+
+	f := findings[0]
+	if f.ID != "workloadhealth-statefulset-headless-service" || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Errorf("Unexpected finding %s (%s)", f.ID, f.Status)
+	}
+	for _, want := range []string{"app/missing -> does-not-exist (missing)", "app/clusterip -> regular (not headless)"} {
+		if !strings.Contains(f.Description, want) {
+			t.Errorf("Expected description to contain %q, got %q", want, f.Description)
+		}
+	}
+	if strings.Contains(f.Description, "good") || strings.Contains(f.Description, "prometheus") {
+		t.Errorf("Description should not list healthy or system StatefulSets: %q", f.Description)
+	}
+
+	// The status can be overridden through validator config.
+	ctx := validator.WithConfig(context.Background(), map[string]string{configStatefulSetServiceStatus: "fail"})
+	findings = v.checkStatefulSetServices(ctx, c)
+	if findings[0].Status != assessmentv1alpha1.FindingStatusFail {
+		t.Errorf("Expected overridden status FAIL, got %s", findings[0].Status)
+	}
+}
+
+func newDeployment(ns, name string, replicas int32, spec corev1.PodSpec) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{Spec: spec},
+		},
+	}
+}
+
+func TestCheckReplicaSpread(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	spread := corev1.PodSpec{TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       "kubernetes.io/hostname",
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+	}}}
+	antiAffinity := corev1.PodSpec{Affinity: &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+			Weight:          100,
+			PodAffinityTerm: corev1.PodAffinityTerm{TopologyKey: "kubernetes.io/hostname"},
+		}},
+	}}}
+
+	replicas := int32(3)
+	sts := newStatefulSet("app", "db", "db-headless")
+	sts.Spec.Replicas = &replicas
+
+	objs := []client.Object{
+		newDeployment("app", "spread", 3, spread),
+		newDeployment("app", "anti-affinity", 2, antiAffinity),
+		newDeployment("app", "packed", 3, corev1.PodSpec{}),
+		newDeployment("app", "single", 1, corev1.PodSpec{}),
+		newDeployment("openshift-console", "console", 2, corev1.PodSpec{}),
+		sts,
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	v := &WorkloadHealthValidator{}
+
+	findings := v.checkReplicaSpread(context.Background(), c, profiles.GetProfile("production"))
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 finding, got %d", len(findings))
+	}
+	f := findings[0]
+	if f.ID != "workloadhealth-replica-spread" || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Errorf("Unexpected finding %s (%s)", f.ID, f.Status)
+	}
+	for _, want := range []string{"Deployment app/packed (3 replicas)", "StatefulSet app/db (3 replicas)", "2 of 4"} {
+		if !strings.Contains(f.Description, want) {
+			t.Errorf("Expected description to contain %q, got %q", want, f.Description)
+		}
+	}
+	for _, unwanted := range []string{"spread", "anti-affinity", "single", "console"} {
+		if strings.Contains(f.Description, "app/"+unwanted+" ") || strings.Contains(f.Description, unwanted+" (") {
+			t.Errorf("Description should not list %q: %q", unwanted, f.Description)
+		}
+	}
+
+	findings = v.checkReplicaSpread(context.Background(), c, profiles.GetProfile("development"))
+	if findings[0].Status != assessmentv1alpha1.FindingStatusInfo {
+		t.Errorf("Expected INFO with the development profile, got %s", findings[0].Status)
+	}
+}
+
+func TestCheckConfigReferences(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	optional := true
+	spec := corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"},
+			}}},
+			{Name: "tls", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "app-tls"}}},
+			{Name: "extra", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "extra", Optional: &optional}}},
+		},
+		Containers: []corev1.Container{{
+			Name: "app",
+			EnvFrom: []corev1.EnvFromSource{{
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "db-creds"}},
+			}},
+			Env: []corev1.EnvVar{{
+				Name: "MODE",
+				ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"},
+					Key:                  "mode",
+				}},
+			}},
+		}},
+	}
+
+	objs := []client.Object{
+		newDeployment("app", "web", 1, spec),
+		newDeployment("other", "web", 1, spec),
+		newDeployment("openshift-console", "console", 1, spec),
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "app"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "app-tls", Namespace: "app"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db-creds", Namespace: "app"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "other"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	v := &WorkloadHealthValidator{}
+
+	findings := v.checkConfigReferences(context.Background(), c)
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 finding, got %d", len(findings))
+	}
+	f := findings[0]
+	if f.ID != "workloadhealth-missing-config-refs" || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("Unexpected finding %s (%s)", f.ID, f.Status)
+	}
+	for _, want := range []string{"Found 2 reference(s)", "Deployment other/web -> Secret app-tls", "Deployment other/web -> Secret db-creds"} {
+		if !strings.Contains(f.Description, want) {
+			t.Errorf("Expected description to contain %q, got %q", want, f.Description)
+		}
+	}
+	for _, unwanted := range []string{"app/web", "console", "extra"} {
+		if strings.Contains(f.Description, unwanted) {
+			t.Errorf("Description should not mention %q: %q", unwanted, f.Description)
+		}
+	}
+}
+
+func newDaemonSet(ns, name string, generation int64, status appsv1.DaemonSetStatus) *appsv1.DaemonSet {
+	status.ObservedGeneration = generation
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns, Generation: generation},
+		Status:     status,
+	}
+}
+
+func TestCheckDaemonSetCoverage(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	rollingOut := newDaemonSet("app", "rolling", 2, appsv1.DaemonSetStatus{DesiredNumberScheduled: 3})
+	rollingOut.Status.ObservedGeneration = 1
+
+	objs := []client.Object{
+		newDaemonSet("app", "agent", 1, appsv1.DaemonSetStatus{DesiredNumberScheduled: 5, CurrentNumberScheduled: 3}),
+		newDaemonSet("app", "proxy", 1, appsv1.DaemonSetStatus{DesiredNumberScheduled: 2, CurrentNumberScheduled: 2, NumberMisscheduled: 1}),
+		newDaemonSet("app", "healthy", 1, appsv1.DaemonSetStatus{DesiredNumberScheduled: 4, CurrentNumberScheduled: 4}),
+		newDaemonSet("openshift-dns", "dns-default", 1, appsv1.DaemonSetStatus{DesiredNumberScheduled: 6}),
+		rollingOut,
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	v := &WorkloadHealthValidator{}
+
+	findings := v.checkDaemonSetCoverage(context.Background(), c)
+	if len(findings) != 2 {
+		t.Fatalf("Expected 2 findings, got %d: %+v", len(findings), findings)
+	}
+	if f := findings[0]; f.ID != "workloadhealth-daemonset-coverage" || f.Description != "Found 1 DaemonSet(s) with fewer scheduled pods than eligible nodes: app/agent (3/5 nodes)" {
+		t.Errorf("Unexpected coverage finding %s: %q", f.ID, f.Description)
+	}
+	if f := findings[1]; f.ID != "workloadhealth-daemonset-misscheduled" || !strings.Contains(f.Description, "app/proxy (1 pods)") {
+		t.Errorf("Unexpected misscheduled finding %s: %q", f.ID, f.Description)
+	}
+
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newDaemonSet("app", "healthy", 1, appsv1.DaemonSetStatus{DesiredNumberScheduled: 4, CurrentNumberScheduled: 4}),
+	).Build()
+	findings = v.checkDaemonSetCoverage(context.Background(), c)
+	if len(findings) != 1 || findings[0].ID != "workloadhealth-daemonset-coverage-ok" {
+		t.Errorf("Expected workloadhealth-daemonset-coverage-ok, got %+v", findings)
+	}
+}
+
+func newRunningPod(ns, name, app, node string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns, Labels: map[string]string{"app": app}},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestCheckReplicaColocation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	withSelector := func(d *appsv1.Deployment, app string) *appsv1.Deployment {
+		d.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}}
+		return d
+	}
+	antiAffinity := corev1.PodSpec{Affinity: &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+			Weight:          100,
+			PodAffinityTerm: corev1.PodAffinityTerm{TopologyKey: "kubernetes.io/hostname"},
+		}},
+	}}}
+	pending := newRunningPod("app", "spread-3", "spread", "")
+	pending.Status.Phase = corev1.PodPending
+
+	objs := []client.Object{
+		withSelector(newDeployment("app", "packed", 2, corev1.PodSpec{}), "packed"),
+		newRunningPod("app", "packed-1", "packed", "worker-1"),
+		newRunningPod("app", "packed-2", "packed", "worker-1"),
+		withSelector(newDeployment("app", "declared", 3, antiAffinity), "declared"),
+		newRunningPod("app", "declared-1", "declared", "worker-2"),
+		newRunningPod("app", "declared-2", "declared", "worker-2"),
+		withSelector(newDeployment("app", "spread", 3, corev1.PodSpec{}), "spread"),
+		newRunningPod("app", "spread-1", "spread", "worker-1"),
+		newRunningPod("app", "spread-2", "spread", "worker-2"),
+		pending,
+		withSelector(newDeployment("app", "starting", 2, corev1.PodSpec{}), "starting"),
+		newRunningPod("app", "starting-1", "starting", "worker-1"),
+		withSelector(newDeployment("openshift-console", "console", 2, corev1.PodSpec{}), "console"),
+		newRunningPod("openshift-console", "console-1", "console", "master-0"),
+		newRunningPod("openshift-console", "console-2", "console", "master-0"),
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	v := &WorkloadHealthValidator{}
+
+	findings := v.checkReplicaColocation(context.Background(), c)
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 finding, got %d: %+v", len(findings), findings)
+	}
+	f := findings[0]
+	if f.ID != "workloadhealth-replicas-colocated" || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Errorf("Unexpected finding %s (%s)", f.ID, f.Status)
+	}
+	for _, want := range []string{"2 of 3", "app/packed (2 pods on worker-1)", "app/declared (2 pods on worker-2 despite declared spreading)"} {
+		if !strings.Contains(f.Description, want) {
+			t.Errorf("Expected description to contain %q, got %q", want, f.Description)
+		}
+	}
+	for _, unwanted := range []string{"app/spread", "app/starting", "console"} {
+		if strings.Contains(f.Description, unwanted) {
+			t.Errorf("Description should not list %q: %q", unwanted, f.Description)
+		}
+	}
+	if f.Evidence != "" {
+		t.Errorf("Expected no evidence by default, got %q", f.Evidence)
+	}
+
+	findings = v.checkReplicaColocation(validator.WithEvidence(context.Background()), c)
+	if !strings.Contains(findings[0].Evidence, "name: declared") {
+		t.Errorf("Expected evidence of the first colocated Deployment, got %q", findings[0].Evidence)
+	}
+
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		withSelector(newDeployment("app", "spread", 2, corev1.PodSpec{}), "spread"),
+		newRunningPod("app", "spread-1", "spread", "worker-1"),
+		newRunningPod("app", "spread-2", "spread", "worker-2"),
+	).Build()
+	findings = v.checkReplicaColocation(context.Background(), c)
+	if len(findings) != 1 || findings[0].ID != "workloadhealth-replica-colocation-ok" {
+		t.Errorf("Expected workloadhealth-replica-colocation-ok, got %+v", findings)
+	}
+}
+
+func TestCheckPriorityClasses(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	deployment := func(ns, name, priorityClass string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{PriorityClassName: priorityClass},
+			}},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vault", Labels: map[string]string{clusterMonitoringLabel: "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		deployment("vault", "vault-agent", ""),
+		deployment("vault", "vault-server", "infra-high"),
+		deployment("shop", "frontend", "system-cluster-critical"),
+		deployment("shop", "backend", ""),
+		deployment("gitops", "argocd-server", ""),
+		deployment("openshift-dns", "dns", "system-node-critical"),
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "log-agent", Namespace: "shop"},
+			Spec: appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{PriorityClassName: "system-node-critical"},
+			}},
+		},
+	).Build()
+
+	v := &WorkloadHealthValidator{}
+	findings := v.checkPriorityClasses(context.Background(), c)
+
+	f := validatortest.FindingByID(findings, "workloadhealth-system-priority-misuse")
+	if f == nil || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("expected system priority misuse WARN, got %+v", findings)
+	}
+	if !strings.Contains(f.Description, "Found 2 ") ||
+		!strings.Contains(f.Description, "Deployment shop/frontend (system-cluster-critical)") ||
+		!strings.Contains(f.Description, "DaemonSet shop/log-agent (system-node-critical)") {
+		t.Errorf("unexpected description: %s", f.Description)
+	}
+
+	f = validatortest.FindingByID(findings, "workloadhealth-critical-namespace-no-priority")
+	if f == nil || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("expected critical namespace WARN, got %+v", findings)
+	}
+	if !strings.Contains(f.Description, "Found 1 ") || !strings.Contains(f.Description, "Deployment vault/vault-agent") {
+		t.Errorf("unexpected description: %s", f.Description)
+	}
+
+	// Namespaces listed in the override are critical as well.
+	ctx := validator.WithConfig(context.Background(), map[string]string{configCriticalNamespaces: "gitops"})
+	findings = v.checkPriorityClasses(ctx, c)
+	if f := validatortest.FindingByID(findings, "workloadhealth-critical-namespace-no-priority"); f == nil || !strings.Contains(f.Description, "Deployment gitops/argocd-server") {
+		t.Errorf("expected gitops to be critical, got %+v", findings)
+	}
+}
+
+func TestCheckTerminationGracePeriods(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+
+	zero, thirty := int64(0), int64(30)
+	controller := true
+	ownedPod := func(name, kind, owner string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop",
+				OwnerReferences: []metav1.OwnerReference{{Kind: kind, Name: owner, Controller: &controller}}},
+			Spec: corev1.PodSpec{TerminationGracePeriodSeconds: &zero},
+		}
+	}
+	db := newStatefulSet("shop", "postgres", "postgres")
+	db.Spec.Template.Spec.TerminationGracePeriodSeconds = &zero
+	bare := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "shop"},
+		Spec:       corev1.PodSpec{TerminationGracePeriodSeconds: &zero},
+	}
+	cleanup := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "cleanup", Namespace: "shop"},
+		Spec: batchv1.CronJobSpec{JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{TerminationGracePeriodSeconds: &zero}},
+		}}},
+	}
+	scheduled := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "cleanup-1", Namespace: "shop",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: "cleanup", Controller: &controller}}},
+		Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{TerminationGracePeriodSeconds: &zero}}},
+	}
+	migrate := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "shop"},
+		Spec:       batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{TerminationGracePeriodSeconds: &zero}}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		db, bare, cleanup, scheduled, migrate,
+		ownedPod("postgres-0", "StatefulSet", "postgres"),
+		ownedPod("cleanup-1-abcde", "Job", "cleanup-1"),
+		ownedPod("migrate-abcde", "Job", "migrate"),
+		// Pods of other controllers are checked on their own
+		ownedPod("kafka-0", "KafkaCluster", "kafka"),
+		newDeployment("shop", "frontend", 2, corev1.PodSpec{TerminationGracePeriodSeconds: &thirty}),
+		newDeployment("shop", "backend", 2, corev1.PodSpec{}),
+		newDeployment("openshift-dns", "dns", 2, corev1.PodSpec{TerminationGracePeriodSeconds: &zero}),
+	).Build()
+
+	v := &WorkloadHealthValidator{}
+	findings := v.checkTerminationGracePeriods(context.Background(), c)
+	if len(findings) != 1 || findings[0].ID != "workloadhealth-zero-grace-period" || findings[0].Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Fatalf("expected a single zero grace period WARN, got %+v", findings)
+	}
+	if !strings.Contains(findings[0].Description, "Found 5 ") ||
+		!strings.Contains(findings[0].Description, "StatefulSet shop/postgres") ||
+		!strings.Contains(findings[0].Description, "CronJob shop/cleanup") ||
+		!strings.Contains(findings[0].Description, "Job shop/migrate") ||
+		!strings.Contains(findings[0].Description, "Pod shop/debug") ||
+		!strings.Contains(findings[0].Description, "Pod shop/kafka-0") {
+		t.Errorf("unexpected description: %s", findings[0].Description)
+	}
+
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newDeployment("shop", "backend", 2, corev1.PodSpec{}),
+	).Build()
+	findings = v.checkTerminationGracePeriods(context.Background(), c)
+	if len(findings) != 1 || findings[0].ID != "workloadhealth-grace-period-ok" {
+		t.Errorf("expected a pass finding, got %+v", findings)
+	}
+}
+
+func TestCheckSpotPlacement(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = policyv1.AddToScheme(scheme)
+
+	selectApp := func(app string) (*metav1.LabelSelector, corev1.PodTemplateSpec) {
+		return &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+			corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": app}}}
+	}
+	deployment := func(name string, replicas int32) *appsv1.Deployment {
+		d := newDeployment("app", name, replicas, corev1.PodSpec{})
+		d.Spec.Selector, d.Spec.Template = selectApp(name)
+		return d
+	}
+	statefulSet := newStatefulSet("app", "db", "db")
+	statefulSet.Spec.Selector, statefulSet.Spec.Template = selectApp("db")
+	budget := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "guarded", Namespace: "app"},
+		Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "guarded"}}},
+	}
+	spot := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "spot-1", Labels: map[string]string{"machine.openshift.io/interruptible-instance": ""}}}
+	onDemand := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}}
+
+	objs := []client.Object{
+		spot, onDemand, budget, statefulSet,
+		newRunningPod("app", "db-0", "db", "spot-1"),
+		deployment("single", 1),
+		newRunningPod("app", "single-1", "single", "spot-1"),
+		deployment("guarded", 1),
+		newRunningPod("app", "guarded-1", "guarded", "spot-1"),
+		deployment("stable", 1),
+		newRunningPod("app", "stable-1", "stable", "worker-1"),
+		deployment("scaled", 3),
+		newRunningPod("app", "scaled-1", "scaled", "spot-1"),
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	v := &WorkloadHealthValidator{}
+
+	findings := v.checkSpotPlacement(context.Background(), c)
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 finding, got %d: %+v", len(findings), findings)
+	}
+	f := findings[0]
+	if f.ID != "workloadhealth-spot-unprotected" || f.Status != assessmentv1alpha1.FindingStatusWarn {
+		t.Errorf("Unexpected finding %s (%s)", f.ID, f.Status)
+	}
+	for _, want := range []string{"2 of 3", "Deployment app/single on spot-1", "StatefulSet app/db on spot-1"} {
+		if !strings.Contains(f.Description, want) {
+			t.Errorf("Expected description to contain %q, got %q", want, f.Description)
+		}
+	}
+	for _, unwanted := range []string{"guarded", "stable", "scaled"} {
+		if strings.Contains(f.Description, unwanted) {
+			t.Errorf("Description should not list %q: %q", unwanted, f.Description)
+		}
+	}
+
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(onDemand, deployment("single", 1),
+		newRunningPod("app", "single-1", "single", "worker-1")).Build()
+	if findings := v.checkSpotPlacement(context.Background(), c); len(findings) != 0 {
+		t.Errorf("Expected no findings without spot nodes, got %+v", findings)
+	}
+}